@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/analyzer"
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/evaluator"
+	"github.com/funvibe/funxy/internal/lexer"
+	"github.com/funvibe/funxy/internal/modules"
+	"github.com/funvibe/funxy/internal/parser"
+	"github.com/funvibe/funxy/internal/pipeline"
+	"github.com/funvibe/funxy/internal/vm"
+)
+
+// Standalone executables are a copy of the funxy binary followed by a serialized
+// vm.Bundle and a fixed-size trailer:
+// - Payload length (8 bytes, big-endian)
+// - Magic marker (8 bytes): "FXYSTAND"
+var standaloneMagic = []byte("FXYSTAND")
+
+const standaloneTrailerSize = 16
+
+// handleBuild builds a standalone executable: funxy build -o <output> <file>
+func handleBuild() bool {
+	if len(os.Args) < 2 || os.Args[1] != "build" {
+		return false
+	}
+
+	fs := flag.NewFlagSet("build", flag.ExitOnError)
+	output := fs.String("o", "", "output executable path")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s build -o <output> <file>\n", os.Args[0])
+	}
+	_ = fs.Parse(os.Args[2:])
+
+	// Allow flags after the source file as well: build app.lang -o app
+	sourcePath := fs.Arg(0)
+	if fs.NArg() > 1 {
+		_ = fs.Parse(fs.Args()[1:])
+		if fs.NArg() > 0 {
+			fs.Usage()
+			os.Exit(1)
+		}
+	}
+	if sourcePath == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+	}
+
+	bundle, err := compileBundle(sourcePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	payload, err := bundle.Serialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Serialization error: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeStandalone(outputPath, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing executable: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Built %s -> %s\n", sourcePath, outputPath)
+	fmt.Printf("Bytecode size: %d bytes\n", len(payload))
+	return true
+}
+
+// compileBundle analyzes a program and compiles it together with its user packages
+func compileBundle(sourcePath string) (*vm.Bundle, error) {
+	sourceCode, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source file: %s", err)
+	}
+
+	absPath, err := filepath.Abs(sourcePath)
+	if err != nil {
+		absPath = sourcePath
+	}
+
+	initialContext := pipeline.NewPipelineContext(string(sourceCode))
+	initialContext.FilePath = absPath
+
+	processingPipeline := pipeline.New(
+		&lexer.LexerProcessor{},
+		&parser.ParserProcessor{},
+		&analyzer.SemanticAnalyzerProcessor{},
+	)
+
+	finalContext := processingPipeline.Run(initialContext)
+
+	if len(finalContext.Errors) > 0 {
+		var sb strings.Builder
+		sb.WriteString("Compilation failed with errors:")
+		for _, err := range finalContext.Errors {
+			sb.WriteString("\n- " + err.Error())
+		}
+		return nil, fmt.Errorf("%s", sb.String())
+	}
+
+	program, ok := finalContext.AstRoot.(*ast.Program)
+	if !ok {
+		return nil, fmt.Errorf("Internal error: AST root is not a Program")
+	}
+
+	baseDir := filepath.Dir(absPath)
+	compiler := vm.NewCompiler()
+	compiler.SetBaseDir(baseDir)
+	chunk, err := compiler.Compile(program)
+	if err != nil {
+		return nil, fmt.Errorf("Compilation error: %s", err)
+	}
+	chunk.File = absPath
+
+	loader, ok := finalContext.Loader.(*modules.Loader)
+	if !ok {
+		loader = modules.NewLoader()
+	}
+
+	bundle, err := vm.BuildBundle(chunk, baseDir, loader, compiler.GetTypeAliases(), finalContext.TraitDefaults)
+	if err != nil {
+		return nil, fmt.Errorf("Compilation error: %s", err)
+	}
+	return bundle, nil
+}
+
+// writeStandalone copies the running funxy binary to outputPath and appends the payload
+func writeStandalone(outputPath string, payload []byte) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// Never nest payloads: strip one if funxy itself is a standalone executable
+	runtime, err := os.ReadFile(self)
+	if err != nil {
+		return err
+	}
+	if _, size, ok := findPayload(runtime); ok {
+		runtime = runtime[:int64(len(runtime))-standaloneTrailerSize-size]
+	}
+
+	trailer := make([]byte, standaloneTrailerSize)
+	binary.BigEndian.PutUint64(trailer[:8], uint64(len(payload)))
+	copy(trailer[8:], standaloneMagic)
+
+	var buf bytes.Buffer
+	buf.Grow(len(runtime) + len(payload) + len(trailer))
+	buf.Write(runtime)
+	buf.Write(payload)
+	buf.Write(trailer)
+
+	return os.WriteFile(outputPath, buf.Bytes(), 0755)
+}
+
+// findPayload locates an appended payload in an executable image
+func findPayload(image []byte) (offset, size int64, ok bool) {
+	if len(image) < standaloneTrailerSize {
+		return 0, 0, false
+	}
+	trailer := image[len(image)-standaloneTrailerSize:]
+	if !bytes.Equal(trailer[8:], standaloneMagic) {
+		return 0, 0, false
+	}
+	size = int64(binary.BigEndian.Uint64(trailer[:8]))
+	offset = int64(len(image)) - standaloneTrailerSize - size
+	if size <= 0 || offset < 0 {
+		return 0, 0, false
+	}
+	return offset, size, true
+}
+
+// readEmbeddedPayload returns the payload appended to the running executable, if any
+func readEmbeddedPayload() ([]byte, bool) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, false
+	}
+	f, err := os.Open(self)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.Size() < standaloneTrailerSize {
+		return nil, false
+	}
+
+	trailer := make([]byte, standaloneTrailerSize)
+	if _, err := f.ReadAt(trailer, info.Size()-standaloneTrailerSize); err != nil {
+		return nil, false
+	}
+	if !bytes.Equal(trailer[8:], standaloneMagic) {
+		return nil, false
+	}
+
+	size := int64(binary.BigEndian.Uint64(trailer[:8]))
+	offset := info.Size() - standaloneTrailerSize - size
+	if size <= 0 || offset < 0 {
+		return nil, false
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(io.NewSectionReader(f, offset, size), payload); err != nil {
+		return nil, false
+	}
+	return payload, true
+}
+
+// runEmbeddedProgram runs the bundle appended to this executable, if present.
+// All command line arguments belong to the embedded program.
+func runEmbeddedProgram() bool {
+	payload, ok := readEmbeddedPayload()
+	if !ok {
+		return false
+	}
+
+	bundle, err := vm.DeserializeBundle(payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Corrupted embedded program: %s\n", err)
+		os.Exit(1)
+	}
+
+	// The program sees its own path first, like "funxy app.lang args..."
+	evaluator.ScriptArgs = os.Args
+
+	machine := vm.New()
+	machine.RegisterBuiltins()
+	machine.RegisterFPTraits()
+	machine.SetBundle(bundle)
+	if bundle.Main.File != "" {
+		machine.SetCurrentFile(filepath.Base(bundle.Main.File))
+	}
+
+	if err := machine.ProcessImports(bundle.Main.PendingImports); err != nil {
+		fmt.Fprintf(os.Stderr, "Import error: %s\n", err)
+		os.Exit(1)
+	}
+
+	if _, err := machine.Run(bundle.Main); err != nil {
+		fmt.Fprintf(os.Stderr, "Runtime error: %s\n", err)
+		os.Exit(1)
+	}
+	return true
+}
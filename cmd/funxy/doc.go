@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/analyzer"
+	"github.com/funvibe/funxy/internal/lexer"
+	"github.com/funvibe/funxy/internal/modules"
+	"github.com/funvibe/funxy/internal/parser"
+	"github.com/funvibe/funxy/internal/pipeline"
+)
+
+// handleDoc generates documentation for a user package: funxy doc <package-dir> [-html|-md] [-o <file>]
+func handleDoc() bool {
+	if len(os.Args) < 2 || os.Args[1] != "doc" {
+		return false
+	}
+
+	fs := flag.NewFlagSet("doc", flag.ExitOnError)
+	asHTML := fs.Bool("html", false, "generate an HTML page")
+	asMarkdown := fs.Bool("md", false, "generate Markdown")
+	output := fs.String("o", "", "write to file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s doc <package-dir> [-html|-md] [-o <file>]\n", os.Args[0])
+	}
+	_ = fs.Parse(os.Args[2:])
+
+	// Allow flags after the directory as well: doc kit/web -md
+	dir := fs.Arg(0)
+	if fs.NArg() > 1 {
+		_ = fs.Parse(fs.Args()[1:])
+		if fs.NArg() > 0 {
+			fs.Usage()
+			os.Exit(1)
+		}
+	}
+	if dir == "" || (*asHTML && *asMarkdown) {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	mod, err := analyzePackage(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	pkgs := modules.GenerateModuleDocs(mod)
+	var text string
+	switch {
+	case *asHTML:
+		text = modules.FormatDocHTML(pkgs)
+	case *asMarkdown:
+		text = modules.FormatDocMarkdown(pkgs)
+	default:
+		var sb strings.Builder
+		for _, pkg := range pkgs {
+			sb.WriteString(modules.FormatDocPackage(pkg))
+		}
+		text = sb.String()
+	}
+
+	if *output == "" {
+		fmt.Print(text)
+		return true
+	}
+	if err := os.WriteFile(*output, []byte(text), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %s\n", *output, err)
+		os.Exit(1)
+	}
+	return true
+}
+
+// analyzePackage loads and type-checks a package directory by analyzing a
+// program that imports it, so that the package goes through the same
+// headers/bodies passes as any imported package.
+func analyzePackage(dir string) (*modules.Module, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error: %s", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("Error: %s is not a package directory", dir)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error: %s", err)
+	}
+
+	initialContext := pipeline.NewPipelineContext("import " + strconv.Quote(absDir) + "\n")
+	initialContext.FilePath = filepath.Join(absDir, "doc.lang")
+
+	processingPipeline := pipeline.New(
+		&lexer.LexerProcessor{},
+		&parser.ParserProcessor{},
+		&analyzer.SemanticAnalyzerProcessor{},
+	)
+	finalContext := processingPipeline.Run(initialContext)
+
+	if len(finalContext.Errors) > 0 {
+		var sb strings.Builder
+		sb.WriteString("Analysis failed with errors:")
+		for _, err := range finalContext.Errors {
+			sb.WriteString("\n- " + err.Error())
+		}
+		return nil, fmt.Errorf("%s", sb.String())
+	}
+
+	loader, ok := finalContext.Loader.(*modules.Loader)
+	if !ok {
+		return nil, fmt.Errorf("Internal error: no module loader")
+	}
+	mod, ok := loader.LoadedModules[absDir]
+	if !ok {
+		return nil, fmt.Errorf("Error: no package found in %s", dir)
+	}
+	return mod, nil
+}
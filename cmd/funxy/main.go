@@ -18,11 +18,15 @@ import (
 	"strings"
 )
 
-// BackendType determines the execution backend.
-// Can be set at build time using: -ldflags "-X main.BackendType=tree"
+// BackendType determines the execution backend: "vm", "tree" or "both".
+// The build-time default can be changed using: -ldflags "-X main.BackendType=tree"
+// At run time it is overridden by FUNXY_BACKEND and then by --backend=<name>.
 // Default is "vm".
 var BackendType = "vm"
 
+// backendEnvVar names the environment variable that selects the backend
+const backendEnvVar = "FUNXY_BACKEND"
+
 var moduleCache = make(map[string]evaluator.Object)
 
 // isSourceFile checks if a file has a recognized source extension
@@ -45,14 +49,6 @@ func getImportName(imp *ast.ImportStatement) string {
 	return file
 }
 
-func resolvePath(baseDir, importPath string) string {
-	if strings.HasPrefix(importPath, ".") {
-		return filepath.Join(baseDir, importPath)
-	}
-	abs, _ := filepath.Abs(importPath)
-	return abs
-}
-
 func evaluateModule(mod *modules.Module, loader *modules.Loader) (evaluator.Object, error) {
 	if cached, ok := moduleCache[mod.Dir]; ok {
 		return cached, nil
@@ -75,7 +71,7 @@ func evaluateModule(mod *modules.Module, loader *modules.Loader) (evaluator.Obje
 	// Process imports for this module
 	for _, file := range mod.Files {
 		for _, imp := range file.Imports {
-			absPath := resolvePath(mod.Dir, imp.Path.Value)
+			absPath := loader.ResolveImport(mod.Dir, imp.Path.Value)
 			depMod, err := loader.Load(absPath)
 			if err != nil {
 				return nil, err
@@ -114,6 +110,12 @@ func evaluateModule(mod *modules.Module, loader *modules.Loader) (evaluator.Obje
 
 func runModule(path string) {
 	loader := modules.NewLoader()
+	searchPath, err := modules.NewSearchPath(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+	loader.SearchPath = searchPath
 	mod, err := loader.Load(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading module: %s\n", err)
@@ -202,6 +204,10 @@ func handleTest() bool {
 		return true
 	}
 
+	if BackendType == backend.NameBoth {
+		fmt.Fprintln(os.Stderr, "Error: --backend=both is not supported for tests, use vm or tree")
+		os.Exit(1)
+	}
 	useTreeWalk := isTreeWalkMode()
 
 	// Initialize test runner
@@ -247,8 +253,13 @@ func runTestFile(path string, useTreeWalk bool) {
 		absPath = path
 	}
 
+	backendName := backend.NameVM
+	if useTreeWalk {
+		backendName = backend.NameTree
+	}
+
 	// Use unified pipeline logic with test mode enabled
-	runPipeline(string(sourceCode), absPath, useTreeWalk, true)
+	runPipeline(string(sourceCode), absPath, backendName, true)
 }
 
 func handleHelp() bool {
@@ -465,9 +476,43 @@ func handleRunCompiled() bool {
 }
 
 // isTreeWalkMode returns true if the backend is configured to use Tree-Walk interpreter.
-// This is now determined at build time via BackendType variable.
 func isTreeWalkMode() bool {
-	return BackendType == "tree"
+	return BackendType == backend.NameTree
+}
+
+// selectBackend resolves BackendType from FUNXY_BACKEND and leading --backend flags.
+// The flags are removed from os.Args so that sysArgs and flagParse never see them.
+func selectBackend() error {
+	if env := os.Getenv(backendEnvVar); env != "" {
+		BackendType = env
+	}
+
+	args := []string{os.Args[0]}
+	i := 1
+	for ; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		if arg == "--backend" || arg == "-backend" {
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("%s requires a value (vm, tree or both)", arg)
+			}
+			BackendType = os.Args[i+1]
+			i++
+			continue
+		}
+		if value, ok := strings.CutPrefix(arg, "--backend="); ok {
+			BackendType = value
+			continue
+		}
+		if value, ok := strings.CutPrefix(arg, "-backend="); ok {
+			BackendType = value
+			continue
+		}
+		break
+	}
+	os.Args = append(args, os.Args[i:]...)
+
+	_, err := backend.ByName(BackendType)
+	return err
 }
 
 // Get args - simply returns os.Args as we don't strip flags anymore
@@ -476,18 +521,23 @@ func getArgs() []string {
 }
 
 // Run code using the unified pipeline
-func runPipeline(sourceCode string, filePath string, useTreeWalk bool, isTestMode bool) {
+func runPipeline(sourceCode string, filePath string, backendName string, isTestMode bool) {
 	// 1. Create the initial pipeline context
 	initialContext := pipeline.NewPipelineContext(sourceCode)
 	initialContext.FilePath = filePath
 	initialContext.IsTestMode = isTestMode
 
-	// 2. Select backend based on flag
-	var execBackend backend.Backend
-	if useTreeWalk {
-		execBackend = backend.NewTreeWalk()
-	} else {
-		execBackend = backend.NewVM()
+	// The VM reuses analyzed and compiled modules from previous runs; the tree-walk
+	// interpreter needs module ASTs, so it always loads from source
+	if backendName == backend.NameVM {
+		initialContext.Loader = modules.NewCachedLoader()
+	}
+
+	// 2. Select backend by name (validated in selectBackend)
+	execBackend, err := backend.ByName(backendName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
 	}
 
 	// 3. Create and configure the processing pipeline
@@ -528,6 +578,17 @@ func main() {
 		}
 	}()
 
+	// Standalone executables run their embedded program with all arguments untouched
+	if runEmbeddedProgram() {
+		return
+	}
+
+	// Resolve the execution backend before anything inspects os.Args
+	if err := selectBackend(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Handle help first
 	if handleHelp() {
 		return
@@ -538,6 +599,26 @@ func main() {
 		return
 	}
 
+	// Handle standalone executable builds
+	if handleBuild() {
+		return
+	}
+
+	// Handle package documentation
+	if handleDoc() {
+		return
+	}
+
+	// Handle inferred type listing
+	if handleTypes() {
+		return
+	}
+
+	// Handle dependency vendoring
+	if handleVendor() {
+		return
+	}
+
 	// Handle compile mode (-c or --compile)
 	if handleCompile() {
 		return
@@ -548,15 +629,14 @@ func main() {
 		return
 	}
 
-	useTreeWalk := isTreeWalkMode()
 	args := getArgs()
 
 	if len(args) == 2 {
 		path := args[1]
 		fileInfo, err := os.Stat(path)
 		if err == nil && fileInfo.IsDir() {
-			if !useTreeWalk {
-				fmt.Fprintln(os.Stderr, "VM mode not supported for modules yet, please rebuild with -ldflags \"-X main.BackendType=tree\"")
+			if !isTreeWalkMode() {
+				fmt.Fprintln(os.Stderr, "VM mode not supported for modules yet, please run with --backend=tree")
 				os.Exit(1)
 			}
 			runModule(path)
@@ -579,7 +659,7 @@ func main() {
 	}
 
 	// Use unified pipeline execution
-	runPipeline(sourceCode, filePath, useTreeWalk, false)
+	runPipeline(sourceCode, filePath, BackendType, false)
 }
 
 func readInputFromArgs(args []string) (string, error) {
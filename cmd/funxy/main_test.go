@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSelectBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		args     []string
+		want     string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name:     "Default",
+			args:     []string{"funxy", "main.lang"},
+			want:     "vm",
+			wantArgs: []string{"funxy", "main.lang"},
+		},
+		{
+			name:     "Environment",
+			env:      "tree",
+			args:     []string{"funxy", "main.lang"},
+			want:     "tree",
+			wantArgs: []string{"funxy", "main.lang"},
+		},
+		{
+			name:     "Flag overrides environment",
+			env:      "tree",
+			args:     []string{"funxy", "--backend", "both", "main.lang"},
+			want:     "both",
+			wantArgs: []string{"funxy", "main.lang"},
+		},
+		{
+			name:     "Flag with equals sign",
+			args:     []string{"funxy", "-backend=tree", "main.lang", "x"},
+			want:     "tree",
+			wantArgs: []string{"funxy", "main.lang", "x"},
+		},
+		{
+			name:     "Flags after the script belong to the script",
+			args:     []string{"funxy", "main.lang", "--backend=tree"},
+			want:     "vm",
+			wantArgs: []string{"funxy", "main.lang", "--backend=tree"},
+		},
+		{
+			name:    "Missing value",
+			args:    []string{"funxy", "--backend"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown backend",
+			args:    []string{"funxy", "--backend=jit", "main.lang"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown backend in environment",
+			env:     "jit",
+			args:    []string{"funxy", "main.lang"},
+			wantErr: true,
+		},
+	}
+
+	savedArgs, savedBackend := os.Args, BackendType
+	defer func() { os.Args, BackendType = savedArgs, savedBackend }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(backendEnvVar, tt.env)
+			os.Args = tt.args
+			BackendType = "vm"
+
+			err := selectBackend()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectBackend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if BackendType != tt.want {
+				t.Errorf("BackendType = %q, want %q", BackendType, tt.want)
+			}
+			if !reflect.DeepEqual(os.Args, tt.wantArgs) {
+				t.Errorf("os.Args = %q, want %q", os.Args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/analyzer"
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/lexer"
+	"github.com/funvibe/funxy/internal/parser"
+	"github.com/funvibe/funxy/internal/pipeline"
+	"github.com/funvibe/funxy/internal/prettyprinter"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// handleTypes prints the inferred type of every top-level binding: funxy types <file>
+func handleTypes() bool {
+	if len(os.Args) < 2 || os.Args[1] != "types" {
+		return false
+	}
+	if len(os.Args) != 3 {
+		fmt.Fprintf(os.Stderr, "Usage: %s types <file>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	path := os.Args[2]
+	sourceCode, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file %s: %s\n", path, err)
+		os.Exit(1)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	initialContext := pipeline.NewPipelineContext(string(sourceCode))
+	initialContext.FilePath = absPath
+
+	processingPipeline := pipeline.New(
+		&lexer.LexerProcessor{},
+		&parser.ParserProcessor{},
+		&analyzer.SemanticAnalyzerProcessor{},
+	)
+	finalContext := processingPipeline.Run(initialContext)
+
+	if len(finalContext.Errors) > 0 {
+		fmt.Fprintln(os.Stderr, "Analysis failed with errors:")
+		for _, err := range finalContext.Errors {
+			fmt.Fprintf(os.Stderr, "- %s\n", err.Error())
+		}
+		os.Exit(1)
+	}
+
+	program, ok := finalContext.AstRoot.(*ast.Program)
+	if !ok {
+		return true
+	}
+	for _, stmt := range program.Statements {
+		name, node := topLevelBinding(stmt)
+		if node == nil {
+			continue
+		}
+		if t, ok := finalContext.TypeMap[node]; ok {
+			fmt.Printf("%s : %s\n", name, typeScheme(t))
+		}
+	}
+	return true
+}
+
+// topLevelBinding returns the name a statement binds and the node whose
+// inferred type is the binding's type, or nil if it binds nothing.
+func topLevelBinding(stmt ast.Statement) (string, ast.Node) {
+	switch s := stmt.(type) {
+	case *ast.FunctionStatement:
+		if s.Receiver != nil && s.Receiver.Type != nil {
+			printer := prettyprinter.NewCodePrinter()
+			s.Receiver.Type.Accept(printer)
+			return printer.String() + "." + s.Name.Value, s
+		}
+		return s.Name.Value, s
+	case *ast.ConstantDeclaration:
+		if s.Name != nil {
+			return s.Name.Value, s.Value
+		}
+	case *ast.ExpressionStatement:
+		if assign, ok := s.Expression.(*ast.AssignExpression); ok {
+			if ident, ok := assign.Left.(*ast.Identifier); ok {
+				return ident.Value, assign.Value
+			}
+		}
+	}
+	return "", nil
+}
+
+// typeScheme renders a type with readable type variables and its
+// constraints, e.g. (a) -> String where a: Show
+func typeScheme(t typesystem.Type) string {
+	text := t.Apply(typesystem.RenameGeneratedVars(t)).String()
+	fn, ok := t.(typesystem.TFunc)
+	if !ok || len(fn.Constraints) == 0 {
+		return text
+	}
+	constraints := make([]string, len(fn.Constraints))
+	for i, c := range fn.Constraints {
+		constraints[i] = c.TypeVar + ": " + c.Trait
+	}
+	return text + " where " + strings.Join(constraints, ", ")
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/funvibe/funxy/internal/modules"
+)
+
+// handleVendor copies the dependencies declared in funxy.mod into vendor/: funxy vendor
+func handleVendor() bool {
+	if len(os.Args) < 2 || os.Args[1] != "vendor" {
+		return false
+	}
+	if len(os.Args) > 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s vendor\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	manifest, err := modules.FindManifest(".")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+	if manifest == nil {
+		fmt.Fprintf(os.Stderr, "Error: no %s found in the current directory or its parents\n", modules.ManifestFile)
+		os.Exit(1)
+	}
+
+	names, err := manifest.Vendor()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	for _, name := range names {
+		fmt.Printf("vendored %s -> %s\n", name, filepath.Join(modules.VendorDir, name))
+	}
+	if len(names) == 0 {
+		fmt.Printf("No dependencies in %s\n", filepath.Join(manifest.Root, modules.ManifestFile))
+	}
+	return true
+}
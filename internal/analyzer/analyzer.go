@@ -25,6 +25,7 @@ type Analyzer struct {
 type ModuleLoader interface {
 	GetModule(path string) (interface{}, error)       // Returns *modules.Module (which implements LoadedModule)
 	GetModuleByPackageName(name string) interface{}   // Returns module by package name (for extension methods/traits lookup)
+	ResolveImport(baseDir, importPath string) string  // Resolves an import path to a module directory (lib/* unchanged)
 }
 
 // LoadedModule interface representing a fully loaded AND analyzed module
@@ -90,6 +91,9 @@ type walker struct {
 	mode              AnalysisMode
 	TraitDefaults     map[string]*ast.FunctionStatement // "TraitName.methodName" -> FunctionStatement
 	currentModuleName string // Name of the module being analyzed (for OriginModule tracking)
+	taskScope         *symbols.SymbolTable // Scope where the enclosing task function was created (see captures.go)
+	taskSpawner       string               // lib/task function the enclosing task function was passed to
+	functions         []functionScope      // Functions whose bodies are being analyzed, innermost last
 }
 
 // addError adds an error to the walker, deduplicating by position and message
@@ -0,0 +1,205 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+var (
+	bitsType  = typesystem.TCon{Name: config.BitsTypeName}
+	bytesType = typesystem.TCon{Name: config.BytesTypeName}
+)
+
+// bitsSegmentValueType is the type of the value a segment encodes or binds.
+func bitsSegmentValueType(seg *ast.BitsSegment) typesystem.Type {
+	if seg.IntType != "" {
+		return typesystem.TCon{Name: seg.IntType}
+	}
+	switch seg.Type {
+	case ast.BitsFloat:
+		return typesystem.Float
+	case ast.BitsBinary:
+		return bytesType
+	case ast.BitsBitstring:
+		return bitsType
+	case ast.BitsUTF8:
+		return typesystem.Char
+	default:
+		return typesystem.Int
+	}
+}
+
+func bitsSegmentNode(seg *ast.BitsSegment) ast.Node {
+	if seg.Value != nil {
+		return seg.Value
+	}
+	return seg.Pattern
+}
+
+// checkBitsSegment validates the size and specifiers of a segment.
+// In patterns only the last binary or bits segment may omit its size.
+func checkBitsSegment(seg *ast.BitsSegment, last, isPattern bool) error {
+	node := bitsSegmentNode(seg)
+	unit := seg.Unit
+	if unit == 0 {
+		unit = 1
+	}
+
+	if seg.IsStringLiteral() {
+		if seg.Type != ast.BitsBinary && seg.Type != ast.BitsUTF8 {
+			return inferErrorf(node, "string literal segment cannot be %s", seg.Type)
+		}
+		if seg.Size != nil {
+			return inferErrorf(node, "string literal segment takes its size from the string")
+		}
+		return nil
+	}
+
+	if seg.IntType != "" && (seg.Size != nil || seg.Unit != 0) {
+		return inferErrorf(node, "%s segment takes its size from the type", strings.ToLower(seg.IntType))
+	}
+
+	switch seg.Type {
+	case ast.BitsUTF8:
+		if seg.Size != nil || seg.Unit != 0 {
+			return inferErrorf(node, "utf8 segment cannot have a size or unit")
+		}
+	case ast.BitsBinary, ast.BitsBitstring:
+		if seg.Signed || seg.Endianness != "" {
+			return inferErrorf(node, "signedness and endianness do not apply to %s segments", seg.Type)
+		}
+		if seg.Size == nil && isPattern && !last {
+			return inferErrorf(node, "only the last segment of a binary pattern may omit the size of a %s segment", seg.Type)
+		}
+	}
+
+	size, ok := seg.Size.(*ast.IntegerLiteral)
+	if !ok {
+		return nil
+	}
+	bits := size.Value * unit
+	switch seg.Type {
+	case ast.BitsInteger:
+		if bits < 1 || bits > 64 {
+			return inferErrorf(node, "integer segment size must be between 1 and 64 bits, got %d", bits)
+		}
+	case ast.BitsFloat:
+		if bits != 16 && bits != 32 && bits != 64 {
+			return inferErrorf(node, "float segment size must be 16, 32 or 64 bits, got %d", bits)
+		}
+	default:
+		if size.Value < 0 {
+			return inferErrorf(node, "segment size cannot be negative, got %d", size.Value)
+		}
+	}
+	return nil
+}
+
+// inferBitsExpression checks a binary construction <<v:size/spec, ...>>.
+func inferBitsExpression(ctx *InferenceContext, n *ast.BitsExpression, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Type, typesystem.Subst, error) {
+	totalSubst := typesystem.Subst{}
+	for i, seg := range n.Segments {
+		if err := checkBitsSegment(seg, i == len(n.Segments)-1, false); err != nil {
+			return nil, nil, err
+		}
+
+		valType, s1, err := inferFn(seg.Value, table)
+		if err != nil {
+			return nil, nil, err
+		}
+		totalSubst = s1.Compose(totalSubst)
+		if !seg.IsStringLiteral() {
+			want := bitsSegmentValueType(seg)
+			s2, err := typesystem.Unify(want, valType.Apply(totalSubst))
+			if err != nil {
+				return nil, nil, inferErrorf(seg.Value, "%s segment expects %s, got %s", seg.Type, want, valType.Apply(totalSubst))
+			}
+			totalSubst = s2.Compose(totalSubst)
+		}
+
+		if seg.Size != nil {
+			sizeType, s3, err := inferFn(seg.Size, table)
+			if err != nil {
+				return nil, nil, err
+			}
+			totalSubst = s3.Compose(totalSubst)
+			s4, err := typesystem.Unify(typesystem.Int, sizeType.Apply(totalSubst))
+			if err != nil {
+				return nil, nil, inferErrorf(seg.Size, "segment size must be Int, got %s", sizeType.Apply(totalSubst))
+			}
+			totalSubst = s4.Compose(totalSubst)
+		}
+	}
+	return bitsType, totalSubst, nil
+}
+
+// inferBitsPattern checks a binary pattern against Bits or Bytes and binds
+// its named segments. Sizes may refer to earlier segments of the same
+// pattern or to Int variables in scope.
+func inferBitsPattern(p *ast.BitsPattern, expectedType typesystem.Type, table *symbols.SymbolTable) (typesystem.Subst, error) {
+	totalSubst := typesystem.Subst{}
+	if tCon, ok := expectedType.(typesystem.TCon); !ok || tCon.Name != config.BytesTypeName {
+		subst, err := typesystem.Unify(expectedType, bitsType)
+		if err != nil {
+			return nil, inferErrorf(p, "binary pattern expects Bits or Bytes, got %s", expectedType)
+		}
+		totalSubst = subst
+	}
+
+	bound := make(map[string]bool)
+	for i, seg := range p.Segments {
+		if err := checkBitsSegment(seg, i == len(p.Segments)-1, true); err != nil {
+			return nil, err
+		}
+		if seg.Size != nil {
+			if err := checkBitsPatternSize(seg.Size, table); err != nil {
+				return nil, err
+			}
+		}
+
+		switch pat := seg.Pattern.(type) {
+		case *ast.IdentifierPattern:
+			if bound[pat.Value] {
+				return nil, inferErrorf(pat, "%s is bound more than once in binary pattern", pat.Value)
+			}
+			bound[pat.Value] = true
+			table.Define(pat.Value, bitsSegmentValueType(seg), "")
+		case *ast.LiteralPattern:
+			if _, isInt := pat.Value.(int64); isInt && seg.Type != ast.BitsInteger {
+				return nil, inferErrorf(pat, "integer literal in %s segment", seg.Type)
+			}
+		}
+	}
+	return totalSubst, nil
+}
+
+// checkBitsPatternSize restricts pattern sizes to arithmetic over integer
+// literals and Int names, which the matcher evaluates as segments are read.
+func checkBitsPatternSize(expr ast.Expression, table *symbols.SymbolTable) error {
+	switch e := expr.(type) {
+	case *ast.IntegerLiteral:
+		return nil
+	case *ast.Identifier:
+		sym, ok := table.Find(e.Value)
+		if !ok {
+			return inferErrorf(e, "undefined size variable in binary pattern: %s", e.Value)
+		}
+		if _, err := typesystem.Unify(typesystem.Int, sym.Type); err != nil {
+			return inferErrorf(e, "segment size %s must be Int, got %s", e.Value, sym.Type)
+		}
+		return nil
+	case *ast.InfixExpression:
+		switch e.Operator {
+		case "+", "-", "*", "/", "%":
+			if err := checkBitsPatternSize(e.Left, table); err != nil {
+				return err
+			}
+			return checkBitsPatternSize(e.Right, table)
+		}
+	}
+	return inferErrorf(expr, "binary pattern sizes may only use integers, names and + - * / %%")
+}
@@ -20,6 +20,13 @@ func RegisterBuiltins(table *symbols.SymbolTable) {
 	table.Define("Char", typesystem.TType{Type: typesystem.Char}, prelude)
 	table.Define("BigInt", typesystem.TType{Type: typesystem.BigInt}, prelude)
 	table.Define("Rational", typesystem.TType{Type: typesystem.Rational}, prelude)
+	table.Define("Decimal", typesystem.TType{Type: typesystem.Decimal}, prelude)
+	for _, info := range config.IntTypes {
+		if info.Suffix != "" {
+			table.Define(info.Name, typesystem.TType{Type: typesystem.TCon{Name: info.Name}}, prelude)
+		}
+	}
+	table.Define("Byte", typesystem.TType{Type: typesystem.UInt8}, prelude)
 	table.Define("String", typesystem.TType{Type: typesystem.TApp{
 		Constructor: typesystem.TCon{Name: config.ListTypeName},
 		Args:        []typesystem.Type{typesystem.Char},
@@ -182,6 +189,47 @@ func RegisterBuiltins(table *symbols.SymbolTable) {
 	}
 	table.Define("floatToInt", floatToIntType, prelude)
 
+	// Integer conversions and overflow-aware arithmetic over Integral types:
+	// intCast: (Type<T>, A) -> Option<T>, intWrap/intSaturate: (Type<T>, A) -> T,
+	// checkedAdd: (T, T) -> Option<T>, wrappingAdd/saturatingAdd: (T, T) -> T, ...
+	tVar := typesystem.TVar{Name: "T"}
+	aVar := typesystem.TVar{Name: "A"}
+	optionT := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: config.OptionTypeName},
+		Args:        []typesystem.Type{tVar},
+	}
+	integralTA := []typesystem.Constraint{
+		{TypeVar: "T", Trait: config.IntegralTraitName},
+		{TypeVar: "A", Trait: config.IntegralTraitName},
+	}
+	integralT := integralTA[:1]
+	table.Define("intCast", typesystem.TFunc{
+		Params:      []typesystem.Type{typesystem.TType{Type: tVar}, aVar},
+		ReturnType:  optionT,
+		Constraints: integralTA,
+	}, prelude)
+	for _, name := range []string{"intWrap", "intSaturate"} {
+		table.Define(name, typesystem.TFunc{
+			Params:      []typesystem.Type{typesystem.TType{Type: tVar}, aVar},
+			ReturnType:  tVar,
+			Constraints: integralTA,
+		}, prelude)
+	}
+	for _, name := range []string{"checkedAdd", "checkedSub", "checkedMul", "checkedDiv"} {
+		table.Define(name, typesystem.TFunc{
+			Params:      []typesystem.Type{tVar, tVar},
+			ReturnType:  optionT,
+			Constraints: integralT,
+		}, prelude)
+	}
+	for _, name := range []string{"wrappingAdd", "wrappingSub", "wrappingMul", "saturatingAdd", "saturatingSub", "saturatingMul"} {
+		table.Define(name, typesystem.TFunc{
+			Params:      []typesystem.Type{tVar, tVar},
+			ReturnType:  tVar,
+			Constraints: integralT,
+		}, prelude)
+	}
+
 	// sprintf: (format: String, args: ...Any) -> String
 	sprintfType := typesystem.TFunc{
 		Params: []typesystem.Type{
@@ -414,6 +462,7 @@ func registerPrimitiveInstances(table *symbols.SymbolTable) {
 		typesystem.Float,
 		typesystem.BigInt,
 		typesystem.Rational,
+		typesystem.Decimal,
 	}
 
 	for _, t := range numericTypes {
@@ -427,6 +476,19 @@ func registerPrimitiveInstances(table *symbols.SymbolTable) {
 	_ = table.RegisterImplementation("Bitwise", typesystem.Int)
 	_ = table.RegisterImplementation("Bitwise", typesystem.BigInt)
 
+	// Fixed-width integers: Int and the sized types
+	for _, info := range config.IntTypes {
+		t := typesystem.TCon{Name: info.Name}
+		if info.Suffix != "" {
+			_ = table.RegisterImplementation("Equal", t)
+			_ = table.RegisterImplementation("Order", t)
+			_ = table.RegisterImplementation("Numeric", t)
+			_ = table.RegisterImplementation("Default", t)
+			_ = table.RegisterImplementation("Bitwise", t)
+		}
+		_ = table.RegisterImplementation(config.IntegralTraitName, t)
+	}
+
 	// Bool implements Equal, Order, Default (false < true)
 	_ = table.RegisterImplementation("Equal", typesystem.Bool)
 	_ = table.RegisterImplementation("Order", typesystem.Bool)
@@ -556,4 +618,41 @@ func registerPrimitiveInstances(table *symbols.SymbolTable) {
 
 	// Iter implementation for List
 	_ = table.RegisterImplementation("Iter", listType)
+
+	// Library collections implement Iter and compare by contents. Their
+	// iterators yield the elements, and OrderedMap's the (key, value) pairs.
+	for _, name := range []string{"Set", "OrderedSet", "Heap"} {
+		elemVar := typesystem.TVar{Name: "t"}
+		collection := typesystem.TApp{
+			Constructor: typesystem.TCon{Name: name},
+			Args:        []typesystem.Type{elemVar},
+		}
+		_ = table.RegisterImplementation("Iter", collection)
+		_ = table.RegisterImplementation("Equal", collection)
+		registerIterMethod(table, name, collection, elemVar)
+	}
+	keyVar, valueVar := typesystem.TVar{Name: "k"}, typesystem.TVar{Name: "v"}
+	orderedMapType := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "OrderedMap"},
+		Args:        []typesystem.Type{keyVar, valueVar},
+	}
+	_ = table.RegisterImplementation("Iter", orderedMapType)
+	_ = table.RegisterImplementation("Equal", orderedMapType)
+	registerIterMethod(table, "OrderedMap", orderedMapType, typesystem.TTuple{Elements: []typesystem.Type{keyVar, valueVar}})
+
+	// Dynamic values compare by the value they hold
+	_ = table.RegisterImplementation("Equal", typesystem.TCon{Name: "Dynamic"})
+}
+
+// registerIterMethod records the signature of a built-in Iter instance,
+// iter: (C) -> () -> Option<T>, so that loops over C get items of type T.
+func registerIterMethod(table *symbols.SymbolTable, typeName string, collection, item typesystem.Type) {
+	iterType := typesystem.TFunc{
+		Params: []typesystem.Type{collection},
+		ReturnType: typesystem.TFunc{
+			Params:     []typesystem.Type{},
+			ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{item}},
+		},
+	}
+	table.RegisterInstanceMethod(config.IterTraitName, typeName, config.IterMethodName, iterType)
 }
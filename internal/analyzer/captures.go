@@ -0,0 +1,349 @@
+package analyzer
+
+import (
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/diagnostics"
+	"github.com/funvibe/funxy/internal/symbols"
+	"slices"
+)
+
+// taskSpawners maps the library functions whose function argument runs on
+// other goroutines to the package defining them. Bindings captured by that
+// function are shared with the caller, so assigning to them is a data race.
+var taskSpawners = map[string]string{
+	"async":       "task",
+	"taskMap":     "task",
+	"taskFlatMap": "task",
+	"parMap":      "list",
+	"parFilter":   "list",
+	"parFold":     "list",
+}
+
+// taskSpawnerName returns the name of the library function called by expr
+// if it is one of taskSpawners, or "" otherwise.
+func (w *walker) taskSpawnerName(expr *ast.CallExpression) string {
+	return libraryFunctionName(expr, taskSpawners, w.symbolTable)
+}
+
+// functionScope is a function whose body is being analyzed and the scope
+// of its parameters.
+type functionScope struct {
+	fn    ast.Node
+	scope *symbols.SymbolTable
+}
+
+// spawnedFunction is a function passed to a task spawner: a function
+// literal, a variable holding a known function, or a call to a known
+// function that returns one (maker). It is checked once all function
+// bodies are analyzed.
+type spawnedFunction struct {
+	arg     ast.Expression
+	fn      ast.Node
+	maker   ast.Node
+	spawner string
+}
+
+// capturedBinding is a binding assigned by a function that captures it,
+// with the scope defining it.
+type capturedBinding struct {
+	name  string
+	scope *symbols.SymbolTable
+}
+
+// callSite is a call to a known function inside another function.
+type callSite struct {
+	callee ast.Node
+	at     *ast.Identifier
+}
+
+// taskCaptures is kept across the analysis passes: a function may be passed
+// to a spawner before its body is analyzed.
+type taskCaptures struct {
+	values  map[*symbols.SymbolTable]map[string]ast.Node // Known functions held by variables
+	assigns map[ast.Node][]capturedBinding               // Captured bindings each function assigns to
+	calls   map[ast.Node][]callSite                      // Known functions each function calls
+	returns map[ast.Node]ast.Node                        // Known function each function returns
+	scopes  map[ast.Node]*symbols.SymbolTable            // Parameter scope of each function
+	spawned []spawnedFunction
+}
+
+// enterFunction starts the analysis of the body of fn, whose parameters are
+// defined in the current scope. The returned function ends it and records
+// the function fn returns, if it is known.
+func (w *walker) enterFunction(fn ast.Node) func() {
+	c := &w.inferCtx.captures
+	if c.scopes == nil {
+		c.scopes = make(map[ast.Node]*symbols.SymbolTable)
+		c.returns = make(map[ast.Node]ast.Node)
+	}
+	c.scopes[fn] = w.symbolTable
+	w.functions = append(w.functions, functionScope{fn: fn, scope: w.symbolTable})
+	return func() {
+		if ret := w.assignedFunction(resultExpression(functionBody(fn))); ret != nil {
+			c.returns[fn] = ret
+		}
+		w.functions = w.functions[:len(w.functions)-1]
+	}
+}
+
+// functionBody returns the body of a FunctionStatement or FunctionLiteral.
+func functionBody(fn ast.Node) *ast.BlockStatement {
+	switch f := fn.(type) {
+	case *ast.FunctionStatement:
+		return f.Body
+	case *ast.FunctionLiteral:
+		return f.Body
+	}
+	return nil
+}
+
+// resultExpression returns the expression whose value a block produces.
+func resultExpression(body *ast.BlockStatement) ast.Expression {
+	if body == nil || len(body.Statements) == 0 {
+		return nil
+	}
+	es, ok := body.Statements[len(body.Statements)-1].(*ast.ExpressionStatement)
+	if !ok {
+		return nil
+	}
+	if ann, ok := es.Expression.(*ast.AnnotatedExpression); ok {
+		return ann.Expression
+	}
+	return es.Expression
+}
+
+// bindFunction records that name, defined in scope, holds the function fn
+// (a FunctionStatement or FunctionLiteral); a nil fn forgets it.
+func (w *walker) bindFunction(scope *symbols.SymbolTable, name string, fn ast.Node) {
+	c := &w.inferCtx.captures
+	if c.values == nil {
+		c.values = make(map[*symbols.SymbolTable]map[string]ast.Node)
+	}
+	if c.values[scope] == nil {
+		c.values[scope] = make(map[string]ast.Node)
+	}
+	if fn == nil {
+		delete(c.values[scope], name)
+		return
+	}
+	c.values[scope][name] = fn
+}
+
+// assignedFunction returns the function an assignment of value stores: a
+// function literal or a variable holding a known function.
+func (w *walker) assignedFunction(value ast.Expression) ast.Node {
+	switch v := value.(type) {
+	case *ast.FunctionLiteral:
+		return v
+	case *ast.Identifier:
+		return w.boundFunction(v.Value)
+	}
+	return nil
+}
+
+// boundFunction returns the function the variable name currently holds, if
+// it is known.
+func (w *walker) boundFunction(name string) ast.Node {
+	scope := definingScope(w.symbolTable, name)
+	if scope == nil {
+		return nil
+	}
+	return w.inferCtx.captures.values[scope][name]
+}
+
+// spawnFunction records a function passed to a task spawner so that the
+// functions it calls, or the function it is, can be checked once they have
+// been analyzed.
+func (w *walker) spawnFunction(arg ast.Expression, spawner string) {
+	sp := spawnedFunction{arg: arg, spawner: spawner}
+	switch a := arg.(type) {
+	case *ast.FunctionLiteral:
+		sp.fn = a
+	case *ast.Identifier:
+		sp.fn = w.boundFunction(a.Value)
+	case *ast.CallExpression:
+		if callee, ok := a.Function.(*ast.Identifier); ok {
+			sp.maker = w.boundFunction(callee.Value)
+		}
+	}
+	if sp.fn != nil || sp.maker != nil {
+		c := &w.inferCtx.captures
+		c.spawned = append(c.spawned, sp)
+	}
+}
+
+// recordCall notes a call to the function held by callee in every
+// enclosing function.
+func (w *walker) recordCall(callee *ast.Identifier) {
+	fn := w.boundFunction(callee.Value)
+	if fn == nil {
+		return
+	}
+	c := &w.inferCtx.captures
+	if c.calls == nil {
+		c.calls = make(map[ast.Node][]callSite)
+	}
+	for _, f := range w.functions {
+		c.calls[f.fn] = append(c.calls[f.fn], callSite{callee: fn, at: callee})
+	}
+}
+
+// reportSpawnedFunctions reports the functions passed to task spawners that
+// assign, themselves or through the functions they call, to bindings they
+// capture.
+func (w *walker) reportSpawnedFunctions() {
+	c := &w.inferCtx.captures
+	effects := make(map[ast.Node][]capturedBinding)
+	for _, sp := range c.spawned {
+		switch arg := sp.arg.(type) {
+		case *ast.FunctionLiteral:
+			// Assignments in the literal itself are reported where they are
+			// analyzed; here only the functions it calls are left.
+			for _, call := range c.calls[sp.fn] {
+				for _, b := range c.effects(call.callee, effects) {
+					if c.captures(sp.fn, b) {
+						w.spawnError(call.at, "cannot call "+call.at.Value+" inside a function passed to "+sp.spawner+
+							": it assigns to captured variable '"+b.name+"'")
+					}
+				}
+			}
+		case *ast.Identifier:
+			for _, b := range c.effects(sp.fn, effects) {
+				w.spawnError(arg, "cannot pass "+arg.Value+" to "+sp.spawner+
+					": it assigns to captured variable '"+b.name+"'")
+			}
+		case *ast.CallExpression:
+			fn := c.returns[sp.maker]
+			if fn == nil {
+				continue
+			}
+			maker := arg.Function.(*ast.Identifier)
+			for _, b := range c.effects(fn, effects) {
+				w.spawnError(maker, "cannot pass "+maker.Value+"() to "+sp.spawner+
+					": the function it returns assigns to captured variable '"+b.name+"'")
+			}
+		}
+	}
+	c.spawned = nil
+}
+
+// spawnError reports a task function that would race on a binding.
+func (w *walker) spawnError(at ast.Expression, message string) {
+	w.addError(diagnostics.NewError(
+		diagnostics.ErrA003,
+		at.GetToken(),
+		message+", so tasks would race on it; share a Ref from lib/ref instead",
+	))
+}
+
+// effects returns the captured bindings fn assigns to, directly or through
+// the known functions it calls. Results are memoized in seen; a recursive
+// call contributes nothing while its callee is being computed.
+func (c *taskCaptures) effects(fn ast.Node, seen map[ast.Node][]capturedBinding) []capturedBinding {
+	if bindings, ok := seen[fn]; ok {
+		return bindings
+	}
+	seen[fn] = nil
+	bindings := slices.Clone(c.assigns[fn])
+	for _, call := range c.calls[fn] {
+		for _, b := range c.effects(call.callee, seen) {
+			if c.captures(fn, b) && !slices.Contains(bindings, b) {
+				bindings = append(bindings, b)
+			}
+		}
+	}
+	seen[fn] = bindings
+	return bindings
+}
+
+// captures reports whether b is defined outside fn, so that fn shares it
+// with the code that created fn.
+func (c *taskCaptures) captures(fn ast.Node, b capturedBinding) bool {
+	scope := c.scopes[fn]
+	if scope == nil {
+		return false
+	}
+	for s := scope.Outer(); s != nil; s = s.Outer() {
+		if s == b.scope {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCapturedAssignment reports an assignment to name when it updates a
+// binding that was visible where the enclosing task function was created.
+func (w *walker) checkCapturedAssignment(name string, at ast.Expression) {
+	definedIn := definingScope(w.symbolTable, name)
+	if definedIn == nil {
+		return
+	}
+	w.recordCapturedAssignment(name, definedIn)
+	if w.taskScope == nil {
+		return
+	}
+	for s := w.taskScope; s != nil; s = s.Outer() {
+		if s == definedIn {
+			w.addError(diagnostics.NewError(
+				diagnostics.ErrA003,
+				at.GetToken(),
+				"cannot assign to captured variable '"+name+"' inside a function passed to "+w.taskSpawner+
+					": tasks would race on it; share a Ref from lib/ref instead",
+			))
+			return
+		}
+	}
+}
+
+// recordCapturedAssignment notes an assignment to name, defined in
+// definedIn, for every enclosing function that captures it.
+func (w *walker) recordCapturedAssignment(name string, definedIn *symbols.SymbolTable) {
+	for _, f := range w.functions {
+		captured := false
+		for s := w.symbolTable; s != nil; s = s.Outer() {
+			if s == definedIn {
+				break
+			}
+			if s == f.scope {
+				captured = true
+				break
+			}
+		}
+		if !captured {
+			continue
+		}
+		c := &w.inferCtx.captures
+		if c.assigns == nil {
+			c.assigns = make(map[ast.Node][]capturedBinding)
+		}
+		b := capturedBinding{name: name, scope: definedIn}
+		if !slices.Contains(c.assigns[f.fn], b) {
+			c.assigns[f.fn] = append(c.assigns[f.fn], b)
+		}
+	}
+}
+
+// definingScope returns the innermost scope of table's chain that defines name
+func definingScope(table *symbols.SymbolTable, name string) *symbols.SymbolTable {
+	for s := table; s != nil; s = s.Outer() {
+		if _, ok := s.All()[name]; ok {
+			return s
+		}
+	}
+	return nil
+}
+
+// assignmentRoot returns the variable whose value an assignment target
+// changes: x for x, x.a.b = ...
+func assignmentRoot(target ast.Expression) *ast.Identifier {
+	for {
+		switch t := target.(type) {
+		case *ast.Identifier:
+			return t
+		case *ast.MemberExpression:
+			target = t.Left
+		default:
+			return nil
+		}
+	}
+}
@@ -45,6 +45,7 @@ func tagModule(t typesystem.Type, moduleName string, exportedTypes map[string]bo
 			IsVariadic:   t.IsVariadic,
 			DefaultCount: t.DefaultCount,
 			Constraints:  t.Constraints,
+			ParamNames:   t.ParamNames,
 		}
 	case typesystem.TTuple:
 		newElems := []typesystem.Type{}
@@ -71,7 +72,7 @@ func (w *walker) VisitImportStatement(n *ast.ImportStatement) {
 
 	// Resolve absolute path from ImportStatement
 	importPath := n.Path.Value
-	pathToCheck := utils.ResolveImportPath(w.BaseDir, importPath)
+	pathToCheck := w.loader.ResolveImport(w.BaseDir, importPath)
 
 	modInterface, err := w.loader.GetModule(pathToCheck)
 	if err != nil {
@@ -231,10 +232,17 @@ func (w *walker) VisitImportStatement(n *ast.ImportStatement) {
 
 					// Automatically import constructors for ADTs
 					if loadedModSymTable := loadedMod.GetSymbolTable(); loadedModSymTable != nil {
+						if kind, ok := loadedModSymTable.GetKind(symName); ok {
+							w.symbolTable.RegisterKind(symName, kind)
+						}
+						if wrapped, ok := loadedModSymTable.GetNewtype(symName); ok {
+							w.symbolTable.RegisterNewtype(symName, tagModule(wrapped, packageName, exportedTypes))
+						}
 						if variants, ok := loadedModSymTable.GetVariants(symName); ok {
 							for _, variantName := range variants {
 								// Only import if the variant is actually exported by the module
 								if variantSym, ok := exportSymbols[variantName]; ok {
+									w.symbolTable.RegisterVariant(symName, variantName)
 									// Check for conflict/redefinition logic similar to main loop?
 									// Since it's implicit, maybe we should be softer or just overwrite?
 									// Main loop checks "existing.OriginModule == origin".
@@ -285,6 +293,8 @@ func (w *walker) VisitImportStatement(n *ast.ImportStatement) {
 							}
 						}
 					}
+				} else if sym.IsFunction {
+					w.symbolTable.DefineFunction(symName, taggedType, origin)
 				} else {
 					w.symbolTable.Define(symName, taggedType, origin)
 				}
@@ -672,6 +682,7 @@ func RegisterFunctionDeclaration(n *ast.FunctionStatement, table *symbols.Symbol
 		IsVariadic:   isVariadic,
 		DefaultCount: defaultCount,
 		Constraints:  fnConstraints,
+		ParamNames:   paramNames(n.Receiver, n.Parameters),
 	}
 
 	// 5. Define in Table (Outer)
@@ -679,16 +690,24 @@ func RegisterFunctionDeclaration(n *ast.FunctionStatement, table *symbols.Symbol
 		typeName := resolveReceiverTypeName(n.Receiver.Type, table)
 		if typeName == "" {
 			errors = append(errors, diagnostics.NewError(diagnostics.ErrA003, n.Receiver.Token, "invalid receiver type"))
+		} else if _, isNewtype := table.GetNewtype(typeName); isNewtype {
+			errors = append(errors, diagnostics.NewError(diagnostics.ErrA003, n.Receiver.Token, newtypeReceiverError(typeName)))
 		} else {
 			table.RegisterExtensionMethod(typeName, n.Name.Value, fnType)
 		}
 	} else {
-		table.Define(n.Name.Value, fnType, origin)
+		table.DefineFunction(n.Name.Value, fnType, origin)
 	}
 
 	return errors
 }
 
+// newtypeReceiverError explains why a newtype cannot have extension methods:
+// its values are the wrapped values at runtime.
+func newtypeReceiverError(typeName string) string {
+	return fmt.Sprintf("cannot define extension methods on newtype %s: its values are not wrapped at runtime", typeName)
+}
+
 func (w *walker) VisitFunctionStatement(n *ast.FunctionStatement) {
 	// Skip if function was not properly parsed
 	if n == nil || n.Name == nil {
@@ -759,6 +778,7 @@ func (w *walker) VisitFunctionStatement(n *ast.FunctionStatement) {
 		IsVariadic:   isVariadic,
 		DefaultCount: defaultCount,
 		Constraints:  fnConstraints,
+		ParamNames:   paramNames(n.Receiver, n.Parameters),
 	}
 
 	if n.Receiver != nil {
@@ -769,11 +789,14 @@ func (w *walker) VisitFunctionStatement(n *ast.FunctionStatement) {
 				n.Receiver.Token,
 				"invalid receiver type for extension method",
 			))
+		} else if _, isNewtype := outer.GetNewtype(typeName); isNewtype {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, n.Receiver.Token, newtypeReceiverError(typeName)))
 		} else {
 			outer.RegisterExtensionMethod(typeName, n.Name.Value, fnType)
 		}
 	} else {
-		outer.Define(n.Name.Value, fnType, w.currentModuleName)
+		outer.DefineFunction(n.Name.Value, fnType, w.currentModuleName)
+		w.bindFunction(outer, n.Name.Value, n)
 	}
 
 	// 2.5 Register Receiver in scope
@@ -804,7 +827,10 @@ func (w *walker) VisitFunctionStatement(n *ast.FunctionStatement) {
 	if n.Body != nil {
 		prevInLoop := w.inLoop
 		w.inLoop = false
+		packReturn(n.Body, n.ReturnType, retType)
+		leave := w.enterFunction(n)
 		n.Body.Accept(w)
+		leave()
 		w.markTailCalls(n.Body)
 		w.inLoop = prevInLoop
 
@@ -886,6 +912,11 @@ func (w *walker) applySubstToNode(node ast.Node, subst typesystem.Subst) {
 	case *ast.ContinueStatement:
 		// No expression children
 
+	case *ast.TypedHole:
+		if w.inferCtx != nil {
+			w.inferCtx.applySubstToHole(n, subst)
+		}
+
 	case *ast.InstanceDeclaration:
 		for _, method := range n.Methods {
 			w.applySubstToNode(method.Body, subst)
@@ -913,6 +944,9 @@ func (w *walker) applySubstToNode(node ast.Node, subst typesystem.Subst) {
 		for _, arg := range n.Arguments {
 			w.applySubstToNode(arg, subst)
 		}
+		if w.inferCtx != nil {
+			w.inferCtx.applySubstToDecode(n, subst)
+		}
 
 	case *ast.TypeApplicationExpression:
 		w.applySubstToNode(n.Expression, subst)
@@ -958,6 +992,15 @@ func (w *walker) applySubstToNode(node ast.Node, subst typesystem.Subst) {
 			w.applySubstToNode(elem, subst)
 		}
 
+	case *ast.ListComprehension:
+		w.applySubstToNode(n.Output, subst)
+		w.applySubstToClauses(n.Clauses, subst)
+
+	case *ast.MapComprehension:
+		w.applySubstToNode(n.Key, subst)
+		w.applySubstToNode(n.Value, subst)
+		w.applySubstToClauses(n.Clauses, subst)
+
 	case *ast.RecordLiteral:
 		if n.Spread != nil {
 			w.applySubstToNode(n.Spread, subst)
@@ -989,7 +1032,9 @@ func (w *walker) applySubstToNode(node ast.Node, subst typesystem.Subst) {
 		*ast.BooleanLiteral,
 		*ast.NilLiteral,
 		*ast.BigIntLiteral,
+		*ast.SizedIntLiteral,
 		*ast.RationalLiteral,
+		*ast.DecimalLiteral,
 		*ast.StringLiteral,
 		*ast.InterpolatedString,
 		*ast.CharLiteral:
@@ -997,6 +1042,18 @@ func (w *walker) applySubstToNode(node ast.Node, subst typesystem.Subst) {
 	}
 }
 
+// applySubstToClauses applies substitution to comprehension generators and filters.
+func (w *walker) applySubstToClauses(clauses []*ast.ComprehensionClause, subst typesystem.Subst) {
+	for _, clause := range clauses {
+		if clause.Pattern != nil {
+			w.applySubstToNode(clause.Iterable, subst)
+			w.applySubstToPattern(clause.Pattern, subst)
+		} else {
+			w.applySubstToNode(clause.Filter, subst)
+		}
+	}
+}
+
 // applySubstToPattern applies substitution to patterns that may contain sub-patterns.
 func (w *walker) applySubstToPattern(pattern ast.Pattern, subst typesystem.Subst) {
 	if pattern == nil {
@@ -1048,6 +1105,15 @@ func RegisterTypeDeclaration(stmt *ast.TypeDeclarationStatement, table *symbols.
 		return errors
 	}
 
+	if stmt.IsNewtype && len(stmt.TypeParameters) > 0 {
+		errors = append(errors, diagnostics.NewError(
+			diagnostics.ErrA003,
+			stmt.TypeParameters[0].GetToken(),
+			fmt.Sprintf("newtype %s cannot have type parameters", stmt.Name.Value),
+		))
+		return errors
+	}
+
 	// Check for shadowing, but allow overwriting Pending symbols (forward declarations)
 	if table.IsDefined(stmt.Name.Value) {
 		sym, ok := table.Find(stmt.Name.Value)
@@ -1172,6 +1238,10 @@ func RegisterTypeDeclaration(stmt *ast.TypeDeclarationStatement, table *symbols.
 				}
 			}
 		}
+		if stmt.IsNewtype && stmt.TargetType != nil {
+			var ignored []*diagnostics.DiagnosticError // already reported for the constructor
+			table.RegisterNewtype(stmt.Name.Value, BuildType(stmt.TargetType, typeScope, &ignored))
+		}
 	}
 	return errors
 }
@@ -1180,6 +1250,7 @@ func (w *walker) VisitTypeDeclarationStatement(stmt *ast.TypeDeclarationStatemen
 	// Use RegisterTypeDeclaration to register and get errors
 	errs := RegisterTypeDeclaration(stmt, w.symbolTable, w.currentModuleName)
 	w.addErrors(errs)
+	w.deriveInstances(stmt)
 }
 
 func (w *walker) VisitTraitDeclaration(n *ast.TraitDeclaration) {
@@ -1408,6 +1479,19 @@ func (w *walker) VisitInstanceDeclaration(n *ast.InstanceDeclaration) {
 	}
 	targetType := BuildType(n.Target, w.symbolTable, &w.errors)
 
+	// Newtype values are the wrapped values at runtime, so dispatch would
+	// never reach a separate instance
+	if tCon, ok := targetType.(typesystem.TCon); ok {
+		if wrapped, isNewtype := w.symbolTable.GetNewtype(tCon.Name); isNewtype {
+			w.addError(diagnostics.NewError(
+				diagnostics.ErrA003,
+				n.Target.GetToken(),
+				fmt.Sprintf("cannot implement %s for newtype %s: it shares the instances of %s, derive it instead", traitName, tCon.Name, wrapped),
+			))
+			return
+		}
+	}
+
 	// Kind check: For HKT traits like Functor<F>, F must be a type constructor
 	// Use registered kinds (from symbol table) to verify
 	// Automatically detect HKT traits by checking if type param is applied in method signatures
@@ -1843,6 +1927,8 @@ func (w *walker) VisitUnionType(n *ast.UnionType) {
 	}
 }
 
+func (w *walker) VisitDynType(n *ast.DynType) {}
+
 // renameConflictingTypeVars renames type variables in `t` that conflict with `conflictNames`.
 // This is needed when creating substitutions for trait instances where the target type
 // might have type variables with the same name as the trait's type parameters.
@@ -1885,6 +1971,14 @@ func (w *walker) importTraitImplementations(importedTable *symbols.SymbolTable,
 
 	// For each trait, copy implementations for exported types
 	for traitName, impls := range allImpls {
+		// Traits declared in the imported module are only visible qualified (m.Trait)
+		if !w.symbolTable.TraitExists(traitName) {
+			qualifiedName := moduleName + "." + traitName
+			if !w.symbolTable.TraitExists(qualifiedName) {
+				continue
+			}
+			traitName = qualifiedName
+		}
 		for _, implType := range impls {
 			// Check if this implementation should be imported
 			// We import implementations for types that are either:
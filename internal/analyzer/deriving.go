@@ -0,0 +1,438 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/diagnostics"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/token"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// derivableTraits lists the traits accepted in a 'deriving' clause, in the
+// order their instances are synthesized (Equal before its subtrait Order).
+// Show and Json have no instance bodies: the runtimes implement them
+// structurally for every type that derives them.
+var derivableTraits = []string{"Equal", "Order", "Default", "Show", "Json"}
+
+// deriveInstances checks the deriving clause of a type declaration, stores the
+// synthesized instances in stmt.Derived and analyzes them like user instances.
+func (w *walker) deriveInstances(stmt *ast.TypeDeclarationStatement) {
+	stmt.Derived = nil
+	if stmt.Name == nil || len(stmt.Deriving) == 0 {
+		return
+	}
+
+	if stmt.IsNewtype {
+		w.deriveNewtypeInstances(stmt)
+		return
+	}
+
+	requested := make(map[string]*ast.Identifier)
+	for _, trait := range stmt.Deriving {
+		if !isDerivable(trait.Value) {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, trait.Token,
+				fmt.Sprintf("cannot derive %s for %s: only Equal, Order, Default, Show and Json can be derived", trait.Value, stmt.Name.Value)))
+			continue
+		}
+		if _, dup := requested[trait.Value]; dup {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, trait.Token,
+				fmt.Sprintf("%s is derived more than once for %s", trait.Value, stmt.Name.Value)))
+			continue
+		}
+		requested[trait.Value] = trait
+	}
+
+	var record *ast.RecordType
+	if stmt.IsAlias {
+		rt, ok := stmt.TargetType.(*ast.RecordType)
+		if !ok {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, stmt.Deriving[0].Token,
+				fmt.Sprintf("cannot derive instances for %s: deriving needs an ADT or a record type", stmt.Name.Value)))
+			return
+		}
+		record = rt
+	}
+
+	for _, name := range derivableTraits {
+		trait, ok := requested[name]
+		if !ok {
+			continue
+		}
+		d := &deriver{stmt: stmt, record: record, pos: trait.Token}
+
+		var inst *ast.InstanceDeclaration
+		switch name {
+		case "Equal":
+			inst = d.equalInstance()
+			w.symbolTable.RegisterDerivedInstance(name, stmt.Name.Value)
+		case "Order":
+			inst = d.orderInstance()
+			w.symbolTable.RegisterDerivedInstance(name, stmt.Name.Value)
+		case "Default":
+			var err string
+			inst, err = d.defaultInstance()
+			if err != "" {
+				w.addError(diagnostics.NewError(diagnostics.ErrA003, trait.Token,
+					fmt.Sprintf("cannot derive Default for %s: %s", stmt.Name.Value, err)))
+				continue
+			}
+		case "Show":
+			// A user-declared Show trait still gets to see the implementation
+			// so that 'T: Show' constraints accept the type.
+			if sym, ok := w.symbolTable.Find("Show"); ok && sym.Kind == symbols.TraitSymbol {
+				_ = w.symbolTable.RegisterImplementation("Show", BuildType(d.target(), w.symbolTable, &w.errors))
+			}
+			continue
+		default:
+			continue
+		}
+
+		stmt.Derived = append(stmt.Derived, inst)
+		inst.Accept(w)
+	}
+}
+
+// deriveNewtypeInstances lets a newtype reuse instances of the type it wraps.
+// Its values are the wrapped values at runtime, so nothing is synthesized:
+// deriving only makes the analyzer accept the newtype where the trait is
+// required. Show and Json are structural, as for other types.
+func (w *walker) deriveNewtypeInstances(stmt *ast.TypeDeclarationStatement) {
+	wrapped, ok := w.symbolTable.GetNewtype(stmt.Name.Value)
+	if !ok {
+		return
+	}
+	d := &deriver{stmt: stmt}
+	target := BuildType(d.target(), w.symbolTable, &w.errors)
+
+	seen := make(map[string]bool)
+	for _, trait := range stmt.Deriving {
+		if seen[trait.Value] {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, trait.Token,
+				fmt.Sprintf("%s is derived more than once for %s", trait.Value, stmt.Name.Value)))
+			continue
+		}
+		seen[trait.Value] = true
+
+		sym, isTrait := w.symbolTable.Find(trait.Value)
+		isTrait = isTrait && sym.Kind == symbols.TraitSymbol
+		if trait.Value == "Show" || trait.Value == "Json" {
+			if isTrait {
+				_ = w.symbolTable.RegisterImplementation(trait.Value, target)
+			}
+			continue
+		}
+		if !isTrait {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, trait.Token,
+				fmt.Sprintf("cannot derive %s for %s: %s is not a trait", trait.Value, stmt.Name.Value, trait.Value)))
+			continue
+		}
+		if !w.symbolTable.IsImplementationExists(trait.Value, wrapped) {
+			w.addError(diagnostics.NewError(diagnostics.ErrA003, trait.Token,
+				fmt.Sprintf("cannot derive %s for %s: %s does not implement %s", trait.Value, stmt.Name.Value, wrapped, trait.Value)))
+			continue
+		}
+		_ = w.symbolTable.RegisterImplementation(trait.Value, target)
+	}
+}
+
+// derivedArgsImplement reports whether the type arguments of t implement
+// traitName when t's instance of it was derived: MkBox(f) == MkBox(g)
+// compares f and g, so Box<T> is Equal only if T is.
+func derivedArgsImplement(ctx *InferenceContext, table *symbols.SymbolTable, traitName string, t typesystem.Type) bool {
+	app, ok := t.(typesystem.TApp)
+	if !ok {
+		return true
+	}
+	con, ok := app.Constructor.(typesystem.TCon)
+	if !ok || !table.IsDerivedInstance(traitName, con.Name) {
+		return true
+	}
+	for _, arg := range app.Args {
+		if !derivedFieldImplements(ctx, table, traitName, arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// derivedFieldImplements checks a type compared by a derived instance.
+// Records have no Equal instance but compare field by field.
+func derivedFieldImplements(ctx *InferenceContext, table *symbols.SymbolTable, traitName string, t typesystem.Type) bool {
+	if _, isVar := t.(typesystem.TVar); isVar || typeHasConstraint(ctx, t, traitName) {
+		return true
+	}
+	if traitName == "Equal" {
+		rec, isRecord := t.(typesystem.TRecord)
+		if con, ok := t.(typesystem.TCon); ok {
+			if alias, ok := table.GetTypeAlias(con.Name); ok {
+				rec, isRecord = alias.(typesystem.TRecord)
+			}
+		}
+		if isRecord {
+			for _, field := range rec.Fields {
+				if !derivedFieldImplements(ctx, table, traitName, field) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return table.IsImplementationExists(traitName, t) && derivedArgsImplement(ctx, table, traitName, t)
+}
+
+func isDerivable(name string) bool {
+	for _, t := range derivableTraits {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// deriver builds the AST of a derived instance. Every synthesized node carries
+// the position of the trait name in the deriving clause, so errors inside the
+// generated code point there.
+type deriver struct {
+	stmt   *ast.TypeDeclarationStatement
+	record *ast.RecordType // non-nil for record types
+	pos    token.Token
+}
+
+func (d *deriver) tok(t token.TokenType, lexeme string) token.Token {
+	return token.Token{Type: t, Lexeme: lexeme, Literal: lexeme, Line: d.pos.Line, Column: d.pos.Column}
+}
+
+func (d *deriver) ident(name string) *ast.Identifier {
+	return &ast.Identifier{Token: d.tok(token.IDENT_LOWER, name), Value: name}
+}
+
+// target is the instance target type, e.g. Box<T> for 'type Box<T> = ...'.
+func (d *deriver) target() ast.Type {
+	nt := &ast.NamedType{Token: d.tok(token.IDENT_UPPER, d.stmt.Name.Value), Name: d.stmt.Name}
+	for _, tp := range d.stmt.TypeParameters {
+		nt.Args = append(nt.Args, &ast.NamedType{Token: tp.Token, Name: tp})
+	}
+	return nt
+}
+
+func (d *deriver) instance(trait string, methods ...*ast.FunctionStatement) *ast.InstanceDeclaration {
+	return &ast.InstanceDeclaration{
+		Token:     d.tok(token.INSTANCE, "instance"),
+		TraitName: &ast.Identifier{Token: d.tok(token.IDENT_UPPER, trait), Value: trait},
+		Target:    d.target(),
+		Methods:   methods,
+	}
+}
+
+// operator builds 'operator (op)(a: T, b: T) -> Bool { body }'.
+func (d *deriver) operator(op string, body ast.Expression) *ast.FunctionStatement {
+	return &ast.FunctionStatement{
+		Token:    d.tok(token.OPERATOR, "operator"),
+		Name:     &ast.Identifier{Token: d.tok(token.IDENT_LOWER, "("+op+")"), Value: "(" + op + ")"},
+		Operator: op,
+		Parameters: []*ast.Parameter{
+			{Token: d.tok(token.IDENT_LOWER, "a"), Name: d.ident("a"), Type: d.target()},
+			{Token: d.tok(token.IDENT_LOWER, "b"), Name: d.ident("b"), Type: d.target()},
+		},
+		ReturnType: &ast.NamedType{Token: d.tok(token.IDENT_UPPER, "Bool"), Name: &ast.Identifier{Token: d.tok(token.IDENT_UPPER, "Bool"), Value: "Bool"}},
+		Body:       d.block(body),
+	}
+}
+
+func (d *deriver) block(e ast.Expression) *ast.BlockStatement {
+	return &ast.BlockStatement{
+		Token:      d.tok(token.LBRACE, "{"),
+		Statements: []ast.Statement{&ast.ExpressionStatement{Token: e.GetToken(), Expression: e}},
+	}
+}
+
+func (d *deriver) infix(left ast.Expression, op string, right ast.Expression) ast.Expression {
+	return &ast.InfixExpression{Token: d.tok(token.TokenType(op), op), Left: left, Operator: op, Right: right}
+}
+
+func (d *deriver) and(left, right ast.Expression) ast.Expression {
+	if left == nil {
+		return right
+	}
+	return d.infix(left, "&&", right)
+}
+
+func (d *deriver) boolean(v bool) ast.Expression {
+	if v {
+		return &ast.BooleanLiteral{Token: d.tok(token.TRUE, "true"), Value: true}
+	}
+	return &ast.BooleanLiteral{Token: d.tok(token.FALSE, "false"), Value: false}
+}
+
+func (d *deriver) not(e ast.Expression) ast.Expression {
+	return &ast.PrefixExpression{Token: d.tok(token.BANG, "!"), Operator: "!", Right: e}
+}
+
+// fieldPairs returns the operands compared field by field: the fields of both
+// records in name order, or the variables bound by constructor patterns.
+func (d *deriver) fieldPairs(ctor *ast.DataConstructor) (xs, ys []ast.Expression) {
+	if d.record != nil {
+		names := make([]string, 0, len(d.record.Fields))
+		for name := range d.record.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			xs = append(xs, &ast.MemberExpression{Token: d.tok(token.DOT, "."), Left: d.ident("a"), Member: d.ident(name)})
+			ys = append(ys, &ast.MemberExpression{Token: d.tok(token.DOT, "."), Left: d.ident("b"), Member: d.ident(name)})
+		}
+		return xs, ys
+	}
+	for i := range ctor.Parameters {
+		xs = append(xs, d.ident(fmt.Sprintf("x%d", i)))
+		ys = append(ys, d.ident(fmt.Sprintf("y%d", i)))
+	}
+	return xs, ys
+}
+
+// ctorPattern matches ctor binding its fields to prefix0, prefix1, ...
+func (d *deriver) ctorPattern(ctor *ast.DataConstructor, prefix string) ast.Pattern {
+	cp := &ast.ConstructorPattern{Token: ctor.Name.Token, Name: ctor.Name, Elements: []ast.Pattern{}}
+	for i := range ctor.Parameters {
+		if prefix == "" {
+			cp.Elements = append(cp.Elements, &ast.WildcardPattern{Token: d.tok(token.UNDERSCORE, "_")})
+		} else {
+			name := fmt.Sprintf("%s%d", prefix, i)
+			cp.Elements = append(cp.Elements, &ast.IdentifierPattern{Token: d.tok(token.IDENT_LOWER, name), Value: name})
+		}
+	}
+	return cp
+}
+
+// byConstructor builds 'match (a, b) { (C(x..), C(y..)) -> same(C), ..., _ -> other }'
+// for ADTs, and just same(nil) for records.
+func (d *deriver) byConstructor(same func(ctor *ast.DataConstructor) ast.Expression, other ast.Expression) ast.Expression {
+	if d.record != nil {
+		return same(nil)
+	}
+	m := &ast.MatchExpression{
+		Token:      d.tok(token.MATCH, "match"),
+		Expression: &ast.TupleLiteral{Token: d.tok(token.LPAREN, "("), Elements: []ast.Expression{d.ident("a"), d.ident("b")}},
+	}
+	for _, ctor := range d.stmt.Constructors {
+		m.Arms = append(m.Arms, &ast.MatchArm{
+			Pattern:    &ast.TuplePattern{Token: d.tok(token.LPAREN, "("), Elements: []ast.Pattern{d.ctorPattern(ctor, "x"), d.ctorPattern(ctor, "y")}},
+			Expression: same(ctor),
+		})
+	}
+	if len(d.stmt.Constructors) > 1 {
+		m.Arms = append(m.Arms, &ast.MatchArm{Pattern: &ast.WildcardPattern{Token: d.tok(token.UNDERSCORE, "_")}, Expression: other})
+	}
+	return m
+}
+
+func (d *deriver) equalInstance() *ast.InstanceDeclaration {
+	eq := d.byConstructor(func(ctor *ast.DataConstructor) ast.Expression {
+		xs, ys := d.fieldPairs(ctor)
+		var cond ast.Expression
+		for i := range xs {
+			cond = d.and(cond, d.infix(xs[i], "==", ys[i]))
+		}
+		if cond == nil {
+			return d.boolean(true)
+		}
+		return cond
+	}, d.boolean(false))
+
+	return d.instance("Equal",
+		d.operator("==", eq),
+		d.operator("!=", d.not(d.infix(d.ident("a"), "==", d.ident("b")))),
+	)
+}
+
+// orderInstance compares constructors by declaration order first and then
+// their fields lexicographically.
+func (d *deriver) orderInstance() *ast.InstanceDeclaration {
+	less := func(orEqual bool) ast.Expression {
+		return d.byConstructor(func(ctor *ast.DataConstructor) ast.Expression {
+			xs, ys := d.fieldPairs(ctor)
+			if len(xs) == 0 {
+				return d.boolean(orEqual)
+			}
+			last := "<"
+			if orEqual {
+				last = "<="
+			}
+			cond := d.infix(xs[len(xs)-1], last, ys[len(ys)-1])
+			for i := len(xs) - 2; i >= 0; i-- {
+				cond = d.infix(d.infix(xs[i], "<", ys[i]), "||", d.and(d.infix(xs[i], "==", ys[i]), cond))
+			}
+			return cond
+		}, d.infix(d.ctorIndex("a"), "<", d.ctorIndex("b")))
+	}
+
+	return d.instance("Order",
+		d.operator("<", less(false)),
+		d.operator("<=", less(true)),
+		d.operator(">", d.infix(d.ident("b"), "<", d.ident("a"))),
+		d.operator(">=", d.infix(d.ident("b"), "<=", d.ident("a"))),
+	)
+}
+
+// ctorIndex builds 'match v { C0(_) -> 0, C1 -> 1, ... }'.
+func (d *deriver) ctorIndex(v string) ast.Expression {
+	m := &ast.MatchExpression{Token: d.tok(token.MATCH, "match"), Expression: d.ident(v)}
+	for i, ctor := range d.stmt.Constructors {
+		m.Arms = append(m.Arms, &ast.MatchArm{
+			Pattern:    d.ctorPattern(ctor, ""),
+			Expression: &ast.IntegerLiteral{Token: d.tok(token.INT, fmt.Sprint(i)), Value: int64(i)},
+		})
+	}
+	return m
+}
+
+// defaultInstance builds the first constructor applied to the defaults of its
+// fields. Records need no method: 'instance Default R {}' already fills every
+// field with its default.
+func (d *deriver) defaultInstance() (*ast.InstanceDeclaration, string) {
+	if d.record != nil {
+		return d.instance("Default"), ""
+	}
+	if len(d.stmt.Constructors) == 0 {
+		return nil, "it has no constructors"
+	}
+
+	ctor := d.stmt.Constructors[0]
+	var value ast.Expression = &ast.Identifier{Token: ctor.Name.Token, Value: ctor.Name.Value}
+	if len(ctor.Parameters) > 0 {
+		call := &ast.CallExpression{Token: d.tok(token.LPAREN, "("), Function: value}
+		for _, param := range ctor.Parameters {
+			nt, ok := param.(*ast.NamedType)
+			if !ok || len(nt.Args) > 0 || d.isTypeParameter(nt.Name.Value) {
+				return nil, fmt.Sprintf("field of %s has no default value", ctor.Name.Value)
+			}
+			call.Arguments = append(call.Arguments, &ast.CallExpression{
+				Token:     d.tok(token.LPAREN, "("),
+				Function:  d.ident("default"),
+				Arguments: []ast.Expression{&ast.Identifier{Token: nt.Name.Token, Value: nt.Name.Value}},
+			})
+		}
+		value = call
+	}
+
+	getDefault := &ast.FunctionStatement{
+		Token:      d.tok(token.FUN, "fun"),
+		Name:       d.ident("getDefault"),
+		Parameters: []*ast.Parameter{{Token: d.tok(token.IDENT_LOWER, "dummy"), Name: d.ident("dummy"), Type: d.target()}},
+		ReturnType: d.target(),
+		Body:       d.block(value),
+	}
+	return d.instance("Default", getDefault), ""
+}
+
+func (d *deriver) isTypeParameter(name string) bool {
+	for _, tp := range d.stmt.TypeParameters {
+		if tp.Value == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,365 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/diagnostics"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// buildDynType resolves `dyn Trait`. Only traits whose methods take the
+// implementing type as their first parameter, and nowhere else, can be used:
+// a trait object only knows the type of the single value it packs.
+func buildDynType(t *ast.DynType, table *symbols.SymbolTable, errs *[]*diagnostics.DiagnosticError) typesystem.Type {
+	name := t.Trait.Value
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		name = name[idx+1:]
+	}
+	if table == nil {
+		return typesystem.TDyn(name)
+	}
+	if sym, ok := table.Find(name); !ok || sym.Kind != symbols.TraitSymbol {
+		*errs = append(*errs, diagnostics.NewError(
+			diagnostics.ErrA003,
+			t.GetToken(),
+			fmt.Sprintf("cannot use dyn %s: %s is not a trait", name, name),
+		))
+		return typesystem.TDyn(name)
+	}
+	if reason := dynUnsafeReason(name, table); reason != "" {
+		*errs = append(*errs, diagnostics.NewError(
+			diagnostics.ErrA003,
+			t.GetToken(),
+			fmt.Sprintf("trait %s cannot be used as dyn %s: %s", name, name, reason),
+		))
+	}
+	return typesystem.TDyn(name)
+}
+
+// dynUnsafeReason explains why a trait cannot back a trait object, or returns "".
+func dynUnsafeReason(trait string, table *symbols.SymbolTable) string {
+	params, _ := table.GetTraitTypeParams(trait)
+	if len(params) != 1 {
+		return "it has more than one type parameter"
+	}
+	if table.IsHKTTrait(trait) {
+		return "its type parameter is a type constructor"
+	}
+	param := params[0]
+	methods := table.GetTraitAllMethods(trait)
+	// Methods of built-in traits are not listed in the table, and all their
+	// operators take two operands of the implementing type
+	for _, builtin := range config.BuiltinTraits {
+		if builtin.Name == trait {
+			if len(builtin.Operators) > 0 {
+				return fmt.Sprintf("operator (%s) takes a second %s", builtin.Operators[0], param)
+			}
+			methods = append(methods, builtin.Methods...)
+		}
+	}
+	for _, method := range methods {
+		methodType, ok := table.GetTraitMethodType(method)
+		if !ok {
+			continue
+		}
+		fn, ok := methodType.(typesystem.TFunc)
+		if !ok || len(fn.Params) == 0 || !isTypeParamNamed(fn.Params[0], param) {
+			return fmt.Sprintf("method %s does not take %s as its first parameter", method, param)
+		}
+		for _, p := range fn.Params[1:] {
+			if mentionsTypeParam(p, param) {
+				return fmt.Sprintf("method %s takes a second %s", method, param)
+			}
+		}
+		if mentionsTypeParam(fn.ReturnType, param) {
+			return fmt.Sprintf("method %s returns %s", method, param)
+		}
+	}
+	// A trait object implements the supertraits too
+	supers, _ := table.GetTraitSuperTraits(trait)
+	for _, super := range supers {
+		if reason := dynUnsafeReason(super, table); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func isTypeParamNamed(t typesystem.Type, name string) bool {
+	switch t := t.(type) {
+	case typesystem.TVar:
+		return t.Name == name
+	case typesystem.TCon:
+		return t.Name == name && t.Module == ""
+	}
+	return false
+}
+
+func mentionsTypeParam(t typesystem.Type, name string) bool {
+	if isTypeParamNamed(t, name) {
+		return true
+	}
+	switch t := t.(type) {
+	case typesystem.TApp:
+		if mentionsTypeParam(t.Constructor, name) {
+			return true
+		}
+		for _, arg := range t.Args {
+			if mentionsTypeParam(arg, name) {
+				return true
+			}
+		}
+	case typesystem.TTuple:
+		for _, el := range t.Elements {
+			if mentionsTypeParam(el, name) {
+				return true
+			}
+		}
+	case typesystem.TRecord:
+		for _, f := range t.Fields {
+			if mentionsTypeParam(f, name) {
+				return true
+			}
+		}
+	case typesystem.TFunc:
+		for _, p := range t.Params {
+			if mentionsTypeParam(p, name) {
+				return true
+			}
+		}
+		return mentionsTypeParam(t.ReturnType, name)
+	case typesystem.TUnion:
+		for _, m := range t.Types {
+			if mentionsTypeParam(m, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// packsDyn returns the type a value is packed into when it is stored at
+// type t, or nil when t holds no trait objects.
+func packsDyn(t typesystem.Type) typesystem.Type {
+	t = typesystem.UnwrapUnderlying(t)
+	if typesystem.MentionsDyn(t) {
+		return t
+	}
+	return nil
+}
+
+// inferPacked infers expr where a value of type expected (which mentions
+// dyn Trait) is required. List literals are checked element by element, so
+// their elements may have different types as long as each can be packed.
+func inferPacked(ctx *InferenceContext, expr ast.Expression, expected typesystem.Type, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Subst, error) {
+	expected = typesystem.UnwrapUnderlying(expected)
+	if list, ok := expr.(*ast.ListLiteral); ok && isList(expected) {
+		elemType := getListElementType(expected)
+		totalSubst := typesystem.Subst{}
+		for _, el := range list.Elements {
+			var s typesystem.Subst
+			var err error
+			if spread, ok := el.(*ast.SpreadExpression); ok {
+				s, err = inferPacked(ctx, spread.Expression, expected, table, inferFn)
+			} else {
+				s, err = inferPacked(ctx, el, elemType.Apply(totalSubst), table, inferFn)
+			}
+			if err != nil {
+				return nil, err
+			}
+			totalSubst = s.Compose(totalSubst)
+		}
+		if ctx.TypeMap != nil {
+			ctx.TypeMap[list] = expected.Apply(totalSubst)
+		}
+		return totalSubst, nil
+	}
+
+	if m, ok := expr.(*ast.MapLiteral); ok && isMapType(expected) {
+		// Keys are checked as usual, values are packed one by one
+		args := expected.(typesystem.TApp).Args
+		totalSubst := typesystem.Subst{}
+		for _, pair := range m.Pairs {
+			keyType, s, err := inferFn(pair.Key, table)
+			if err != nil {
+				return nil, err
+			}
+			totalSubst = s.Compose(totalSubst)
+			s, err = typesystem.Unify(args[0].Apply(totalSubst), keyType.Apply(totalSubst))
+			if err != nil {
+				return nil, inferErrorf(pair.Key, "map key type mismatch: %s vs %s", args[0].Apply(totalSubst), keyType.Apply(totalSubst))
+			}
+			totalSubst = s.Compose(totalSubst)
+			s, err = inferPacked(ctx, pair.Value, args[1].Apply(totalSubst), table, inferFn)
+			if err != nil {
+				return nil, err
+			}
+			totalSubst = s.Compose(totalSubst)
+		}
+		if ctx.TypeMap != nil {
+			ctx.TypeMap[m] = expected.Apply(totalSubst)
+		}
+		return totalSubst, nil
+	}
+
+	actual, s1, err := inferFn(expr, table)
+	if err != nil {
+		return nil, err
+	}
+	actual = table.ResolveTypeAlias(actual.Apply(s1))
+	s2, err := packInto(expected.Apply(s1), actual, table)
+	if err != nil {
+		return nil, inferErrorf(expr, "cannot use %s as %s: %s", actual, expected, err)
+	}
+	return s2.Compose(s1), nil
+}
+
+// packReturn makes the result of a function body a packing site when the
+// declared return type ret mentions dyn Trait. The result expression is
+// wrapped in an annotation with the return type, so it is checked and packed
+// like `x : dyn Draw`: if and match branches and list elements may have
+// different types that each implement the trait.
+func packReturn(body *ast.BlockStatement, returnType ast.Type, ret typesystem.Type) {
+	if body == nil || returnType == nil || packsDyn(ret) == nil || len(body.Statements) == 0 {
+		return
+	}
+	es, ok := body.Statements[len(body.Statements)-1].(*ast.ExpressionStatement)
+	if !ok {
+		return
+	}
+	if ann, ok := es.Expression.(*ast.AnnotatedExpression); ok && ann.TypeAnnotation == returnType {
+		return // Wrapped by an earlier pass
+	}
+	es.Expression = &ast.AnnotatedExpression{Token: es.Token, Expression: es.Expression, TypeAnnotation: returnType}
+}
+
+// packInto checks that a value of type actual can be stored where expected is
+// required, packing it into trait objects wherever expected says dyn Trait.
+// Inside other type constructors than List and Option the types must match.
+func packInto(expected, actual typesystem.Type, table *symbols.SymbolTable) (typesystem.Subst, error) {
+	expected = typesystem.UnwrapUnderlying(expected)
+	if trait, ok := typesystem.DynTrait(expected); ok {
+		if other, ok := typesystem.DynTrait(actual); ok {
+			if table.TraitExtends(other, trait) {
+				return typesystem.Subst{}, nil
+			}
+			return nil, fmt.Errorf("trait %s does not extend %s", other, trait)
+		}
+		switch a := actual.(type) {
+		case typesystem.TVar:
+			return typesystem.Bind(a, expected)
+		case typesystem.TUnion:
+			for _, member := range a.Types {
+				if _, err := packInto(expected, member, table); err != nil {
+					return nil, err
+				}
+			}
+			return typesystem.Subst{}, nil
+		}
+		if table.IsImplementationExists(trait, actual) {
+			return typesystem.Subst{}, nil
+		}
+		return nil, fmt.Errorf("type %s does not implement trait %s", actual, trait)
+	}
+	if !typesystem.MentionsDyn(expected) {
+		return typesystem.UnifyAllowExtra(expected, actual)
+	}
+
+	switch e := expected.(type) {
+	case typesystem.TApp:
+		a, ok := typesystem.UnwrapUnderlying(actual).(typesystem.TApp)
+		if !ok || len(a.Args) != len(e.Args) || !isPackableContainer(e) {
+			break
+		}
+		subst, err := typesystem.Unify(e.Constructor, a.Constructor)
+		if err != nil {
+			return nil, err
+		}
+		for i := range e.Args {
+			s, err := packInto(e.Args[i].Apply(subst), a.Args[i].Apply(subst), table)
+			if err != nil {
+				return nil, err
+			}
+			subst = s.Compose(subst)
+		}
+		return subst, nil
+	case typesystem.TTuple:
+		a, ok := actual.(typesystem.TTuple)
+		if !ok || len(a.Elements) != len(e.Elements) {
+			break
+		}
+		subst := typesystem.Subst{}
+		for i := range e.Elements {
+			s, err := packInto(e.Elements[i].Apply(subst), a.Elements[i].Apply(subst), table)
+			if err != nil {
+				return nil, err
+			}
+			subst = s.Compose(subst)
+		}
+		return subst, nil
+	case typesystem.TRecord:
+		a, ok := typesystem.UnwrapUnderlying(actual).(typesystem.TRecord)
+		if !ok {
+			break
+		}
+		subst := typesystem.Subst{}
+		for name, field := range e.Fields {
+			actualField, ok := a.Fields[name]
+			if !ok {
+				return nil, fmt.Errorf("missing field %s", name)
+			}
+			s, err := packInto(field.Apply(subst), actualField.Apply(subst), table)
+			if err != nil {
+				return nil, err
+			}
+			subst = s.Compose(subst)
+		}
+		return subst, nil
+	}
+	return typesystem.UnifyAllowExtra(expected, actual)
+}
+
+// isPackableContainer reports whether the runtime can pack the elements of t.
+// Map values are packed, but keys are left as they are.
+func isPackableContainer(t typesystem.TApp) bool {
+	tCon, ok := t.Constructor.(typesystem.TCon)
+	if ok && tCon.Name == config.MapTypeName {
+		return len(t.Args) == 2 && !typesystem.MentionsDyn(t.Args[0])
+	}
+	return ok && (tCon.Name == config.ListTypeName || tCon.Name == config.OptionTypeName)
+}
+
+// isMapType reports whether t is Map<K, V> with keys that are not packed.
+func isMapType(t typesystem.Type) bool {
+	app, ok := t.(typesystem.TApp)
+	if !ok {
+		return false
+	}
+	tCon, ok := app.Constructor.(typesystem.TCon)
+	return ok && tCon.Name == config.MapTypeName && isPackableContainer(app)
+}
+
+// dynOperandType returns the type the right operand of a ++ or ?? is packed
+// into when the left operand l holds dyn Trait values: shapes ++ [c] packs
+// into l itself, m[k] ?? c into the Option's element type. It returns nil
+// when the operand is not packed.
+func dynOperandType(operator string, l typesystem.Type, table *symbols.SymbolTable) typesystem.Type {
+	switch operator {
+	case "++":
+		if pack := packsDyn(l); pack != nil && isList(pack) {
+			return pack
+		}
+	case "??":
+		if !table.IsImplementationExists("Optional", l) {
+			return nil
+		}
+		if inner, ok := getOptionalInnerType(l, table); ok {
+			return packsDyn(inner)
+		}
+	}
+	return nil
+}
@@ -35,7 +35,8 @@ func (w *walker) VisitAssignExpression(expr *ast.AssignExpression) {
 		// Handle Annotation
 		if expr.AnnotatedType != nil {
 			annotType := BuildType(expr.AnnotatedType, w.symbolTable, &w.errors)
-			if varType != nil {
+			// Values packed into dyn Trait are checked by inferAssignExpression
+			if varType != nil && packsDyn(annotType) == nil {
 				// Check if varType is compatible with annotType (subtyping)
 				subst, err := typesystem.UnifyAllowExtra(annotType, varType)
 				if err != nil {
@@ -65,14 +66,19 @@ func (w *walker) VisitAssignExpression(expr *ast.AssignExpression) {
 				))
 				return
 			}
-			// Mutable variable - reassignment allowed
+			// Mutable variable - reassignment allowed, except from another task
+			w.checkCapturedAssignment(ident.Value, expr)
 		} else {
 			// New variable definition
 			w.symbolTable.Define(ident.Value, varType, "")
 		}
+		w.bindFunction(definingScope(w.symbolTable, ident.Value), ident.Value, w.assignedFunction(expr.Value))
 	} else if ma, ok := expr.Left.(*ast.MemberExpression); ok {
 		// Update member
 		ma.Accept(w) // Check if left exists
+		if root := assignmentRoot(ma); root != nil {
+			w.checkCapturedAssignment(root.Value, expr)
+		}
 	} else {
 		w.addError(diagnostics.NewError(
 			diagnostics.ErrA003,
@@ -128,10 +134,27 @@ func (w *walker) VisitCallExpression(expr *ast.CallExpression) {
 	if expr.Function != nil {
 		expr.Function.Accept(w)
 	}
+	if callee, ok := expr.Function.(*ast.Identifier); ok {
+		w.recordCall(callee)
+	}
+	spawner := w.taskSpawnerName(expr)
 	for _, arg := range expr.Arguments {
-		if arg != nil {
+		if arg == nil {
+			continue
+		}
+		if _, isFn := arg.(*ast.FunctionLiteral); isFn && spawner != "" {
+			// The function runs as a task: bindings visible here are shared with it
+			prevScope, prevSpawner := w.taskScope, w.taskSpawner
+			w.taskScope, w.taskSpawner = w.symbolTable, spawner
 			arg.Accept(w)
+			w.taskScope, w.taskSpawner = prevScope, prevSpawner
+			w.spawnFunction(arg, spawner)
+			continue
 		}
+		if spawner != "" {
+			w.spawnFunction(arg, spawner)
+		}
+		arg.Accept(w)
 	}
 }
 
@@ -169,6 +192,8 @@ func (w *walker) VisitSpreadExpression(n *ast.SpreadExpression) {
 	n.Expression.Accept(w)
 }
 
+func (w *walker) VisitDefaultArgument(n *ast.DefaultArgument) {}
+
 func (w *walker) VisitFunctionLiteral(n *ast.FunctionLiteral) {
 	// Similar to FunctionStatement but no name registration in outer scope
 
@@ -204,7 +229,13 @@ func (w *walker) VisitFunctionLiteral(n *ast.FunctionLiteral) {
 	prevInLoop := w.inLoop
 	w.inLoop = false
 	
+	if n.ReturnType != nil {
+		var errs []*diagnostics.DiagnosticError // Reported where the return type is checked
+		packReturn(n.Body, n.ReturnType, BuildType(n.ReturnType, w.symbolTable, &errs))
+	}
+	leave := w.enterFunction(n)
 	n.Body.Accept(w)
+	leave()
 	
 	w.markTailCalls(n.Body) // Mark tail calls in lambda body
 	w.inLoop = prevInLoop
@@ -239,10 +270,16 @@ func (w *walker) VisitIdentifier(ident *ast.Identifier) {
 	// Inference handles undefined checks
 }
 
+func (w *walker) VisitTypedHole(hole *ast.TypedHole) {
+	// Inference records the hole; it is reported at the end of VisitProgram
+}
+
 func (w *walker) VisitIntegerLiteral(lit *ast.IntegerLiteral) {}
 func (w *walker) VisitFloatLiteral(lit *ast.FloatLiteral)     {}
 func (w *walker) VisitBigIntLiteral(lit *ast.BigIntLiteral)   {}
+func (w *walker) VisitSizedIntLiteral(lit *ast.SizedIntLiteral) {}
 func (w *walker) VisitRationalLiteral(lit *ast.RationalLiteral) {}
+func (w *walker) VisitDecimalLiteral(lit *ast.DecimalLiteral)   {}
 func (w *walker) VisitBooleanLiteral(lit *ast.BooleanLiteral) {}
 func (w *walker) VisitNilLiteral(lit *ast.NilLiteral)         {}
 func (w *walker) VisitStringLiteral(n *ast.StringLiteral) {}
@@ -258,6 +295,15 @@ func (w *walker) VisitBytesLiteral(n *ast.BytesLiteral) {}
 
 func (w *walker) VisitBitsLiteral(n *ast.BitsLiteral) {}
 
+func (w *walker) VisitBitsExpression(n *ast.BitsExpression) {
+	for _, seg := range n.Segments {
+		seg.Value.Accept(w)
+		if seg.Size != nil {
+			seg.Size.Accept(w)
+		}
+	}
+}
+
 func (w *walker) VisitTupleLiteral(lit *ast.TupleLiteral) {
 	for _, el := range lit.Elements {
 		el.Accept(w)
@@ -270,6 +316,53 @@ func (w *walker) VisitListLiteral(n *ast.ListLiteral) {
 	}
 }
 
+func (w *walker) VisitListComprehension(n *ast.ListComprehension) {
+	outer := w.symbolTable
+	w.symbolTable = symbols.NewEnclosedSymbolTable(outer)
+	defer func() { w.symbolTable = outer }()
+
+	if w.visitComprehensionClauses(n.Clauses) {
+		n.Output.Accept(w)
+	}
+}
+
+func (w *walker) VisitMapComprehension(n *ast.MapComprehension) {
+	outer := w.symbolTable
+	w.symbolTable = symbols.NewEnclosedSymbolTable(outer)
+	defer func() { w.symbolTable = outer }()
+
+	if w.visitComprehensionClauses(n.Clauses) {
+		n.Key.Accept(w)
+		n.Value.Accept(w)
+	}
+}
+
+// visitComprehensionClauses walks generators and filters, binding generator
+// patterns in the current scope. Errors are reported by inference; it returns
+// false if a pattern could not be bound, to avoid cascading errors.
+func (w *walker) visitComprehensionClauses(clauses []*ast.ComprehensionClause) bool {
+	for _, clause := range clauses {
+		if clause.Pattern == nil {
+			clause.Filter.Accept(w)
+			continue
+		}
+
+		clause.Iterable.Accept(w)
+		var itemType typesystem.Type = w.freshVar()
+		if iterableType, s1, err := InferWithContext(w.inferCtx, clause.Iterable, w.symbolTable); err == nil {
+			iterableType = iterableType.Apply(s1)
+			w.TypeMap[clause.Iterable] = iterableType
+			if t, _ := iterItemType(w.inferCtx, iterableType, w.symbolTable); t != nil {
+				itemType = t
+			}
+		}
+		if _, err := inferPattern(w.inferCtx, clause.Pattern, itemType, w.symbolTable); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func (w *walker) VisitRecordLiteral(n *ast.RecordLiteral) {
 	// Visit spread expression first if present
 	if n.Spread != nil {
@@ -0,0 +1,192 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/diagnostics"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// Typed holes. `?` and `?name` stand in for a missing expression: inference
+// gives the hole a fresh type variable and remembers the local bindings in
+// scope. Once the program is analyzed, each hole is reported with the type
+// it must have, the bindings it could use and the imported functions that fit.
+
+// maxHoleFits caps the candidate functions listed for one hole
+const maxHoleFits = 10
+
+// holeInfo is a typed hole recorded during inference
+type holeInfo struct {
+	hole     *ast.TypedHole
+	typ      typesystem.Type
+	bindings []holeBinding
+}
+
+type holeBinding struct {
+	name   string
+	typ    typesystem.Type
+	origin string // for top-level bindings, the module that defined them
+	global bool
+}
+
+func inferTypedHole(ctx *InferenceContext, hole *ast.TypedHole, table *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error) {
+	info := &holeInfo{hole: hole, typ: ctx.FreshVar()}
+
+	// Inner bindings shadow outer ones. Top-level functions are left out:
+	// they are not what a hole is usually missing, and there are many.
+	seen := make(map[string]bool)
+	for scope := table; scope != nil; scope = scope.Outer() {
+		global := scope.Outer() == nil
+		for name, sym := range scope.All() {
+			if seen[name] || sym.Kind != symbols.VariableSymbol || sym.Type == nil || !isBindingName(name) {
+				continue
+			}
+			if _, isFunc := sym.Type.(typesystem.TFunc); isFunc && global {
+				continue
+			}
+			seen[name] = true
+			info.bindings = append(info.bindings, holeBinding{name: name, typ: sym.Type, origin: sym.OriginModule, global: global})
+		}
+	}
+
+	// A body inferred again replaces the earlier record of its holes
+	for i, h := range ctx.holes {
+		if h.hole == hole {
+			ctx.holes[i] = info
+			return info.typ, typesystem.Subst{}, nil
+		}
+	}
+	ctx.holes = append(ctx.holes, info)
+	return info.typ, typesystem.Subst{}, nil
+}
+
+func isBindingName(name string) bool {
+	return name != "" && name != "_" && name[0] >= 'a' && name[0] <= 'z'
+}
+
+// applySubstToHole resolves the recorded types of a hole as inference
+// of the enclosing expression completes.
+func (ctx *InferenceContext) applySubstToHole(hole *ast.TypedHole, subst typesystem.Subst) {
+	for _, h := range ctx.holes {
+		if h.hole == hole {
+			h.apply(subst)
+		}
+	}
+}
+
+// applySubstToHoles resolves every pending hole. Function bodies use it
+// since their nodes are not rewritten with the final substitution.
+func (ctx *InferenceContext) applySubstToHoles(subst typesystem.Subst) {
+	for _, h := range ctx.holes {
+		h.apply(subst)
+	}
+}
+
+func (h *holeInfo) apply(subst typesystem.Subst) {
+	h.typ = h.typ.Apply(subst)
+	for i := range h.bindings {
+		h.bindings[i].typ = h.bindings[i].typ.Apply(subst)
+	}
+}
+
+// reportHoles turns the holes recorded so far into errors
+func (w *walker) reportHoles() {
+	if w.inferCtx == nil {
+		return
+	}
+	for _, h := range w.inferCtx.holes {
+		w.addError(w.holeError(h))
+	}
+	w.inferCtx.holes = nil
+	w.inferCtx.decodes = nil
+}
+
+func (w *walker) holeError(h *holeInfo) *diagnostics.DiagnosticError {
+	var bindings []holeBinding
+	for _, b := range h.bindings {
+		if !b.global || b.origin == w.currentModuleName {
+			bindings = append(bindings, b)
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].name < bindings[j].name })
+
+	// Rename generated type variables the same way across the whole report
+	types := []typesystem.Type{h.typ}
+	for _, b := range bindings {
+		types = append(types, b.typ)
+	}
+	rename := typesystem.RenameGeneratedVars(types...)
+
+	var details strings.Builder
+	if len(bindings) > 0 {
+		parts := make([]string, len(bindings))
+		for i, b := range bindings {
+			parts[i] = b.name + ": " + b.typ.Apply(rename).String()
+		}
+		details.WriteString("\n  bindings in scope: " + strings.Join(parts, ", "))
+	}
+	if fits := w.holeFits(h.typ); len(fits) > 0 {
+		details.WriteString("\n  valid fits: " + strings.Join(fits, ", "))
+	}
+
+	return diagnostics.NewError(diagnostics.ErrA010, h.hole.Token,
+		"?"+h.hole.Name, h.typ.Apply(rename).String(), details.String())
+}
+
+// holeFits lists the imported functions whose type unifies with t. Nothing
+// is suggested for an unconstrained hole, since every function would fit.
+func (w *walker) holeFits(t typesystem.Type) []string {
+	if _, ok := t.(typesystem.TVar); ok {
+		return nil
+	}
+	fits := func(candidate typesystem.Type) bool {
+		if _, ok := candidate.(typesystem.TFunc); !ok {
+			return false
+		}
+		_, err := typesystem.Unify(InstantiateWithContext(w.inferCtx, candidate), t)
+		return err == nil
+	}
+
+	all := w.symbolTable.All()
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []string
+	for _, name := range names {
+		sym := all[name]
+		switch sym.Kind {
+		case symbols.VariableSymbol:
+			if sym.OriginModule == w.currentModuleName || sym.OriginModule == "prelude" {
+				continue
+			}
+			if fits(sym.Type) {
+				result = append(result, name)
+			}
+		case symbols.ModuleSymbol:
+			record, ok := sym.Type.(typesystem.TRecord)
+			if !ok {
+				continue
+			}
+			fields := make([]string, 0, len(record.Fields))
+			for field := range record.Fields {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				if isBindingName(field) && fits(record.Fields[field]) {
+					result = append(result, name+"."+field)
+				}
+			}
+		}
+	}
+	if len(result) > maxHoleFits {
+		result = append(result[:maxHoleFits], "...")
+	}
+	return result
+}
@@ -18,6 +18,16 @@ type InferenceContext struct {
 	ActiveConstraints map[string][]string
 	// Loader for looking up extension methods and traits in source modules
 	Loader ModuleLoader
+	// Typed holes seen so far, reported after the program is analyzed
+	holes []*holeInfo
+	// Calls decoding into their expected type (see schema.go)
+	decodes []*decodeTarget
+	// Function values passed to task spawners and what they assign to,
+	// checked once all bodies are analyzed (see captures.go)
+	captures taskCaptures
+	// monomorphic holds the type variables of the untyped parameters of the
+	// lambdas being inferred: uses of a parameter share its type
+	monomorphic map[string]bool
 }
 
 // NewInferenceContext creates a new inference context.
@@ -147,13 +157,16 @@ func InferWithContext(ctx *InferenceContext, node ast.Node, table *symbols.Symbo
 	case *ast.AnnotatedExpression:
 		resultType, subst, err = inferAnnotatedExpression(ctx, n, table, recursiveInfer)
 
-	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.BigIntLiteral, *ast.RationalLiteral,
+	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.BigIntLiteral, *ast.SizedIntLiteral, *ast.RationalLiteral, *ast.DecimalLiteral,
 		*ast.TupleLiteral, *ast.RecordLiteral, *ast.ListLiteral, *ast.MapLiteral, *ast.StringLiteral, *ast.FormatStringLiteral, *ast.InterpolatedString, *ast.CharLiteral, *ast.BytesLiteral, *ast.BitsLiteral, *ast.BooleanLiteral, *ast.NilLiteral:
 		resultType, subst, err = inferLiteral(ctx, n, table, recursiveInfer)
 
 	case *ast.Identifier:
 		resultType, subst, err = inferIdentifier(ctx, n, table)
 
+	case *ast.TypedHole:
+		resultType, subst, err = inferTypedHole(ctx, n, table)
+
 	case *ast.IfExpression:
 		resultType, subst, err = inferIfExpression(ctx, n, table, recursiveInfer)
 
@@ -175,6 +188,9 @@ func InferWithContext(ctx *InferenceContext, node ast.Node, table *symbols.Symbo
 	case *ast.SpreadExpression:
 		resultType, subst, err = inferSpreadExpression(ctx, n, table, recursiveInfer)
 
+	case *ast.DefaultArgument:
+		resultType, subst = ctx.FreshVar(), typesystem.Subst{}
+
 	case *ast.MemberExpression:
 		resultType, subst, err = inferMemberExpression(ctx, n, table, recursiveInfer)
 
@@ -183,6 +199,12 @@ func InferWithContext(ctx *InferenceContext, node ast.Node, table *symbols.Symbo
 
 	case *ast.CallExpression:
 		resultType, subst, err = inferCallExpression(ctx, n, table, recursiveInfer)
+		if err == nil {
+			resultType, subst, err = defaultIntResult(n, resultType, subst, table)
+		}
+		if err == nil {
+			ctx.trackDecodeTarget(n, resultType.Apply(subst), table)
+		}
 
 	case *ast.PrefixExpression:
 		resultType, subst, err = inferPrefixExpression(ctx, n, table, recursiveInfer)
@@ -199,6 +221,15 @@ func InferWithContext(ctx *InferenceContext, node ast.Node, table *symbols.Symbo
 	case *ast.ForExpression:
 		resultType, subst, err = inferForExpression(ctx, n, table, recursiveInfer)
 
+	case *ast.ListComprehension:
+		resultType, subst, err = inferListComprehension(ctx, n, table, recursiveInfer)
+
+	case *ast.MapComprehension:
+		resultType, subst, err = inferMapComprehension(ctx, n, table, recursiveInfer)
+
+	case *ast.BitsExpression:
+		resultType, subst, err = inferBitsExpression(ctx, n, table, recursiveInfer)
+
 	case *ast.BreakStatement:
 		resultType, subst, err = inferBreakStatement(ctx, n, table, recursiveInfer)
 
@@ -243,7 +274,9 @@ func InstantiateWithContext(ctx *InferenceContext, t typesystem.Type) typesystem
 
 	subst := typesystem.Subst{}
 	for _, v := range vars {
-		subst[v.Name] = ctx.FreshVar()
+		if !ctx.monomorphic[v.Name] {
+			subst[v.Name] = ctx.FreshVar()
+		}
 	}
 	return t.Apply(subst)
 }
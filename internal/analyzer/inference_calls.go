@@ -44,12 +44,55 @@ func inferCallExpression(ctx *InferenceContext, n *ast.CallExpression, table *sy
 		// Note: Function types from inferIdentifier are already instantiated.
 		// We don't instantiate again here to keep TypeMap entries consistent.
 
+		// Arguments passed by name are put in parameter order
+		if err := resolveNamedArgs(n, tFunc, table, inferFn); err != nil {
+			return nil, nil, err
+		}
+
+		// Arguments line up with parameters unless something is spread
+		hasSpread := false
+		for _, arg := range n.Arguments {
+			if _, ok := arg.(*ast.SpreadExpression); ok {
+				hasSpread = true
+			}
+		}
+		n.PackArgs = nil
+
 		for i, arg := range n.Arguments {
 			isSpread := false
 			if _, ok := arg.(*ast.SpreadExpression); ok {
 				isSpread = true
 			}
 
+			// A skipped parameter keeps its default
+			if _, ok := arg.(*ast.DefaultArgument); ok {
+				paramIdx++
+				continue
+			}
+
+			// Arguments to dyn Trait parameters are packed into trait objects
+			fixedCount := len(tFunc.Params)
+			if tFunc.IsVariadic {
+				fixedCount--
+			}
+			if !isSpread && paramIdx < fixedCount {
+				if pack := packsDyn(tFunc.Params[paramIdx].Apply(totalSubst)); pack != nil {
+					sArg, err := inferPacked(ctx, arg, pack, table, inferFn)
+					if err != nil {
+						return nil, nil, err
+					}
+					totalSubst = sArg.Compose(totalSubst)
+					if !hasSpread {
+						if n.PackArgs == nil {
+							n.PackArgs = make([]typesystem.Type, len(n.Arguments))
+						}
+						n.PackArgs[i] = pack.Apply(totalSubst)
+					}
+					paramIdx++
+					continue
+				}
+			}
+
 			argType, sArg, err := inferFn(arg, table)
 			if err != nil {
 				return nil, nil, err
@@ -223,6 +266,9 @@ func inferCallExpression(ctx *InferenceContext, n *ast.CallExpression, table *sy
 				DefaultCount: max(0, tFunc.DefaultCount-(fixedCount-paramIdx)),
 				Constraints:  tFunc.Constraints,
 			}
+			if len(tFunc.ParamNames) == len(tFunc.Params) {
+				partialFuncType.ParamNames = tFunc.ParamNames[paramIdx:]
+			}
 			return partialFuncType, totalSubst, nil
 		}
 
@@ -242,6 +288,9 @@ func inferCallExpression(ctx *InferenceContext, n *ast.CallExpression, table *sy
 			if !table.IsImplementationExists(c.Trait, concreteType) && !typeHasConstraint(ctx, concreteType, c.Trait) {
 				return nil, nil, inferErrorf(n, "type %s does not implement trait %s", concreteType, c.Trait)
 			}
+			if !derivedArgsImplement(ctx, table, c.Trait, concreteType) {
+				return nil, nil, inferErrorf(n, "type %s does not implement trait %s", concreteType, c.Trait)
+			}
 		}
 
 		return tFunc.ReturnType.Apply(totalSubst), totalSubst, nil
@@ -309,3 +358,29 @@ func getListElementType(t typesystem.Type) typesystem.Type {
 	}
 	return typesystem.TVar{Name: "unknown"}
 }
+
+// intResultBuiltins maps the builtins whose integer result type is set by an
+// optional third argument, a type, to the package defining them. Called
+// without it they return Int.
+var intResultBuiltins = map[string]string{
+	"bytesDecodeInt": "bytes",
+}
+
+// defaultIntResult fixes the result of a call to one of intResultBuiltins
+// to Int when the type argument is left out.
+func defaultIntResult(n *ast.CallExpression, resultType typesystem.Type, subst typesystem.Subst, table *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error) {
+	name := libraryFunctionName(n, intResultBuiltins, table)
+	if name == "" || len(n.Arguments) >= 3 {
+		return resultType, subst, nil
+	}
+	for _, arg := range n.Arguments {
+		if _, ok := arg.(*ast.SpreadExpression); ok {
+			return resultType, subst, nil
+		}
+	}
+	s, err := typesystem.Unify(typesystem.Int, resultType.Apply(subst))
+	if err != nil {
+		return nil, nil, inferErrorf(n, "%s returns Int unless a type is passed, got %s", name, resultType.Apply(subst))
+	}
+	return typesystem.Int, s.Compose(subst), nil
+}
@@ -46,7 +46,7 @@ func inferIfExpression(ctx *InferenceContext, n *ast.IfExpression, table *symbol
 		}
 		totalSubst = subst.Compose(totalSubst)
 
-		return conseqType.Apply(totalSubst), totalSubst, nil
+		return typesystem.JoinCallInfo(conseqType.Apply(totalSubst), altType.Apply(totalSubst)), totalSubst, nil
 	} else {
 		// No else clause: if consequence returns Nil, that's fine
 		// Otherwise, result is T | Nil where T is consequence type
@@ -127,7 +127,7 @@ func inferMatchExpression(ctx *InferenceContext, n *ast.MatchExpression, table *
 				continue
 			}
 			totalSubst = subst.Compose(totalSubst)
-			resType = resType.Apply(totalSubst)
+			resType = typesystem.JoinCallInfo(resType.Apply(totalSubst), armType.Apply(totalSubst))
 		}
 	}
 
@@ -201,6 +201,66 @@ func inferBlockStatement(ctx *InferenceContext, n *ast.BlockStatement, table *sy
 	return lastType.Apply(totalSubst), totalSubst, nil
 }
 
+// iterItemType returns the element type produced by iterating over iterableType:
+// T for List<T>, or the Option payload of the iterator returned by the Iter
+// instance. itemType is nil if the type is not iterable.
+func iterItemType(ctx *InferenceContext, iterableType typesystem.Type, table *symbols.SymbolTable) (typesystem.Type, typesystem.Subst) {
+	// Direct support for List<T>
+	if itemType := listItemType(iterableType); itemType != nil {
+		return itemType, typesystem.Subst{}
+	}
+
+	// The signature of the type's own instance ties the items to the type
+	// arguments (Set<T> yields T)
+	if typeName := getCanonicalTypeName(iterableType); typeName != "" {
+		if iterType, ok := table.GetInstanceMethodType(config.IterTraitName, typeName, config.IterMethodName); ok {
+			if itemType, subst := iteratorItemType(ctx, iterType, iterableType); itemType != nil {
+				return itemType, subst
+			}
+		}
+	}
+
+	// Check for iter method via Iter trait protocol
+	// We look for an iter function that can handle this type.
+	if iterSym, ok := table.Find(config.IterMethodName); ok {
+		return iteratorItemType(ctx, iterSym.Type, iterableType)
+	}
+	return nil, typesystem.Subst{}
+}
+
+// listItemType returns T for List<T>, and nil for any other type
+func listItemType(t typesystem.Type) typesystem.Type {
+	tApp, ok := t.(typesystem.TApp)
+	if !ok || len(tApp.Args) != 1 {
+		return nil
+	}
+	if tCon, ok := tApp.Constructor.(typesystem.TCon); ok && tCon.Name == config.ListTypeName {
+		return tApp.Args[0]
+	}
+	return nil
+}
+
+// iteratorItemType applies an iter signature, (C) -> () -> Option<T>, to
+// iterableType and returns T, or nil if the signature does not fit.
+func iteratorItemType(ctx *InferenceContext, iterType typesystem.Type, iterableType typesystem.Type) (typesystem.Type, typesystem.Subst) {
+	tFunc, ok := InstantiateWithContext(ctx, iterType).(typesystem.TFunc)
+	if !ok || len(tFunc.Params) == 0 {
+		return nil, typesystem.Subst{}
+	}
+	subst, err := typesystem.Unify(tFunc.Params[0], iterableType)
+	if err != nil {
+		return nil, typesystem.Subst{}
+	}
+	if iteratorFunc, ok := tFunc.ReturnType.Apply(subst).(typesystem.TFunc); ok {
+		if tApp, ok := iteratorFunc.ReturnType.(typesystem.TApp); ok {
+			if tCon, ok := tApp.Constructor.(typesystem.TCon); ok && tCon.Name == config.OptionTypeName && len(tApp.Args) >= 1 {
+				return tApp.Args[0], subst
+			}
+		}
+	}
+	return nil, typesystem.Subst{}
+}
+
 func inferForExpression(ctx *InferenceContext, n *ast.ForExpression, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Type, typesystem.Subst, error) {
 	loopScope := symbols.NewEnclosedSymbolTable(table)
 	totalSubst := typesystem.Subst{}
@@ -213,38 +273,8 @@ func inferForExpression(ctx *InferenceContext, n *ast.ForExpression, table *symb
 		totalSubst = s1.Compose(totalSubst)
 		iterableType = iterableType.Apply(totalSubst)
 
-		var itemType typesystem.Type
-
-		// Direct support for List<T>
-		if tApp, ok := iterableType.(typesystem.TApp); ok {
-			if tCon, ok := tApp.Constructor.(typesystem.TCon); ok && tCon.Name == config.ListTypeName && len(tApp.Args) == 1 {
-				itemType = tApp.Args[0]
-			}
-		}
-
-		if itemType == nil {
-			// Check for iter method via Iter trait protocol
-			// We look for an iter function that can handle this type.
-			if iterSym, ok := table.Find(config.IterMethodName); ok {
-				iterType := InstantiateWithContext(ctx, iterSym.Type)
-				if tFunc, ok := iterType.(typesystem.TFunc); ok && len(tFunc.Params) > 0 {
-					subst, err := typesystem.Unify(tFunc.Params[0], iterableType)
-					if err == nil {
-						totalSubst = subst.Compose(totalSubst)
-						retType := tFunc.ReturnType.Apply(totalSubst)
-
-						if iteratorFunc, ok := retType.(typesystem.TFunc); ok {
-							iteratorRet := iteratorFunc.ReturnType
-							if tApp, ok := iteratorRet.(typesystem.TApp); ok {
-								if tCon, ok := tApp.Constructor.(typesystem.TCon); ok && tCon.Name == config.OptionTypeName && len(tApp.Args) >= 1 {
-									itemType = tApp.Args[0]
-								}
-							}
-						}
-					}
-				}
-			}
-		}
+		itemType, sIter := iterItemType(ctx, iterableType, table)
+		totalSubst = sIter.Compose(totalSubst)
 
 		if itemType == nil {
 			return nil, nil, inferErrorf(n.Iterable, "iterable in for-loop must be List or implement Iter trait, got %s", iterableType)
@@ -314,3 +344,93 @@ func inferBreakStatement(ctx *InferenceContext, n *ast.BreakStatement, table *sy
 func inferContinueStatement(ctx *InferenceContext, n *ast.ContinueStatement) (typesystem.Type, typesystem.Subst, error) {
 	return typesystem.Nil, typesystem.Subst{}, nil
 }
+
+// inferComprehensionClauses binds generator patterns in scope, in order, and
+// checks that filters are Bool.
+func inferComprehensionClauses(ctx *InferenceContext, clauses []*ast.ComprehensionClause, scope *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Subst, error) {
+	totalSubst := typesystem.Subst{}
+
+	for _, clause := range clauses {
+		if clause.Pattern == nil {
+			condType, s1, err := inferFn(clause.Filter, scope)
+			if err != nil {
+				return nil, err
+			}
+			totalSubst = s1.Compose(totalSubst)
+
+			subst, err := typesystem.Unify(typesystem.Bool, condType.Apply(totalSubst))
+			if err != nil {
+				return nil, inferErrorf(clause.Filter, "comprehension filter must be Bool, got %s", condType.Apply(totalSubst))
+			}
+			totalSubst = subst.Compose(totalSubst)
+			continue
+		}
+
+		iterableType, s1, err := inferFn(clause.Iterable, scope)
+		if err != nil {
+			return nil, err
+		}
+		totalSubst = s1.Compose(totalSubst)
+		iterableType = iterableType.Apply(totalSubst)
+
+		itemType, sIter := iterItemType(ctx, iterableType, scope)
+		if itemType == nil {
+			return nil, inferErrorf(clause.Iterable, "comprehension source must be List or implement Iter trait, got %s", iterableType)
+		}
+		totalSubst = sIter.Compose(totalSubst)
+
+		patSubst, err := inferPattern(ctx, clause.Pattern, itemType.Apply(totalSubst), scope)
+		if err != nil {
+			return nil, err
+		}
+		totalSubst = patSubst.Compose(totalSubst)
+	}
+
+	return totalSubst, nil
+}
+
+func inferListComprehension(ctx *InferenceContext, n *ast.ListComprehension, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Type, typesystem.Subst, error) {
+	scope := symbols.NewEnclosedSymbolTable(table)
+
+	totalSubst, err := inferComprehensionClauses(ctx, n.Clauses, scope, inferFn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outType, s1, err := inferFn(n.Output, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalSubst = s1.Compose(totalSubst)
+
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: config.ListTypeName},
+		Args:        []typesystem.Type{outType.Apply(totalSubst)},
+	}, totalSubst, nil
+}
+
+func inferMapComprehension(ctx *InferenceContext, n *ast.MapComprehension, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Type, typesystem.Subst, error) {
+	scope := symbols.NewEnclosedSymbolTable(table)
+
+	totalSubst, err := inferComprehensionClauses(ctx, n.Clauses, scope, inferFn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyType, s1, err := inferFn(n.Key, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalSubst = s1.Compose(totalSubst)
+
+	valType, s2, err := inferFn(n.Value, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalSubst = s2.Compose(totalSubst)
+
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: config.MapTypeName},
+		Args:        []typesystem.Type{keyType.Apply(totalSubst), valType.Apply(totalSubst)},
+	}, totalSubst, nil
+}
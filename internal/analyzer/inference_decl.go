@@ -9,14 +9,6 @@ import (
 )
 
 func inferAnnotatedExpression(ctx *InferenceContext, n *ast.AnnotatedExpression, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) (typesystem.Type, typesystem.Subst, error) {
-	// Infer type of inner expression
-	exprType, s1, err := inferFn(n.Expression, table)
-	if err != nil {
-		return nil, nil, err
-	}
-	totalSubst := s1
-	exprType = exprType.Apply(totalSubst)
-
 	if n.TypeAnnotation == nil {
 		return nil, nil, inferError(n, "missing type annotation")
 	}
@@ -28,6 +20,24 @@ func inferAnnotatedExpression(ctx *InferenceContext, n *ast.AnnotatedExpression,
 		return nil, nil, err
 	}
 
+	// Annotating with dyn Trait packs the value into a trait object
+	if pack := packsDyn(annotatedType); pack != nil {
+		subst, err := inferPacked(ctx, n.Expression, pack, table, inferFn)
+		if err != nil {
+			return nil, nil, err
+		}
+		n.Pack = pack.Apply(subst)
+		return annotatedType.Apply(subst), subst, nil
+	}
+
+	// Infer type of inner expression
+	exprType, s1, err := inferFn(n.Expression, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalSubst := s1
+	exprType = exprType.Apply(totalSubst)
+
 	// Unify them (Check if exprType is a subtype of annotatedType)
 	// Swap args: Expected, Actual
 	subst, err := typesystem.UnifyAllowExtra(annotatedType, exprType)
@@ -76,7 +86,26 @@ func inferIdentifier(ctx *InferenceContext, n *ast.Identifier, table *symbols.Sy
 }
 
 func inferAssignExpression(ctx *InferenceContext, n *ast.AssignExpression, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error), typeMap map[ast.Node]typesystem.Type) (typesystem.Type, typesystem.Subst, error) {
-	valType, s1, err := inferFn(n.Value, table)
+	var explicitType typesystem.Type
+	if n.AnnotatedType != nil {
+		var errs []*diagnostics.DiagnosticError
+		explicitType = BuildType(n.AnnotatedType, table, &errs)
+		if err := wrapBuildTypeError(errs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var valType typesystem.Type
+	var s1 typesystem.Subst
+	var err error
+	if pack := packsDyn(explicitType); pack != nil {
+		// x: dyn Trait = value packs the value into a trait object
+		s1, err = inferPacked(ctx, n.Value, pack, table, inferFn)
+		valType = explicitType
+		n.Pack = pack
+	} else {
+		valType, s1, err = inferFn(n.Value, table)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -87,11 +116,6 @@ func inferAssignExpression(ctx *InferenceContext, n *ast.AssignExpression, table
 	declaredType := valType
 
 	if n.AnnotatedType != nil {
-		var errs []*diagnostics.DiagnosticError
-		explicitType := BuildType(n.AnnotatedType, table, &errs)
-		if err := wrapBuildTypeError(errs); err != nil {
-			return nil, nil, err
-		}
 
 		// Unify: TCon with UnderlyingType will automatically unify with TRecord
 		subst, err := typesystem.UnifyAllowExtra(explicitType, valType)
@@ -121,12 +145,24 @@ func inferAssignExpression(ctx *InferenceContext, n *ast.AssignExpression, table
 			// It exists. Unify types.
 			// Note: If sym.Type is nil?
 			if sym.Type != nil {
+				// Reassigning a dyn Trait variable packs the new value
+				if pack := packsDyn(sym.Type); pack != nil && n.AnnotatedType == nil {
+					subst, err := packInto(pack, valType, table)
+					if err != nil {
+						return nil, nil, inferErrorf(n, "cannot assign %s to variable %s of type %s", valType, ident.Value, sym.Type)
+					}
+					n.Pack = pack
+					totalSubst = subst.Compose(totalSubst)
+					return sym.Type.Apply(totalSubst), totalSubst, nil
+				}
 				// Allow subtype assignment
 				subst, err := typesystem.UnifyAllowExtra(sym.Type, valType)
 				if err != nil {
 					return nil, nil, inferErrorf(n, "cannot assign %s to variable %s of type %s", valType, ident.Value, sym.Type)
 				}
 				totalSubst = subst.Compose(totalSubst)
+				// Parameter names and defaults are only kept if the new value has them too
+				_ = table.Reassign(ident.Value, typesystem.JoinCallInfo(sym.Type, valType.Apply(totalSubst)))
 				return valType.Apply(totalSubst), totalSubst, nil
 			}
 		} else {
@@ -186,7 +222,13 @@ func inferFunctionLiteral(ctx *InferenceContext, n *ast.FunctionLiteral, table *
 				return nil, nil, err
 			}
 		} else {
-			pt = ctx.FreshVar()
+			tv := ctx.FreshVar()
+			if ctx.monomorphic == nil {
+				ctx.monomorphic = make(map[string]bool)
+			}
+			ctx.monomorphic[tv.Name] = true
+			defer delete(ctx.monomorphic, tv.Name)
+			pt = tv
 		}
 
 		// Store element type in signature
@@ -211,6 +253,10 @@ func inferFunctionLiteral(ctx *InferenceContext, n *ast.FunctionLiteral, table *
 	}
 
 	// 3. Infer body
+	if n.ReturnType != nil {
+		var errs []*diagnostics.DiagnosticError // Reported where the return type is checked
+		packReturn(n.Body, n.ReturnType, BuildType(n.ReturnType, enclosedTable, &errs))
+	}
 	bodyType, sBody, err := inferFn(n.Body, enclosedTable)
 	if err != nil {
 		return nil, nil, err
@@ -248,6 +294,7 @@ func inferFunctionLiteral(ctx *InferenceContext, n *ast.FunctionLiteral, table *
 		ReturnType:   bodyType,
 		IsVariadic:   isVariadic,
 		DefaultCount: defaultCount,
+		ParamNames:   paramNames(nil, n.Parameters),
 	}, totalSubst, nil
 }
 
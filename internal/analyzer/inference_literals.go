@@ -19,9 +19,34 @@ func inferLiteral(ctx *InferenceContext, node ast.Node, table *symbols.SymbolTab
 	case *ast.BigIntLiteral:
 		return typesystem.BigInt, typesystem.Subst{}, nil
 
+	case *ast.SizedIntLiteral:
+		// Signed magnitudes may reach 2^(N-1) so that the minimum can be
+		// written; only its negation fits.
+		info := config.GetIntTypeInfo(n.Type)
+		if n.Negative && !info.Signed && n.Value != 0 {
+			return nil, nil, inferErrorf(n, "%s cannot be negative", n.Type)
+		}
+		limit := n.Value
+		if info.Signed && n.Negative {
+			limit--
+		}
+		if info.Bits < 64 || info.Signed {
+			bits := info.Bits
+			if info.Signed {
+				bits--
+			}
+			if limit >= uint64(1)<<bits {
+				return nil, nil, inferErrorf(n, "integer literal out of range for %s", n.Type)
+			}
+		}
+		return typesystem.TCon{Name: n.Type}, typesystem.Subst{}, nil
+
 	case *ast.RationalLiteral:
 		return typesystem.Rational, typesystem.Subst{}, nil
 
+	case *ast.DecimalLiteral:
+		return typesystem.Decimal, typesystem.Subst{}, nil
+
 	case *ast.BooleanLiteral:
 		return typesystem.Bool, typesystem.Subst{}, nil
 
@@ -231,7 +256,7 @@ func inferLiteral(ctx *InferenceContext, node ast.Node, table *symbols.SymbolTab
 					return nil, nil, inferErrorf(node, "list element type mismatch: %s vs %s", elemType, itemType)
 				}
 				totalSubst = subst.Compose(totalSubst)
-				elemType = elemType.Apply(totalSubst)
+				elemType = typesystem.JoinCallInfo(elemType.Apply(totalSubst), itemType.Apply(totalSubst))
 			}
 
 			return typesystem.TApp{
@@ -32,7 +32,7 @@ func eitherHasConstraint(ctx *InferenceContext, table *symbols.SymbolTable, l, r
 	// First check local implementations
 	if table.IsImplementationExists(traitName, l) ||
 		table.IsImplementationExists(traitName, r) {
-		return true
+		return derivedArgsImplement(ctx, table, traitName, l) && derivedArgsImplement(ctx, table, traitName, r)
 	}
 
 	// Then check in source modules (for types with module prefix)
@@ -46,6 +46,12 @@ func eitherHasConstraint(ctx *InferenceContext, table *symbols.SymbolTable, l, r
 		typeHasConstraint(ctx, r, traitName)
 }
 
+// isSizedIntType reports whether t is one of Int8 ... UInt64
+func isSizedIntType(t typesystem.Type) bool {
+	tCon, ok := t.(typesystem.TCon)
+	return ok && config.IsSizedIntType(tCon.Name)
+}
+
 // getTraitForOp returns the expected trait name for an operator (for error messages)
 func getTraitForOp(op string) string {
 	switch op {
@@ -88,8 +94,13 @@ func inferPrefixExpression(ctx *InferenceContext, n *ast.PrefixExpression, table
 		} else if subst, err := typesystem.Unify(right, typesystem.Rational); err == nil {
 			totalSubst = subst.Compose(totalSubst)
 			return typesystem.Rational, totalSubst, nil
+		} else if subst, err := typesystem.Unify(right, typesystem.Decimal); err == nil {
+			totalSubst = subst.Compose(totalSubst)
+			return typesystem.Decimal, totalSubst, nil
+		} else if isSizedIntType(right) {
+			return right, totalSubst, nil
 		} else {
-			return nil, nil, inferErrorf(n, "operator '-' expects Int, Float, BigInt or Rational, got %s", right)
+			return nil, nil, inferErrorf(n, "operator '-' expects Int, Float, BigInt, Rational, Decimal or a sized integer, got %s", right)
 		}
 	case "!":
 		subst, err := typesystem.Unify(right, typesystem.Bool)
@@ -99,6 +110,9 @@ func inferPrefixExpression(ctx *InferenceContext, n *ast.PrefixExpression, table
 		totalSubst = subst.Compose(totalSubst)
 		return typesystem.Bool, totalSubst, nil
 	case "~":
+		if isSizedIntType(right) {
+			return right, totalSubst, nil
+		}
 		subst, err := typesystem.Unify(right, typesystem.Int)
 		if err != nil {
 			return nil, nil, inferErrorf(n, "operator '~' expects Int, got %s", right)
@@ -118,6 +132,22 @@ func inferInfixExpression(ctx *InferenceContext, n *ast.InfixExpression, table *
 	totalSubst := s1
 	l = l.Apply(totalSubst)
 
+	// The other operand of a list or Option of dyn Trait is packed like a
+	// value stored there
+	n.PackLeft, n.PackRight = nil, nil
+	if pack := dynOperandType(n.Operator, l, table); pack != nil {
+		s2, err := inferPacked(ctx, n.Right, pack, table, inferFn)
+		if err != nil {
+			return nil, nil, err
+		}
+		totalSubst = s2.Compose(totalSubst)
+		n.PackRight = pack.Apply(totalSubst)
+		if n.Operator == "??" {
+			return n.PackRight, totalSubst, nil
+		}
+		return l.Apply(totalSubst), totalSubst, nil
+	}
+
 	r, s2, err := inferFn(n.Right, table)
 	if err != nil {
 		return nil, nil, err
@@ -186,6 +216,13 @@ func inferInfixExpression(ctx *InferenceContext, n *ast.InfixExpression, table *
 		}
 
 	case "&", "|", "^", "<<", ">>":
+		// Sized integers shift by an Int
+		if (n.Operator == "<<" || n.Operator == ">>") && isSizedIntType(l) {
+			if subst, err := typesystem.Unify(r, typesystem.Int); err == nil {
+				return l, subst.Compose(totalSubst), nil
+			}
+		}
+
 		// First, check if there's a trait implementation for this operator on the type
 		if traitName, ok := table.GetTraitForOperator(n.Operator); ok {
 			if eitherHasConstraint(ctx, table, l, r, traitName) {
@@ -299,6 +336,13 @@ func inferInfixExpression(ctx *InferenceContext, n *ast.InfixExpression, table *
 			}
 		}
 
+		// A derived Equal compares the fields with their own Equal
+		for _, t := range []typesystem.Type{l, r} {
+			if !derivedArgsImplement(ctx, table, "Equal", t) {
+				return nil, nil, inferErrorf(n, "type %s does not implement trait Equal", t)
+			}
+		}
+
 		// Fallback to built-in equality
 		subst, err := typesystem.Unify(l, r)
 		if err != nil {
@@ -342,6 +386,17 @@ func inferInfixExpression(ctx *InferenceContext, n *ast.InfixExpression, table *
 		return innerType.Apply(totalSubst), totalSubst, nil
 
 	case "++":
+		// [c] ++ shapes packs the left list into the right one's type
+		if pack := packsDyn(r); pack != nil && isList(pack) && packsDyn(l) == nil {
+			subst, err := packInto(pack, l, table)
+			if err != nil {
+				return nil, nil, inferErrorf(n.Left, "cannot use %s as %s: %s", l, pack, err)
+			}
+			totalSubst = subst.Compose(totalSubst)
+			n.PackLeft = pack.Apply(totalSubst)
+			return n.PackLeft, totalSubst, nil
+		}
+
 		// First check if type implements Concat trait
 		if table.IsImplementationExists("Concat", l) {
 			// User-defined Concat - operands must be same type, return same type
@@ -581,6 +636,13 @@ func inferInfixExpression(ctx *InferenceContext, n *ast.InfixExpression, table *
 	}
 }
 
+// readableTypes renames the generated type variables of two types reported
+// together, so messages do not depend on how many variables inference made.
+func readableTypes(got, want typesystem.Type) (typesystem.Type, typesystem.Type) {
+	rename := typesystem.RenameGeneratedVars(got, want)
+	return got.Apply(rename), want.Apply(rename)
+}
+
 // inferHKTOperator handles type inference for Higher-Kinded Type operators
 // like <*> (Applicative) and >>= (Monad) where operand types are different.
 // For example: <*> : F<(A -> B)> -> F<A> -> F<B>
@@ -612,7 +674,8 @@ func inferHKTOperator(ctx *InferenceContext, n *ast.InfixExpression, l, r typesy
 	// e.g., Option<(Int) -> Int> with F<(A -> B)>
 	subst1, err := typesystem.Unify(freshMethodType.Params[0], l)
 	if err != nil {
-		return nil, nil, inferErrorf(n, "left operand type %s does not match expected %s for %s", l, freshMethodType.Params[0], n.Operator)
+		got, want := readableTypes(l, freshMethodType.Params[0])
+		return nil, nil, inferErrorf(n, "left operand type %s does not match expected %s for %s", got, want, n.Operator)
 	}
 	totalSubst = subst1.Compose(totalSubst)
 
@@ -623,7 +686,8 @@ func inferHKTOperator(ctx *InferenceContext, n *ast.InfixExpression, l, r typesy
 	// e.g., Option<Int> with F<A> where F=Option from previous step
 	subst2, err := typesystem.Unify(expectedRight, r)
 	if err != nil {
-		return nil, nil, inferErrorf(n, "right operand type %s does not match expected %s for %s", r, expectedRight, n.Operator)
+		got, want := readableTypes(r, expectedRight)
+		return nil, nil, inferErrorf(n, "right operand type %s does not match expected %s for %s", got, want, n.Operator)
 	}
 	totalSubst = subst2.Compose(totalSubst)
 
@@ -0,0 +1,169 @@
+package analyzer
+
+import (
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// paramNames lists the names arguments can be passed by, in the order of the
+// function type's parameters ("" for ignored ones).
+func paramNames(receiver *ast.Parameter, params []*ast.Parameter) []string {
+	var names []string
+	if receiver != nil {
+		names = append(names, receiver.Name.Value)
+	}
+	for _, p := range params {
+		if p.IsIgnored || p.Name == nil {
+			names = append(names, "")
+		} else {
+			names = append(names, p.Name.Value)
+		}
+	}
+	return names
+}
+
+// staticCallee reports whether callee is known to be a declared function: a
+// function declaration, an imported or builtin function, a module member, a
+// function literal or a partial application of one of these. Any other
+// function value may hold a function with other parameter names.
+func staticCallee(callee ast.Expression, table *symbols.SymbolTable) bool {
+	switch c := callee.(type) {
+	case *ast.Identifier:
+		sym, ok := table.Find(c.Value)
+		return ok && sym.IsFunction
+	case *ast.MemberExpression:
+		ident, ok := c.Left.(*ast.Identifier)
+		if !ok {
+			return false
+		}
+		sym, ok := table.Find(ident.Value)
+		return ok && sym.Kind == symbols.ModuleSymbol
+	case *ast.FunctionLiteral:
+		return true
+	case *ast.CallExpression:
+		return staticCallee(c.Function, table)
+	}
+	return false
+}
+
+// resolveNamedArgs turns the `name: value` arguments of a call into
+// positional ones when their names are parameters of fn. Parameters skipped
+// on the way get a DefaultArgument, so the backends see a plain positional
+// call; ArgOrder keeps the source order for evaluation. When no name is a parameter, the arguments stay a record (Argument
+// Shorthand Sugar). Names are only matched for a staticCallee.
+func resolveNamedArgs(n *ast.CallExpression, fn typesystem.TFunc, table *symbols.SymbolTable, inferFn func(ast.Node, *symbols.SymbolTable) (typesystem.Type, typesystem.Subst, error)) error {
+	if len(n.NamedArgs) == 0 || len(fn.ParamNames) != len(fn.Params) || len(n.Arguments) == 0 {
+		return nil
+	}
+	rec, ok := n.Arguments[len(n.Arguments)-1].(*ast.RecordLiteral)
+	if !ok {
+		return nil
+	}
+
+	index := make(map[string]int, len(fn.ParamNames))
+	for i, name := range fn.ParamNames {
+		if name != "" {
+			index[name] = i
+		}
+	}
+	var unknown string
+	matched := 0
+	for _, name := range n.NamedArgs {
+		if _, ok := index[name]; ok {
+			matched++
+		} else if unknown == "" {
+			unknown = name
+		}
+	}
+	if matched == 0 {
+		return nil
+	}
+	if !staticCallee(n.Function, table) {
+		return inferErrorf(rec.Fields[n.NamedArgs[0]], "cannot pass '%s' by name: the called function value may have other parameter names", n.NamedArgs[0])
+	}
+	if unknown != "" {
+		return inferErrorf(rec.Fields[unknown], "unknown argument name '%s'", unknown)
+	}
+
+	// Count the parameters the positional arguments fill
+	positional := n.Arguments[:len(n.Arguments)-1]
+	given := 0
+	for _, arg := range positional {
+		spread, ok := arg.(*ast.SpreadExpression)
+		if !ok {
+			given++
+			continue
+		}
+		t, s, err := inferFn(spread.Expression, table)
+		if err != nil {
+			return err
+		}
+		tuple, ok := table.ResolveTypeAlias(t.Apply(s)).(typesystem.TTuple)
+		if !ok {
+			return inferErrorf(arg, "named arguments cannot follow a spread list")
+		}
+		given += len(tuple.Elements)
+	}
+
+	fixedCount := len(fn.Params)
+	if fn.IsVariadic {
+		fixedCount--
+	}
+	last := -1
+	for _, name := range n.NamedArgs {
+		i := index[name]
+		if i >= fixedCount {
+			return inferErrorf(rec.Fields[name], "variadic parameter '%s' cannot be passed by name", name)
+		}
+		if i < given {
+			return inferErrorf(rec.Fields[name], "argument '%s' is already given by position", name)
+		}
+		last = max(last, i)
+	}
+
+	firstDefault := fixedCount - fn.DefaultCount
+	args := append([]ast.Expression{}, positional...)
+	for i := given; i <= last; i++ {
+		name := fn.ParamNames[i]
+		if val, ok := rec.Fields[name]; ok && name != "" {
+			args = append(args, val)
+		} else if i >= firstDefault {
+			args = append(args, &ast.DefaultArgument{Token: n.Token})
+		} else {
+			return inferErrorf(n, "missing argument '%s': only parameters with defaults can be skipped", fn.ParamNames[i])
+		}
+	}
+	n.Arguments = args
+	n.ArgOrder = namedArgOrder(n.NamedArgs, len(positional), given, index, len(args))
+	n.NamedArgs = nil
+	return nil
+}
+
+// namedArgOrder lists the indexes of the resolved arguments in the order they
+// were written: positional ones, then the named ones as given, then the
+// skipped defaults. Returns nil when that is the order of the arguments.
+func namedArgOrder(names []string, positional, given int, index map[string]int, count int) []int {
+	order := make([]int, 0, count)
+	seen := make([]bool, count)
+	for i := 0; i < positional; i++ {
+		order = append(order, i)
+		seen[i] = true
+	}
+	for _, name := range names {
+		i := positional + index[name] - given
+		order = append(order, i)
+		seen[i] = true
+	}
+	for i := positional; i < count; i++ {
+		if !seen[i] {
+			order = append(order, i)
+		}
+	}
+	for i, j := range order {
+		if i != j {
+			return order
+		}
+	}
+	return nil
+}
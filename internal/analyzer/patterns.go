@@ -80,6 +80,13 @@ func inferPattern(ctx *InferenceContext, pat ast.Pattern, expectedType typesyste
 			}
 		case rune:
 			litType = typesystem.Char
+		case *ast.SizedIntLiteral:
+			// Checked like the literal in an expression: 300u8 is out of range
+			t, _, err := inferLiteral(ctx, p.Value.(*ast.SizedIntLiteral), table, nil)
+			if err != nil {
+				return nil, err
+			}
+			litType = t
 		default:
 			return nil, inferErrorf(p, "unknown literal type in pattern: %T", p.Value)
 		}
@@ -99,6 +106,9 @@ func inferPattern(ctx *InferenceContext, pat ast.Pattern, expectedType typesyste
 		}
 		return typesystem.Unify(expectedType, stringType)
 
+	case *ast.BitsPattern:
+		return inferBitsPattern(p, expectedType, table)
+
 	case *ast.ConstructorPattern:
 		sym, ok := table.Find(p.Name.Value)
 		if !ok || sym.Kind != symbols.ConstructorSymbol {
@@ -109,6 +119,9 @@ func inferPattern(ctx *InferenceContext, pat ast.Pattern, expectedType typesyste
 		totalSubst := typesystem.Subst{}
 
 		if tFunc, ok := freshCtorType.(typesystem.TFunc); ok {
+			if tCon, ok := tFunc.ReturnType.(typesystem.TCon); ok {
+				_, p.Newtype = table.GetNewtype(tCon.Name)
+			}
 			subst, err := typesystem.Unify(expectedType, tFunc.ReturnType)
 			if err != nil {
 				return nil, inferErrorf(p, "pattern type mismatch: expected %s, got %s (%s)", expectedType, tFunc.ReturnType, p.Name.Value)
@@ -480,6 +493,15 @@ func (w *walker) VisitStringPattern(n *ast.StringPattern) {
 	}
 }
 
+func (w *walker) VisitBitsPattern(n *ast.BitsPattern) {
+	// Define bound segments with their segment types
+	for _, seg := range n.Segments {
+		if ident, ok := seg.Pattern.(*ast.IdentifierPattern); ok {
+			w.symbolTable.Define(ident.Value, bitsSegmentValueType(seg), "")
+		}
+	}
+}
+
 func (w *walker) VisitPinPattern(n *ast.PinPattern) {
 	// Pin pattern requires the variable to already exist
 	if !w.symbolTable.IsDefined(n.Name) {
@@ -1,8 +1,10 @@
 package analyzer
 
 import (
+	"github.com/funvibe/funxy/internal/diagnostics"
 	"github.com/funvibe/funxy/internal/modules"
 	"github.com/funvibe/funxy/internal/pipeline"
+	"github.com/funvibe/funxy/internal/token"
 	"github.com/funvibe/funxy/internal/utils"
 )
 
@@ -16,9 +18,26 @@ func (sap *SemanticAnalyzerProcessor) Process(ctx *pipeline.PipelineContext) *pi
 	// Register built-in functions (print, typeOf, panic)
 	RegisterBuiltins(ctx.SymbolTable)
 
-	// Create loader and store in context for sharing with evaluator
-	loader := modules.NewLoader()
-	ctx.Loader = loader
+	// Create loader (unless the caller provided one) and store in context for sharing with evaluator
+	loader, ok := ctx.Loader.(*modules.Loader)
+	if !ok {
+		loader = modules.NewLoader()
+		ctx.Loader = loader
+	}
+
+	// Non-relative imports are resolved via funxy.mod and FUNXY_PATH
+	if loader.SearchPath == nil {
+		dir := "."
+		if ctx.FilePath != "" {
+			dir = utils.GetModuleDir(ctx.FilePath)
+		}
+		searchPath, err := modules.NewSearchPath(dir)
+		if err != nil {
+			ctx.Errors = append(ctx.Errors, diagnostics.NewPhaseError(diagnostics.PhaseAnalyzer, diagnostics.ErrA009, token.Token{}, err.Error()))
+			return ctx
+		}
+		loader.SearchPath = searchPath
+	}
 
 	analyzer := New(ctx.SymbolTable)
 	analyzer.SetLoader(loader)
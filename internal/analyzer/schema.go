@@ -0,0 +1,276 @@
+package analyzer
+
+import (
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// attachTypeSchema records the definitions behind a declared type. The
+// runtime value of the type carries them, so builtins that decode or describe
+// values by type (jsonDecodeAs, fieldNamesOf, constructorsOf) see them however
+// the type reaches them.
+func attachTypeSchema(stmt *ast.TypeDeclarationStatement, table *symbols.SymbolTable, loader ModuleLoader) {
+	if stmt == nil || stmt.Name == nil {
+		return
+	}
+	b := newSchemaBuilder(table, loader)
+	b.schema.Type = b.resolve(typesystem.TCon{Name: stmt.Name.Value})
+	stmt.Schema = b.schema
+}
+
+// resultSchemaBuiltins maps the builtins decoding into their expected result
+// type to the package defining them. They get the schema of that type as an
+// extra last argument, so values decode into that type (Option fields, derived
+// Json ADTs) instead of into whatever the JSON looks like.
+var resultSchemaBuiltins = map[string]string{
+	config.JsonDecodeFuncName: "json",
+}
+
+// decodeTarget is a call decoding into its expected type. The type is often
+// known only once an annotation or return type is unified, so the schema is
+// rebuilt as substitutions are applied.
+type decodeTarget struct {
+	call   *ast.CallExpression
+	typ    typesystem.Type
+	table  *symbols.SymbolTable
+	loader ModuleLoader
+}
+
+// trackDecodeTarget records n if it calls one of resultSchemaBuiltins
+func (ctx *InferenceContext) trackDecodeTarget(n *ast.CallExpression, resultType typesystem.Type, table *symbols.SymbolTable) {
+	if len(n.Arguments) != 1 || libraryFunctionName(n, resultSchemaBuiltins, table) == "" {
+		return
+	}
+	if _, ok := n.Arguments[0].(*ast.SpreadExpression); ok {
+		return
+	}
+	target := &decodeTarget{call: n, typ: resultType, table: table, loader: ctx.Loader}
+	// A body inferred again replaces the earlier record of its calls
+	replaced := false
+	for i, d := range ctx.decodes {
+		if d.call == n {
+			ctx.decodes[i] = target
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		ctx.decodes = append(ctx.decodes, target)
+	}
+	target.attach()
+}
+
+// applySubstToDecode refreshes the schema of a recorded call
+func (ctx *InferenceContext) applySubstToDecode(n *ast.CallExpression, subst typesystem.Subst) {
+	for _, d := range ctx.decodes {
+		if d.call == n {
+			d.typ = d.typ.Apply(subst)
+			d.attach()
+		}
+	}
+}
+
+// applySubstToDecodes refreshes every recorded call. Function bodies use it
+// since their nodes are not rewritten with the final substitution.
+func (ctx *InferenceContext) applySubstToDecodes(subst typesystem.Subst) {
+	for _, d := range ctx.decodes {
+		d.typ = d.typ.Apply(subst)
+		d.attach()
+	}
+}
+
+// attach sets the schema of the decoded type: Result<String, T>. While T is
+// still unknown the value is decoded by its shape.
+func (d *decodeTarget) attach() {
+	d.call.Schema = nil
+	app, ok := d.typ.(typesystem.TApp)
+	if !ok || len(app.Args) != 2 {
+		return
+	}
+	if _, ok := app.Args[1].(typesystem.TVar); ok {
+		return
+	}
+	b := newSchemaBuilder(d.table, d.loader)
+	b.schema.Type = b.resolve(app.Args[1])
+	d.call.Schema = b.schema
+}
+
+// libraryFunctionName returns the name of the function called by n if it is
+// one of funcs, either imported or accessed through a module alias, and
+// comes from the package funcs maps it to. It returns "" otherwise.
+func libraryFunctionName(n *ast.CallExpression, funcs map[string]string, table *symbols.SymbolTable) string {
+	switch fn := n.Function.(type) {
+	case *ast.Identifier:
+		pkg, ok := funcs[fn.Value]
+		if !ok {
+			return ""
+		}
+		if sym, ok := table.Find(fn.Value); ok && sym.OriginModule == pkg {
+			return fn.Value
+		}
+	case *ast.MemberExpression:
+		pkg, ok := funcs[fn.Member.Value]
+		if !ok {
+			return ""
+		}
+		alias, ok := fn.Left.(*ast.Identifier)
+		if !ok {
+			return ""
+		}
+		if sym, ok := table.Find(alias.Value); !ok || sym.Kind != symbols.ModuleSymbol {
+			return ""
+		}
+		if name, ok := table.GetPackageNameByAlias(alias.Value); ok && name == pkg {
+			return fn.Member.Value
+		}
+	}
+	return ""
+}
+
+type schemaBuilder struct {
+	table  *symbols.SymbolTable
+	loader ModuleLoader // Finds the definitions of types qualified by a module
+	schema *typesystem.Schema
+}
+
+func newSchemaBuilder(table *symbols.SymbolTable, loader ModuleLoader) *schemaBuilder {
+	return &schemaBuilder{
+		table:  table,
+		loader: loader,
+		schema: &typesystem.Schema{
+			Records: make(map[string]typesystem.SchemaRecord),
+			ADTs:    make(map[string]typesystem.SchemaADT),
+		},
+	}
+}
+
+// resolve normalizes t for the schema: aliases of non-record types are
+// expanded, records and ADTs keep their names and get a definition.
+func (b *schemaBuilder) resolve(t typesystem.Type) typesystem.Type {
+	switch typ := t.(type) {
+	case typesystem.TCon:
+		return b.resolveNamed(typ)
+	case typesystem.TApp:
+		args := make([]typesystem.Type, len(typ.Args))
+		for i, arg := range typ.Args {
+			args[i] = b.resolve(arg)
+		}
+		con := typ.Constructor
+		if tCon, ok := con.(typesystem.TCon); ok {
+			con = b.resolveNamed(tCon)
+		}
+		return typesystem.TApp{Constructor: con, Args: args}
+	case typesystem.TRecord:
+		return b.resolveRecord(typ)
+	case typesystem.TTuple:
+		elems := make([]typesystem.Type, len(typ.Elements))
+		for i, el := range typ.Elements {
+			elems[i] = b.resolve(el)
+		}
+		return typesystem.TTuple{Elements: elems}
+	case typesystem.TUnion:
+		types := make([]typesystem.Type, len(typ.Types))
+		for i, el := range typ.Types {
+			types[i] = b.resolve(el)
+		}
+		return typesystem.TUnion{Types: types}
+	}
+	return t
+}
+
+func (b *schemaBuilder) resolveRecord(rec typesystem.TRecord) typesystem.TRecord {
+	fields := make(map[string]typesystem.Type, len(rec.Fields))
+	for name, ft := range rec.Fields {
+		fields[name] = b.resolve(ft)
+	}
+	return typesystem.TRecord{Fields: fields}
+}
+
+// nativeSchemaTypes are decoded by the runtime itself and never expanded.
+var nativeSchemaTypes = map[string]bool{
+	config.ListTypeName:   true,
+	config.MapTypeName:    true,
+	config.OptionTypeName: true,
+	config.ResultTypeName: true,
+	"Json":                true,
+}
+
+func (b *schemaBuilder) resolveNamed(t typesystem.TCon) typesystem.Type {
+	name := t.Name
+	if nativeSchemaTypes[name] {
+		return typesystem.TCon{Name: name}
+	}
+	if _, ok := b.schema.Records[name]; ok {
+		return typesystem.TCon{Name: name}
+	}
+	if _, ok := b.schema.ADTs[name]; ok {
+		return typesystem.TCon{Name: name}
+	}
+	if t.Module != "" {
+		if table := b.moduleTable(t.Module); table != nil && table != b.table {
+			// Resolve in the defining module, where its names are unqualified
+			outer := b.table
+			b.table = table
+			defer func() { b.table = outer }()
+			return b.resolveNamed(typesystem.TCon{Name: name})
+		}
+	}
+	if wrapped, ok := b.table.GetNewtype(name); ok {
+		// Decoded like the wrapped type: newtype values are not wrapped at runtime
+		return b.resolve(wrapped)
+	}
+	params, _ := b.table.GetTypeParams(name)
+
+	if ctors, ok := b.table.GetVariants(name); ok {
+		// Register before resolving fields so recursive types terminate
+		b.schema.ADTs[name] = typesystem.SchemaADT{Params: params}
+		adt := typesystem.SchemaADT{Params: params}
+		for _, ctorName := range ctors {
+			ctor := typesystem.SchemaConstructor{Name: ctorName}
+			if sym, ok := b.table.Find(ctorName); ok {
+				if fn, ok := sym.Type.(typesystem.TFunc); ok {
+					for _, p := range fn.Params {
+						ctor.Fields = append(ctor.Fields, b.resolve(p))
+					}
+				}
+			}
+			adt.Constructors = append(adt.Constructors, ctor)
+		}
+		b.schema.ADTs[name] = adt
+		return typesystem.TCon{Name: name}
+	}
+
+	underlying := t.UnderlyingType
+	if underlying == nil {
+		underlying, _ = b.table.GetTypeAlias(name)
+	}
+	switch u := underlying.(type) {
+	case nil:
+		return typesystem.TCon{Name: name}
+	case typesystem.TRecord:
+		b.schema.Records[name] = typesystem.SchemaRecord{Params: params} // Recursion guard
+		b.schema.Records[name] = typesystem.SchemaRecord{Params: params, Fields: b.resolveRecord(u).Fields}
+		return typesystem.TCon{Name: name}
+	default:
+		return b.resolve(u)
+	}
+}
+
+// moduleTable returns the symbol table of the package a type was imported
+// from, given the package name or its import alias
+func (b *schemaBuilder) moduleTable(module string) *symbols.SymbolTable {
+	if b.loader == nil {
+		return nil
+	}
+	packageName, ok := b.table.GetPackageNameByAlias(module)
+	if !ok {
+		packageName = module
+	}
+	mod, ok := b.loader.GetModuleByPackageName(packageName).(LoadedModule)
+	if !ok {
+		return nil
+	}
+	return mod.GetSymbolTable()
+}
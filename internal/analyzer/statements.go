@@ -77,6 +77,7 @@ func (w *walker) VisitProgram(program *ast.Program) {
 				if len(errs) > 0 {
 					w.addErrors(errs)
 				}
+				w.deriveInstances(s)
 			case *ast.TraitDeclaration:
 				s.Accept(w)
 			case *ast.InstanceDeclaration:
@@ -86,6 +87,9 @@ func (w *walker) VisitProgram(program *ast.Program) {
 				if len(errs) > 0 {
 					w.addErrors(errs)
 				}
+				if s.Receiver == nil && s.Name != nil {
+					w.bindFunction(w.symbolTable, s.Name.Value, s)
+				}
 			case *ast.ConstantDeclaration:
 				// Process constants in order with other statements
 				s.Accept(w)
@@ -103,10 +107,15 @@ func (w *walker) VisitProgram(program *ast.Program) {
 			switch s := stmt.(type) {
 			case *ast.FunctionStatement:
 				w.analyzeFunctionBody(s)
+			case *ast.TypeDeclarationStatement:
+				// Every type is registered by now, imported ones included
+				attachTypeSchema(s, w.symbolTable, w.loader)
 			case *ast.ImportStatement:
 				s.Accept(w) // Ensure dependency bodies are analyzed
 			}
 		}
+		w.reportSpawnedFunctions()
+		w.reportHoles()
 		return
 	}
 
@@ -120,6 +129,9 @@ func (w *walker) VisitProgram(program *ast.Program) {
 					w.addError(e)
 				}
 			}
+			if s.Receiver == nil && s.Name != nil {
+				w.bindFunction(w.symbolTable, s.Name.Value, s)
+			}
 		case *ast.TypeDeclarationStatement:
 			errs := RegisterTypeDeclaration(s, w.symbolTable, w.currentModuleName)
 			if len(errs) > 0 {
@@ -147,8 +159,9 @@ func (w *walker) VisitProgram(program *ast.Program) {
 			w.analyzeFunctionBody(s)
 
 		case *ast.TypeDeclarationStatement:
-			// Already registered.
-			continue
+			// Already registered; only the derived instances are left.
+			w.deriveInstances(s)
+			attachTypeSchema(s, w.symbolTable, w.loader)
 
 		case *ast.TraitDeclaration:
 			s.Accept(w)
@@ -164,6 +177,8 @@ func (w *walker) VisitProgram(program *ast.Program) {
 			stmt.Accept(w)
 		}
 	}
+	w.reportSpawnedFunctions()
+	w.reportHoles()
 }
 
 func (w *walker) analyzeFunctionBody(n *ast.FunctionStatement) {
@@ -268,7 +283,10 @@ func (w *walker) analyzeFunctionBody(n *ast.FunctionStatement) {
 	if n.Body != nil {
 		prevInLoop := w.inLoop
 		w.inLoop = false
+		packReturn(n.Body, n.ReturnType, expectedRetType)
+		leave := w.enterFunction(n)
 		n.Body.Accept(w)
+		leave()
 		w.markTailCalls(n.Body)
 		w.inLoop = prevInLoop
 
@@ -282,11 +300,15 @@ func (w *walker) analyzeFunctionBody(n *ast.FunctionStatement) {
 			
 			subst, err := typesystem.Unify(expectedRetType, bodyType)
 			if err != nil {
+				w.inferCtx.applySubstToHoles(sBody)
+				w.inferCtx.applySubstToDecodes(sBody)
 				w.addError(diagnostics.NewError(diagnostics.ErrA003, n.Body.GetToken(),
 					"function body type "+bodyType.String()+" does not match return type "+expectedRetType.String()))
 			} else {
 				// Success! Update TypeMap and SymbolTable with resolved types
 				finalSubst := subst.Compose(sBody)
+				w.inferCtx.applySubstToHoles(finalSubst)
+				w.inferCtx.applySubstToDecodes(finalSubst)
 				
 				// FIX: Remove bindings for generic type params to avoid replacing TVars with Rigid TCons in the signature
 				for _, tp := range n.TypeParams {
@@ -313,7 +335,7 @@ func (w *walker) analyzeFunctionBody(n *ast.FunctionStatement) {
 						}
 					} else {
 						// Global function update
-						outer.Define(n.Name.Value, resolvedFnType, w.currentModuleName)
+						outer.DefineFunction(n.Name.Value, resolvedFnType, w.currentModuleName)
 					}
 				}
 			}
@@ -380,37 +402,8 @@ func (w *walker) VisitForExpression(n *ast.ForExpression) {
 			iterableType = iterableType.Apply(s1)
 			w.TypeMap[n.Iterable] = iterableType
 
-			// Check if iterable is List (direct support)
-			var itemType typesystem.Type
-
-			if tApp, ok := iterableType.(typesystem.TApp); ok {
-				if tCon, ok := tApp.Constructor.(typesystem.TCon); ok && tCon.Name == config.ListTypeName && len(tApp.Args) == 1 {
-					itemType = tApp.Args[0]
-				}
-			}
-
-			if itemType == nil {
-				// Check for iter method via Iter trait protocol
-				// We look for an iter function that can handle this type.
-				// The function exists if the type (or a compatible type) implements Iter.
-				if iterSym, ok := w.symbolTable.Find(config.IterMethodName); ok {
-					iterType := InstantiateWithContext(w.inferCtx, iterSym.Type)
-					if tFunc, ok := iterType.(typesystem.TFunc); ok && len(tFunc.Params) > 0 {
-						subst, err := typesystem.Unify(tFunc.Params[0], iterableType)
-						if err == nil {
-							retType := tFunc.ReturnType.Apply(subst)
-							if iteratorFunc, ok := retType.(typesystem.TFunc); ok {
-								iteratorRet := iteratorFunc.ReturnType
-								if tApp, ok := iteratorRet.(typesystem.TApp); ok {
-									if tCon, ok := tApp.Constructor.(typesystem.TCon); ok && tCon.Name == config.OptionTypeName && len(tApp.Args) >= 1 {
-										itemType = tApp.Args[0]
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+			// List<T>, or the item type of the Iter instance
+			itemType, _ := iterItemType(w.inferCtx, iterableType, w.symbolTable)
 
 			if itemType == nil {
 				w.addError(diagnostics.NewError(diagnostics.ErrA003, n.Iterable.GetToken(), "iterable must be List or implement Iter trait, got "+iterableType.String()))
@@ -92,13 +92,22 @@ func BuildType(t ast.Type, table *symbols.SymbolTable, errs *[]*diagnostics.Diag
 		if !isDefined && !isType {
 			// Types that require import (not in prelude)
 			requiresImport := map[string]string{
-				"Uuid":     "lib/uuid",
-				"Logger":   "lib/log",
-				"Task":     "lib/task",
-				"SqlValue": "lib/sql",
-				"SqlDB":    "lib/sql",
-				"SqlTx":    "lib/sql",
-				"Date":     "lib/sql",
+				"Uuid":       "lib/uuid",
+				"Logger":     "lib/log",
+				"Task":       "lib/task",
+				"Pid":        "lib/actor",
+				"Chan":       "lib/chan",
+				"Ref":        "lib/ref",
+				"Stream":     "lib/stream",
+				"Set":        "lib/set",
+				"OrderedMap": "lib/ordered",
+				"OrderedSet": "lib/ordered",
+				"Heap":       "lib/heap",
+				"Dynamic":    "lib/reflect",
+				"SqlValue":   "lib/sql",
+				"SqlDB":      "lib/sql",
+				"SqlTx":      "lib/sql",
+				"Date":       "lib/sql",
 			}
 			
 			if pkg, needsImport := requiresImport[name]; needsImport {
@@ -286,6 +295,9 @@ func BuildType(t ast.Type, table *symbols.SymbolTable, errs *[]*diagnostics.Diag
 			IsVariadic: false,
 		}
 
+	case *ast.DynType:
+		return buildDynType(t, table, errs)
+
 	case *ast.UnionType:
 		types := []typesystem.Type{}
 		for _, ut := range t.Types {
@@ -3,6 +3,7 @@ package ast
 import (
 	"math/big"
 	"github.com/funvibe/funxy/internal/token"
+	"github.com/funvibe/funxy/internal/typesystem"
 )
 
 // TokenProvider is an interface for any AST node that can provide its primary token.
@@ -119,6 +120,18 @@ func (i *Identifier) expressionNode()       {}
 func (i *Identifier) TokenLiteral() string  { return i.Token.Lexeme }
 func (i *Identifier) GetToken() token.Token { return i.Token }
 
+// TypedHole is a `?` or `?name` placeholder for a missing expression.
+// The analyzer reports its expected type instead of compiling it.
+type TypedHole struct {
+	Token token.Token // the token.QUESTION or token.HOLE token
+	Name  string      // empty for an anonymous hole
+}
+
+func (h *TypedHole) Accept(v Visitor)      { v.VisitTypedHole(h) }
+func (h *TypedHole) expressionNode()       {}
+func (h *TypedHole) TokenLiteral() string  { return h.Token.Lexeme }
+func (h *TypedHole) GetToken() token.Token { return h.Token }
+
 // IntegerLiteral represents an integer literal.
 type IntegerLiteral struct {
 	Token token.Token
@@ -173,6 +186,33 @@ func (bi *BigIntLiteral) expressionNode()       {}
 func (bi *BigIntLiteral) TokenLiteral() string  { return bi.Token.Lexeme }
 func (bi *BigIntLiteral) GetToken() token.Token { return bi.Token }
 
+// SizedIntLiteral represents a sized integer literal, e.g. 200u8 or -5i16.
+// Value is the magnitude; a leading minus is folded in by the parser.
+type SizedIntLiteral struct {
+	Token    token.Token
+	Type     string // Int8, UInt16, ...
+	Value    uint64
+	Negative bool
+}
+
+func (si *SizedIntLiteral) Accept(v Visitor)      { v.VisitSizedIntLiteral(si) }
+func (si *SizedIntLiteral) expressionNode()       {}
+func (si *SizedIntLiteral) TokenLiteral() string  { return si.Token.Lexeme }
+func (si *SizedIntLiteral) GetToken() token.Token { return si.Token }
+
+// DecimalLiteral represents a Decimal literal, e.g. 12.34d.
+// The value is Unscaled / 10^Scale.
+type DecimalLiteral struct {
+	Token    token.Token
+	Unscaled *big.Int
+	Scale    int
+}
+
+func (dl *DecimalLiteral) Accept(v Visitor)      { v.VisitDecimalLiteral(dl) }
+func (dl *DecimalLiteral) expressionNode()       {}
+func (dl *DecimalLiteral) TokenLiteral() string  { return dl.Token.Lexeme }
+func (dl *DecimalLiteral) GetToken() token.Token { return dl.Token }
+
 // RationalLiteral represents a Rational (Rat) literal.
 type RationalLiteral struct {
 	Token token.Token
@@ -229,6 +269,40 @@ func (ml *MapLiteral) expressionNode()       {}
 func (ml *MapLiteral) TokenLiteral() string  { return ml.Token.Lexeme }
 func (ml *MapLiteral) GetToken() token.Token { return ml.Token }
 
+// ComprehensionClause is one clause of a comprehension: either a generator
+// `pattern <- iterable` or a filter expression.
+type ComprehensionClause struct {
+	Token    token.Token // The '<-' token for generators, the first token of a filter
+	Pattern  Pattern     // Generator binding (nil for filters)
+	Iterable Expression  // Generator source, List or any Iter instance (nil for filters)
+	Filter   Expression  // Bool condition (nil for generators)
+}
+
+// ListComprehension represents [output | x <- xs, pred(x), y <- ys]
+type ListComprehension struct {
+	Token   token.Token // The '[' token
+	Output  Expression
+	Clauses []*ComprehensionClause
+}
+
+func (lc *ListComprehension) Accept(v Visitor)      { v.VisitListComprehension(lc) }
+func (lc *ListComprehension) expressionNode()       {}
+func (lc *ListComprehension) TokenLiteral() string  { return lc.Token.Lexeme }
+func (lc *ListComprehension) GetToken() token.Token { return lc.Token }
+
+// MapComprehension represents %{ key => value | (key, value) <- items }
+type MapComprehension struct {
+	Token   token.Token // The '%{' token
+	Key     Expression
+	Value   Expression
+	Clauses []*ComprehensionClause
+}
+
+func (mc *MapComprehension) Accept(v Visitor)      { v.VisitMapComprehension(mc) }
+func (mc *MapComprehension) expressionNode()       {}
+func (mc *MapComprehension) TokenLiteral() string  { return mc.Token.Lexeme }
+func (mc *MapComprehension) GetToken() token.Token { return mc.Token }
+
 // IndexExpression represents indexing, e.g. arr[i]
 type IndexExpression struct {
 	Token token.Token // The '[' token
@@ -323,12 +397,62 @@ func (bl *BitsLiteral) expressionNode()       {}
 func (bl *BitsLiteral) TokenLiteral() string  { return bl.Token.Lexeme }
 func (bl *BitsLiteral) GetToken() token.Token { return bl.Token }
 
+// Segment types of binary constructions and patterns
+const (
+	BitsInteger   = "integer"
+	BitsFloat     = "float"
+	BitsBinary    = "binary"
+	BitsBitstring = "bits"
+	BitsUTF8      = "utf8"
+)
+
+// BitsSegment is one segment of a binary construction or pattern:
+// value:size/specifiers, e.g. len:16/little-signed or rest/binary
+type BitsSegment struct {
+	Token      token.Token
+	Value      Expression // Construction: the value to encode
+	Pattern    Pattern    // Pattern: identifier, wildcard or literal
+	Size       Expression // nil when omitted
+	Type       string     // One of the Bits* segment types
+	Endianness string     // "big", "little", "native" or "" (big)
+	Signed     bool
+	Unit       int64  // 0 when omitted
+	IntType    string // Sized integer specifier (uint16, ...): value type, size and signedness
+}
+
+// IsStringLiteral reports whether the segment holds a string literal,
+// which stands for its UTF-8 bytes.
+func (s *BitsSegment) IsStringLiteral() bool {
+	if _, ok := s.Value.(*StringLiteral); ok {
+		return true
+	}
+	if lit, ok := s.Pattern.(*LiteralPattern); ok {
+		_, isString := lit.Value.(string)
+		return isString
+	}
+	return false
+}
+
+// BitsExpression builds Bits from segments: <<ver:4, len:16, payload/binary>>
+type BitsExpression struct {
+	Token    token.Token // The '<<' token
+	Segments []*BitsSegment
+}
+
+func (be *BitsExpression) Accept(v Visitor)      { v.VisitBitsExpression(be) }
+func (be *BitsExpression) expressionNode()       {}
+func (be *BitsExpression) TokenLiteral() string  { return be.Token.Lexeme }
+func (be *BitsExpression) GetToken() token.Token { return be.Token }
+
 // AnnotatedExpression represents an expression with an explicit type annotation.
 // E.g., x: Int
 type AnnotatedExpression struct {
 	Token          token.Token // The COLON token
 	Expression     Expression
 	TypeAnnotation Type
+	// Set by Analyzer when the annotation mentions dyn Trait: the resolved
+	// type the value is packed into.
+	Pack typesystem.Type
 }
 
 func (ae *AnnotatedExpression) Accept(v Visitor)      { v.VisitAnnotatedExpression(ae) }
@@ -479,6 +603,10 @@ type InfixExpression struct {
 	Left     Expression
 	Operator string
 	Right    Expression
+	// Set by Analyzer when an operand of ++ or ?? meets a list or Option of
+	// dyn Trait: the types the operands are packed into (nil if unpacked).
+	PackLeft  typesystem.Type
+	PackRight typesystem.Type
 }
 
 func (ie *InfixExpression) Accept(v Visitor)      { v.VisitInfixExpression(ie) }
@@ -504,6 +632,9 @@ type AssignExpression struct {
 	Left          Expression  // Changed from Name *Identifier to Left Expression to support l-values like obj.x
 	AnnotatedType Type        // Optional type annotation from x: Int = ...
 	Value         Expression
+	// Set by Analyzer when the annotation mentions dyn Trait: the resolved
+	// type the value is packed into before it is stored.
+	Pack typesystem.Type
 }
 
 func (ae *AssignExpression) Accept(v Visitor)      { v.VisitAssignExpression(ae) }
@@ -529,6 +660,21 @@ type CallExpression struct {
 	Function  Expression  // Identifier or FunctionLiteral
 	Arguments []Expression
 	IsTail    bool // Set by Analyzer if this call is in a tail position
+	// Set by Analyzer for builtins decoding into their expected result type
+	// (jsonDecode): that type with its definitions resolved, passed as an
+	// extra last argument.
+	Schema *typesystem.Schema
+	// Set by Analyzer for arguments passed to dyn Trait parameters: the
+	// parameter type to pack each argument into, nil where nothing is packed.
+	PackArgs []typesystem.Type
+	// Set by Parser for calls ending in `name: value` arguments: the names in
+	// source order. The last argument is the record literal holding them
+	// until the Analyzer matches them to parameter names and reorders them.
+	NamedArgs []string
+	// Set by Analyzer when it reorders named arguments: the indexes of
+	// Arguments in source order, so that they are still evaluated left to
+	// right. Nil when Arguments are already in source order.
+	ArgOrder []int
 }
 
 func (ce *CallExpression) Accept(v Visitor)      { v.VisitCallExpression(ce) }
@@ -547,6 +693,18 @@ func (se *SpreadExpression) expressionNode()       {}
 func (se *SpreadExpression) TokenLiteral() string  { return se.Token.Lexeme }
 func (se *SpreadExpression) GetToken() token.Token { return se.Token }
 
+// DefaultArgument stands for a parameter skipped by named arguments, e.g.
+// the second argument of f(1, c: 3) for fun f(a, b = 2, c = 0). It is
+// inserted by the Analyzer; the callee binds the parameter's default.
+type DefaultArgument struct {
+	Token token.Token // The token of the call
+}
+
+func (da *DefaultArgument) Accept(v Visitor)      { v.VisitDefaultArgument(da) }
+func (da *DefaultArgument) expressionNode()       {}
+func (da *DefaultArgument) TokenLiteral() string  { return da.Token.Lexeme }
+func (da *DefaultArgument) GetToken() token.Token { return da.Token }
+
 // TypeApplicationExpression represents applying types to a generic function/identifier.
 // E.g. foo<Int>(...)
 type TypeApplicationExpression struct {
@@ -640,6 +798,18 @@ func (ut *UnionType) typeNode()             {}
 func (ut *UnionType) TokenLiteral() string  { return ut.Token.Lexeme }
 func (ut *UnionType) GetToken() token.Token { return ut.Token }
 
+// DynType represents a trait object type, e.g. dyn Draw: any value whose type
+// implements the trait, packaged with that implementation.
+type DynType struct {
+	Token token.Token // The 'dyn' token
+	Trait *Identifier
+}
+
+func (dt *DynType) Accept(v Visitor)      { v.VisitDynType(dt) }
+func (dt *DynType) typeNode()             {}
+func (dt *DynType) TokenLiteral() string  { return dt.Token.Lexeme }
+func (dt *DynType) GetToken() token.Token { return dt.Token }
+
 // DataConstructor represents a single case in an ADT definition.
 // E.g., 'Triangle Int Int Int' or 'Empty'.
 type DataConstructor struct {
@@ -652,17 +822,26 @@ func (dc *DataConstructor) Accept(v Visitor)      { v.VisitDataConstructor(dc) }
 func (dc *DataConstructor) TokenLiteral() string  { return dc.Token.Lexeme }
 func (dc *DataConstructor) GetToken() token.Token { return dc.Token }
 
-// TypeDeclarationStatement represents a 'type' or 'type alias' definition.
-// E.g., 'type alias Money = Float' or 'type List a = Empty | List a (List a)'
+// TypeDeclarationStatement represents a 'type', 'type alias' or 'newtype'
+// definition. E.g., 'type alias Money = Float',
+// 'type List a = Empty | List a (List a)' or 'newtype UserId = Int'.
 type TypeDeclarationStatement struct {
-	Token          token.Token // the 'type' token
+	Token          token.Token // the 'type' or 'newtype' token
 	Name           *Identifier
 	IsAlias        bool
+	IsNewtype      bool          // TargetType is wrapped by the single one-field constructor
 	TypeParameters []*Identifier // For polymorphism, e.g., ['a']
 	// For an alias, this holds the target type.
 	// For an ADT, this holds the various constructors.
 	TargetType   Type
 	Constructors []*DataConstructor
+	// Traits listed in a 'deriving (...)' clause, e.g. ['Equal', 'Show'].
+	Deriving []*Identifier
+	// Instances synthesized by the analyzer for Deriving (Equal, Order, Default).
+	Derived []*InstanceDeclaration
+	// Set by Analyzer: the definitions behind the type, carried by its runtime
+	// type value for builtins that inspect types (jsonDecodeAs, fieldNamesOf).
+	Schema *typesystem.Schema
 }
 
 func (tds *TypeDeclarationStatement) Accept(v Visitor)      { v.VisitTypeDeclarationStatement(tds) }
@@ -772,6 +951,9 @@ type ConstructorPattern struct {
 	Token    token.Token // Constructor name
 	Name     *Identifier
 	Elements []Pattern
+	// Set by the analyzer when Name is a newtype constructor: the value is
+	// not wrapped at runtime, so the single element matches it directly.
+	Newtype bool
 }
 
 func (p *ConstructorPattern) Accept(v Visitor)      { v.VisitConstructorPattern(p) }
@@ -854,6 +1036,18 @@ func (p *StringPattern) patternNode()          {}
 func (p *StringPattern) TokenLiteral() string  { return p.Token.Lexeme }
 func (p *StringPattern) GetToken() token.Token { return p.Token }
 
+// BitsPattern: <<ver:4, ihl:4, len:16/big, rest/binary>>
+// Matches Bits or Bytes segment by segment and binds the named segments
+type BitsPattern struct {
+	Token    token.Token // The '<<' token
+	Segments []*BitsSegment
+}
+
+func (p *BitsPattern) Accept(v Visitor)      { v.VisitBitsPattern(p) }
+func (p *BitsPattern) patternNode()          {}
+func (p *BitsPattern) TokenLiteral() string  { return p.Token.Lexeme }
+func (p *BitsPattern) GetToken() token.Token { return p.Token }
+
 // PinPattern: ^variable
 // Matches if value equals the existing variable's value (like Elixir's pin operator)
 type PinPattern struct {
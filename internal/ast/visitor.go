@@ -12,10 +12,13 @@ type Visitor interface {
 	VisitTraitDeclaration(n *TraitDeclaration)
 	VisitInstanceDeclaration(n *InstanceDeclaration)
 	VisitIdentifier(n *Identifier)
+	VisitTypedHole(n *TypedHole)
 	VisitIntegerLiteral(n *IntegerLiteral)
 	VisitFloatLiteral(n *FloatLiteral)
 	VisitBigIntLiteral(n *BigIntLiteral)
+	VisitSizedIntLiteral(n *SizedIntLiteral)
 	VisitRationalLiteral(n *RationalLiteral)
+	VisitDecimalLiteral(n *DecimalLiteral)
 	VisitBooleanLiteral(n *BooleanLiteral)
 	VisitNilLiteral(n *NilLiteral)
 	VisitTupleLiteral(n *TupleLiteral)
@@ -41,6 +44,7 @@ type Visitor interface {
 	VisitTuplePattern(n *TuplePattern)
 	VisitAnnotatedExpression(n *AnnotatedExpression)
 	VisitSpreadExpression(n *SpreadExpression)
+	VisitDefaultArgument(n *DefaultArgument)
 	VisitSpreadPattern(n *SpreadPattern)
 	VisitListPattern(n *ListPattern)
 	VisitListLiteral(n *ListLiteral)
@@ -51,16 +55,21 @@ type Visitor interface {
 	VisitCharLiteral(n *CharLiteral)
 	VisitBytesLiteral(n *BytesLiteral)
 	VisitBitsLiteral(n *BitsLiteral)
+	VisitBitsExpression(n *BitsExpression)
 	VisitPostfixExpression(n *PostfixExpression)
 	VisitFunctionLiteral(n *FunctionLiteral)
 	VisitRecordLiteral(n *RecordLiteral)
 	VisitMapLiteral(n *MapLiteral)
+	VisitListComprehension(n *ListComprehension)
+	VisitMapComprehension(n *MapComprehension)
 	VisitRecordType(n *RecordType)
 	VisitUnionType(n *UnionType)
+	VisitDynType(n *DynType)
 	VisitRecordPattern(n *RecordPattern)
 	VisitTypePattern(n *TypePattern)
 	VisitStringPattern(n *StringPattern)
 	VisitPinPattern(n *PinPattern)
+	VisitBitsPattern(n *BitsPattern)
 	VisitMemberExpression(n *MemberExpression)
 	// Loops
 	VisitForExpression(n *ForExpression)
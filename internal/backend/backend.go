@@ -3,6 +3,7 @@
 package backend
 
 import (
+	"fmt"
 	"github.com/funvibe/funxy/internal/evaluator"
 	"github.com/funvibe/funxy/internal/pipeline"
 )
@@ -15,3 +16,23 @@ type Backend interface {
 	// Name returns the backend name for display
 	Name() string
 }
+
+// Backend names accepted by ByName
+const (
+	NameVM   = "vm"
+	NameTree = "tree"
+	NameBoth = "both"
+)
+
+// ByName returns the backend registered under the given name ("vm", "tree" or "both")
+func ByName(name string) (Backend, error) {
+	switch name {
+	case NameVM:
+		return NewVM(), nil
+	case NameTree:
+		return NewTreeWalk(), nil
+	case NameBoth:
+		return NewDifferential(), nil
+	}
+	return nil, fmt.Errorf("unknown backend %q (expected vm, tree or both)", name)
+}
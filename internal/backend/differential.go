@@ -0,0 +1,144 @@
+package backend
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/funvibe/funxy/internal/evaluator"
+	"github.com/funvibe/funxy/internal/pipeline"
+	"io"
+	"os"
+	"strings"
+)
+
+// DifferentialBackend runs a program on both the VM and the tree-walk interpreter,
+// captures their output and results, and reports any divergence between them.
+// The VM run is treated as the primary one: its output is forwarded to Out.
+//
+// The program really runs twice. Standard input read by the VM run is recorded
+// and replayed to the tree-walk run, but other side effects (files, network,
+// processes) happen twice, and clocks or random numbers can make the runs
+// differ. It is meant for deterministic programs such as the test suite.
+type DifferentialBackend struct {
+	// Out receives the primary (VM) output (defaults to os.Stdout when nil)
+	Out io.Writer
+}
+
+// NewDifferential creates a new differential backend
+func NewDifferential() *DifferentialBackend {
+	return &DifferentialBackend{}
+}
+
+// DivergenceError describes how the VM and tree-walk runs disagree
+type DivergenceError struct {
+	Diffs []string
+}
+
+func (e *DivergenceError) Error() string {
+	return "backend divergence (vm vs tree-walk):\n" + strings.Join(e.Diffs, "\n")
+}
+
+// backendRun holds the captured outcome of a single backend run
+type backendRun struct {
+	output string
+	result string
+	err    string
+}
+
+func captureRun(b Backend, out *bytes.Buffer, ctx *pipeline.PipelineContext) (run backendRun, result evaluator.Object, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("internal error: %v", r)
+			run.err = err.Error()
+			run.output = out.String()
+		}
+	}()
+
+	result, err = b.Run(ctx)
+	run.output = out.String()
+	if err != nil {
+		run.err = err.Error()
+	} else if result != nil && result.Type() == evaluator.ERROR_OBJ {
+		run.err = result.Inspect()
+	} else if result != nil {
+		run.result = result.Inspect()
+	}
+	return run, result, err
+}
+
+// Run executes the program on both backends and compares the outcomes
+func (b *DifferentialBackend) Run(ctx *pipeline.PipelineContext) (evaluator.Object, error) {
+	var vmOut, treeOut, input bytes.Buffer
+
+	evaluator.SetStdin(io.TeeReader(os.Stdin, &input))
+	vmRun, result, err := captureRun(&VMBackend{Out: &vmOut}, &vmOut, ctx)
+	evaluator.SetStdin(io.MultiReader(&input, os.Stdin))
+	treeRun, _, _ := captureRun(&TreeWalkBackend{Out: &treeOut}, &treeOut, ctx)
+
+	out := b.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	_, _ = io.WriteString(out, vmRun.output)
+
+	if diffs := compareRuns(vmRun, treeRun); len(diffs) > 0 {
+		return nil, &DivergenceError{Diffs: diffs}
+	}
+	return result, err
+}
+
+// Name returns the backend name
+func (b *DifferentialBackend) Name() string {
+	return "both"
+}
+
+// compareRuns returns human-readable descriptions of differences between two runs
+func compareRuns(vmRun, treeRun backendRun) []string {
+	var diffs []string
+
+	if vmRun.output != treeRun.output {
+		diffs = append(diffs, describeOutputDiff(vmRun.output, treeRun.output))
+	}
+	if vmRun.result != treeRun.result {
+		diffs = append(diffs, fmt.Sprintf("  result differs:\n    vm:   %s\n    tree: %s", vmRun.result, treeRun.result))
+	}
+	if (vmRun.err == "") != (treeRun.err == "") {
+		diffs = append(diffs, fmt.Sprintf("  error differs:\n    vm:   %s\n    tree: %s",
+			orNone(firstLine(vmRun.err)), orNone(firstLine(treeRun.err))))
+	}
+	return diffs
+}
+
+// describeOutputDiff reports the first line at which two outputs differ
+func describeOutputDiff(vmOut, treeOut string) string {
+	vmLines := strings.Split(vmOut, "\n")
+	treeLines := strings.Split(treeOut, "\n")
+
+	i := 0
+	for i < len(vmLines) && i < len(treeLines) && vmLines[i] == treeLines[i] {
+		i++
+	}
+
+	return fmt.Sprintf("  stdout differs at line %d:\n    vm:   %s\n    tree: %s",
+		i+1, orNone(lineAt(vmLines, i)), orNone(lineAt(treeLines, i)))
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return fmt.Sprintf("%q", lines[i])
+	}
+	return ""
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareRuns(t *testing.T) {
+	tests := []struct {
+		name  string
+		vm    backendRun
+		tree  backendRun
+		diffs []string // substrings expected in the reported differences, in order
+	}{
+		{
+			name: "Identical",
+			vm:   backendRun{output: "1\n2\n", result: "Nil"},
+			tree: backendRun{output: "1\n2\n", result: "Nil"},
+		},
+		{
+			name:  "Output differs at second line",
+			vm:    backendRun{output: "1\n2\n"},
+			tree:  backendRun{output: "1\n3\n"},
+			diffs: []string{"stdout differs at line 2:\n    vm:   \"2\"\n    tree: \"3\""},
+		},
+		{
+			name:  "Tree output is shorter",
+			vm:    backendRun{output: "1\n2"},
+			tree:  backendRun{output: "1"},
+			diffs: []string{"stdout differs at line 2:\n    vm:   \"2\"\n    tree: <none>"},
+		},
+		{
+			name:  "Result differs",
+			vm:    backendRun{result: "3"},
+			tree:  backendRun{result: "4"},
+			diffs: []string{"result differs:\n    vm:   3\n    tree: 4"},
+		},
+		{
+			name:  "Only one run fails",
+			vm:    backendRun{err: "division by zero\n  at f"},
+			tree:  backendRun{},
+			diffs: []string{"error differs:\n    vm:   division by zero\n    tree: <none>"},
+		},
+		{
+			name: "Both fail with different messages",
+			vm:   backendRun{err: "vm message"},
+			tree: backendRun{err: "tree message"},
+		},
+		{
+			name:  "Output and result differ",
+			vm:    backendRun{output: "a", result: "1"},
+			tree:  backendRun{output: "b", result: "2"},
+			diffs: []string{"stdout differs at line 1", "result differs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareRuns(tt.vm, tt.tree)
+			if len(got) != len(tt.diffs) {
+				t.Fatalf("compareRuns() = %q, want %d differences", got, len(tt.diffs))
+			}
+			for i, want := range tt.diffs {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("difference %d = %q, want it to contain %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
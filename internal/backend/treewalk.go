@@ -6,11 +6,15 @@ import (
 	"github.com/funvibe/funxy/internal/evaluator"
 	"github.com/funvibe/funxy/internal/modules"
 	"github.com/funvibe/funxy/internal/pipeline"
+	"io"
 	"path/filepath"
 )
 
 // TreeWalkBackend wraps the existing tree-walk interpreter
-type TreeWalkBackend struct{}
+type TreeWalkBackend struct {
+	// Out receives program output (defaults to os.Stdout when nil)
+	Out io.Writer
+}
 
 // NewTreeWalk creates a new tree-walk backend
 func NewTreeWalk() *TreeWalkBackend {
@@ -28,6 +32,9 @@ func (b *TreeWalkBackend) Run(ctx *pipeline.PipelineContext) (evaluator.Object,
 	}
 	
 	eval := evaluator.New()
+	if b.Out != nil {
+		eval.Out = b.Out
+	}
 	
 	// Use shared loader from analyzer
 	if loader, ok := ctx.Loader.(*modules.Loader); ok {
@@ -7,11 +7,15 @@ import (
 	"github.com/funvibe/funxy/internal/modules"
 	"github.com/funvibe/funxy/internal/pipeline"
 	"github.com/funvibe/funxy/internal/vm"
+	"io"
 	"path/filepath"
 )
 
 // VMBackend executes programs using the bytecode VM
-type VMBackend struct{}
+type VMBackend struct {
+	// Out receives program output (defaults to os.Stdout when nil)
+	Out io.Writer
+}
 
 // NewVM creates a new VM backend
 func NewVM() *VMBackend {
@@ -44,6 +48,9 @@ func (b *VMBackend) Run(ctx *pipeline.PipelineContext) (evaluator.Object, error)
 	machine := vm.New()
 	machine.RegisterBuiltins()
 	machine.RegisterFPTraits()
+	if b.Out != nil {
+		machine.SetOutput(b.Out)
+	}
 
 	// Register test builtins if in test mode
 	if ctx.IsTestMode {
@@ -26,6 +26,14 @@ var BuiltinTypes = []TypeInfo{
 	{Name: "Nil", Kind: "*", Description: "Unit type with single value Nil"},
 	{Name: "BigInt", Kind: "*", Description: "Arbitrary precision integer"},
 	{Name: "Rational", Kind: "*", Description: "Arbitrary precision rational number"},
+	{Name: "Decimal", Kind: "*", Description: "Fixed-point decimal number with a scale", Example: "12.34d"},
+	{Name: "Int8", Kind: "*", Description: "8-bit signed integer", Example: "-5i8"},
+	{Name: "Int16", Kind: "*", Description: "16-bit signed integer", Example: "1000i16"},
+	{Name: "Int32", Kind: "*", Description: "32-bit signed integer", Example: "70000i32"},
+	{Name: "UInt8", Kind: "*", Description: "8-bit unsigned integer", Example: "255u8"},
+	{Name: "UInt16", Kind: "*", Description: "16-bit unsigned integer", Example: "0xFFFFu16"},
+	{Name: "UInt32", Kind: "*", Description: "32-bit unsigned integer", Example: "4000000000u32"},
+	{Name: "UInt64", Kind: "*", Description: "64-bit unsigned integer", Example: "18446744073709551615u64"},
 
 	// Type constructors
 	{Name: "List", Kind: "* -> *", Description: "Linked list of elements"},
@@ -39,6 +47,44 @@ var BuiltinTypes = []TypeInfo{
 
 	// Aliases
 	{Name: "String", Kind: "= List<Char>", Description: "String is a list of characters"},
+	{Name: "Byte", Kind: "= UInt8", Description: "Byte is an 8-bit unsigned integer"},
+}
+
+// IntTypeInfo describes a fixed-width integer type
+type IntTypeInfo struct {
+	Name   string
+	Bits   int
+	Signed bool
+	Suffix string // Literal suffix: 200u8
+}
+
+// IntTypes lists the fixed-width integer types. Int is the 64-bit signed one;
+// the others check their arithmetic for overflow.
+var IntTypes = []IntTypeInfo{
+	{Name: "Int", Bits: 64, Signed: true},
+	{Name: "Int8", Bits: 8, Signed: true, Suffix: "i8"},
+	{Name: "Int16", Bits: 16, Signed: true, Suffix: "i16"},
+	{Name: "Int32", Bits: 32, Signed: true, Suffix: "i32"},
+	{Name: "UInt8", Bits: 8, Suffix: "u8"},
+	{Name: "UInt16", Bits: 16, Suffix: "u16"},
+	{Name: "UInt32", Bits: 32, Suffix: "u32"},
+	{Name: "UInt64", Bits: 64, Suffix: "u64"},
+}
+
+// GetIntTypeInfo returns info for Int or a sized integer type
+func GetIntTypeInfo(name string) *IntTypeInfo {
+	for i := range IntTypes {
+		if IntTypes[i].Name == name {
+			return &IntTypes[i]
+		}
+	}
+	return nil
+}
+
+// IsSizedIntType reports whether name is one of the sized integer types (not Int)
+func IsSizedIntType(name string) bool {
+	info := GetIntTypeInfo(name)
+	return info != nil && info.Suffix != ""
 }
 
 // GetTypeInfo returns type info by name
@@ -79,6 +125,8 @@ var BuiltinTraits = []TraitInfo{
 		Operators: []string{"++"}, Description: "Concatenation"},
 	{Name: "Default", TypeParams: []string{"T"}, Kind: "*",
 		Methods: []string{"default"}, Description: "Default value for type"},
+	{Name: "Integral", TypeParams: []string{"T"}, Kind: "*",
+		Description: "Fixed-width integers: Int, Int8, Int16, Int32, UInt8, UInt16, UInt32, UInt64"},
 
 	// FP traits (HKT)
 	{Name: "Semigroup", TypeParams: []string{"A"}, Kind: "*",
@@ -146,6 +194,24 @@ var BuiltinFunctions = []FunctionInfo{
 		Example: "read(\"42\", Int)", Category: "Conversion"},
 	{Name: "intToFloat", Signature: "(Int) -> Float", Description: "Convert Int to Float", Category: "Conversion"},
 	{Name: "floatToInt", Signature: "(Float) -> Int", Description: "Convert Float to Int (truncate)", Category: "Conversion"},
+	{Name: "intCast", Signature: "(Type, A) -> Option<T>", Description: "Convert between integer types, Zero if out of range",
+		Example: "intCast(UInt8, 300) // Zero", Category: "Conversion", Constraint: "Integral<T>, Integral<A>"},
+	{Name: "intWrap", Signature: "(Type, A) -> T", Description: "Convert between integer types, keeping the low bits",
+		Example: "intWrap(UInt8, 300) // 44", Category: "Conversion", Constraint: "Integral<T>, Integral<A>"},
+	{Name: "intSaturate", Signature: "(Type, A) -> T", Description: "Convert between integer types, clamping to the range",
+		Example: "intSaturate(UInt8, 300) // 255", Category: "Conversion", Constraint: "Integral<T>, Integral<A>"},
+
+	// Integer arithmetic
+	{Name: "checkedAdd", Signature: "(T, T) -> Option<T>", Description: "Add, Zero on overflow", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "checkedSub", Signature: "(T, T) -> Option<T>", Description: "Subtract, Zero on overflow", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "checkedMul", Signature: "(T, T) -> Option<T>", Description: "Multiply, Zero on overflow", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "checkedDiv", Signature: "(T, T) -> Option<T>", Description: "Divide, Zero on overflow or division by zero", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "wrappingAdd", Signature: "(T, T) -> T", Description: "Add, wrapping around on overflow", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "wrappingSub", Signature: "(T, T) -> T", Description: "Subtract, wrapping around on overflow", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "wrappingMul", Signature: "(T, T) -> T", Description: "Multiply, wrapping around on overflow", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "saturatingAdd", Signature: "(T, T) -> T", Description: "Add, clamping to the range of T", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "saturatingSub", Signature: "(T, T) -> T", Description: "Subtract, clamping to the range of T", Category: "Integer", Constraint: "Integral<T>"},
+	{Name: "saturatingMul", Signature: "(T, T) -> T", Description: "Multiply, clamping to the range of T", Category: "Integer", Constraint: "Integral<T>"},
 	{Name: "sprintf", Signature: "(String, ...T) -> String", Description: "Format string (printf style)", Category: "String"},
 
 	// Reflection
@@ -7,10 +7,15 @@ var SourceFileExtensions = []string{".lang", ".funxy", ".fx"}
 
 // Built-in trait and method names
 const (
-	IterTraitName  = "Iter"
-	IterMethodName = "iter"
+	IterTraitName     = "Iter"
+	IterMethodName    = "iter"
+	IntegralTraitName = "Integral"
 )
 
+// DecimalDivisionScale is the number of fraction digits '/' computes for
+// Decimal operands before dropping trailing zeros
+const DecimalDivisionScale = 16
+
 // Built-in function names
 const (
 	PrintFuncName    = "print"
@@ -27,6 +32,11 @@ const (
 	ReadFuncName     = "read"
 	IdFuncName       = "id"
 	ConstFuncName    = "const"
+
+	JsonDecodeAsFuncName   = "jsonDecodeAs"
+	JsonDecodeFuncName     = "jsonDecode"
+	ConstructorsOfFuncName = "constructorsOf"
+	FieldNamesOfFuncName   = "fieldNamesOf"
 )
 
 // Built-in type names
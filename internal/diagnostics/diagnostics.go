@@ -28,6 +28,8 @@ const (
 	ErrP004 ErrorCode = "P004" // No prefix parse function found
 	ErrP005 ErrorCode = "P005" // Expected closing parenthesis
 	ErrP006 ErrorCode = "P006" // Invalid import syntax
+	ErrP007 ErrorCode = "P007" // Invalid do block
+	ErrP008 ErrorCode = "P008" // Invalid binary segment
 
 	// Analyzer Errors
 	ErrA001 ErrorCode = "A001" // Undeclared variable
@@ -38,6 +40,8 @@ const (
 	ErrA006 ErrorCode = "A006" // Undefined symbol
 	ErrA007 ErrorCode = "A007" // Match not exhaustive
 	ErrA008 ErrorCode = "A008" // Naming convention error
+	ErrA009 ErrorCode = "A009" // Invalid project manifest (funxy.mod)
+	ErrA010 ErrorCode = "A010" // Typed hole
 
 	// Runtime Errors
 	ErrR001 ErrorCode = "R001" // Runtime error
@@ -51,6 +55,8 @@ var errorTemplates = map[ErrorCode]string{
 	ErrP004: "cannot parse expression starting with '%s'",
 	ErrP005: "expected next token to be '%s', but got '%s' instead",
 	ErrP006: "%s",
+	ErrP007: "invalid do block: %s",
+	ErrP008: "invalid binary segment: %s",
 	ErrA001: "undeclared variable: '%s'",
 	ErrA002: "undeclared type: '%s'",
 	ErrA003: "type error: %s",
@@ -59,6 +65,8 @@ var errorTemplates = map[ErrorCode]string{
 	ErrA006: "undefined symbol: '%s'",
 	ErrA007: "match expression is not exhaustive. Missing cases: %s",
 	ErrA008: "naming convention: %s",
+	ErrA009: "invalid project manifest: %s",
+	ErrA010: "found hole %s of type %s%s",
 	ErrR001: "runtime error: %s",
 }
 
@@ -0,0 +1,407 @@
+package evaluator
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/funvibe/funbit/pkg/funbit"
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+)
+
+// Binary constructions and patterns (<<ver:4, len:16/little, rest/binary>>).
+// Both backends share this code. Segments are lowered from the AST into
+// plain data so the VM can keep them in its (cached) constant pool.
+
+// BitsSegment is the runtime description of one segment.
+type BitsSegment struct {
+	Type       string // One of the ast.Bits* segment types
+	Endianness string
+	Signed     bool
+	Unit       int64
+	HasSize    bool
+	Size       *BitsSize // Pattern size, evaluated while matching
+	Bind       string    // Pattern: name bound to the segment value
+	Literal    bool      // Pattern: the segment must equal IntValue or StrValue
+	IsString   bool      // String literal segment (its UTF-8 bytes)
+	IntValue   int64
+	StrValue   string
+	IntType    string // Sized integer type of the value, e.g. UInt16
+}
+
+// BitsSize is a pattern size: an integer, a name or an arithmetic operation.
+type BitsSize struct {
+	Op          string // "" for leaves
+	Value       int64
+	Name        string
+	Left, Right *BitsSize
+}
+
+// LowerBitsSegments converts parsed segments to their runtime form.
+func LowerBitsSegments(segments []*ast.BitsSegment) []BitsSegment {
+	out := make([]BitsSegment, len(segments))
+	for i, seg := range segments {
+		s := BitsSegment{
+			Type:       seg.Type,
+			Endianness: seg.Endianness,
+			Signed:     seg.Signed,
+			Unit:       seg.Unit,
+			HasSize:    seg.Size != nil,
+			IntType:    seg.IntType,
+		}
+		switch pat := seg.Pattern.(type) {
+		case *ast.IdentifierPattern:
+			s.Bind = pat.Value
+		case *ast.LiteralPattern:
+			s.Literal = true
+			switch v := pat.Value.(type) {
+			case int64:
+				s.IntValue = v
+			case string:
+				s.IsString, s.StrValue = true, v
+			}
+		}
+		if lit, ok := seg.Value.(*ast.StringLiteral); ok {
+			s.IsString, s.StrValue = true, lit.Value
+		}
+		if seg.Pattern != nil && seg.Size != nil {
+			s.Size = lowerBitsSize(seg.Size)
+		}
+		out[i] = s
+	}
+	return out
+}
+
+func lowerBitsSize(expr ast.Expression) *BitsSize {
+	switch e := expr.(type) {
+	case *ast.IntegerLiteral:
+		return &BitsSize{Value: e.Value}
+	case *ast.Identifier:
+		return &BitsSize{Name: e.Value}
+	case *ast.InfixExpression:
+		return &BitsSize{Op: e.Operator, Left: lowerBitsSize(e.Left), Right: lowerBitsSize(e.Right)}
+	}
+	return nil // Rejected by the analyzer
+}
+
+// eval computes a pattern size from the integers bound so far.
+func (s *BitsSize) eval(scope map[string]int64) (int64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	if s.Op == "" {
+		if s.Name == "" {
+			return s.Value, true
+		}
+		v, ok := scope[s.Name]
+		return v, ok
+	}
+	l, ok := s.Left.eval(scope)
+	if !ok {
+		return 0, false
+	}
+	r, ok := s.Right.eval(scope)
+	if !ok {
+		return 0, false
+	}
+	switch s.Op {
+	case "+":
+		return l + r, true
+	case "-":
+		return l - r, true
+	case "*":
+		return l * r, true
+	case "/":
+		if r != 0 {
+			return l / r, true
+		}
+	case "%":
+		if r != 0 {
+			return l % r, true
+		}
+	}
+	return 0, false
+}
+
+// BitsFreeNames lists the names used in pattern sizes that are not bound
+// by earlier segments, in order of first use. Their values come from the
+// enclosing scope.
+func BitsFreeNames(segments []BitsSegment) []string {
+	var names []string
+	bound := make(map[string]bool)
+	seen := make(map[string]bool)
+	var walk func(s *BitsSize)
+	walk = func(s *BitsSize) {
+		if s == nil {
+			return
+		}
+		if s.Name != "" && !bound[s.Name] && !seen[s.Name] {
+			seen[s.Name] = true
+			names = append(names, s.Name)
+		}
+		walk(s.Left)
+		walk(s.Right)
+	}
+	for _, seg := range segments {
+		walk(seg.Size)
+		if seg.Bind != "" {
+			bound[seg.Bind] = true
+		}
+	}
+	return names
+}
+
+// width returns the width of the segment in bits, or -1 when it takes
+// whatever is left (or, for utf8, whatever the encoding needs).
+func (seg *BitsSegment) width(size int64) int64 {
+	if !seg.HasSize {
+		switch seg.Type {
+		case ast.BitsInteger:
+			if seg.IntType != "" {
+				return int64(config.GetIntTypeInfo(seg.IntType).Bits)
+			}
+			return 8
+		case ast.BitsFloat:
+			return 64
+		default:
+			return -1
+		}
+	}
+	unit := seg.Unit
+	if unit == 0 {
+		unit = 1
+		if seg.Type == ast.BitsBinary {
+			unit = 8
+		}
+	}
+	return size * unit
+}
+
+func (seg *BitsSegment) options(width int64) []funbit.SegmentOption {
+	opts := []funbit.SegmentOption{funbit.WithSize(uint(width)), funbit.WithSigned(seg.Signed)}
+	if seg.Endianness != "" {
+		opts = append(opts, funbit.WithEndianness(seg.Endianness))
+	}
+	return opts
+}
+
+func toBitString(b *Bits) *funbit.BitString {
+	return funbit.NewBitStringFromBits(b.data, uint(b.length))
+}
+
+func fromBitString(bs *funbit.BitString) *Bits {
+	return &Bits{data: bs.ToBytes(), length: int(bs.Length())}
+}
+
+// BuildBits encodes the segments of a binary construction with a funbit
+// Builder. sizes[i] is ignored when segment i has no size.
+func BuildBits(segments []BitsSegment, values []Object, sizes []Object) (*Bits, error) {
+	b := funbit.NewBuilder()
+	for i := range segments {
+		seg := &segments[i]
+		var size int64
+		if seg.HasSize {
+			n, ok := sizes[i].(*Integer)
+			if !ok || n.Value < 0 {
+				return nil, fmt.Errorf("segment %d: invalid size %s", i+1, sizes[i].Inspect())
+			}
+			size = n.Value
+		}
+		width := seg.width(size)
+		if width == 0 {
+			continue
+		}
+
+		switch seg.Type {
+		case ast.BitsInteger:
+			var n interface{}
+			switch v := values[i].(type) {
+			case *Integer:
+				n = v.Value
+			case *SizedInt:
+				// UInt64 values above the Int range are passed unsigned
+				n = v.Value
+				if v.info().Signed {
+					n = int64(v.Value)
+				}
+			default:
+				return nil, fmt.Errorf("segment %d: integer segment expects Int, got %s", i+1, values[i].Type())
+			}
+			if width > 64 {
+				return nil, fmt.Errorf("segment %d: integer segment size must be at most 64 bits, got %d", i+1, width)
+			}
+			funbit.AddInteger(b, n, seg.options(width)...)
+		case ast.BitsFloat:
+			f, ok := values[i].(*Float)
+			if !ok {
+				return nil, fmt.Errorf("segment %d: float segment expects Float, got %s", i+1, values[i].Type())
+			}
+			funbit.AddFloat(b, f.Value, seg.options(width)...)
+		case ast.BitsUTF8:
+			c, ok := values[i].(*Char)
+			if !ok {
+				return nil, fmt.Errorf("segment %d: utf8 segment expects Char, got %s", i+1, values[i].Type())
+			}
+			funbit.AddUTF8Codepoint(b, int(c.Value))
+		default:
+			var data *Bits
+			switch v := values[i].(type) {
+			case *Bits:
+				data = v
+			case *Bytes:
+				data = bitsFromBytes(v)
+			case *List:
+				data = bitsFromBytes(bytesFromString(ListToString(v)))
+			default:
+				return nil, fmt.Errorf("segment %d: %s segment expects Bytes or Bits, got %s", i+1, seg.Type, values[i].Type())
+			}
+			if width < 0 {
+				width = int64(data.length)
+			}
+			if width > int64(data.length) {
+				return nil, fmt.Errorf("segment %d: value has %d bits, segment needs %d", i+1, data.length, width)
+			}
+			if width > 0 {
+				funbit.AddBitstring(b, toBitString(data), funbit.WithSize(uint(width)))
+			}
+		}
+	}
+
+	bs, err := funbit.Build(b)
+	if err != nil {
+		return nil, err
+	}
+	return fromBitString(bs), nil
+}
+
+// MatchBitsPattern matches Bits or Bytes against the segments of a binary
+// pattern. outer holds the values of the free size names (see
+// BitsFreeNames). On success it returns the values of the bound segments
+// in pattern order. The whole input must be consumed.
+func MatchBitsPattern(segments []BitsSegment, val Object, outer map[string]int64) ([]Object, bool) {
+	var bits *Bits
+	switch v := val.(type) {
+	case *Bits:
+		bits = v
+	case *Bytes:
+		bits = bitsFromBytes(v)
+	default:
+		return nil, false
+	}
+
+	scope := make(map[string]int64, len(outer))
+	for name, v := range outer {
+		scope[name] = v
+	}
+	var captures []Object
+	offset := 0
+	for i := range segments {
+		seg := &segments[i]
+		obj, width, ok := seg.match(bits, offset, scope)
+		if !ok {
+			return nil, false
+		}
+		offset += width
+
+		if seg.Literal && !seg.IsString {
+			if _, n, ok := integralValue(obj); !ok || !n.IsInt64() || n.Int64() != seg.IntValue {
+				return nil, false
+			}
+		}
+		if seg.Bind != "" {
+			captures = append(captures, obj)
+			if n, ok := obj.(*Integer); ok {
+				scope[seg.Bind] = n.Value
+			} else {
+				delete(scope, seg.Bind)
+			}
+		}
+	}
+	if offset != bits.length {
+		return nil, false
+	}
+	return captures, true
+}
+
+// match reads the segment at offset and returns its value and width.
+func (seg *BitsSegment) match(bits *Bits, offset int, scope map[string]int64) (Object, int, bool) {
+	remaining := bits.length - offset
+
+	if seg.IsString {
+		width := len(seg.StrValue) * 8
+		if width > remaining || string(bits.slice(offset, offset+width).toBytes("low").data) != seg.StrValue {
+			return nil, 0, false
+		}
+		return nil, width, true
+	}
+
+	var size int64
+	if seg.HasSize {
+		var ok bool
+		if size, ok = seg.Size.eval(scope); !ok || size < 0 {
+			return nil, 0, false
+		}
+	}
+	width := seg.width(size)
+	if width > int64(remaining) {
+		return nil, 0, false
+	}
+
+	switch seg.Type {
+	case ast.BitsBinary:
+		if width < 0 {
+			width = int64(remaining)
+		}
+		if width%8 != 0 {
+			return nil, 0, false
+		}
+		return bits.slice(offset, offset+int(width)).toBytes("low"), int(width), true
+	case ast.BitsBitstring:
+		if width < 0 {
+			width = int64(remaining)
+		}
+		return bits.slice(offset, offset+int(width)), int(width), true
+	case ast.BitsUTF8:
+		// At most 4 bytes; the matcher reports the code point it decoded
+		window := bits.slice(offset, offset+min(remaining, 32))
+		var cp int
+		m := funbit.NewMatcher()
+		funbit.UTF8(m, &cp)
+		if _, err := funbit.Match(m, toBitString(window)); err != nil {
+			return nil, 0, false
+		}
+		return &Char{Value: int64(cp)}, utf8.RuneLen(rune(cp)) * 8, true
+	}
+
+	if width == 0 {
+		return &Integer{Value: 0}, 0, seg.Type == ast.BitsInteger
+	}
+	if seg.IntType != "" {
+		var n uint64
+		m := funbit.NewMatcher()
+		funbit.Integer(m, &n, seg.options(width)...)
+		if _, err := funbit.Match(m, toBitString(bits.slice(offset, offset+int(width)))); err != nil {
+			return nil, 0, false
+		}
+		return wrapSizedInt(config.GetIntTypeInfo(seg.IntType), n), int(width), true
+	}
+	if seg.Type == ast.BitsInteger && width > 64 {
+		return nil, 0, false
+	}
+	field := toBitString(bits.slice(offset, offset+int(width)))
+	m := funbit.NewMatcher()
+	if seg.Type == ast.BitsFloat {
+		var f float64
+		funbit.Float(m, &f, seg.options(width)...)
+		if _, err := funbit.Match(m, field); err != nil {
+			return nil, 0, false
+		}
+		return &Float{Value: f}, int(width), true
+	}
+	var n int64
+	funbit.Integer(m, &n, seg.options(width)...)
+	if _, err := funbit.Match(m, field); err != nil {
+		return nil, 0, false
+	}
+	return &Integer{Value: n}, int(width), true
+}
@@ -81,6 +81,11 @@ var Builtins = map[string]*Builtin{
 				// Unquote strings: if arg is a List of Chars, print it as a string directly
 				// Empty generic list [] should print as [], but empty string "" should print nothing
 				if list, ok := arg.(*List); ok {
+					if s, ok := list.AsString(); ok {
+						_, _ = fmt.Fprint(e.Out, s)
+						continue
+					}
+
 					// If it's explicitly marked as a Char list (string), print as string
 					if list.ElementType == "Char" {
 						var s string
@@ -133,6 +138,11 @@ var Builtins = map[string]*Builtin{
 
 				// Unquote strings: if arg is a List of Chars, print it as a string directly
 				if list, ok := arg.(*List); ok {
+					if s, ok := list.AsString(); ok {
+						_, _ = fmt.Fprint(e.Out, s)
+						continue
+					}
+
 					if list.ElementType == "Char" {
 						var s string
 						for _, el := range list.ToSlice() {
@@ -327,6 +337,8 @@ var Builtins = map[string]*Builtin{
 					goVal = v.Value
 				case *Rational:
 					goVal = v.Value
+				case *Decimal:
+					goVal = decimalFormatter{v}
 				case *List:
 					// If string, convert to string
 					if s := listToString(v); s != "" || v.len() == 0 {
@@ -491,6 +503,9 @@ func objectToString(obj Object) string {
 	// For strings (List<Char>), extract the string directly
 	// But empty list should be shown as [] not ""
 	if list, ok := obj.(*List); ok && list.len() > 0 {
+		if s, ok := list.AsString(); ok {
+			return s
+		}
 		isString := true
 		var s string
 		for _, el := range list.ToSlice() {
@@ -510,11 +525,7 @@ func objectToString(obj Object) string {
 
 // stringToList converts a Go string to List<Char>
 func stringToList(s string) *List {
-	chars := make([]Object, 0, len(s))
-	for _, r := range s {
-		chars = append(chars, &Char{Value: int64(r)})
-	}
-	return newListWithType(chars, "Char")
+	return newStringList(s)
 }
 
 // getDefaultValue returns the default value for a type
@@ -534,11 +545,16 @@ func getDefaultValue(t typesystem.Type) Object {
 			return &BigInt{Value: big.NewInt(0)}
 		case "Rational":
 			return &Rational{Value: big.NewRat(0, 1)}
+		case "Decimal":
+			return &Decimal{Unscaled: big.NewInt(0)}
 		case "Nil":
 			return &Nil{}
 		case config.ListTypeName:
 			return newList([]Object{})
 		}
+		if config.IsSizedIntType(typ.Name) {
+			return &SizedInt{Kind: typ.Name}
+		}
 	case typesystem.TApp:
 		if con, ok := typ.Constructor.(typesystem.TCon); ok {
 			switch con.Name {
@@ -601,6 +617,12 @@ func checkType(val Object, expected typesystem.Type) bool {
 		case "Rational":
 			_, ok := val.(*Rational)
 			return ok
+		case "Decimal":
+			_, ok := val.(*Decimal)
+			return ok
+		case "Int8", "Int16", "Int32", "UInt8", "UInt16", "UInt32", "UInt64":
+			s, ok := val.(*SizedInt)
+			return ok && s.Kind == t.Name
 		case "Bytes":
 			_, ok := val.(*Bytes)
 			return ok
@@ -714,6 +736,13 @@ func RegisterBuiltins(env *Environment) {
 	env.Set("Nil", &Nil{})
 	env.Set("BigInt", &TypeObject{TypeVal: typesystem.TCon{Name: "BigInt"}})
 	env.Set("Rational", &TypeObject{TypeVal: typesystem.TCon{Name: "Rational"}})
+	env.Set("Decimal", &TypeObject{TypeVal: typesystem.Decimal})
+	for _, info := range config.IntTypes {
+		if info.Suffix != "" {
+			env.Set(info.Name, &TypeObject{TypeVal: typesystem.TCon{Name: info.Name}})
+		}
+	}
+	env.Set("Byte", &TypeObject{TypeVal: typesystem.TCon{Name: "UInt8"}})
 	env.Set(config.ListTypeName, &TypeObject{TypeVal: typesystem.TCon{Name: config.ListTypeName}})
 	stringType := typesystem.TApp{
 		Constructor: typesystem.TCon{Name: config.ListTypeName},
@@ -758,6 +787,11 @@ func RegisterBuiltins(env *Environment) {
 	for name, builtin := range Builtins {
 		env.Set(name, builtin)
 	}
+
+	// Integer conversions and overflow-aware arithmetic
+	for name, builtin := range IntegerBuiltins() {
+		env.Set(name, builtin)
+	}
 }
 
 // listToString extracts a Go string from a List<Char> object
@@ -771,15 +805,18 @@ func listToString(obj Object) string {
 
 // ListToString converts List<Char> to Go string (exported for VM)
 func ListToString(list *List) string {
-	var result string
+	if s, ok := list.AsString(); ok {
+		return s
+	}
+	var result strings.Builder
 	for _, el := range list.ToSlice() {
 		if c, ok := el.(*Char); ok {
-			result += string(rune(c.Value))
+			result.WriteRune(rune(c.Value))
 		} else {
 			return ""
 		}
 	}
-	return result
+	return result.String()
 }
 
 // makeZero creates an Option Zero (None) value
@@ -820,11 +857,7 @@ func makeFail(err Object) Object {
 
 // makeFailStr creates a Result Fail with string error message
 func makeFailStr(errMsg string) Object {
-	chars := make([]Object, 0, len(errMsg))
-	for _, r := range errMsg {
-		chars = append(chars, &Char{Value: int64(r)})
-	}
-	return makeFail(newList(chars))
+	return makeFail(newStringList(errMsg))
 }
 
 // parseStringToType parses a string into a value of the specified type
@@ -870,6 +903,12 @@ func parseStringToType(s string, t typesystem.Type) Object {
 				return makeZero()
 			}
 			return makeSome(&Rational{Value: val})
+		case "Decimal":
+			val, ok := parseDecimal(strings.TrimSpace(s))
+			if !ok {
+				return makeZero()
+			}
+			return makeSome(val)
 		case "Char":
 			runes := []rune(s)
 			if len(runes) != 1 {
@@ -877,6 +916,9 @@ func parseStringToType(s string, t typesystem.Type) Object {
 			}
 			return makeSome(&Char{Value: int64(runes[0])})
 		default:
+			if info := config.GetIntTypeInfo(ty.Name); info != nil {
+				return parseSizedInt(strings.TrimSpace(s), info)
+			}
 			return makeZero()
 		}
 	case typesystem.TApp:
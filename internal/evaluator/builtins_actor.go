@@ -0,0 +1,953 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Process - lightweight actor with a mailbox
+// ============================================================================
+
+// Process is a goroutine running a function in its own evaluator (or VM)
+// clone. Processes share nothing: they communicate by sending messages,
+// and learn about each other's exits through links and monitors.
+type Process struct {
+	id       int64
+	mu       sync.Mutex
+	mailbox  []Object
+	notify   chan struct{} // signalled when a message or kill arrives
+	links    map[*Process]bool
+	monitors map[*Process]bool // processes watching this one
+	trapExit bool
+	finished bool
+	done     chan struct{}
+	reason   string
+	killed   string // exit reason received from a link or exit
+	sup      *supervisor
+
+	// ctx is cancelled when the process is killed, so that blocking builtins
+	// (sleep, receive, channels, I/O) return instead of running on
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (p *Process) Type() ObjectType { return "PID" }
+func (p *Process) TypeName() string { return "Pid" }
+func (p *Process) Inspect() string  { return fmt.Sprintf("<Pid:%d>", p.id) }
+func (p *Process) RuntimeType() typesystem.Type {
+	return typesystem.TCon{Name: "Pid"}
+}
+func (p *Process) Hash() uint32 { return uint32(p.id) }
+
+var (
+	processCounter    int64
+	mainProcess       *Process
+	mainProcessOnce   sync.Once
+	processRegistry   = make(map[string]*Process)
+	processRegistryMu sync.Mutex
+)
+
+func newProcess() *Process {
+	p := &Process{
+		id:       atomic.AddInt64(&processCounter, 1),
+		notify:   make(chan struct{}, 1),
+		links:    make(map[*Process]bool),
+		monitors: make(map[*Process]bool),
+		done:     make(chan struct{}),
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	return p
+}
+
+// currentProcess returns the process an evaluator runs in. Code outside any
+// spawned process belongs to the main program's process.
+func currentProcess(e *Evaluator) *Process {
+	if e.Process != nil {
+		return e.Process
+	}
+	mainProcessOnce.Do(func() { mainProcess = newProcess() })
+	return mainProcess
+}
+
+// spawnProcess starts fn in a new process, optionally linked to parent
+func spawnProcess(e *Evaluator, fn Object, parent *Process, link bool) *Process {
+	if e.CaptureHandler != nil {
+		fn = e.CaptureHandler(fn)
+	}
+
+	// Use Fork if available (creates isolated VM), otherwise Clone (tree-walk)
+	var procEval *Evaluator
+	if e.Fork != nil {
+		procEval = e.Fork()
+	} else {
+		procEval = e.Clone()
+	}
+
+	p := newProcess()
+	procEval.Process = p
+	procEval.ctx = p.ctx
+	if link {
+		linkProcesses(parent, p)
+	}
+
+	go func() {
+		reason := "normal"
+		defer func() {
+			if r := recover(); r != nil {
+				reason = fmt.Sprint(r)
+			}
+			if killed := p.killedReason(); killed != "" {
+				reason = killed
+			}
+			p.terminate(reason)
+		}()
+
+		result := procEval.ApplyFunction(fn, []Object{})
+		if err, ok := result.(*Error); ok {
+			// Errors raised inside the VM carry their printed prefix
+			reason = strings.TrimPrefix(err.Message, "ERROR: ")
+		}
+	}()
+
+	return p
+}
+
+// deliver appends a message to the mailbox and wakes the receiver
+func (p *Process) deliver(msg Object) {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.mailbox = append(p.mailbox, msg)
+	p.mu.Unlock()
+	p.wake()
+}
+
+func (p *Process) wake() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// kill makes the process exit with reason. A process blocked in a builtin
+// is interrupted through its context; one that is computing exits at its
+// next receive or blocking call.
+func (p *Process) kill(reason string) {
+	p.mu.Lock()
+	if p.killed == "" {
+		p.killed = reason
+	}
+	p.mu.Unlock()
+	p.cancel()
+	p.wake()
+}
+
+func (p *Process) killedReason() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.killed
+}
+
+func (p *Process) isAlive() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.finished
+}
+
+// receive waits for the first message accepted by match, leaving the others
+// queued in arrival order. A negative timeout waits forever. The second
+// result is false on timeout; a kill or cancellation is reported as an error.
+func (p *Process) receive(ctx context.Context, match func(Object) (Object, bool), timeout time.Duration) (Object, bool) {
+	var deadline <-chan time.Time
+	if timeout >= 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	scanned := 0
+	for {
+		p.mu.Lock()
+		if p.killed != "" {
+			reason := p.killed
+			p.mu.Unlock()
+			return newError("process %s exited: %s", p.Inspect(), reason), true
+		}
+		if scanned < len(p.mailbox) {
+			msg := p.mailbox[scanned]
+			p.mu.Unlock()
+
+			// The handler runs unlocked: it may send to this very process
+			result, ok := match(msg)
+			if ok || isError(result) {
+				p.remove(msg)
+				return result, true
+			}
+			scanned++
+			continue
+		}
+		p.mu.Unlock()
+
+		if timeout == 0 {
+			return nil, false
+		}
+		select {
+		case <-p.notify:
+		case <-deadline:
+			return nil, false
+		case <-ctx.Done():
+			if p.killedReason() != "" {
+				continue // report the exit reason rather than "cancelled"
+			}
+			return cancelledError(), true
+		}
+	}
+}
+
+func (p *Process) remove(msg Object) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, m := range p.mailbox {
+		if m == msg {
+			p.mailbox = append(p.mailbox[:i], p.mailbox[i+1:]...)
+			return
+		}
+	}
+}
+
+// terminate marks the process as exited and notifies monitors and links
+func (p *Process) terminate(reason string) {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.reason = reason
+	p.mailbox = nil
+	links := sortedProcesses(p.links)
+	monitors := sortedProcesses(p.monitors)
+	p.links = make(map[*Process]bool)
+	p.monitors = make(map[*Process]bool)
+	close(p.done)
+	p.mu.Unlock()
+	p.cancel()
+
+	for _, m := range monitors {
+		m.deliver(makeSignal("Down", p, reason))
+	}
+	for _, l := range links {
+		l.removeLink(p)
+		l.exitSignal(p, reason)
+	}
+	unregisterProcess(p)
+}
+
+// exitSignal delivers the exit of a linked process. Processes trapping exits
+// receive it as an Exit message; others exit too unless the reason is
+// "normal". The reason "kill" cannot be trapped.
+func (p *Process) exitSignal(from *Process, reason string) {
+	if reason == "kill" {
+		p.kill("killed")
+		return
+	}
+	p.mu.Lock()
+	trap := p.trapExit
+	p.mu.Unlock()
+	if trap {
+		p.deliver(makeSignal("Exit", from, reason))
+	} else if reason != "normal" {
+		p.kill(reason)
+	}
+}
+
+func (p *Process) addLink(other *Process) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return false
+	}
+	p.links[other] = true
+	return true
+}
+
+func (p *Process) removeLink(other *Process) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.links, other)
+}
+
+// linkProcesses links a and b both ways. Linking to a process that has
+// already exited delivers a "noproc" exit signal.
+func linkProcesses(a, b *Process) {
+	if a == b {
+		return
+	}
+	if !b.addLink(a) {
+		a.exitSignal(b, "noproc")
+		return
+	}
+	a.addLink(b)
+}
+
+func unlinkProcesses(a, b *Process) {
+	a.removeLink(b)
+	b.removeLink(a)
+}
+
+func sortedProcesses(set map[*Process]bool) []*Process {
+	result := make([]*Process, 0, len(set))
+	for p := range set {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result
+}
+
+// makeSignal builds a Down or Exit message
+func makeSignal(name string, from *Process, reason string) Object {
+	return &DataInstance{
+		Name:     name,
+		Fields:   []Object{from, newStringList(reason)},
+		TypeName: "Signal",
+	}
+}
+
+func unregisterProcess(p *Process) {
+	processRegistryMu.Lock()
+	defer processRegistryMu.Unlock()
+	for name, registered := range processRegistry {
+		if registered == p {
+			delete(processRegistry, name)
+		}
+	}
+}
+
+// ============================================================================
+// Supervisor
+// ============================================================================
+
+const (
+	strategyOneForOne  = "one_for_one"
+	strategyOneForAll  = "one_for_all"
+	strategyRestForOne = "rest_for_one"
+)
+
+// supervisor restarts the children of a supervisor process when they crash.
+// Children that exit normally are not restarted.
+type supervisor struct {
+	mu          sync.Mutex
+	strategy    string
+	maxRestarts int
+	restarts    int
+	specs       []Object
+	children    []*Process // nil once a child has exited normally
+	eval        *Evaluator
+	self        *Process
+}
+
+// supervisorShutdownTimeout bounds how long a supervisor waits for the
+// children it stops to exit
+const supervisorShutdownTimeout = 5 * time.Second
+
+func (s *supervisor) start(i int) {
+	s.children[i] = spawnProcess(s.eval, s.specs[i], s.self, true)
+}
+
+// stop shuts the children from..to-1 down without the supervisor seeing
+// their exits, and waits for them to exit
+func (s *supervisor) stop(from, to int) {
+	var stopped []*Process
+	for i := from; i < to; i++ {
+		if child := s.children[i]; child != nil {
+			unlinkProcesses(s.self, child)
+			child.kill("shutdown")
+			s.children[i] = nil
+			stopped = append(stopped, child)
+		}
+	}
+	deadline := time.NewTimer(supervisorShutdownTimeout)
+	defer deadline.Stop()
+	for _, child := range stopped {
+		select {
+		case <-child.done:
+		case <-deadline.C:
+			return
+		}
+	}
+}
+
+func (s *supervisor) indexOf(p *Process) int {
+	for i, child := range s.children {
+		if child == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// run handles exit signals until the supervisor shuts down
+func (s *supervisor) run() {
+	for {
+		msg, _ := s.self.receive(context.Background(), func(msg Object) (Object, bool) {
+			return msg, true
+		}, -1)
+		if err, ok := msg.(*Error); ok {
+			s.shutdown(err.Message)
+			return
+		}
+		signal, ok := msg.(*DataInstance)
+		if !ok || signal.Name != "Exit" {
+			continue
+		}
+		from := signal.Fields[0].(*Process)
+		reason, _ := listToGoString(signal.Fields[1])
+
+		s.mu.Lock()
+		i := s.indexOf(from)
+		if i < 0 {
+			// A link other than a child exited: take the children down with us
+			s.mu.Unlock()
+			s.shutdown(reason)
+			return
+		}
+		if reason == "normal" {
+			s.children[i] = nil
+			s.mu.Unlock()
+			continue
+		}
+		s.restarts++
+		if s.restarts > s.maxRestarts {
+			s.mu.Unlock()
+			s.shutdown("shutdown")
+			return
+		}
+		s.children[i] = nil
+		switch s.strategy {
+		case strategyOneForOne:
+			s.start(i)
+		case strategyOneForAll:
+			s.stop(0, len(s.children))
+			for j := range s.children {
+				s.start(j)
+			}
+		case strategyRestForOne:
+			s.stop(i+1, len(s.children))
+			for j := i; j < len(s.children); j++ {
+				s.start(j)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *supervisor) shutdown(reason string) {
+	s.mu.Lock()
+	s.stop(0, len(s.children))
+	s.mu.Unlock()
+	s.self.terminate(reason)
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterActorBuiltins registers actor types, signal constructors and functions
+func RegisterActorBuiltins(env *Environment) {
+	// Types
+	env.Set("Pid", &TypeObject{TypeVal: typesystem.TCon{Name: "Pid"}})
+	env.Set("Signal", &TypeObject{TypeVal: typesystem.TCon{Name: "Signal"}})
+
+	// Constructors
+	env.Set("Down", &Constructor{Name: "Down", TypeName: "Signal", Arity: 2})
+	env.Set("Exit", &Constructor{Name: "Exit", TypeName: "Signal", Arity: 2})
+
+	// Functions
+	builtins := ActorBuiltins()
+	SetActorBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// ActorBuiltins returns built-in functions for lib/actor virtual package
+func ActorBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		// Processes
+		"spawn":     {Fn: builtinSpawn, Name: "spawn"},
+		"spawnLink": {Fn: builtinSpawnLink, Name: "spawnLink"},
+		"self":      {Fn: builtinSelf, Name: "self"},
+		"isAlive":   {Fn: builtinIsAlive, Name: "isAlive"},
+		"exit":      {Fn: builtinProcessExit, Name: "exit"},
+
+		// Messages
+		"send":           {Fn: builtinSend, Name: "send"},
+		"receive":        {Fn: builtinReceive, Name: "receive"},
+		"receiveTimeout": {Fn: builtinReceiveTimeout, Name: "receiveTimeout"},
+
+		// Links and monitors
+		"link":      {Fn: builtinLink, Name: "link"},
+		"unlink":    {Fn: builtinUnlink, Name: "unlink"},
+		"monitor":   {Fn: builtinMonitor, Name: "monitor"},
+		"demonitor": {Fn: builtinDemonitor, Name: "demonitor"},
+		"trapExit":  {Fn: builtinTrapExit, Name: "trapExit"},
+
+		// Registry
+		"register": {Fn: builtinRegister, Name: "register"},
+		"whereis":  {Fn: builtinWhereis, Name: "whereis"},
+
+		// Supervision
+		"supervise":          {Fn: builtinSupervise, Name: "supervise"},
+		"supervisorChildren": {Fn: builtinSupervisorChildren, Name: "supervisorChildren"},
+	}
+}
+
+// spawn: (() -> T) -> Pid
+func builtinSpawn(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("spawn expects 1 argument, got %d", len(args))
+	}
+	return spawnProcess(e, args[0], currentProcess(e), false)
+}
+
+// spawnLink: (() -> T) -> Pid
+func builtinSpawnLink(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("spawnLink expects 1 argument, got %d", len(args))
+	}
+	return spawnProcess(e, args[0], currentProcess(e), true)
+}
+
+// self: () -> Pid
+func builtinSelf(e *Evaluator, args ...Object) Object {
+	if len(args) != 0 {
+		return newError("self expects 0 arguments, got %d", len(args))
+	}
+	return currentProcess(e)
+}
+
+// isAlive: Pid -> Bool
+func builtinIsAlive(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("isAlive expects 1 argument, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("isAlive expects a Pid, got %s", args[0].Type())
+	}
+	return boolObject(p.isAlive())
+}
+
+// exit: (Pid, String) -> Nil
+func builtinProcessExit(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("exit expects 2 arguments, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("exit expects a Pid, got %s", args[0].Type())
+	}
+	reason, ok := listToGoString(args[1])
+	if !ok {
+		return newError("exit expects a String reason, got %s", args[1].Type())
+	}
+	p.exitSignal(currentProcess(e), reason)
+	return &Nil{}
+}
+
+// send: (Pid, T) -> Nil
+func builtinSend(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("send expects 2 arguments, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("send expects a Pid, got %s", args[0].Type())
+	}
+	msg := args[1]
+	if e.CaptureHandler != nil {
+		msg = e.CaptureHandler(msg)
+	}
+	// Messages to exited processes are dropped
+	p.deliver(msg)
+	return &Nil{}
+}
+
+// matchMessage applies a receive handler: Some(x) accepts the message.
+// Messages that do not fit the handler's declared parameter type are left
+// in the mailbox without calling it.
+func matchMessage(e *Evaluator, handler Object) func(Object) (Object, bool) {
+	var paramType typesystem.Type
+	if fn, ok := handler.RuntimeType().(typesystem.TFunc); ok && len(fn.Params) > 0 {
+		paramType = fn.Params[0]
+	}
+	return func(msg Object) (Object, bool) {
+		if paramType != nil && !e.acceptsMessage(msg, paramType) {
+			return nil, false
+		}
+		result := e.ApplyFunction(handler, []Object{msg})
+		if isError(result) {
+			return result, false
+		}
+		option, ok := result.(*DataInstance)
+		if !ok || option.TypeName != config.OptionTypeName {
+			return newError("receive handler must return an Option, got %s", result.Type()), false
+		}
+		if option.Name == config.SomeCtorName && len(option.Fields) == 1 {
+			return option.Fields[0], true
+		}
+		return nil, false
+	}
+}
+
+// acceptsMessage reports whether msg fits the declared type t of a receive
+// handler's parameter. Only the outer type constructor is compared; type
+// aliases are expanded, and unannotated, function and trait object
+// parameters accept any message.
+func (e *Evaluator) acceptsMessage(msg Object, t typesystem.Type) bool {
+	msgName := typeHeadName(msg.RuntimeType())
+	for range 16 { // alias chains
+		name := typeHeadName(t)
+		if isStringType(t) {
+			name = "String"
+		}
+		switch {
+		case name == "", name == "?", strings.HasPrefix(name, typesystem.DynPrefix):
+			return true
+		case name == msgName:
+			return true
+		case name == "String":
+			list, ok := msg.(*List)
+			return ok && (list.Len() == 0 || IsStringList(list))
+		}
+		if di, ok := msg.(*DataInstance); ok && di.Name == name {
+			return true
+		}
+		underlying, isAlias := e.TypeAliases[name]
+		if !isAlias {
+			return false
+		}
+		t = underlying
+	}
+	return true
+}
+
+// typeHeadName names the outer type constructor of t: "" for type
+// variables and function types, "(,)" for tuples and "{}" for records
+func typeHeadName(t typesystem.Type) string {
+	switch tt := t.(type) {
+	case typesystem.TCon:
+		return tt.Name
+	case typesystem.TApp:
+		return typeHeadName(tt.Constructor)
+	case typesystem.TTuple:
+		return "(,)"
+	case typesystem.TRecord:
+		return "{}"
+	}
+	return ""
+}
+
+// receive: ((T) -> Option<R>) -> R
+func builtinReceive(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("receive expects 1 argument, got %d", len(args))
+	}
+	result, _ := currentProcess(e).receive(e.taskContext(), matchMessage(e, args[0]), -1)
+	return result
+}
+
+// receiveTimeout: ((T) -> Option<R>, Int) -> Option<R>
+func builtinReceiveTimeout(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("receiveTimeout expects 2 arguments, got %d", len(args))
+	}
+	timeoutMs, ok := args[1].(*Integer)
+	if !ok {
+		return newError("receiveTimeout expects Int for timeout, got %s", args[1].Type())
+	}
+	if timeoutMs.Value < 0 {
+		return newError("receiveTimeout expects a non-negative timeout, got %d", timeoutMs.Value)
+	}
+	result, ok := currentProcess(e).receive(e.taskContext(), matchMessage(e, args[0]), time.Duration(timeoutMs.Value)*time.Millisecond)
+	if !ok {
+		return makeZero()
+	}
+	if isError(result) {
+		return result
+	}
+	return makeSome(result)
+}
+
+// link: Pid -> Nil
+func builtinLink(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("link expects 1 argument, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("link expects a Pid, got %s", args[0].Type())
+	}
+	linkProcesses(currentProcess(e), p)
+	return &Nil{}
+}
+
+// unlink: Pid -> Nil
+func builtinUnlink(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("unlink expects 1 argument, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("unlink expects a Pid, got %s", args[0].Type())
+	}
+	unlinkProcesses(currentProcess(e), p)
+	return &Nil{}
+}
+
+// monitor: Pid -> Nil
+func builtinMonitor(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("monitor expects 1 argument, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("monitor expects a Pid, got %s", args[0].Type())
+	}
+	watcher := currentProcess(e)
+	p.mu.Lock()
+	finished := p.finished
+	if !finished {
+		p.monitors[watcher] = true
+	}
+	p.mu.Unlock()
+	if finished {
+		watcher.deliver(makeSignal("Down", p, "noproc"))
+	}
+	return &Nil{}
+}
+
+// demonitor: Pid -> Nil
+func builtinDemonitor(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("demonitor expects 1 argument, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("demonitor expects a Pid, got %s", args[0].Type())
+	}
+	p.mu.Lock()
+	delete(p.monitors, currentProcess(e))
+	p.mu.Unlock()
+	return &Nil{}
+}
+
+// trapExit: Bool -> Nil
+func builtinTrapExit(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("trapExit expects 1 argument, got %d", len(args))
+	}
+	flag, ok := args[0].(*Boolean)
+	if !ok {
+		return newError("trapExit expects a Bool, got %s", args[0].Type())
+	}
+	p := currentProcess(e)
+	p.mu.Lock()
+	p.trapExit = flag.Value
+	p.mu.Unlock()
+	return &Nil{}
+}
+
+// register: (String, Pid) -> Bool
+func builtinRegister(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("register expects 2 arguments, got %d", len(args))
+	}
+	name, ok := listToGoString(args[0])
+	if !ok {
+		return newError("register expects a String name, got %s", args[0].Type())
+	}
+	p, ok := args[1].(*Process)
+	if !ok {
+		return newError("register expects a Pid, got %s", args[1].Type())
+	}
+	if !p.isAlive() {
+		return boolObject(false)
+	}
+
+	processRegistryMu.Lock()
+	defer processRegistryMu.Unlock()
+	if existing, taken := processRegistry[name]; taken && existing.isAlive() {
+		return boolObject(false)
+	}
+	processRegistry[name] = p
+	return boolObject(true)
+}
+
+// whereis: String -> Option<Pid>
+func builtinWhereis(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("whereis expects 1 argument, got %d", len(args))
+	}
+	name, ok := listToGoString(args[0])
+	if !ok {
+		return newError("whereis expects a String name, got %s", args[0].Type())
+	}
+
+	processRegistryMu.Lock()
+	p, found := processRegistry[name]
+	processRegistryMu.Unlock()
+	if !found || !p.isAlive() {
+		return makeZero()
+	}
+	return makeSome(p)
+}
+
+// supervise: (String, Int, List<() -> T>) -> Pid
+func builtinSupervise(e *Evaluator, args ...Object) Object {
+	if len(args) != 3 {
+		return newError("supervise expects 3 arguments, got %d", len(args))
+	}
+	strategy, ok := listToGoString(args[0])
+	if !ok {
+		return newError("supervise expects a String strategy, got %s", args[0].Type())
+	}
+	switch strategy {
+	case strategyOneForOne, strategyOneForAll, strategyRestForOne:
+	default:
+		return newError("supervise: unknown strategy %q, expected one_for_one, one_for_all or rest_for_one", strategy)
+	}
+	maxRestarts, ok := args[1].(*Integer)
+	if !ok {
+		return newError("supervise expects Int for max restarts, got %s", args[1].Type())
+	}
+	list, ok := args[2].(*List)
+	if !ok {
+		return newError("supervise expects a List of child functions, got %s", args[2].Type())
+	}
+
+	specs := list.ToSlice()
+	if e.CaptureHandler != nil {
+		for i, spec := range specs {
+			specs[i] = e.CaptureHandler(spec)
+		}
+	}
+
+	p := newProcess()
+	p.trapExit = true
+	s := &supervisor{
+		strategy:    strategy,
+		maxRestarts: int(maxRestarts.Value),
+		specs:       specs,
+		children:    make([]*Process, len(specs)),
+		self:        p,
+	}
+	if e.Fork != nil {
+		s.eval = e.Fork()
+	} else {
+		s.eval = e.Clone()
+	}
+	s.eval.Process = p
+	p.sup = s
+
+	s.mu.Lock()
+	for i := range specs {
+		s.start(i)
+	}
+	s.mu.Unlock()
+	go s.run()
+
+	return p
+}
+
+// supervisorChildren: Pid -> List<Pid>
+func builtinSupervisorChildren(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("supervisorChildren expects 1 argument, got %d", len(args))
+	}
+	p, ok := args[0].(*Process)
+	if !ok {
+		return newError("supervisorChildren expects a Pid, got %s", args[0].Type())
+	}
+	if p.sup == nil {
+		return newError("supervisorChildren: %s is not a supervisor", p.Inspect())
+	}
+
+	p.sup.mu.Lock()
+	defer p.sup.mu.Unlock()
+	children := []Object{}
+	for _, child := range p.sup.children {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return newList(children)
+}
+
+// SetActorBuiltinTypes sets type info for actor builtins
+func SetActorBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	R := typesystem.TVar{Name: "R"}
+	pid := typesystem.TCon{Name: "Pid"}
+	stringType := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: config.ListTypeName},
+		Args:        []typesystem.Type{typesystem.Char},
+	}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{t}}
+	}
+	thunk := typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: T}
+	handler := typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: optionOf(R)}
+	pidToNil := typesystem.TFunc{Params: []typesystem.Type{pid}, ReturnType: typesystem.Nil}
+
+	types := map[string]typesystem.Type{
+		"spawn":     typesystem.TFunc{Params: []typesystem.Type{thunk}, ReturnType: pid},
+		"spawnLink": typesystem.TFunc{Params: []typesystem.Type{thunk}, ReturnType: pid},
+		"self":      typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: pid},
+		"isAlive":   typesystem.TFunc{Params: []typesystem.Type{pid}, ReturnType: typesystem.Bool},
+		"exit":      typesystem.TFunc{Params: []typesystem.Type{pid, stringType}, ReturnType: typesystem.Nil},
+
+		"send":           typesystem.TFunc{Params: []typesystem.Type{pid, T}, ReturnType: typesystem.Nil},
+		"receive":        typesystem.TFunc{Params: []typesystem.Type{handler}, ReturnType: R},
+		"receiveTimeout": typesystem.TFunc{Params: []typesystem.Type{handler, typesystem.Int}, ReturnType: optionOf(R)},
+
+		"link":      pidToNil,
+		"unlink":    pidToNil,
+		"monitor":   pidToNil,
+		"demonitor": pidToNil,
+		"trapExit":  typesystem.TFunc{Params: []typesystem.Type{typesystem.Bool}, ReturnType: typesystem.Nil},
+
+		"register": typesystem.TFunc{Params: []typesystem.Type{stringType, pid}, ReturnType: typesystem.Bool},
+		"whereis":  typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: optionOf(pid)},
+
+		"supervise": typesystem.TFunc{
+			Params: []typesystem.Type{
+				stringType,
+				typesystem.Int,
+				typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{thunk}},
+			},
+			ReturnType: pid,
+		},
+		"supervisorChildren": typesystem.TFunc{
+			Params:     []typesystem.Type{pid},
+			ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{pid}},
+		},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
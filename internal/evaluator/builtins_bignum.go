@@ -3,6 +3,7 @@ package evaluator
 import (
 	"math"
 	"math/big"
+	"strconv"
 
 	"github.com/funvibe/funxy/internal/typesystem"
 )
@@ -23,6 +24,19 @@ func BignumBuiltins() map[string]*Builtin {
 		"ratDenom":    {Fn: builtinDenominator, Name: "ratDenom"},
 		"ratToFloat":  {Fn: builtinRationalToFloat, Name: "ratToFloat"},
 		"ratToString": {Fn: builtinRationalToString, Name: "ratToString"},
+
+		// Decimal
+		"decimalNew":          {Fn: builtinDecimalNew, Name: "decimalNew"},
+		"decimalFromInt":      {Fn: builtinDecimalFromInt, Name: "decimalFromInt"},
+		"decimalFromFloat":    {Fn: builtinDecimalFromFloat, Name: "decimalFromFloat"},
+		"decimalFromUnscaled": {Fn: builtinDecimalFromUnscaled, Name: "decimalFromUnscaled"},
+		"decimalUnscaled":     {Fn: builtinDecimalUnscaled, Name: "decimalUnscaled"},
+		"decimalScale":        {Fn: builtinDecimalScale, Name: "decimalScale"},
+		"decimalRound":        {Fn: builtinDecimalRound, Name: "decimalRound"},
+		"decimalDiv":          {Fn: builtinDecimalDiv, Name: "decimalDiv"},
+		"decimalToString":     {Fn: builtinDecimalToString, Name: "decimalToString"},
+		"decimalToFloat":      {Fn: builtinDecimalToFloat, Name: "decimalToFloat"},
+		"decimalToRational":   {Fn: builtinDecimalToRational, Name: "decimalToRational"},
 	}
 }
 
@@ -41,6 +55,10 @@ func SetBignumBuiltinTypes(builtins map[string]*Builtin) {
 		Constructor: typesystem.TCon{Name: "Option"},
 		Args:        []typesystem.Type{typesystem.Float},
 	}
+	optionDecimal := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Option"},
+		Args:        []typesystem.Type{typesystem.Decimal},
+	}
 
 	types := map[string]typesystem.Type{
 		"bigIntNew":      typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: typesystem.BigInt},
@@ -53,6 +71,18 @@ func SetBignumBuiltinTypes(builtins map[string]*Builtin) {
 		"ratDenom":       typesystem.TFunc{Params: []typesystem.Type{typesystem.Rational}, ReturnType: typesystem.BigInt},
 		"ratToFloat":     typesystem.TFunc{Params: []typesystem.Type{typesystem.Rational}, ReturnType: optionFloat},
 		"ratToString":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Rational}, ReturnType: stringType},
+
+		"decimalNew":          typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: typesystem.Decimal},
+		"decimalFromInt":      typesystem.TFunc{Params: []typesystem.Type{typesystem.Int}, ReturnType: typesystem.Decimal},
+		"decimalFromFloat":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Float}, ReturnType: typesystem.Decimal},
+		"decimalFromUnscaled": typesystem.TFunc{Params: []typesystem.Type{typesystem.BigInt, typesystem.Int}, ReturnType: typesystem.Decimal},
+		"decimalUnscaled":     typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.BigInt},
+		"decimalScale":        typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.Int},
+		"decimalRound":        typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal, typesystem.Int, stringType}, ReturnType: typesystem.Decimal, DefaultCount: 1},
+		"decimalDiv":          typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal, typesystem.Decimal, typesystem.Int, stringType}, ReturnType: optionDecimal, DefaultCount: 1},
+		"decimalToString":     typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: stringType},
+		"decimalToFloat":      typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.Float},
+		"decimalToRational":   typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.Rational},
 	}
 
 	for name, typ := range types {
@@ -248,3 +278,213 @@ func builtinRationalToString(e *Evaluator, args ...Object) Object {
 	result := rat.Value.RatString()
 	return stringToList(result)
 }
+
+// =============================================================================
+// Decimal functions
+// =============================================================================
+
+// decimalNew: (String) -> Decimal
+func builtinDecimalNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalNew expects 1 argument, got %d", len(args))
+	}
+
+	str, ok := args[0].(*List)
+	if !ok {
+		return newError("decimalNew expects a string argument, got %s", args[0].Type())
+	}
+
+	s := listToString(str)
+	d, ok := parseDecimal(s)
+	if !ok {
+		return newError("decimalNew: invalid number string: %s", s)
+	}
+	return d
+}
+
+// decimalFromInt: (Int) -> Decimal
+func builtinDecimalFromInt(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalFromInt expects 1 argument, got %d", len(args))
+	}
+
+	i, ok := args[0].(*Integer)
+	if !ok {
+		return newError("decimalFromInt expects an integer argument, got %s", args[0].Type())
+	}
+
+	return &Decimal{Unscaled: big.NewInt(i.Value)}
+}
+
+// decimalFromFloat: (Float) -> Decimal
+// Uses the shortest decimal that reads back as the same Float, so 0.1 is 0.1.
+func builtinDecimalFromFloat(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalFromFloat expects 1 argument, got %d", len(args))
+	}
+
+	f, ok := args[0].(*Float)
+	if !ok {
+		return newError("decimalFromFloat expects a Float argument, got %s", args[0].Type())
+	}
+	if math.IsInf(f.Value, 0) || math.IsNaN(f.Value) {
+		return newError("decimalFromFloat: %v has no decimal value", f.Value)
+	}
+
+	d, _ := parseDecimal(strconv.FormatFloat(f.Value, 'f', -1, 64))
+	return d
+}
+
+// decimalFromUnscaled: (BigInt, Int) -> Decimal
+func builtinDecimalFromUnscaled(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("decimalFromUnscaled expects 2 arguments, got %d", len(args))
+	}
+
+	u, ok1 := args[0].(*BigInt)
+	scale, ok2 := args[1].(*Integer)
+	if !ok1 || !ok2 {
+		return newError("decimalFromUnscaled expects BigInt and Int arguments")
+	}
+	if scale.Value < 0 {
+		return newError("decimalFromUnscaled: scale cannot be negative, got %d", scale.Value)
+	}
+
+	return &Decimal{Unscaled: new(big.Int).Set(u.Value), Scale: int(scale.Value)}
+}
+
+// decimalUnscaled: (Decimal) -> BigInt
+func builtinDecimalUnscaled(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalUnscaled expects 1 argument, got %d", len(args))
+	}
+
+	d, ok := args[0].(*Decimal)
+	if !ok {
+		return newError("decimalUnscaled expects a Decimal argument, got %s", args[0].Type())
+	}
+
+	return &BigInt{Value: new(big.Int).Set(d.Unscaled)}
+}
+
+// decimalScale: (Decimal) -> Int
+func builtinDecimalScale(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalScale expects 1 argument, got %d", len(args))
+	}
+
+	d, ok := args[0].(*Decimal)
+	if !ok {
+		return newError("decimalScale expects a Decimal argument, got %s", args[0].Type())
+	}
+
+	return &Integer{Value: int64(d.Scale)}
+}
+
+// decimalRoundingArgs reads the scale and the optional rounding mode
+// (half-even by default) of decimalRound and decimalDiv.
+func decimalRoundingArgs(name string, args []Object) (int, string, *Error) {
+	scale, ok := args[0].(*Integer)
+	if !ok {
+		return 0, "", newError("%s expects an Int scale, got %s", name, args[0].Type())
+	}
+	if scale.Value < 0 {
+		return 0, "", newError("%s: scale cannot be negative, got %d", name, scale.Value)
+	}
+	mode := roundHalfEven
+	if len(args) > 1 {
+		list, ok := args[1].(*List)
+		if !ok {
+			return 0, "", newError("%s expects a String rounding mode, got %s", name, args[1].Type())
+		}
+		mode = listToString(list)
+		if !isRoundingMode(mode) {
+			return 0, "", newError("%s: unknown rounding mode %q", name, mode)
+		}
+	}
+	return int(scale.Value), mode, nil
+}
+
+// decimalRound: (Decimal, Int, String?) -> Decimal
+func builtinDecimalRound(e *Evaluator, args ...Object) Object {
+	if len(args) < 2 || len(args) > 3 {
+		return newError("decimalRound expects 2 or 3 arguments, got %d", len(args))
+	}
+
+	d, ok := args[0].(*Decimal)
+	if !ok {
+		return newError("decimalRound expects a Decimal argument, got %s", args[0].Type())
+	}
+	scale, mode, err := decimalRoundingArgs("decimalRound", args[1:])
+	if err != nil {
+		return err
+	}
+
+	return roundDecimal(d, scale, mode)
+}
+
+// decimalDiv: (Decimal, Decimal, Int, String?) -> Option<Decimal>
+func builtinDecimalDiv(e *Evaluator, args ...Object) Object {
+	if len(args) < 3 || len(args) > 4 {
+		return newError("decimalDiv expects 3 or 4 arguments, got %d", len(args))
+	}
+
+	a, ok1 := args[0].(*Decimal)
+	b, ok2 := args[1].(*Decimal)
+	if !ok1 || !ok2 {
+		return newError("decimalDiv expects Decimal arguments")
+	}
+	scale, mode, err := decimalRoundingArgs("decimalDiv", args[2:])
+	if err != nil {
+		return err
+	}
+
+	result, ok := divideDecimals(a, b, scale, mode)
+	if !ok {
+		return makeZero()
+	}
+	return makeSome(result)
+}
+
+// decimalToString: (Decimal) -> String
+func builtinDecimalToString(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalToString expects 1 argument, got %d", len(args))
+	}
+
+	d, ok := args[0].(*Decimal)
+	if !ok {
+		return newError("decimalToString expects a Decimal argument, got %s", args[0].Type())
+	}
+
+	return stringToList(formatDecimal(d))
+}
+
+// decimalToFloat: (Decimal) -> Float
+func builtinDecimalToFloat(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalToFloat expects 1 argument, got %d", len(args))
+	}
+
+	d, ok := args[0].(*Decimal)
+	if !ok {
+		return newError("decimalToFloat expects a Decimal argument, got %s", args[0].Type())
+	}
+
+	f, _ := decimalToRat(d).Float64()
+	return &Float{Value: f}
+}
+
+// decimalToRational: (Decimal) -> Rational
+func builtinDecimalToRational(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("decimalToRational expects 1 argument, got %d", len(args))
+	}
+
+	d, ok := args[0].(*Decimal)
+	if !ok {
+		return newError("decimalToRational expects a Decimal argument, got %s", args[0].Type())
+	}
+
+	return &Rational{Value: decimalToRat(d)}
+}
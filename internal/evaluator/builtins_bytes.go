@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"github.com/funvibe/funxy/internal/config"
 	"github.com/funvibe/funxy/internal/typesystem"
 	"strconv"
 	"strings"
@@ -275,13 +276,20 @@ func builtinBytesConcat(e *Evaluator, args ...Object) Object {
 
 // === Numeric encoding/decoding ===
 
+// bytesEncodeInt: (T, Int, String) -> Bytes for any integer type T. Sized
+// integers are written with their own signedness, truncated or extended to size.
 func builtinBytesEncodeInt(e *Evaluator, args ...Object) Object {
 	if len(args) < 2 || len(args) > 3 {
 		return newError("bytesEncodeInt expects 2-3 arguments, got %d", len(args))
 	}
-	val, ok := args[0].(*Integer)
-	if !ok {
-		return newError("bytesEncodeInt expects Int as first argument, got %s", args[0].Type())
+	var value uint64
+	switch v := args[0].(type) {
+	case *Integer:
+		value = uint64(v.Value)
+	case *SizedInt:
+		value = v.Value
+	default:
+		return newError("bytesEncodeInt expects an integer as first argument, got %s", args[0].Type())
 	}
 	size, ok := args[1].(*Integer)
 	if !ok {
@@ -298,42 +306,34 @@ func builtinBytesEncodeInt(e *Evaluator, args ...Object) Object {
 		endianness = listToString(endianList)
 	}
 
-	isLittle := strings.HasPrefix(endianness, "little")
-	if strings.HasPrefix(endianness, "native") {
-		isLittle = isNativeLittleEndian()
+	var order binary.ByteOrder = binary.BigEndian
+	if bytesLittleEndian(endianness) {
+		order = binary.LittleEndian
 	}
 
 	data := make([]byte, size.Value)
 	switch size.Value {
 	case 1:
-		data[0] = byte(val.Value)
+		data[0] = byte(value)
 	case 2:
-		if isLittle {
-			binary.LittleEndian.PutUint16(data, uint16(val.Value))
-		} else {
-			binary.BigEndian.PutUint16(data, uint16(val.Value))
-		}
+		order.PutUint16(data, uint16(value))
 	case 4:
-		if isLittle {
-			binary.LittleEndian.PutUint32(data, uint32(val.Value))
-		} else {
-			binary.BigEndian.PutUint32(data, uint32(val.Value))
-		}
+		order.PutUint32(data, uint32(value))
 	case 8:
-		if isLittle {
-			binary.LittleEndian.PutUint64(data, uint64(val.Value))
-		} else {
-			binary.BigEndian.PutUint64(data, uint64(val.Value))
-		}
+		order.PutUint64(data, value)
 	default:
 		return newError("bytesEncodeInt: size must be 1, 2, 4, or 8, got %d", size.Value)
 	}
 	return bytesFromSlice(data)
 }
 
+// bytesDecodeInt: (Bytes, String, Type<T>) -> T. Without a type the result
+// is an Int read from 1, 2, 4 or 8 bytes, signed if the endianness says so;
+// with a sized integer type the bytes must match its width and its
+// signedness applies.
 func builtinBytesDecodeInt(e *Evaluator, args ...Object) Object {
-	if len(args) < 1 || len(args) > 2 {
-		return newError("bytesDecodeInt expects 1-2 arguments, got %d", len(args))
+	if len(args) < 1 || len(args) > 3 {
+		return newError("bytesDecodeInt expects 1-3 arguments, got %d", len(args))
 	}
 	b, ok := args[0].(*Bytes)
 	if !ok {
@@ -342,67 +342,65 @@ func builtinBytesDecodeInt(e *Evaluator, args ...Object) Object {
 
 	// Default big-endian
 	endianness := "big"
-	if len(args) == 2 {
+	if len(args) >= 2 {
 		endianList, ok := args[1].(*List)
 		if !ok {
 			return newError("bytesDecodeInt expects String as second argument, got %s", args[1].Type())
 		}
 		endianness = listToString(endianList)
 	}
+	var info *config.IntTypeInfo
+	if len(args) == 3 {
+		typeObj, ok := args[2].(*TypeObject)
+		if !ok {
+			return newError("bytesDecodeInt expects a type as third argument, got %s", args[2].Type())
+		}
+		con, _ := typeObj.TypeVal.(typesystem.TCon)
+		if info = config.GetIntTypeInfo(con.Name); info == nil {
+			return newError("bytesDecodeInt expects an integer type, got %s", typeObj.TypeVal)
+		}
+	}
 
-	isLittle := strings.HasPrefix(endianness, "little")
-	if strings.HasPrefix(endianness, "native") {
-		isLittle = isNativeLittleEndian()
+	var order binary.ByteOrder = binary.BigEndian
+	if bytesLittleEndian(endianness) {
+		order = binary.LittleEndian
 	}
 
 	data := b.ToSlice()
-	var result int64
+	if info != nil && len(data) != info.Bits/8 {
+		return newError("bytesDecodeInt: %s needs %d bytes, got %d", info.Name, info.Bits/8, len(data))
+	}
+	var v uint64
 	switch len(data) {
 	case 1:
-		result = int64(data[0])
+		v = uint64(data[0])
 	case 2:
-		if isLittle {
-			result = int64(binary.LittleEndian.Uint16(data))
-		} else {
-			result = int64(binary.BigEndian.Uint16(data))
-		}
+		v = uint64(order.Uint16(data))
 	case 4:
-		if isLittle {
-			result = int64(binary.LittleEndian.Uint32(data))
-		} else {
-			result = int64(binary.BigEndian.Uint32(data))
-		}
+		v = uint64(order.Uint32(data))
 	case 8:
-		if isLittle {
-			result = int64(binary.LittleEndian.Uint64(data))
-		} else {
-			result = int64(binary.BigEndian.Uint64(data))
-		}
+		v = order.Uint64(data)
 	default:
 		return newError("bytesDecodeInt: bytes length must be 1, 2, 4, or 8, got %d", len(data))
 	}
+	if info != nil && info.Suffix != "" {
+		return wrapSizedInt(info, v)
+	}
 
 	// Handle signed if specified
-	if strings.Contains(endianness, "signed") {
-		switch len(data) {
-		case 1:
-			result = int64(int8(data[0]))
-		case 2:
-			if isLittle {
-				result = int64(int16(binary.LittleEndian.Uint16(data)))
-			} else {
-				result = int64(int16(binary.BigEndian.Uint16(data)))
-			}
-		case 4:
-			if isLittle {
-				result = int64(int32(binary.LittleEndian.Uint32(data)))
-			} else {
-				result = int64(int32(binary.BigEndian.Uint32(data)))
-			}
-		}
+	if info == nil && strings.Contains(endianness, "signed") && len(data) < 8 {
+		shift := 64 - 8*len(data)
+		return &Integer{Value: int64(v<<shift) >> shift}
 	}
+	return &Integer{Value: int64(v)}
+}
 
-	return &Integer{Value: result}
+// bytesLittleEndian interprets an endianness argument: big, little or native
+func bytesLittleEndian(endianness string) bool {
+	if strings.HasPrefix(endianness, "native") {
+		return isNativeLittleEndian()
+	}
+	return strings.HasPrefix(endianness, "little")
 }
 
 func builtinBytesEncodeFloat(e *Evaluator, args ...Object) Object {
@@ -590,6 +588,10 @@ func SetBytesBuiltinTypes(builtins map[string]*Builtin) {
 	// Option types
 	optionIntType := typesystem.TApp{Constructor: typesystem.TCon{Name: "Option"}, Args: []typesystem.Type{intType}}
 
+	// Sized integers: T with Integral<T>
+	sizedType := typesystem.TVar{Name: "T"}
+	integralSized := []typesystem.Constraint{{TypeVar: "T", Trait: config.IntegralTraitName}}
+
 	types := map[string]typesystem.Type{
 		"bytesNew":        typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: bytesType},
 		"bytesFromString": typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: bytesType},
@@ -608,8 +610,8 @@ func SetBytesBuiltinTypes(builtins map[string]*Builtin) {
 
 		"bytesConcat":     typesystem.TFunc{Params: []typesystem.Type{bytesType, bytesType}, ReturnType: bytesType},
 
-		"bytesEncodeInt":   typesystem.TFunc{Params: []typesystem.Type{intType, intType, stringType}, ReturnType: bytesType, DefaultCount: 1}, // endianness optional
-		"bytesDecodeInt":   typesystem.TFunc{Params: []typesystem.Type{bytesType, stringType}, ReturnType: intType, DefaultCount: 1}, // endianness optional
+		"bytesEncodeInt":   typesystem.TFunc{Params: []typesystem.Type{sizedType, intType, stringType}, ReturnType: bytesType, DefaultCount: 1, Constraints: integralSized}, // endianness optional
+		"bytesDecodeInt":   typesystem.TFunc{Params: []typesystem.Type{bytesType, stringType, typesystem.TType{Type: sizedType}}, ReturnType: sizedType, DefaultCount: 2, Constraints: integralSized}, // endianness and type optional
 		"bytesEncodeFloat": typesystem.TFunc{Params: []typesystem.Type{floatType, intType}, ReturnType: bytesType},
 		"bytesDecodeFloat": typesystem.TFunc{Params: []typesystem.Type{bytesType, intType}, ReturnType: resultFloatType},
 
@@ -624,8 +626,6 @@ func SetBytesBuiltinTypes(builtins map[string]*Builtin) {
 
 	// Override decodeFloat return type if needed based on my analysis
 	types["bytesDecodeFloat"] = typesystem.TFunc{Params: []typesystem.Type{bytesType, intType}, ReturnType: floatType}
-	// Same for decodeInt
-	types["bytesDecodeInt"] = typesystem.TFunc{Params: []typesystem.Type{bytesType, stringType}, ReturnType: intType, DefaultCount: 1}
 
 	for name, typ := range types {
 		if b, ok := builtins[name]; ok {
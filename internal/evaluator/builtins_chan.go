@@ -0,0 +1,373 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Channel - typed channel for communication between tasks
+// ============================================================================
+
+// Channel wraps a Go channel. Values are captured on send, so a channel can
+// be shared between tree-walk clones and forked VMs.
+type Channel struct {
+	id int64
+	ch chan Object
+}
+
+var channelCounter int64
+
+func (c *Channel) Type() ObjectType { return "CHAN" }
+func (c *Channel) TypeName() string { return "Chan" }
+func (c *Channel) Inspect() string  { return fmt.Sprintf("<Chan:%d>", c.id) }
+func (c *Channel) RuntimeType() typesystem.Type {
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Chan"},
+		Args:        []typesystem.Type{typesystem.TVar{Name: "T"}},
+	}
+}
+func (c *Channel) Hash() uint32 { return uint32(c.id) }
+
+// NewChannel creates a channel with the given buffer size (0 = unbuffered)
+func NewChannel(capacity int) *Channel {
+	return &Channel{
+		id: atomic.AddInt64(&channelCounter, 1),
+		ch: make(chan Object, capacity),
+	}
+}
+
+// send blocks until the value is taken or buffered, or ctx is cancelled.
+// Sending on a closed channel is an error rather than a Go panic.
+func (c *Channel) send(ctx context.Context, value Object) (err Object) {
+	defer func() {
+		if recover() != nil {
+			err = newError("send on closed channel %s", c.Inspect())
+		}
+	}()
+	select {
+	case c.ch <- value:
+		return nil
+	case <-ctx.Done():
+		return cancelledError()
+	}
+}
+
+func (c *Channel) close() (err Object) {
+	defer func() {
+		if recover() != nil {
+			err = newError("close of closed channel %s", c.Inspect())
+		}
+	}()
+	close(c.ch)
+	return nil
+}
+
+// SelectCase is one branch of a select: a receive or a send on a channel,
+// and the function producing the select's result when it is chosen.
+type SelectCase struct {
+	Chan    *Channel
+	IsSend  bool
+	Value   Object // value to send
+	Handler Object // (Option<T>) -> R for receive, () -> R for send
+}
+
+func (s *SelectCase) Type() ObjectType { return "SELECT_CASE" }
+func (s *SelectCase) Inspect() string {
+	if s.IsSend {
+		return "<SelectCase:send " + s.Chan.Inspect() + ">"
+	}
+	return "<SelectCase:recv " + s.Chan.Inspect() + ">"
+}
+func (s *SelectCase) RuntimeType() typesystem.Type {
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "SelectCase"},
+		Args:        []typesystem.Type{typesystem.TVar{Name: "R"}},
+	}
+}
+func (s *SelectCase) Hash() uint32 { return s.Chan.Hash() }
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterChanBuiltins registers channel types and functions
+func RegisterChanBuiltins(env *Environment) {
+	// Types
+	env.Set("Chan", &TypeObject{TypeVal: typesystem.TCon{Name: "Chan"}})
+	env.Set("SelectCase", &TypeObject{TypeVal: typesystem.TCon{Name: "SelectCase"}})
+
+	// Functions
+	builtins := ChanBuiltins()
+	SetChanBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// ChanBuiltins returns built-in functions for lib/chan virtual package
+func ChanBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		// Channels
+		"chanNew":   {Fn: builtinChanNew, Name: "chanNew"},
+		"chanSend":  {Fn: builtinChanSend, Name: "chanSend"},
+		"chanRecv":  {Fn: builtinChanRecv, Name: "chanRecv"},
+		"chanClose": {Fn: builtinChanClose, Name: "chanClose"},
+
+		// Select
+		"onRecv":        {Fn: builtinOnRecv, Name: "onRecv"},
+		"onSend":        {Fn: builtinOnSend, Name: "onSend"},
+		"select":        {Fn: builtinSelect, Name: "select"},
+		"selectTimeout": {Fn: builtinSelectTimeout, Name: "selectTimeout"},
+	}
+}
+
+// chanNew: Int -> Chan<T>
+func builtinChanNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("chanNew expects 1 argument, got %d", len(args))
+	}
+	capacity, ok := args[0].(*Integer)
+	if !ok {
+		return newError("chanNew expects Int for capacity, got %s", args[0].Type())
+	}
+	if capacity.Value < 0 {
+		return newError("chanNew expects a non-negative capacity, got %d", capacity.Value)
+	}
+	return NewChannel(int(capacity.Value))
+}
+
+// chanSend: (Chan<T>, T) -> Nil
+func builtinChanSend(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("chanSend expects 2 arguments, got %d", len(args))
+	}
+	ch, ok := args[0].(*Channel)
+	if !ok {
+		return newError("chanSend expects a Chan, got %s", args[0].Type())
+	}
+	value := args[1]
+	if e.CaptureHandler != nil {
+		value = e.CaptureHandler(value)
+	}
+	if err := ch.send(e.taskContext(), value); err != nil {
+		return err
+	}
+	return &Nil{}
+}
+
+// chanRecv: Chan<T> -> Option<T>
+func builtinChanRecv(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("chanRecv expects 1 argument, got %d", len(args))
+	}
+	ch, ok := args[0].(*Channel)
+	if !ok {
+		return newError("chanRecv expects a Chan, got %s", args[0].Type())
+	}
+	select {
+	case value, ok := <-ch.ch:
+		if !ok {
+			return makeZero()
+		}
+		return makeSome(value)
+	case <-e.taskContext().Done():
+		return cancelledError()
+	}
+}
+
+// chanClose: Chan<T> -> Nil
+func builtinChanClose(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("chanClose expects 1 argument, got %d", len(args))
+	}
+	ch, ok := args[0].(*Channel)
+	if !ok {
+		return newError("chanClose expects a Chan, got %s", args[0].Type())
+	}
+	if err := ch.close(); err != nil {
+		return err
+	}
+	return &Nil{}
+}
+
+// onRecv: (Chan<T>, (Option<T>) -> R) -> SelectCase<R>
+func builtinOnRecv(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("onRecv expects 2 arguments, got %d", len(args))
+	}
+	ch, ok := args[0].(*Channel)
+	if !ok {
+		return newError("onRecv expects a Chan, got %s", args[0].Type())
+	}
+	return &SelectCase{Chan: ch, Handler: args[1]}
+}
+
+// onSend: (Chan<T>, T, () -> R) -> SelectCase<R>
+func builtinOnSend(e *Evaluator, args ...Object) Object {
+	if len(args) != 3 {
+		return newError("onSend expects 3 arguments, got %d", len(args))
+	}
+	ch, ok := args[0].(*Channel)
+	if !ok {
+		return newError("onSend expects a Chan, got %s", args[0].Type())
+	}
+	value := args[1]
+	if e.CaptureHandler != nil {
+		value = e.CaptureHandler(value)
+	}
+	return &SelectCase{Chan: ch, IsSend: true, Value: value, Handler: args[2]}
+}
+
+// runSelect waits for the first ready case and applies its handler. A
+// negative timeout waits forever; the second result is false on timeout.
+func runSelect(e *Evaluator, name string, casesObj Object, timeout time.Duration) (result Object, chosen bool) {
+	list, ok := casesObj.(*List)
+	if !ok {
+		return newError("%s expects a List of cases, got %s", name, casesObj.Type()), true
+	}
+
+	items := list.ToSlice()
+	cases := make([]*SelectCase, len(items))
+	selectCases := make([]reflect.SelectCase, 0, len(items)+1)
+	for i, item := range items {
+		c, ok := item.(*SelectCase)
+		if !ok {
+			return newError("%s expects cases built with onRecv or onSend, got %s", name, item.Type()), true
+		}
+		cases[i] = c
+		if c.IsSend {
+			selectCases = append(selectCases, reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(c.Chan.ch),
+				Send: reflect.ValueOf(&c.Value).Elem(),
+			})
+		} else {
+			selectCases = append(selectCases, reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(c.Chan.ch),
+			})
+		}
+	}
+	// Cancellation of the task, then the timeout, follow the cases
+	ctx := e.taskContext()
+	selectCases = append(selectCases, reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(ctx.Done()),
+	})
+	if timeout >= 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		selectCases = append(selectCases, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(timer.C),
+		})
+	} else if len(cases) == 0 {
+		return newError("%s with no cases would block forever", name), true
+	}
+
+	index, value, recvOK, err := doSelect(selectCases)
+	if err != nil {
+		return err, true
+	}
+	if index == len(cases) {
+		return cancelledError(), true
+	}
+	if index == len(cases)+1 {
+		return nil, false
+	}
+
+	c := cases[index]
+	if c.IsSend {
+		return e.ApplyFunction(c.Handler, []Object{}), true
+	}
+	if !recvOK {
+		return e.ApplyFunction(c.Handler, []Object{makeZero()}), true
+	}
+	received, _ := value.Interface().(Object)
+	return e.ApplyFunction(c.Handler, []Object{makeSome(received)}), true
+}
+
+// doSelect runs reflect.Select, turning a send on a closed channel into an error
+func doSelect(cases []reflect.SelectCase) (index int, value reflect.Value, recvOK bool, err Object) {
+	defer func() {
+		if recover() != nil {
+			err = newError("select: send on closed channel")
+		}
+	}()
+	index, value, recvOK = reflect.Select(cases)
+	return index, value, recvOK, nil
+}
+
+// select: List<SelectCase<R>> -> R
+func builtinSelect(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("select expects 1 argument, got %d", len(args))
+	}
+	result, _ := runSelect(e, "select", args[0], -1)
+	return result
+}
+
+// selectTimeout: (List<SelectCase<R>>, Int) -> Option<R>
+func builtinSelectTimeout(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("selectTimeout expects 2 arguments, got %d", len(args))
+	}
+	timeoutMs, ok := args[1].(*Integer)
+	if !ok {
+		return newError("selectTimeout expects Int for timeout, got %s", args[1].Type())
+	}
+	if timeoutMs.Value < 0 {
+		return newError("selectTimeout expects a non-negative timeout, got %d", timeoutMs.Value)
+	}
+	result, chosen := runSelect(e, "selectTimeout", args[0], time.Duration(timeoutMs.Value)*time.Millisecond)
+	if !chosen {
+		return makeZero()
+	}
+	if isError(result) {
+		return result
+	}
+	return makeSome(result)
+}
+
+// SetChanBuiltinTypes sets type info for channel builtins
+func SetChanBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	R := typesystem.TVar{Name: "R"}
+	chanT := typesystem.TApp{Constructor: typesystem.TCon{Name: "Chan"}, Args: []typesystem.Type{T}}
+	caseR := typesystem.TApp{Constructor: typesystem.TCon{Name: "SelectCase"}, Args: []typesystem.Type{R}}
+	listCaseR := typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{caseR}}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{t}}
+	}
+
+	types := map[string]typesystem.Type{
+		"chanNew":   typesystem.TFunc{Params: []typesystem.Type{typesystem.Int}, ReturnType: chanT},
+		"chanSend":  typesystem.TFunc{Params: []typesystem.Type{chanT, T}, ReturnType: typesystem.Nil},
+		"chanRecv":  typesystem.TFunc{Params: []typesystem.Type{chanT}, ReturnType: optionOf(T)},
+		"chanClose": typesystem.TFunc{Params: []typesystem.Type{chanT}, ReturnType: typesystem.Nil},
+
+		"onRecv": typesystem.TFunc{
+			Params:     []typesystem.Type{chanT, typesystem.TFunc{Params: []typesystem.Type{optionOf(T)}, ReturnType: R}},
+			ReturnType: caseR,
+		},
+		"onSend": typesystem.TFunc{
+			Params:     []typesystem.Type{chanT, T, typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: R}},
+			ReturnType: caseR,
+		},
+		"select":        typesystem.TFunc{Params: []typesystem.Type{listCaseR}, ReturnType: R},
+		"selectTimeout": typesystem.TFunc{Params: []typesystem.Type{listCaseR, typesystem.Int}, ReturnType: optionOf(R)},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
@@ -128,10 +128,8 @@ func builtinFlagParse(e *Evaluator, args ...Object) Object {
 			return newError("flagParse expects List<String> as argument")
 		}
 	} else {
-		// Use os.Args, skipping program name
-		if len(os.Args) > 1 {
-			inputArgs = os.Args[1:]
-		}
+		// Use program arguments, skipping program name
+		inputArgs = programArgs()
 	}
 
 	// Reset state
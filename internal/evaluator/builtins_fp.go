@@ -3,6 +3,7 @@ package evaluator
 // RegisterFPTraits registers all built-in FP traits and their instances.
 // FP traits (Semigroup, Monoid, Functor, Applicative, Monad, Fallback) are always available.
 // This includes trait methods: (<>), mempty, fmap, pure, (<*>), (>>=), (??)
+// It also registers the Iter instances of the library collections.
 func RegisterFPTraits(e *Evaluator, env *Environment) {
 	// Initialize ClassImplementations maps
 	for _, traitName := range []string{"Empty", "Semigroup", "Monoid", "Functor", "Applicative", "Monad", "Optional"} {
@@ -54,6 +55,7 @@ func RegisterFPTraits(e *Evaluator, env *Environment) {
 	registerApplicativeInstances(e)
 	registerMonadInstances(e)
 	registerOptionalInstances(e)
+	registerCollectionIterInstances(e)
 }
 
 // ============================================================================
@@ -0,0 +1,286 @@
+package evaluator
+
+import (
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Heap - persistent priority queue
+// ============================================================================
+
+// Heap is an immutable min-priority queue. It is kept in the same balanced
+// tree as OrderedSet, keyed by the element and then by an insertion counter,
+// so equal elements are popped in the order they were pushed and the heap
+// can be listed in order without comparing anything.
+type Heap struct {
+	root *treeNode
+	next int64 // insertion counter of the next element
+}
+
+func (h *Heap) Type() ObjectType { return "HEAP" }
+func (h *Heap) TypeName() string { return "Heap" }
+
+// Inspect shows a heap as the call that builds it
+func (h *Heap) Inspect() string {
+	return inspectCall("heapFrom", h.Elements())
+}
+
+func (h *Heap) RuntimeType() typesystem.Type {
+	var elemType typesystem.Type = typesystem.TVar{Name: "T"}
+	if h.root != nil {
+		elemType = heapValue(h.root).RuntimeType()
+	}
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Heap"},
+		Args:        []typesystem.Type{elemType},
+	}
+}
+
+func (h *Heap) Hash() uint32 {
+	hash := uint32(0)
+	for _, value := range h.Elements() {
+		hash = hash*31 + value.Hash()
+	}
+	return hash
+}
+
+// Elements returns the elements in the order they would be popped
+func (h *Heap) Elements() []Object {
+	nodes := treeNodes(h.root)
+	values := make([]Object, len(nodes))
+	for i, n := range nodes {
+		values[i] = heapValue(n)
+	}
+	return values
+}
+
+// Len returns the number of elements
+func (h *Heap) Len() int {
+	return treeSize(h.root)
+}
+
+// equals checks that both heaps would pop equal elements in the same order
+func (h *Heap) equals(other *Heap, eq func(a, b Object) bool) bool {
+	if h.Len() != other.Len() {
+		return false
+	}
+	b := other.Elements()
+	for i, value := range h.Elements() {
+		if !eq(value, b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// heapValue returns the element stored in a heap node
+func heapValue(n *treeNode) Object {
+	return n.key.(*Tuple).Elements[0]
+}
+
+// heapCompare orders heap keys by element, then by insertion counter
+func heapCompare(order *ordering) compareFunc {
+	return func(a, b Object) int {
+		x, y := a.(*Tuple).Elements, b.(*Tuple).Elements
+		if c := order.compare(x[0], y[0]); c != 0 {
+			return c
+		}
+		return compareObjects(x[1], y[1])
+	}
+}
+
+// push returns h with the values added, or the error of a failing (<)
+func (h *Heap) push(e *Evaluator, values ...Object) (*Heap, Object) {
+	if len(values) == 0 {
+		return h, nil
+	}
+	order := e.orderingFor(values[0])
+	cmp := heapCompare(order)
+	root, next := h.root, h.next
+	for _, value := range values {
+		key := &Tuple{Elements: []Object{value, &Integer{Value: next}}}
+		root = treePut(root, key, nil, cmp)
+		next++
+	}
+	if order.err != nil {
+		return nil, order.err
+	}
+	return &Heap{root: root, next: next}, nil
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterHeapBuiltins registers the Heap type and functions
+func RegisterHeapBuiltins(env *Environment) {
+	// Types
+	env.Set("Heap", &TypeObject{TypeVal: typesystem.TCon{Name: "Heap"}})
+
+	// Functions
+	builtins := HeapBuiltins()
+	SetHeapBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// HeapBuiltins returns built-in functions for lib/heap virtual package
+func HeapBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		"heapNew":    {Fn: builtinHeapNew, Name: "heapNew"},
+		"heapFrom":   {Fn: builtinHeapFrom, Name: "heapFrom"},
+		"heapPush":   {Fn: builtinHeapPush, Name: "heapPush"},
+		"heapPeek":   {Fn: builtinHeapPeek, Name: "heapPeek"},
+		"heapPop":    {Fn: builtinHeapPop, Name: "heapPop"},
+		"heapSize":   {Fn: builtinHeapSize, Name: "heapSize"},
+		"heapToList": {Fn: builtinHeapToList, Name: "heapToList"},
+		"heapMerge":  {Fn: builtinHeapMerge, Name: "heapMerge"},
+	}
+}
+
+// heapArgs checks that there are heaps+extra arguments, the first heaps of
+// them being heaps
+func heapArgs(name string, args []Object, heaps, extra int) ([]*Heap, Object) {
+	if len(args) != heaps+extra {
+		return nil, newError("%s expects %d arguments, got %d", name, heaps+extra, len(args))
+	}
+	result := make([]*Heap, heaps)
+	for i := 0; i < heaps; i++ {
+		h, ok := args[i].(*Heap)
+		if !ok {
+			return nil, newError("%s expects a Heap, got %s", name, args[i].Type())
+		}
+		result[i] = h
+	}
+	return result, nil
+}
+
+// heapNew: () -> Heap<T>
+func builtinHeapNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 0 {
+		return newError("heapNew expects 0 arguments, got %d", len(args))
+	}
+	return &Heap{}
+}
+
+// heapFrom: List<T> -> Heap<T>
+func builtinHeapFrom(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("heapFrom expects 1 argument, got %d", len(args))
+	}
+	list, ok := args[0].(*List)
+	if !ok {
+		return newError("heapFrom expects a list, got %s", args[0].Type())
+	}
+	h, err := (&Heap{}).push(e, list.ToSlice()...)
+	if err != nil {
+		return err
+	}
+	return h
+}
+
+// heapPush: (Heap<T>, T) -> Heap<T>
+func builtinHeapPush(e *Evaluator, args ...Object) Object {
+	heaps, err := heapArgs("heapPush", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	h, err := heaps[0].push(e, args[1])
+	if err != nil {
+		return err
+	}
+	return h
+}
+
+// heapPeek: Heap<T> -> Option<T>
+func builtinHeapPeek(e *Evaluator, args ...Object) Object {
+	heaps, err := heapArgs("heapPeek", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	min := treeMin(heaps[0].root)
+	if min == nil {
+		return makeZero()
+	}
+	return makeSome(heapValue(min))
+}
+
+// heapPop: Heap<T> -> Option<(T, Heap<T>)>
+// The least element and the heap without it. Removing the leftmost node
+// needs no comparisons.
+func builtinHeapPop(e *Evaluator, args ...Object) Object {
+	heaps, err := heapArgs("heapPop", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	h := heaps[0]
+	if h.root == nil {
+		return makeZero()
+	}
+	min := treeMin(h.root)
+	rest := &Heap{root: treeRemoveMin(h.root), next: h.next}
+	return makeSome(&Tuple{Elements: []Object{heapValue(min), rest}})
+}
+
+// heapSize: Heap<T> -> Int
+func builtinHeapSize(e *Evaluator, args ...Object) Object {
+	heaps, err := heapArgs("heapSize", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return &Integer{Value: int64(heaps[0].Len())}
+}
+
+// heapToList: Heap<T> -> List<T>
+// All elements in the order they would be popped.
+func builtinHeapToList(e *Evaluator, args ...Object) Object {
+	heaps, err := heapArgs("heapToList", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return newList(heaps[0].Elements())
+}
+
+// heapMerge: (Heap<T>, Heap<T>) -> Heap<T>
+// Equal elements of the first heap come before those of the second.
+func builtinHeapMerge(e *Evaluator, args ...Object) Object {
+	heaps, err := heapArgs("heapMerge", args, 2, 0)
+	if err != nil {
+		return err
+	}
+	h, err := heaps[0].push(e, heaps[1].Elements()...)
+	if err != nil {
+		return err
+	}
+	return h
+}
+
+// SetHeapBuiltinTypes sets type info for heap builtins
+func SetHeapBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	heapT := typesystem.TApp{Constructor: typesystem.TCon{Name: "Heap"}, Args: []typesystem.Type{T}}
+	listT := typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{T}}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{t}}
+	}
+	orderT := []typesystem.Constraint{{TypeVar: "T", Trait: "Order"}}
+
+	types := map[string]typesystem.Type{
+		"heapNew":    typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: heapT},
+		"heapFrom":   typesystem.TFunc{Params: []typesystem.Type{listT}, ReturnType: heapT, Constraints: orderT},
+		"heapPush":   typesystem.TFunc{Params: []typesystem.Type{heapT, T}, ReturnType: heapT, Constraints: orderT},
+		"heapPeek":   typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: optionOf(T)},
+		"heapPop":    typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: optionOf(typesystem.TTuple{Elements: []typesystem.Type{T, heapT}})},
+		"heapSize":   typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: typesystem.Int},
+		"heapToList": typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: listT},
+		"heapMerge":  typesystem.TFunc{Params: []typesystem.Type{heapT, heapT}, ReturnType: heapT, Constraints: orderT},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
@@ -53,7 +53,7 @@ func builtinHttpGet(e *Evaluator, args ...Object) Object {
 	}
 
 	url := listToString(urlList)
-	return doHttpRequest("GET", url, nil, "")
+	return doHttpRequest(e.taskContext(), "GET", url, nil, "")
 }
 
 // httpPost: (String, String) -> Result<HttpResponse, String>
@@ -74,7 +74,7 @@ func builtinHttpPost(e *Evaluator, args ...Object) Object {
 
 	url := listToString(urlList)
 	body := listToString(bodyList)
-	return doHttpRequest("POST", url, nil, body)
+	return doHttpRequest(e.taskContext(), "POST", url, nil, body)
 }
 
 // httpPostJson: (String, A) -> Result<HttpResponse, String>
@@ -97,7 +97,7 @@ func builtinHttpPostJson(e *Evaluator, args ...Object) Object {
 	}
 
 	headers := [][2]string{{"Content-Type", "application/json"}}
-	return doHttpRequest("POST", url, headers, jsonBody)
+	return doHttpRequest(e.taskContext(), "POST", url, headers, jsonBody)
 }
 
 // httpPut: (String, String) -> Result<HttpResponse, String>
@@ -118,7 +118,7 @@ func builtinHttpPut(e *Evaluator, args ...Object) Object {
 
 	url := listToString(urlList)
 	body := listToString(bodyList)
-	return doHttpRequest("PUT", url, nil, body)
+	return doHttpRequest(e.taskContext(), "PUT", url, nil, body)
 }
 
 // httpDelete: (String) -> Result<HttpResponse, String>
@@ -133,7 +133,7 @@ func builtinHttpDelete(e *Evaluator, args ...Object) Object {
 	}
 
 	url := listToString(urlList)
-	return doHttpRequest("DELETE", url, nil, "")
+	return doHttpRequest(e.taskContext(), "DELETE", url, nil, "")
 }
 
 // httpRequest: (String, String, List<(String, String)>, String, Int) -> Result<HttpResponse, String>
@@ -193,7 +193,7 @@ func builtinHttpRequest(e *Evaluator, args ...Object) Object {
 		timeout = time.Duration(timeoutInt.Value) * time.Millisecond
 	}
 
-	return doHttpRequestWithTimeout(method, url, headers, body, timeout)
+	return doHttpRequestWithTimeout(e.taskContext(), method, url, headers, body, timeout)
 }
 
 // httpSetTimeout: (Int) -> Nil
@@ -212,12 +212,13 @@ func builtinHttpSetTimeout(e *Evaluator, args ...Object) Object {
 }
 
 // doHttpRequest performs the actual HTTP request with global timeout
-func doHttpRequest(method, url string, headers [][2]string, body string) Object {
-	return doHttpRequestWithTimeout(method, url, headers, body, httpTimeout)
+func doHttpRequest(ctx context.Context, method, url string, headers [][2]string, body string) Object {
+	return doHttpRequestWithTimeout(ctx, method, url, headers, body, httpTimeout)
 }
 
-// doHttpRequestWithTimeout performs HTTP request with specified timeout
-func doHttpRequestWithTimeout(method, url string, headers [][2]string, body string, timeout time.Duration) Object {
+// doHttpRequestWithTimeout performs HTTP request with specified timeout.
+// Cancelling ctx aborts the request.
+func doHttpRequestWithTimeout(ctx context.Context, method, url string, headers [][2]string, body string, timeout time.Duration) Object {
 	// Check for HTTP mocks first
 	tr := GetTestRunner()
 
@@ -246,7 +247,7 @@ func doHttpRequestWithTimeout(method, url string, headers [][2]string, body stri
 		reqBody = bytes.NewBufferString(body)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return makeFail(stringToList("failed to create request: " + err.Error()))
 	}
@@ -258,12 +259,18 @@ func doHttpRequestWithTimeout(method, url string, headers [][2]string, body stri
 
 	resp, err := client.Do(req)
 	if err != nil {
+		if ctx.Err() != nil {
+			return cancelledError()
+		}
 		return makeFail(stringToList("request failed: " + err.Error()))
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		if ctx.Err() != nil {
+			return cancelledError()
+		}
 		return makeFail(stringToList("failed to read response: " + err.Error()))
 	}
 
@@ -424,6 +431,9 @@ func builtinHttpServe(e *Evaluator, args ...Object) Object {
 			reqEval = serverEval.Clone()
 		}
 
+		// Calls made by the handler stop when the client goes away
+		reqEval.ctx = r.Context()
+
 		// Build HttpRequest object
 		var headers []Object
 		for key, values := range r.Header {
@@ -557,6 +567,9 @@ func builtinHttpServeAsync(e *Evaluator, args ...Object) Object {
 			reqEval = serverEval.Clone()
 		}
 
+		// Calls made by the handler stop when the client goes away
+		reqEval.ctx = r.Context()
+
 		// Build HttpRequest object
 		var headers []Object
 		for key, values := range r.Header {
@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"bufio"
+	"io"
 	"os"
 	"github.com/funvibe/funxy/internal/typesystem"
 	"sync"
@@ -21,6 +22,13 @@ func getStdinReader() *bufio.Reader {
 	return stdinReader
 }
 
+// SetStdin replaces the input readLine reads from. The differential backend
+// uses it to replay the input of its first run to the second one.
+func SetStdin(r io.Reader) {
+	stdinReaderOnce.Do(func() {})
+	stdinReader = bufio.NewReader(r)
+}
+
 // IOBuiltins returns built-in functions for lib/io virtual package
 func IOBuiltins() map[string]*Builtin {
 	return map[string]*Builtin{
@@ -48,17 +56,11 @@ func IOBuiltins() map[string]*Builtin {
 	}
 }
 
-// stringToCharList converts a Go string to List<Char>
-func stringToCharList(s string) []Object {
-	chars := make([]Object, 0, len(s))
-	for _, r := range s {
-		chars = append(chars, &Char{Value: int64(r)})
-	}
-	return chars
-}
-
 // charListToString converts List<Char> to Go string
 func charListToString(list *List) string {
+	if s, ok := list.AsString(); ok {
+		return s
+	}
 	runes := make([]rune, list.len())
 	for i, elem := range list.ToSlice() {
 		if ch, ok := elem.(*Char); ok {
@@ -90,7 +92,7 @@ func builtinReadLine(e *Evaluator, args ...Object) Object {
 		line = line[:len(line)-1]
 	}
 
-	return makeSome(newList(stringToCharList(line)))
+	return makeSome(stringToList(line))
 }
 
 // readFile: (String) -> Result<String, String>
@@ -110,7 +112,7 @@ func builtinReadFile(e *Evaluator, args ...Object) Object {
 		return makeFailStr(err.Error())
 	}
 
-	return makeOk(newList(stringToCharList(string(content))))
+	return makeOk(stringToList(string(content)))
 }
 
 // readFileAt: (String, Int, Int) -> Result<String, String>
@@ -161,7 +163,7 @@ func builtinReadFileAt(e *Evaluator, args ...Object) Object {
 		return makeFailStr(err.Error())
 	}
 
-	return makeOk(newList(stringToCharList(string(buffer[:n]))))
+	return makeOk(stringToList(string(buffer[:n])))
 }
 
 // writeFile: (String, String) -> Result<Int, String>
@@ -384,7 +386,7 @@ func builtinDirList(e *Evaluator, args ...Object) Object {
 
 	names := make([]Object, len(entries))
 	for i, entry := range entries {
-		names[i] = newList(stringToCharList(entry.Name()))
+		names[i] = stringToList(entry.Name())
 	}
 
 	return makeOk(newList(names))
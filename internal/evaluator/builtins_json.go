@@ -3,8 +3,13 @@ package evaluator
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/funvibe/funxy/internal/config"
 	"github.com/funvibe/funxy/internal/typesystem"
+	"math/big"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // JSON encoding/decoding functions for lib/json
@@ -112,6 +117,11 @@ func objectToGo(obj Object) (interface{}, error) {
 	case *Rational:
 		// Rational -> string "num/den"
 		return v.Value.RatString(), nil
+	case *Decimal:
+		// Decimal -> number literal with its exact digits
+		return json.Number(formatDecimal(v)), nil
+	case *Dynamic:
+		return objectToGo(v.Value)
 	default:
 		return nil, fmt.Errorf("cannot encode %s to JSON", obj.Type())
 	}
@@ -146,6 +156,9 @@ func inferFromJson(data interface{}, e *Evaluator) (Object, error) {
 		}
 		return newList(elements), nil
 	case map[string]interface{}:
+		if obj, ok, err := decodeDerivedJson(v, e); ok {
+			return obj, err
+		}
 		fields := make(map[string]Object)
 		for k, val := range v {
 			obj, err := inferFromJson(val, e)
@@ -160,6 +173,49 @@ func inferFromJson(data interface{}, e *Evaluator) (Object, error) {
 	}
 }
 
+// decodeDerivedJson rebuilds an ADT value from its {"_type", "_fields"}
+// encoding when the constructor belongs to a type deriving Json. It is used
+// where the expected type is not known; schemaDecoder handles the rest.
+func decodeDerivedJson(v map[string]interface{}, e *Evaluator) (Object, bool, error) {
+	tag, ok := v["_type"].(string)
+	if !ok || e == nil {
+		return nil, false, nil
+	}
+	var ctor Object
+	for _, impl := range e.ClassImplementations["Json"] {
+		if table, ok := impl.(*MethodTable); ok && table.Methods[tag] != nil {
+			ctor = table.Methods[tag]
+			break
+		}
+	}
+	if ctor == nil {
+		return nil, false, nil
+	}
+
+	raw, _ := v["_fields"].([]interface{})
+	switch c := ctor.(type) {
+	case *DataInstance:
+		if len(raw) != 0 {
+			return nil, true, fmt.Errorf("constructor %s takes no fields, got %d", tag, len(raw))
+		}
+		return c, true, nil
+	case *Constructor:
+		if len(raw) != c.Arity {
+			return nil, true, fmt.Errorf("constructor %s expects %d fields, got %d", tag, c.Arity, len(raw))
+		}
+		fields := make([]Object, len(raw))
+		for i, item := range raw {
+			obj, err := inferFromJson(item, e)
+			if err != nil {
+				return nil, true, err
+			}
+			fields[i] = obj
+		}
+		return &DataInstance{Name: c.Name, TypeName: c.TypeName, Fields: fields}, true, nil
+	}
+	return nil, false, nil
+}
+
 // isStringListJson checks if a list is a string (List<Char>)
 func isStringListJson(l *List) bool {
 	if l.ElementType == "Char" {
@@ -175,6 +231,9 @@ func isStringListJson(l *List) bool {
 
 // listToStringJson converts List<Char> to Go string
 func listToStringJson(l *List) string {
+	if s, ok := l.AsString(); ok {
+		return s
+	}
 	var sb strings.Builder
 	for _, el := range l.ToSlice() {
 		if c, ok := el.(*Char); ok {
@@ -186,12 +245,7 @@ func listToStringJson(l *List) string {
 
 // stringToListJson converts Go string to List<Char>
 func stringToListJson(s string) *List {
-	runes := []rune(s)
-	elements := make([]Object, len(runes))
-	for i, r := range runes {
-		elements[i] = &Char{Value: int64(r)}
-	}
-	return newListWithType(elements, "Char")
+	return newStringList(s)
 }
 
 // makeOkJson creates Result.Ok
@@ -203,6 +257,7 @@ func makeOkJson(value Object) *DataInstance {
 func RegisterJsonBuiltins(env *Environment) {
 	// Types
 	env.Set("Json", &TypeObject{TypeVal: typesystem.TCon{Name: "Json"}})
+	env.Set("JsonError", &TypeObject{TypeVal: typesystem.TCon{Name: "JsonError"}})
 
 	// Constructors
 	env.Set("JNull", &DataInstance{Name: "JNull", Fields: []Object{}, TypeName: "Json"})
@@ -225,6 +280,7 @@ func JsonBuiltins() map[string]*Builtin {
 	return map[string]*Builtin{
 		"jsonEncode":    {Name: "jsonEncode", Fn: builtinEncode},
 		"jsonDecode":    {Name: "jsonDecode", Fn: builtinDecode},
+		"jsonDecodeAs":  {Name: "jsonDecodeAs", Fn: builtinDecodeAs},
 		"jsonParse":     {Name: "jsonParse", Fn: builtinParseJson},
 		"jsonFromValue": {Name: "jsonFromValue", Fn: builtinToJson},
 		"jsonGet":       {Name: "jsonGet", Fn: builtinJsonGet},
@@ -261,6 +317,13 @@ func SetJsonBuiltinTypes(builtins map[string]*Builtin) {
 			Params:     []typesystem.Type{stringType},
 			ReturnType: resultType,
 		},
+		"jsonDecodeAs": typesystem.TFunc{
+			Params: []typesystem.Type{typesystem.TType{Type: typesystem.TVar{Name: "T"}}, stringType},
+			ReturnType: typesystem.TApp{
+				Constructor: typesystem.TCon{Name: "Result"},
+				Args:        []typesystem.Type{typesystem.TCon{Name: "JsonError"}, typesystem.TVar{Name: "T"}},
+			},
+		},
 		"jsonParse": typesystem.TFunc{
 			Params:     []typesystem.Type{stringType},
 			ReturnType: resultJsonType,
@@ -299,7 +362,12 @@ func builtinEncode(e *Evaluator, args ...Object) Object {
 }
 
 func builtinDecode(e *Evaluator, args ...Object) Object {
-	if len(args) != 1 {
+	// The analyzer appends the expected type once it is known
+	var target *TypeObject
+	if len(args) == 2 {
+		target, _ = args[1].(*TypeObject)
+	}
+	if len(args) != 1 && (target == nil || target.Schema == nil) {
 		return newError("decode requires exactly 1 argument")
 	}
 
@@ -311,6 +379,20 @@ func builtinDecode(e *Evaluator, args ...Object) Object {
 		return makeFailStr("decode argument must be a String")
 	}
 
+	if target != nil {
+		// Decode into the expected type, like jsonDecodeAs
+		data, err := parseJsonNumbers(jsonStr)
+		if err != nil {
+			return makeFailStr(err.Error())
+		}
+		d := &schemaDecoder{schema: target.Schema, eval: e}
+		result, decodeErr := d.decode(data, target.Schema.Type, "$")
+		if decodeErr != nil {
+			return makeFailStr(decodeErr.path + ": " + decodeErr.message)
+		}
+		return makeOkJson(result)
+	}
+
 	// Parse JSON first
 	data, parseErr := parseJsonValueWithError(jsonStr)
 	if parseErr != nil {
@@ -326,6 +408,415 @@ func builtinDecode(e *Evaluator, args ...Object) Object {
 	return makeOkJson(result)
 }
 
+func builtinDecodeAs(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("jsonDecodeAs requires exactly 2 arguments")
+	}
+	typeObj, ok := args[0].(*TypeObject)
+	if !ok {
+		return newError("jsonDecodeAs: first argument must be a Type, got %s", args[0].Type())
+	}
+	list, ok := args[1].(*List)
+	if !ok || !isStringListJson(list) {
+		return newError("jsonDecodeAs: second argument must be a String, got %s", args[1].Type())
+	}
+
+	// Numbers stay json.Number until the target type is known, so that
+	// Decimal fields never pass through float64
+	data, err := parseJsonNumbers(listToStringJson(list))
+	if err != nil {
+		return makeFail(newJsonError("$", err.Error()))
+	}
+
+	d := &schemaDecoder{schema: typeObj.Schema, eval: e}
+	if d.schema == nil {
+		d.schema = &typesystem.Schema{Type: typeObj.TypeVal}
+	}
+	result, decodeErr := d.decode(data, d.schema.Type, "$")
+	if decodeErr != nil {
+		return makeFail(newJsonError(decodeErr.path, decodeErr.message))
+	}
+	return makeOk(result)
+}
+
+// newJsonError builds a lib/json JsonError record
+func newJsonError(path, message string) *RecordInstance {
+	rec := NewRecord(map[string]Object{
+		"path":    stringToListJson(path),
+		"message": stringToListJson(message),
+	})
+	rec.TypeName = "JsonError"
+	return rec
+}
+
+// jsonPathError is a decoding mismatch at a JSON path such as $.items[3].price
+type jsonPathError struct {
+	path    string
+	message string
+}
+
+// schemaDecoder converts parsed JSON into values of a schema's type.
+type schemaDecoder struct {
+	schema *typesystem.Schema
+	eval   *Evaluator
+}
+
+func (d *schemaDecoder) mismatch(path string, t typesystem.Type, data interface{}) *jsonPathError {
+	return &jsonPathError{path, fmt.Sprintf("expected %s, got %s", describeSchemaType(t), jsonKind(data))}
+}
+
+func (d *schemaDecoder) decode(data interface{}, t typesystem.Type, path string) (Object, *jsonPathError) {
+	if num, ok := data.(json.Number); ok && t != typesystem.Type(typesystem.Decimal) {
+		data = jsonNumberFloat(num)
+	}
+	switch typ := t.(type) {
+	case typesystem.TVar:
+		// Unconstrained: keep the dynamic jsonDecode behaviour
+		obj, err := inferFromJson(floatJsonNumbers(data), d.eval)
+		if err != nil {
+			return nil, &jsonPathError{path, err.Error()}
+		}
+		return obj, nil
+
+	case typesystem.TCon:
+		return d.decodeNamed(data, typ.Name, nil, path)
+
+	case typesystem.TApp:
+		con, ok := typ.Constructor.(typesystem.TCon)
+		if !ok {
+			break
+		}
+		switch con.Name {
+		case config.ListTypeName:
+			if len(typ.Args) == 1 && typ.Args[0] == typesystem.Char {
+				if str, ok := data.(string); ok {
+					return stringToListJson(str), nil
+				}
+				// jsonEncode cannot tell an empty String from an empty list
+				if arr, ok := data.([]interface{}); ok && len(arr) == 0 {
+					return stringToListJson(""), nil
+				}
+				return nil, d.mismatch(path, t, data)
+			}
+			arr, ok := data.([]interface{})
+			if !ok || len(typ.Args) != 1 {
+				return nil, d.mismatch(path, t, data)
+			}
+			elements := make([]Object, len(arr))
+			for i, item := range arr {
+				obj, err := d.decode(item, typ.Args[0], fmt.Sprintf("%s[%d]", path, i))
+				if err != nil {
+					return nil, err
+				}
+				elements[i] = obj
+			}
+			return newList(elements), nil
+
+		case config.OptionTypeName:
+			if len(typ.Args) != 1 {
+				break
+			}
+			if data == nil {
+				return makeZero(), nil
+			}
+			obj, err := d.decode(data, typ.Args[0], path)
+			if err != nil {
+				return nil, err
+			}
+			return makeSome(obj), nil
+
+		case config.MapTypeName:
+			obj, ok := data.(map[string]interface{})
+			if !ok || len(typ.Args) != 2 || !isStringType(typ.Args[0]) {
+				return nil, d.mismatch(path, t, data)
+			}
+			m := newMap()
+			for _, key := range sortedJsonKeys(obj) {
+				val, err := d.decode(obj[key], typ.Args[1], path+"."+key)
+				if err != nil {
+					return nil, err
+				}
+				m = m.put(stringToListJson(key), val)
+			}
+			return m, nil
+		}
+		return d.decodeNamed(data, con.Name, typ, path)
+
+	case typesystem.TRecord:
+		return d.decodeRecord(data, typ.Fields, "", t, path)
+
+	case typesystem.TTuple:
+		arr, ok := data.([]interface{})
+		if !ok || len(arr) != len(typ.Elements) {
+			return nil, d.mismatch(path, t, data)
+		}
+		elements := make([]Object, len(arr))
+		for i, item := range arr {
+			obj, err := d.decode(item, typ.Elements[i], fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = obj
+		}
+		return &Tuple{Elements: elements}, nil
+
+	case typesystem.TUnion:
+		// First alternative that accepts the value wins
+		for _, alt := range typ.Types {
+			if obj, err := d.decode(data, alt, path); err == nil {
+				return obj, nil
+			}
+		}
+		return nil, d.mismatch(path, t, data)
+	}
+	return nil, &jsonPathError{path, fmt.Sprintf("cannot decode JSON into %s", describeSchemaType(t))}
+}
+
+// decodeNamed decodes primitives and the records and ADTs of the schema.
+// app carries the type arguments of generic types.
+func (d *schemaDecoder) decodeNamed(data interface{}, name string, app typesystem.Type, path string) (Object, *jsonPathError) {
+	t := app
+	if t == nil {
+		t = typesystem.TCon{Name: name}
+	}
+	switch name {
+	case "Int":
+		if num, ok := data.(float64); ok && num == float64(int64(num)) {
+			return &Integer{Value: int64(num)}, nil
+		}
+		return nil, d.mismatch(path, t, data)
+	case "Float":
+		if num, ok := data.(float64); ok {
+			return &Float{Value: num}, nil
+		}
+		return nil, d.mismatch(path, t, data)
+	case "Bool":
+		if b, ok := data.(bool); ok {
+			return &Boolean{Value: b}, nil
+		}
+		return nil, d.mismatch(path, t, data)
+	case "Char":
+		if str, ok := data.(string); ok && utf8.RuneCountInString(str) == 1 {
+			r, _ := utf8.DecodeRuneInString(str)
+			return &Char{Value: int64(r)}, nil
+		}
+		return nil, d.mismatch(path, t, data)
+	case "Nil":
+		if data == nil {
+			return &Nil{}, nil
+		}
+		return nil, d.mismatch(path, t, data)
+	case "BigInt":
+		// jsonEncode writes BigInt as a string; plain integers are accepted too
+		var n big.Int
+		switch v := data.(type) {
+		case string:
+			if _, ok := n.SetString(v, 10); ok {
+				return &BigInt{Value: &n}, nil
+			}
+		case float64:
+			if v == float64(int64(v)) {
+				return &BigInt{Value: n.SetInt64(int64(v))}, nil
+			}
+		}
+		return nil, d.mismatch(path, t, data)
+	case "Rational":
+		if str, ok := data.(string); ok {
+			if r, ok := new(big.Rat).SetString(str); ok {
+				return &Rational{Value: r}, nil
+			}
+		}
+		return nil, d.mismatch(path, t, data)
+	case "Decimal":
+		// Numbers keep their exact digits; strings such as "12.50" are accepted too
+		var text string
+		switch v := data.(type) {
+		case json.Number:
+			text = string(v)
+		case string:
+			text = v
+		}
+		if dec, ok := parseDecimal(text); ok {
+			return dec, nil
+		}
+		return nil, d.mismatch(path, t, floatJsonNumbers(data))
+	case "Json":
+		return goToJsonADT(floatJsonNumbers(data)), nil
+	}
+
+	if rec, ok := d.schema.Records[name]; ok {
+		subst := schemaSubst(rec.Params, app)
+		fields := make(map[string]typesystem.Type, len(rec.Fields))
+		for field, ft := range rec.Fields {
+			fields[field] = ft.Apply(subst)
+		}
+		return d.decodeRecord(data, fields, name, t, path)
+	}
+	if adt, ok := d.schema.ADTs[name]; ok {
+		return d.decodeADT(data, name, adt, schemaSubst(adt.Params, app), path)
+	}
+	return nil, &jsonPathError{path, fmt.Sprintf("cannot decode JSON into %s", describeSchemaType(t))}
+}
+
+func (d *schemaDecoder) decodeRecord(data interface{}, fields map[string]typesystem.Type, typeName string, t typesystem.Type, path string) (Object, *jsonPathError) {
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, d.mismatch(path, t, data)
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make(map[string]Object, len(fields))
+	for _, name := range names {
+		ft := fields[name]
+		raw, present := obj[name]
+		if !present {
+			if isOptionType(ft) {
+				values[name] = makeZero()
+				continue
+			}
+			return nil, &jsonPathError{path + "." + name, "missing required field"}
+		}
+		val, err := d.decode(raw, ft, path+"."+name)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = val
+	}
+	rec := NewRecord(values)
+	rec.TypeName = typeName
+	return rec, nil
+}
+
+// decodeADT reads the {"_type": ..., "_fields": [...]} form written by jsonEncode
+func (d *schemaDecoder) decodeADT(data interface{}, typeName string, adt typesystem.SchemaADT, subst typesystem.Subst, path string) (Object, *jsonPathError) {
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, d.mismatch(path, typesystem.TCon{Name: typeName}, data)
+	}
+	tag, ok := obj["_type"].(string)
+	if !ok {
+		return nil, &jsonPathError{path + "._type", "missing constructor tag for " + typeName}
+	}
+	for _, ctor := range adt.Constructors {
+		if ctor.Name != tag {
+			continue
+		}
+		raw, _ := obj["_fields"].([]interface{})
+		if len(raw) != len(ctor.Fields) {
+			return nil, &jsonPathError{path + "._fields", fmt.Sprintf("%s expects %d fields, got %d", tag, len(ctor.Fields), len(raw))}
+		}
+		if len(ctor.Fields) == 0 {
+			return &DataInstance{Name: tag, Fields: []Object{}, TypeName: typeName}, nil
+		}
+		fields := make([]Object, len(raw))
+		for i, item := range raw {
+			val, err := d.decode(item, ctor.Fields[i].Apply(subst), fmt.Sprintf("%s._fields[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = val
+		}
+		return &DataInstance{Name: tag, Fields: fields, TypeName: typeName}, nil
+	}
+	return nil, &jsonPathError{path + "._type", fmt.Sprintf("unknown constructor %s for %s", tag, typeName)}
+}
+
+// schemaSubst binds a generic definition's parameters to the arguments of app
+func schemaSubst(params []string, app typesystem.Type) typesystem.Subst {
+	subst := typesystem.Subst{}
+	if a, ok := app.(typesystem.TApp); ok {
+		for i, name := range params {
+			if i < len(a.Args) {
+				subst[name] = a.Args[i]
+			}
+		}
+	}
+	return subst
+}
+
+func isOptionType(t typesystem.Type) bool {
+	app, ok := t.(typesystem.TApp)
+	if !ok {
+		return false
+	}
+	con, ok := app.Constructor.(typesystem.TCon)
+	return ok && con.Name == config.OptionTypeName
+}
+
+func isStringType(t typesystem.Type) bool {
+	app, ok := t.(typesystem.TApp)
+	if !ok || len(app.Args) != 1 || app.Args[0] != typesystem.Char {
+		return false
+	}
+	con, ok := app.Constructor.(typesystem.TCon)
+	return ok && con.Name == config.ListTypeName
+}
+
+// describeSchemaType names a type the way it is written in source
+func describeSchemaType(t typesystem.Type) string {
+	switch typ := t.(type) {
+	case typesystem.TApp:
+		if isStringType(typ) {
+			return "String"
+		}
+		args := make([]string, len(typ.Args))
+		for i, arg := range typ.Args {
+			args[i] = describeSchemaType(arg)
+		}
+		return describeSchemaType(typ.Constructor) + "<" + strings.Join(args, ", ") + ">"
+	case typesystem.TTuple:
+		elems := make([]string, len(typ.Elements))
+		for i, el := range typ.Elements {
+			elems[i] = describeSchemaType(el)
+		}
+		return "(" + strings.Join(elems, ", ") + ")"
+	case typesystem.TUnion:
+		alts := make([]string, len(typ.Types))
+		for i, alt := range typ.Types {
+			alts[i] = describeSchemaType(alt)
+		}
+		return strings.Join(alts, " | ")
+	case typesystem.TRecord:
+		return "Record"
+	}
+	return t.String()
+}
+
+// jsonKind names the type of a parsed JSON value
+func jsonKind(data interface{}) string {
+	switch v := data.(type) {
+	case nil:
+		return "Nil"
+	case bool:
+		return "Bool"
+	case float64:
+		if v == float64(int64(v)) {
+			return "Int"
+		}
+		return "Float"
+	case string:
+		return "String"
+	case []interface{}:
+		return "List"
+	case map[string]interface{}:
+		return "Record"
+	}
+	return fmt.Sprintf("%T", data)
+}
+
+func sortedJsonKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // parseJsonValueWithError parses JSON string to interface{} with error
 func parseJsonValueWithError(jsonStr string) (interface{}, error) {
 	var data interface{}
@@ -335,6 +826,48 @@ func parseJsonValueWithError(jsonStr string) (interface{}, error) {
 	return data, nil
 }
 
+// parseJsonNumbers parses like parseJsonValueWithError but keeps numbers
+// as json.Number
+func parseJsonNumbers(jsonStr string) (interface{}, error) {
+	if !json.Valid([]byte(jsonStr)) {
+		return parseJsonValueWithError(jsonStr)
+	}
+	dec := json.NewDecoder(strings.NewReader(jsonStr))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	return data, nil
+}
+
+func jsonNumberFloat(num json.Number) float64 {
+	f, _ := strconv.ParseFloat(string(num), 64)
+	return f
+}
+
+// floatJsonNumbers turns the json.Number values inside data into float64
+// for the code that works on plain parsed JSON
+func floatJsonNumbers(data interface{}) interface{} {
+	switch v := data.(type) {
+	case json.Number:
+		return jsonNumberFloat(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = floatJsonNumbers(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = floatJsonNumbers(item)
+		}
+		return out
+	}
+	return data
+}
+
 // builtinParseJson parses JSON string into Json ADT
 func builtinParseJson(e *Evaluator, args ...Object) Object {
 	if len(args) != 1 {
@@ -504,6 +1037,8 @@ func objectToJsonADT(obj Object) Object {
 		}
 		// Other ADTs
 		return &DataInstance{Name: "JNull", Fields: []Object{}, TypeName: "Json"}
+	case *Dynamic:
+		return objectToJsonADT(v.Value)
 	default:
 		return &DataInstance{Name: "JNull", Fields: []Object{}, TypeName: "Json"}
 	}
@@ -43,6 +43,9 @@ func ListBuiltins() map[string]*Builtin {
 		"dropWhile": {Fn: builtinDropWhile, Name: "dropWhile"},
 		"partition": {Fn: builtinPartition, Name: "partition"},
 		"forEach":   {Fn: builtinForEach, Name: "forEach"},
+		"parMap":    {Fn: builtinParMap, Name: "parMap"},
+		"parFilter": {Fn: builtinParFilter, Name: "parFilter"},
+		"parFold":   {Fn: builtinParFold, Name: "parFold"},
 	}
 }
 
@@ -539,6 +542,28 @@ func builtinSort(e *Evaluator, args ...Object) Object {
 	result := make([]Object, list.len())
 	copy(result, list.ToSlice())
 
+	// Elements of a type with an Order instance (derived or written by hand)
+	// are sorted by its (<)
+	if less, ok := e.lookupTraitMethod("Order", getRuntimeTypeName(result[0]), "(<)"); ok {
+		var failed Object
+		sort.SliceStable(result, func(i, j int) bool {
+			if failed != nil {
+				return false
+			}
+			res := e.ApplyFunction(less, []Object{result[i], result[j]})
+			if isError(res) {
+				failed = res
+				return false
+			}
+			b, ok := res.(*Boolean)
+			return ok && b.Value
+		})
+		if failed != nil {
+			return failed
+		}
+		return newList(result)
+	}
+
 	// Sort using comparison
 	sort.SliceStable(result, func(i, j int) bool {
 		cmp := compareObjects(result[i], result[j])
@@ -876,6 +901,14 @@ func compareObjects(a, b Object) int {
 			}
 			return 0
 		}
+	case *SizedInt:
+		if bv, ok := b.(*SizedInt); ok {
+			return CompareSizedInts(av, bv)
+		}
+	case *Decimal:
+		if bv, ok := b.(*Decimal); ok {
+			return CompareDecimals(av, bv)
+		}
 	}
 	// Fallback: compare string representations
 	aStr := a.Inspect()
@@ -946,6 +979,9 @@ func SetListBuiltinTypes(builtins map[string]*Builtin) {
 		"sort":      typesystem.TFunc{Params: []typesystem.Type{listT}, ReturnType: listT},
 		"sortBy":    typesystem.TFunc{Params: []typesystem.Type{listT, typesystem.TFunc{Params: []typesystem.Type{T, T}, ReturnType: typesystem.Int}}, ReturnType: listT},
 		"range":     typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, typesystem.Int}, ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{typesystem.Int}}},
+		"parMap":    typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: U}, listT}, ReturnType: listU},
+		"parFilter": typesystem.TFunc{Params: []typesystem.Type{predicateT, listT}, ReturnType: listT},
+		"parFold":   typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: U}, listT}, ReturnType: U, Constraints: []typesystem.Constraint{{TypeVar: "U", Trait: "Monoid"}}},
 	}
 
 	for name, typ := range types {
@@ -0,0 +1,799 @@
+package evaluator
+
+import (
+	"bytes"
+
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// OrderedMap and OrderedSet - persistent sorted collections
+// ============================================================================
+
+// ordering compares values with their type's Order instance, as sort does,
+// or with the built-in ordering when the type has none. An error raised by a
+// user-defined (<) is kept in err and the values are treated as equal;
+// callers check err once the operation is done.
+type ordering struct {
+	e    *Evaluator
+	less Object
+	err  Object
+}
+
+// orderingFor returns the ordering of values of sample's type
+func (e *Evaluator) orderingFor(sample Object) *ordering {
+	o := &ordering{e: e}
+	if less, ok := e.lookupTraitMethod("Order", getRuntimeTypeName(sample), "(<)"); ok {
+		o.less = less
+	}
+	return o
+}
+
+func (o *ordering) compare(a, b Object) int {
+	if o.err != nil {
+		return 0
+	}
+	if o.less == nil {
+		return o.e.compareObjects(a, b)
+	}
+	if o.lessThan(a, b) {
+		return -1
+	}
+	if o.lessThan(b, a) {
+		return 1
+	}
+	return 0
+}
+
+func (o *ordering) lessThan(a, b Object) bool {
+	res := o.e.ApplyFunction(o.less, []Object{a, b})
+	if isError(res) {
+		o.err = res
+		return false
+	}
+	result, ok := res.(*Boolean)
+	return ok && result.Value
+}
+
+// OrderedMap is an immutable map that keeps its keys sorted
+type OrderedMap struct {
+	root *treeNode
+}
+
+func (m *OrderedMap) Type() ObjectType { return "ORDERED_MAP" }
+func (m *OrderedMap) TypeName() string { return "OrderedMap" }
+
+// Inspect shows a map as the call that builds it
+func (m *OrderedMap) Inspect() string {
+	var out bytes.Buffer
+	out.WriteString("orderedMapFrom([")
+	for i, n := range treeNodes(m.root) {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString("(")
+		out.WriteString(n.key.Inspect())
+		out.WriteString(", ")
+		out.WriteString(n.value.Inspect())
+		out.WriteString(")")
+	}
+	out.WriteString("])")
+	return out.String()
+}
+
+func (m *OrderedMap) RuntimeType() typesystem.Type {
+	var keyType typesystem.Type = typesystem.TVar{Name: "K"}
+	var valType typesystem.Type = typesystem.TVar{Name: "V"}
+	if m.root != nil {
+		keyType = m.root.key.RuntimeType()
+		valType = m.root.value.RuntimeType()
+	}
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "OrderedMap"},
+		Args:        []typesystem.Type{keyType, valType},
+	}
+}
+
+func (m *OrderedMap) Hash() uint32 {
+	h := uint32(0)
+	for _, n := range treeNodes(m.root) {
+		h = h*31 + (n.key.Hash() ^ (n.value.Hash() * 31))
+	}
+	return h
+}
+
+// Elements returns the (key, value) pairs in key order
+func (m *OrderedMap) Elements() []Object {
+	nodes := treeNodes(m.root)
+	items := make([]Object, len(nodes))
+	for i, n := range nodes {
+		items[i] = &Tuple{Elements: []Object{n.key, n.value}}
+	}
+	return items
+}
+
+// Len returns the number of entries
+func (m *OrderedMap) Len() int {
+	return treeSize(m.root)
+}
+
+// equals checks that both maps hold equal entries in the same order
+func (m *OrderedMap) equals(other *OrderedMap, eq func(a, b Object) bool) bool {
+	if m.Len() != other.Len() {
+		return false
+	}
+	b := treeNodes(other.root)
+	for i, n := range treeNodes(m.root) {
+		if !eq(n.key, b[i].key) || !eq(n.value, b[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+// OrderedSet is an immutable set that keeps its elements sorted
+type OrderedSet struct {
+	root *treeNode
+}
+
+func (s *OrderedSet) Type() ObjectType { return "ORDERED_SET" }
+func (s *OrderedSet) TypeName() string { return "OrderedSet" }
+
+// Inspect shows a set as the call that builds it
+func (s *OrderedSet) Inspect() string {
+	return inspectCall("orderedSetFrom", s.Elements())
+}
+
+func (s *OrderedSet) RuntimeType() typesystem.Type {
+	var elemType typesystem.Type = typesystem.TVar{Name: "T"}
+	if s.root != nil {
+		elemType = s.root.key.RuntimeType()
+	}
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "OrderedSet"},
+		Args:        []typesystem.Type{elemType},
+	}
+}
+
+func (s *OrderedSet) Hash() uint32 {
+	h := uint32(0)
+	for _, n := range treeNodes(s.root) {
+		h = h*31 + n.key.Hash()
+	}
+	return h
+}
+
+// Elements returns the elements in order
+func (s *OrderedSet) Elements() []Object {
+	return treeKeys(treeNodes(s.root))
+}
+
+// Len returns the number of elements
+func (s *OrderedSet) Len() int {
+	return treeSize(s.root)
+}
+
+// equals checks that both sets hold equal elements
+func (s *OrderedSet) equals(other *OrderedSet, eq func(a, b Object) bool) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	b := treeNodes(other.root)
+	for i, n := range treeNodes(s.root) {
+		if !eq(n.key, b[i].key) {
+			return false
+		}
+	}
+	return true
+}
+
+func treeKeys(nodes []*treeNode) []Object {
+	keys := make([]Object, len(nodes))
+	for i, n := range nodes {
+		keys[i] = n.key
+	}
+	return keys
+}
+
+// mergeSorted merges two node lists in key order. Keys only in a, in both
+// and only in b are kept according to the flags; for keys in both, a's node
+// is kept.
+func mergeSorted(a, b []*treeNode, cmp compareFunc, onlyA, both, onlyB bool) []*treeNode {
+	var result []*treeNode
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		c := cmp(a[i].key, b[j].key)
+		switch {
+		case c < 0:
+			if onlyA {
+				result = append(result, a[i])
+			}
+			i++
+		case c > 0:
+			if onlyB {
+				result = append(result, b[j])
+			}
+			j++
+		default:
+			if both {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyA {
+		result = append(result, a[i:]...)
+	}
+	if onlyB {
+		result = append(result, b[j:]...)
+	}
+	return result
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterOrderedBuiltins registers the OrderedMap and OrderedSet types and
+// functions
+func RegisterOrderedBuiltins(env *Environment) {
+	// Types
+	env.Set("OrderedMap", &TypeObject{TypeVal: typesystem.TCon{Name: "OrderedMap"}})
+	env.Set("OrderedSet", &TypeObject{TypeVal: typesystem.TCon{Name: "OrderedSet"}})
+
+	// Functions
+	builtins := OrderedBuiltins()
+	SetOrderedBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// OrderedBuiltins returns built-in functions for lib/ordered virtual package
+func OrderedBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		// OrderedMap
+		"orderedMapNew":      {Fn: builtinOrderedMapNew, Name: "orderedMapNew"},
+		"orderedMapFrom":     {Fn: builtinOrderedMapFrom, Name: "orderedMapFrom"},
+		"orderedMapPut":      {Fn: builtinOrderedMapPut, Name: "orderedMapPut"},
+		"orderedMapGet":      {Fn: builtinOrderedMapGet, Name: "orderedMapGet"},
+		"orderedMapRemove":   {Fn: builtinOrderedMapRemove, Name: "orderedMapRemove"},
+		"orderedMapContains": {Fn: builtinOrderedMapContains, Name: "orderedMapContains"},
+		"orderedMapSize":     {Fn: builtinOrderedMapSize, Name: "orderedMapSize"},
+		"orderedMapKeys":     {Fn: builtinOrderedMapKeys, Name: "orderedMapKeys"},
+		"orderedMapValues":   {Fn: builtinOrderedMapValues, Name: "orderedMapValues"},
+		"orderedMapItems":    {Fn: builtinOrderedMapItems, Name: "orderedMapItems"},
+		"orderedMapMin":      {Fn: builtinOrderedMapMin, Name: "orderedMapMin"},
+		"orderedMapMax":      {Fn: builtinOrderedMapMax, Name: "orderedMapMax"},
+		"orderedMapFloor":    {Fn: builtinOrderedMapFloor, Name: "orderedMapFloor"},
+		"orderedMapCeiling":  {Fn: builtinOrderedMapCeiling, Name: "orderedMapCeiling"},
+		"orderedMapRange":    {Fn: builtinOrderedMapRange, Name: "orderedMapRange"},
+
+		// OrderedSet
+		"orderedSetNew":        {Fn: builtinOrderedSetNew, Name: "orderedSetNew"},
+		"orderedSetFrom":       {Fn: builtinOrderedSetFrom, Name: "orderedSetFrom"},
+		"orderedSetAdd":        {Fn: builtinOrderedSetAdd, Name: "orderedSetAdd"},
+		"orderedSetRemove":     {Fn: builtinOrderedSetRemove, Name: "orderedSetRemove"},
+		"orderedSetContains":   {Fn: builtinOrderedSetContains, Name: "orderedSetContains"},
+		"orderedSetSize":       {Fn: builtinOrderedSetSize, Name: "orderedSetSize"},
+		"orderedSetToList":     {Fn: builtinOrderedSetToList, Name: "orderedSetToList"},
+		"orderedSetMin":        {Fn: builtinOrderedSetMin, Name: "orderedSetMin"},
+		"orderedSetMax":        {Fn: builtinOrderedSetMax, Name: "orderedSetMax"},
+		"orderedSetFloor":      {Fn: builtinOrderedSetFloor, Name: "orderedSetFloor"},
+		"orderedSetCeiling":    {Fn: builtinOrderedSetCeiling, Name: "orderedSetCeiling"},
+		"orderedSetRange":      {Fn: builtinOrderedSetRange, Name: "orderedSetRange"},
+		"orderedSetUnion":      {Fn: builtinOrderedSetUnion, Name: "orderedSetUnion"},
+		"orderedSetIntersect":  {Fn: builtinOrderedSetIntersect, Name: "orderedSetIntersect"},
+		"orderedSetDifference": {Fn: builtinOrderedSetDifference, Name: "orderedSetDifference"},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// OrderedMap
+// ----------------------------------------------------------------------------
+
+// orderedMapArg checks the argument count and that the first argument is an
+// OrderedMap
+func orderedMapArg(name string, args []Object, count int) (*OrderedMap, Object) {
+	if len(args) != count {
+		return nil, newError("%s expects %d arguments, got %d", name, count, len(args))
+	}
+	m, ok := args[0].(*OrderedMap)
+	if !ok {
+		return nil, newError("%s expects an OrderedMap, got %s", name, args[0].Type())
+	}
+	return m, nil
+}
+
+// entryOption returns Some((key, value)) for a node, Zero for nil
+func entryOption(n *treeNode) Object {
+	if n == nil {
+		return makeZero()
+	}
+	return makeSome(&Tuple{Elements: []Object{n.key, n.value}})
+}
+
+// orderedMapNew: () -> OrderedMap<K, V>
+func builtinOrderedMapNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 0 {
+		return newError("orderedMapNew expects 0 arguments, got %d", len(args))
+	}
+	return &OrderedMap{}
+}
+
+// orderedMapFrom: List<(K, V)> -> OrderedMap<K, V>
+// Later pairs win over earlier ones with the same key.
+func builtinOrderedMapFrom(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("orderedMapFrom expects 1 argument, got %d", len(args))
+	}
+	list, ok := args[0].(*List)
+	if !ok {
+		return newError("orderedMapFrom expects a list of pairs, got %s", args[0].Type())
+	}
+	items := list.ToSlice()
+	if len(items) == 0 {
+		return &OrderedMap{}
+	}
+	var root *treeNode
+	var order *ordering
+	for _, item := range items {
+		pair, ok := item.(*Tuple)
+		if !ok || len(pair.Elements) != 2 {
+			return newError("orderedMapFrom expects (key, value) pairs, got %s", item.Type())
+		}
+		if order == nil {
+			order = e.orderingFor(pair.Elements[0])
+		}
+		root = treePut(root, pair.Elements[0], pair.Elements[1], order.compare)
+	}
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedMap{root: root}
+}
+
+// orderedMapPut: (OrderedMap<K, V>, K, V) -> OrderedMap<K, V>
+func builtinOrderedMapPut(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapPut", args, 3)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	root := treePut(m.root, args[1], args[2], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedMap{root: root}
+}
+
+// orderedMapGet: (OrderedMap<K, V>, K) -> Option<V>
+func builtinOrderedMapGet(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapGet", args, 2)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeGet(m.root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	if n == nil {
+		return makeZero()
+	}
+	return makeSome(n.value)
+}
+
+// orderedMapRemove: (OrderedMap<K, V>, K) -> OrderedMap<K, V>
+func builtinOrderedMapRemove(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapRemove", args, 2)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	root := treeRemove(m.root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedMap{root: root}
+}
+
+// orderedMapContains: (OrderedMap<K, V>, K) -> Bool
+func builtinOrderedMapContains(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapContains", args, 2)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeGet(m.root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return e.nativeBoolToBooleanObject(n != nil)
+}
+
+// orderedMapSize: OrderedMap<K, V> -> Int
+func builtinOrderedMapSize(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapSize", args, 1)
+	if err != nil {
+		return err
+	}
+	return &Integer{Value: int64(m.Len())}
+}
+
+// orderedMapKeys: OrderedMap<K, V> -> List<K>
+func builtinOrderedMapKeys(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapKeys", args, 1)
+	if err != nil {
+		return err
+	}
+	return newList(treeKeys(treeNodes(m.root)))
+}
+
+// orderedMapValues: OrderedMap<K, V> -> List<V>
+func builtinOrderedMapValues(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapValues", args, 1)
+	if err != nil {
+		return err
+	}
+	nodes := treeNodes(m.root)
+	values := make([]Object, len(nodes))
+	for i, n := range nodes {
+		values[i] = n.value
+	}
+	return newList(values)
+}
+
+// orderedMapItems: OrderedMap<K, V> -> List<(K, V)>
+func builtinOrderedMapItems(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapItems", args, 1)
+	if err != nil {
+		return err
+	}
+	return newList(m.Elements())
+}
+
+// orderedMapMin: OrderedMap<K, V> -> Option<(K, V)>
+func builtinOrderedMapMin(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapMin", args, 1)
+	if err != nil {
+		return err
+	}
+	return entryOption(treeMin(m.root))
+}
+
+// orderedMapMax: OrderedMap<K, V> -> Option<(K, V)>
+func builtinOrderedMapMax(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapMax", args, 1)
+	if err != nil {
+		return err
+	}
+	return entryOption(treeMax(m.root))
+}
+
+// orderedMapFloor: (OrderedMap<K, V>, K) -> Option<(K, V)>
+// The entry with the greatest key <= k.
+func builtinOrderedMapFloor(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapFloor", args, 2)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeFloor(m.root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return entryOption(n)
+}
+
+// orderedMapCeiling: (OrderedMap<K, V>, K) -> Option<(K, V)>
+// The entry with the least key >= k.
+func builtinOrderedMapCeiling(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapCeiling", args, 2)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeCeiling(m.root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return entryOption(n)
+}
+
+// orderedMapRange: (OrderedMap<K, V>, K, K) -> OrderedMap<K, V>
+// The entries with lo <= key < hi.
+func builtinOrderedMapRange(e *Evaluator, args ...Object) Object {
+	m, err := orderedMapArg("orderedMapRange", args, 3)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	nodes := treeRange(m.root, args[1], args[2], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedMap{root: treeFromSorted(nodes)}
+}
+
+// ----------------------------------------------------------------------------
+// OrderedSet
+// ----------------------------------------------------------------------------
+
+// orderedSetArgs checks that there are sets+extra arguments, the first sets
+// of them being ordered sets
+func orderedSetArgs(name string, args []Object, sets, extra int) ([]*OrderedSet, Object) {
+	if len(args) != sets+extra {
+		return nil, newError("%s expects %d arguments, got %d", name, sets+extra, len(args))
+	}
+	result := make([]*OrderedSet, sets)
+	for i := 0; i < sets; i++ {
+		s, ok := args[i].(*OrderedSet)
+		if !ok {
+			return nil, newError("%s expects an OrderedSet, got %s", name, args[i].Type())
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// keyOption returns Some(key) for a node, Zero for nil
+func keyOption(n *treeNode) Object {
+	if n == nil {
+		return makeZero()
+	}
+	return makeSome(n.key)
+}
+
+// orderedSetNew: () -> OrderedSet<T>
+func builtinOrderedSetNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 0 {
+		return newError("orderedSetNew expects 0 arguments, got %d", len(args))
+	}
+	return &OrderedSet{}
+}
+
+// orderedSetFrom: List<T> -> OrderedSet<T>
+func builtinOrderedSetFrom(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("orderedSetFrom expects 1 argument, got %d", len(args))
+	}
+	list, ok := args[0].(*List)
+	if !ok {
+		return newError("orderedSetFrom expects a list, got %s", args[0].Type())
+	}
+	items := list.ToSlice()
+	if len(items) == 0 {
+		return &OrderedSet{}
+	}
+	order := e.orderingFor(items[0])
+	var root *treeNode
+	for _, item := range items {
+		root = treePut(root, item, nil, order.compare)
+	}
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedSet{root: root}
+}
+
+// orderedSetAdd: (OrderedSet<T>, T) -> OrderedSet<T>
+func builtinOrderedSetAdd(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetAdd", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	root := treePut(sets[0].root, args[1], nil, order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedSet{root: root}
+}
+
+// orderedSetRemove: (OrderedSet<T>, T) -> OrderedSet<T>
+func builtinOrderedSetRemove(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetRemove", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	root := treeRemove(sets[0].root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedSet{root: root}
+}
+
+// orderedSetContains: (OrderedSet<T>, T) -> Bool
+func builtinOrderedSetContains(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetContains", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeGet(sets[0].root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return e.nativeBoolToBooleanObject(n != nil)
+}
+
+// orderedSetSize: OrderedSet<T> -> Int
+func builtinOrderedSetSize(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetSize", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return &Integer{Value: int64(sets[0].Len())}
+}
+
+// orderedSetToList: OrderedSet<T> -> List<T>
+func builtinOrderedSetToList(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetToList", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return newList(sets[0].Elements())
+}
+
+// orderedSetMin: OrderedSet<T> -> Option<T>
+func builtinOrderedSetMin(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetMin", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return keyOption(treeMin(sets[0].root))
+}
+
+// orderedSetMax: OrderedSet<T> -> Option<T>
+func builtinOrderedSetMax(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetMax", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return keyOption(treeMax(sets[0].root))
+}
+
+// orderedSetFloor: (OrderedSet<T>, T) -> Option<T>
+// The greatest element <= x.
+func builtinOrderedSetFloor(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetFloor", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeFloor(sets[0].root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return keyOption(n)
+}
+
+// orderedSetCeiling: (OrderedSet<T>, T) -> Option<T>
+// The least element >= x.
+func builtinOrderedSetCeiling(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetCeiling", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	n := treeCeiling(sets[0].root, args[1], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return keyOption(n)
+}
+
+// orderedSetRange: (OrderedSet<T>, T, T) -> OrderedSet<T>
+// The elements with lo <= x < hi.
+func builtinOrderedSetRange(e *Evaluator, args ...Object) Object {
+	sets, err := orderedSetArgs("orderedSetRange", args, 1, 2)
+	if err != nil {
+		return err
+	}
+	order := e.orderingFor(args[1])
+	nodes := treeRange(sets[0].root, args[1], args[2], order.compare)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedSet{root: treeFromSorted(nodes)}
+}
+
+// combineOrderedSets merges two ordered sets in linear time, keeping the
+// elements selected by the flags of mergeSorted
+func combineOrderedSets(e *Evaluator, name string, args []Object, onlyA, both, onlyB bool) Object {
+	sets, err := orderedSetArgs(name, args, 2, 0)
+	if err != nil {
+		return err
+	}
+	a, b := treeNodes(sets[0].root), treeNodes(sets[1].root)
+	if len(a) == 0 || len(b) == 0 {
+		return &OrderedSet{root: treeFromSorted(mergeSorted(a, b, nil, onlyA, both, onlyB))}
+	}
+	order := e.orderingFor(a[0].key)
+	nodes := mergeSorted(a, b, order.compare, onlyA, both, onlyB)
+	if order.err != nil {
+		return order.err
+	}
+	return &OrderedSet{root: treeFromSorted(nodes)}
+}
+
+// orderedSetUnion: (OrderedSet<T>, OrderedSet<T>) -> OrderedSet<T>
+func builtinOrderedSetUnion(e *Evaluator, args ...Object) Object {
+	return combineOrderedSets(e, "orderedSetUnion", args, true, true, true)
+}
+
+// orderedSetIntersect: (OrderedSet<T>, OrderedSet<T>) -> OrderedSet<T>
+func builtinOrderedSetIntersect(e *Evaluator, args ...Object) Object {
+	return combineOrderedSets(e, "orderedSetIntersect", args, false, true, false)
+}
+
+// orderedSetDifference: (OrderedSet<T>, OrderedSet<T>) -> OrderedSet<T>
+// Elements of the first set that are not in the second.
+func builtinOrderedSetDifference(e *Evaluator, args ...Object) Object {
+	return combineOrderedSets(e, "orderedSetDifference", args, true, false, false)
+}
+
+// SetOrderedBuiltinTypes sets type info for ordered collection builtins
+func SetOrderedBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	K := typesystem.TVar{Name: "K"}
+	V := typesystem.TVar{Name: "V"}
+
+	mapKV := typesystem.TApp{Constructor: typesystem.TCon{Name: "OrderedMap"}, Args: []typesystem.Type{K, V}}
+	setT := typesystem.TApp{Constructor: typesystem.TCon{Name: "OrderedSet"}, Args: []typesystem.Type{T}}
+	listOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{t}}
+	}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{t}}
+	}
+	pairKV := typesystem.TTuple{Elements: []typesystem.Type{K, V}}
+	orderK := []typesystem.Constraint{{TypeVar: "K", Trait: "Order"}}
+	orderT := []typesystem.Constraint{{TypeVar: "T", Trait: "Order"}}
+
+	types := map[string]typesystem.Type{
+		// OrderedMap
+		"orderedMapNew":      typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: mapKV},
+		"orderedMapFrom":     typesystem.TFunc{Params: []typesystem.Type{listOf(pairKV)}, ReturnType: mapKV, Constraints: orderK},
+		"orderedMapPut":      typesystem.TFunc{Params: []typesystem.Type{mapKV, K, V}, ReturnType: mapKV, Constraints: orderK},
+		"orderedMapGet":      typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: optionOf(V), Constraints: orderK},
+		"orderedMapRemove":   typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: mapKV, Constraints: orderK},
+		"orderedMapContains": typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: typesystem.Bool, Constraints: orderK},
+		"orderedMapSize":     typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: typesystem.Int},
+		"orderedMapKeys":     typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: listOf(K)},
+		"orderedMapValues":   typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: listOf(V)},
+		"orderedMapItems":    typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: listOf(pairKV)},
+		"orderedMapMin":      typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: optionOf(pairKV)},
+		"orderedMapMax":      typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: optionOf(pairKV)},
+		"orderedMapFloor":    typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: optionOf(pairKV), Constraints: orderK},
+		"orderedMapCeiling":  typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: optionOf(pairKV), Constraints: orderK},
+		"orderedMapRange":    typesystem.TFunc{Params: []typesystem.Type{mapKV, K, K}, ReturnType: mapKV, Constraints: orderK},
+
+		// OrderedSet
+		"orderedSetNew":        typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: setT},
+		"orderedSetFrom":       typesystem.TFunc{Params: []typesystem.Type{listOf(T)}, ReturnType: setT, Constraints: orderT},
+		"orderedSetAdd":        typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT, Constraints: orderT},
+		"orderedSetRemove":     typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT, Constraints: orderT},
+		"orderedSetContains":   typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: typesystem.Bool, Constraints: orderT},
+		"orderedSetSize":       typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: typesystem.Int},
+		"orderedSetToList":     typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: listOf(T)},
+		"orderedSetMin":        typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: optionOf(T)},
+		"orderedSetMax":        typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: optionOf(T)},
+		"orderedSetFloor":      typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: optionOf(T), Constraints: orderT},
+		"orderedSetCeiling":    typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: optionOf(T), Constraints: orderT},
+		"orderedSetRange":      typesystem.TFunc{Params: []typesystem.Type{setT, T, T}, ReturnType: setT, Constraints: orderT},
+		"orderedSetUnion":      typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT, Constraints: orderT},
+		"orderedSetIntersect":  typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT, Constraints: orderT},
+		"orderedSetDifference": typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT, Constraints: orderT},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package evaluator
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// Parallel list operations
+// ============================================================================
+//
+// The list is split into one contiguous chunk per worker. Each worker gets its
+// own evaluator (a forked VM on the VM backend) and applies the function to
+// every element of its chunk directly, so no task or evaluator is created per
+// element. Results are written by index, which keeps the input order.
+
+// parChunkSize splits n elements into one chunk per CPU, but no more chunks
+// than the global task pool allows
+func parChunkSize(n int) int {
+	workers := runtime.GOMAXPROCS(0)
+	if limit := int(atomic.LoadInt64(&poolLimit)); limit < workers {
+		workers = limit
+	}
+	if n < workers {
+		workers = n
+	}
+	if workers < 1 {
+		return 1
+	}
+	return (n + workers - 1) / workers
+}
+
+// parallelChunks calls run for every chunk of [0, n) of the given size and
+// returns the first error, after all chunks have stopped. A chunk runs on a
+// goroutine of its own only if a pool slot is free; the others run on the
+// calling goroutine, which never waits for a slot, so tasks running parallel
+// operations can't deadlock on the pool. The calling goroutine takes a slot
+// as well unless it already holds one as a task. An error cancels the chunks
+// still running.
+func parallelChunks(e *Evaluator, fn Object, n, size int, run func(w *Evaluator, fn Object, lo, hi int) Object) Object {
+	if size >= n {
+		return run(e, fn, 0, n)
+	}
+
+	if !e.poolSlot && TryAcquirePoolSlot() {
+		e.poolSlot = true
+		defer func() {
+			e.poolSlot = false
+			ReleasePoolSlot()
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(e.taskContext())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr Object
+		wg       sync.WaitGroup
+	)
+	fail := func(err Object) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	shared := fn
+	if e.CaptureHandler != nil {
+		shared = e.CaptureHandler(fn)
+	}
+
+	// Chunks that got no slot, run here after the first one
+	var inline [][2]int
+	for lo := size; lo < n; lo += size {
+		hi := lo + size
+		if hi > n {
+			hi = n
+		}
+		if !TryAcquirePoolSlot() {
+			inline = append(inline, [2]int{lo, hi})
+			continue
+		}
+
+		var w *Evaluator
+		if e.Fork != nil {
+			w = e.Fork()
+		} else {
+			w = e.Clone()
+		}
+		w.ctx = ctx
+		w.poolSlot = true
+
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			defer ReleasePoolSlot()
+			if ctx.Err() != nil {
+				return
+			}
+			if err := run(w, shared, lo, hi); err != nil {
+				fail(err)
+			}
+		}(lo, hi)
+	}
+
+	outerCtx := e.ctx
+	e.ctx = ctx
+	if err := run(e, fn, 0, size); err != nil {
+		fail(err)
+	}
+	for _, c := range inline {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := run(e, fn, c[0], c[1]); err != nil {
+			fail(err)
+		}
+	}
+	e.ctx = outerCtx
+	wg.Wait()
+
+	return firstErr
+}
+
+// parStopped reports whether a chunk should stop because a sibling failed
+func parStopped(w *Evaluator) bool {
+	return w.taskContext().Err() != nil
+}
+
+// applyTraitMethod calls a trait method. On the VM backend the VM dispatches
+// it, since the VM's instances are not registered with the evaluator.
+func applyTraitMethod(e *Evaluator, cm *ClassMethod, args []Object) Object {
+	if e.VMCallHandler != nil {
+		if result := e.VMCallHandler(cm, args); result != nil {
+			return result
+		}
+	}
+	return e.ApplyFunction(cm, args)
+}
+
+// parMap: ((T) -> U, List<T>) -> List<U>
+func builtinParMap(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("parMap expects 2 arguments, got %d", len(args))
+	}
+	list, ok := args[1].(*List)
+	if !ok {
+		return newError("parMap expects a list as second argument, got %s", args[1].Type())
+	}
+
+	items := list.ToSlice()
+	results := make([]Object, len(items))
+	err := parallelChunks(e, args[0], len(items), parChunkSize(len(items)), func(w *Evaluator, fn Object, lo, hi int) Object {
+		for i := lo; i < hi; i++ {
+			if parStopped(w) {
+				return cancelledError()
+			}
+			result := w.ApplyFunction(fn, []Object{items[i]})
+			if isError(result) {
+				return result
+			}
+			results[i] = result
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return newList(results)
+}
+
+// parFilter: ((T) -> Bool, List<T>) -> List<T>
+func builtinParFilter(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("parFilter expects 2 arguments, got %d", len(args))
+	}
+	list, ok := args[1].(*List)
+	if !ok {
+		return newError("parFilter expects a list as second argument, got %s", args[1].Type())
+	}
+
+	items := list.ToSlice()
+	keep := make([]bool, len(items))
+	err := parallelChunks(e, args[0], len(items), parChunkSize(len(items)), func(w *Evaluator, fn Object, lo, hi int) Object {
+		for i := lo; i < hi; i++ {
+			if parStopped(w) {
+				return cancelledError()
+			}
+			result := w.ApplyFunction(fn, []Object{items[i]})
+			if isError(result) {
+				return result
+			}
+			b, ok := result.(*Boolean)
+			if !ok {
+				return newError("parFilter predicate must return Bool, got %s", result.Type())
+			}
+			keep[i] = b.Value
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	results := make([]Object, 0, len(items))
+	for i, item := range items {
+		if keep[i] {
+			results = append(results, item)
+		}
+	}
+	return newList(results)
+}
+
+// parFold: ((T) -> M, List<T>) -> M where M: Monoid
+// Maps every element and combines the results with (<>). Each chunk is
+// combined left to right and the chunk results are combined in order, so
+// the result equals the sequential fold for any lawful Monoid.
+func builtinParFold(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("parFold expects 2 arguments, got %d", len(args))
+	}
+	list, ok := args[1].(*List)
+	if !ok {
+		return newError("parFold expects a list as second argument, got %s", args[1].Type())
+	}
+
+	items := list.ToSlice()
+	if len(items) == 0 {
+		return applyTraitMethod(e, &ClassMethod{Name: "mempty", ClassName: "Monoid", Arity: 0}, []Object{})
+	}
+
+	combine := &ClassMethod{Name: "(<>)", ClassName: "Semigroup", Arity: 2}
+	size := parChunkSize(len(items))
+	partials := make([]Object, (len(items)+size-1)/size)
+	err := parallelChunks(e, args[0], len(items), size, func(w *Evaluator, fn Object, lo, hi int) Object {
+		var acc Object
+		for i := lo; i < hi; i++ {
+			if parStopped(w) {
+				return cancelledError()
+			}
+			value := w.ApplyFunction(fn, []Object{items[i]})
+			if isError(value) {
+				return value
+			}
+			if acc == nil {
+				acc = value
+				continue
+			}
+			acc = applyTraitMethod(w, combine, []Object{acc, value})
+			if isError(acc) {
+				return acc
+			}
+		}
+		partials[lo/size] = acc
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	acc := partials[0]
+	for _, part := range partials[1:] {
+		acc = applyTraitMethod(e, combine, []Object{acc, part})
+		if isError(acc) {
+			return acc
+		}
+	}
+	return acc
+}
@@ -0,0 +1,164 @@
+package evaluator
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Ref - atomic mutable cell shared between tasks
+// ============================================================================
+
+// Ref holds a value that tasks may read and replace concurrently. The value
+// is boxed so that compare-and-swap works on box identity, independently of
+// whether the Object itself is comparable.
+type Ref struct {
+	id  int64
+	box atomic.Pointer[refBox]
+}
+
+type refBox struct {
+	value Object
+}
+
+var refCounter int64
+
+func (r *Ref) Type() ObjectType { return "REF" }
+func (r *Ref) TypeName() string { return "Ref" }
+func (r *Ref) Inspect() string  { return fmt.Sprintf("<Ref:%d>", r.id) }
+func (r *Ref) RuntimeType() typesystem.Type {
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Ref"},
+		Args:        []typesystem.Type{typesystem.TVar{Name: "T"}},
+	}
+}
+func (r *Ref) Hash() uint32 { return uint32(r.id) }
+
+// NewRef creates a Ref holding value
+func NewRef(value Object) *Ref {
+	r := &Ref{id: atomic.AddInt64(&refCounter, 1)}
+	r.box.Store(&refBox{value: value})
+	return r
+}
+
+// Get returns the current value
+func (r *Ref) Get() Object {
+	return r.box.Load().value
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterRefBuiltins registers the Ref type and functions
+func RegisterRefBuiltins(env *Environment) {
+	// Types
+	env.Set("Ref", &TypeObject{TypeVal: typesystem.TCon{Name: "Ref"}})
+
+	// Functions
+	builtins := RefBuiltins()
+	SetRefBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// RefBuiltins returns built-in functions for lib/ref virtual package
+func RefBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		"refNew":    {Fn: builtinRefNew, Name: "refNew"},
+		"refGet":    {Fn: builtinRefGet, Name: "refGet"},
+		"refSet":    {Fn: builtinRefSet, Name: "refSet"},
+		"refUpdate": {Fn: builtinRefUpdate, Name: "refUpdate"},
+	}
+}
+
+// captureShared detaches a value stored for other tasks, as chanSend does
+func (e *Evaluator) captureShared(value Object) Object {
+	if e.CaptureHandler != nil {
+		return e.CaptureHandler(value)
+	}
+	return value
+}
+
+// refNew: T -> Ref<T>
+func builtinRefNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("refNew expects 1 argument, got %d", len(args))
+	}
+	return NewRef(e.captureShared(args[0]))
+}
+
+// refGet: Ref<T> -> T
+func builtinRefGet(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("refGet expects 1 argument, got %d", len(args))
+	}
+	ref, ok := args[0].(*Ref)
+	if !ok {
+		return newError("refGet expects a Ref, got %s", args[0].Type())
+	}
+	return ref.Get()
+}
+
+// refSet: (Ref<T>, T) -> Nil
+func builtinRefSet(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("refSet expects 2 arguments, got %d", len(args))
+	}
+	ref, ok := args[0].(*Ref)
+	if !ok {
+		return newError("refSet expects a Ref, got %s", args[0].Type())
+	}
+	ref.box.Store(&refBox{value: e.captureShared(args[1])})
+	return &Nil{}
+}
+
+// refUpdate: (Ref<T>, (T) -> T) -> T
+// Applies fn to the current value and stores the result with compare-and-swap,
+// retrying with the new current value if another task got there first. fn may
+// therefore run more than once and should not have side effects.
+func builtinRefUpdate(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("refUpdate expects 2 arguments, got %d", len(args))
+	}
+	ref, ok := args[0].(*Ref)
+	if !ok {
+		return newError("refUpdate expects a Ref, got %s", args[0].Type())
+	}
+	for {
+		old := ref.box.Load()
+		next := e.ApplyFunction(args[1], []Object{old.value})
+		if isError(next) {
+			return next
+		}
+		next = e.captureShared(next)
+		if ref.box.CompareAndSwap(old, &refBox{value: next}) {
+			return next
+		}
+	}
+}
+
+// SetRefBuiltinTypes sets type info for ref builtins
+func SetRefBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	refT := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Ref"},
+		Args:        []typesystem.Type{T},
+	}
+
+	types := map[string]typesystem.Type{
+		"refNew":    typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: refT},
+		"refGet":    typesystem.TFunc{Params: []typesystem.Type{refT}, ReturnType: T},
+		"refSet":    typesystem.TFunc{Params: []typesystem.Type{refT, T}, ReturnType: typesystem.Nil},
+		"refUpdate": typesystem.TFunc{Params: []typesystem.Type{refT, typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: T}}, ReturnType: T},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
@@ -0,0 +1,468 @@
+package evaluator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Dynamic - value of any type
+// ============================================================================
+
+// Dynamic boxes a value whose type is only known at runtime, such as a field
+// of a record read through lib/reflect. fromDynamic checks the type on the
+// way out.
+type Dynamic struct {
+	Value Object
+}
+
+func (d *Dynamic) Type() ObjectType { return "DYNAMIC" }
+func (d *Dynamic) TypeName() string { return "Dynamic" }
+
+// Inspect shows the boxed value, so that show works on dynamic fields
+func (d *Dynamic) Inspect() string { return d.Value.Inspect() }
+func (d *Dynamic) RuntimeType() typesystem.Type {
+	return typesystem.TCon{Name: "Dynamic"}
+}
+func (d *Dynamic) Hash() uint32 { return d.Value.Hash() }
+
+// ParamNamer is implemented by function values that know the names of their
+// parameters: tree-walk functions and VM closures.
+type ParamNamer interface {
+	ParamNames() []string
+	IsVariadic() bool // The last parameter collects the remaining arguments
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterReflectBuiltins registers the Dynamic type and reflection functions
+func RegisterReflectBuiltins(env *Environment) {
+	// Types
+	env.Set("Dynamic", &TypeObject{TypeVal: typesystem.TCon{Name: "Dynamic"}})
+
+	// Functions
+	builtins := ReflectBuiltins()
+	SetReflectBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// ReflectBuiltins returns built-in functions for lib/reflect virtual package
+func ReflectBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		"toDynamic":       {Fn: builtinToDynamic, Name: "toDynamic"},
+		"fromDynamic":     {Fn: builtinFromDynamic, Name: "fromDynamic"},
+		"dynamicTypeName": {Fn: builtinDynamicTypeName, Name: "dynamicTypeName"},
+		"fieldNames":      {Fn: builtinFieldNames, Name: "fieldNames"},
+		"fields":          {Fn: builtinFields, Name: "fields"},
+		"getField":        {Fn: builtinGetField, Name: "getField"},
+		"fieldNamesOf":    {Fn: builtinFieldNamesOf, Name: "fieldNamesOf"},
+		"constructorName": {Fn: builtinConstructorName, Name: "constructorName"},
+		"constructorArgs": {Fn: builtinConstructorArgs, Name: "constructorArgs"},
+		"constructorsOf":  {Fn: builtinConstructorsOf, Name: "constructorsOf"},
+		"traitsOf":        {Fn: builtinTraitsOf, Name: "traitsOf"},
+		"implements":      {Fn: builtinImplements, Name: "implements"},
+		"functionArity":   {Fn: builtinFunctionArity, Name: "functionArity"},
+		"functionParams":  {Fn: builtinFunctionParams, Name: "functionParams"},
+	}
+}
+
+// toDynamic: T -> Dynamic
+func builtinToDynamic(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("toDynamic expects 1 argument, got %d", len(args))
+	}
+	if d, ok := args[0].(*Dynamic); ok {
+		return d
+	}
+	return &Dynamic{Value: args[0]}
+}
+
+// fromDynamic: (Type<T>, Dynamic) -> Option<T>
+func builtinFromDynamic(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("fromDynamic expects 2 arguments, got %d", len(args))
+	}
+	typeObj, ok := args[0].(*TypeObject)
+	if !ok {
+		return newError("fromDynamic expects a type, got %s", args[0].Type())
+	}
+	d, ok := args[1].(*Dynamic)
+	if !ok {
+		return newError("fromDynamic expects a Dynamic, got %s", args[1].Type())
+	}
+	if !checkType(d.Value, typeObj.TypeVal) {
+		return makeZero()
+	}
+	return makeSome(d.Value)
+}
+
+// dynamicTypeName: Dynamic -> String
+func builtinDynamicTypeName(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("dynamicTypeName expects 1 argument, got %d", len(args))
+	}
+	d, ok := args[0].(*Dynamic)
+	if !ok {
+		return newError("dynamicTypeName expects a Dynamic, got %s", args[0].Type())
+	}
+	return stringToList(getRuntimeTypeName(d.Value))
+}
+
+// reflectTarget unwraps a Dynamic so that reflection sees the boxed value
+func reflectTarget(obj Object) Object {
+	if d, ok := obj.(*Dynamic); ok {
+		return d.Value
+	}
+	return obj
+}
+
+// stringsToList converts names to List<String>
+func stringsToList(names []string) *List {
+	items := make([]Object, len(names))
+	for i, name := range names {
+		items[i] = stringToList(name)
+	}
+	return newList(items)
+}
+
+// fieldNames: T -> List<String>
+// Field names of a record in alphabetical order; empty for other values.
+func builtinFieldNames(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("fieldNames expects 1 argument, got %d", len(args))
+	}
+	rec, ok := reflectTarget(args[0]).(*RecordInstance)
+	if !ok {
+		return newList([]Object{})
+	}
+	names := make([]string, len(rec.Fields))
+	for i, f := range rec.Fields {
+		names[i] = f.Key
+	}
+	return stringsToList(names)
+}
+
+// fields: T -> List<(String, Dynamic)>
+func builtinFields(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("fields expects 1 argument, got %d", len(args))
+	}
+	rec, ok := reflectTarget(args[0]).(*RecordInstance)
+	if !ok {
+		return newList([]Object{})
+	}
+	items := make([]Object, len(rec.Fields))
+	for i, f := range rec.Fields {
+		items[i] = &Tuple{Elements: []Object{stringToList(f.Key), &Dynamic{Value: f.Value}}}
+	}
+	return newList(items)
+}
+
+// getField: (T, String) -> Option<Dynamic>
+func builtinGetField(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("getField expects 2 arguments, got %d", len(args))
+	}
+	name, ok := args[1].(*List)
+	if !ok {
+		return newError("getField expects a field name, got %s", args[1].Type())
+	}
+	rec, ok := reflectTarget(args[0]).(*RecordInstance)
+	if !ok {
+		return makeZero()
+	}
+	value := rec.Get(ListToString(name))
+	if value == nil {
+		return makeZero()
+	}
+	return makeSome(&Dynamic{Value: value})
+}
+
+// fieldNamesOf: Type<T> -> List<String>
+// Field names of a record type in alphabetical order, read from the schema
+// its type value carries.
+func builtinFieldNamesOf(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("fieldNamesOf expects 1 argument, got %d", len(args))
+	}
+	typeObj, ok := args[0].(*TypeObject)
+	if !ok {
+		return newError("fieldNamesOf expects a type, got %s", args[0].Type())
+	}
+	var fields map[string]typesystem.Type
+	switch t := schemaTarget(typeObj).(type) {
+	case typesystem.TRecord:
+		fields = t.Fields
+	case typesystem.TCon:
+		if typeObj.Schema != nil {
+			if rec, ok := typeObj.Schema.Records[t.Name]; ok {
+				fields = rec.Fields
+				break
+			}
+		}
+		return newError("fieldNamesOf: %s is not a known record type", t.Name)
+	default:
+		return newError("fieldNamesOf: %s is not a known record type", t)
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return stringsToList(names)
+}
+
+// constructorName: T -> Option<String>
+func builtinConstructorName(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("constructorName expects 1 argument, got %d", len(args))
+	}
+	data, ok := reflectTarget(args[0]).(*DataInstance)
+	if !ok {
+		return makeZero()
+	}
+	return makeSome(stringToList(data.Name))
+}
+
+// constructorArgs: T -> List<Dynamic>
+func builtinConstructorArgs(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("constructorArgs expects 1 argument, got %d", len(args))
+	}
+	data, ok := reflectTarget(args[0]).(*DataInstance)
+	if !ok {
+		return newList([]Object{})
+	}
+	items := make([]Object, len(data.Fields))
+	for i, f := range data.Fields {
+		items[i] = &Dynamic{Value: f}
+	}
+	return newList(items)
+}
+
+// nativeConstructors lists the constructors of the ADTs the runtime defines
+// itself, which schemas never expand
+var nativeConstructors = map[string][]typesystem.SchemaConstructor{
+	config.OptionTypeName: {
+		{Name: config.SomeCtorName, Fields: []typesystem.Type{typesystem.TVar{Name: "T"}}},
+		{Name: config.ZeroCtorName},
+	},
+	config.ResultTypeName: {
+		{Name: config.OkCtorName, Fields: []typesystem.Type{typesystem.TVar{Name: "T"}}},
+		{Name: config.FailCtorName, Fields: []typesystem.Type{typesystem.TVar{Name: "E"}}},
+	},
+}
+
+// constructorsOf: Type<T> -> List<(String, Int)>
+// Constructor names and arities of an ADT in declaration order.
+func builtinConstructorsOf(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("constructorsOf expects 1 argument, got %d", len(args))
+	}
+	typeObj, ok := args[0].(*TypeObject)
+	if !ok {
+		return newError("constructorsOf expects a type, got %s", args[0].Type())
+	}
+	con, ok := schemaTarget(typeObj).(typesystem.TCon)
+	if !ok {
+		return newError("constructorsOf: %s is not a known ADT", typeObj.TypeVal)
+	}
+	ctors, ok := nativeConstructors[con.Name]
+	if !ok && typeObj.Schema != nil {
+		var adt typesystem.SchemaADT
+		if adt, ok = typeObj.Schema.ADTs[con.Name]; ok {
+			ctors = adt.Constructors
+		}
+	}
+	if !ok {
+		return newError("constructorsOf: %s is not a known ADT", con.Name)
+	}
+	items := make([]Object, len(ctors))
+	for i, ctor := range ctors {
+		items[i] = &Tuple{Elements: []Object{stringToList(ctor.Name), &Integer{Value: int64(len(ctor.Fields))}}}
+	}
+	return newList(items)
+}
+
+// schemaTarget returns the type named by a type argument, with generic
+// applications reduced to their constructor
+func schemaTarget(typeObj *TypeObject) typesystem.Type {
+	t := typeObj.TypeVal
+	if typeObj.Schema != nil {
+		t = typeObj.Schema.Type
+	}
+	if app, ok := t.(typesystem.TApp); ok {
+		return app.Constructor
+	}
+	return t
+}
+
+// implementedTraits returns the traits with an instance for obj's type,
+// sorted. Standard instances that only one backend registers at runtime are
+// left out, so both report the same traits.
+func (e *Evaluator) implementedTraits(obj Object) []string {
+	typeName := getRuntimeTypeName(obj)
+	if dot := strings.LastIndex(typeName, "."); dot >= 0 {
+		typeName = typeName[dot+1:]
+	}
+	var traits []string
+	for trait, impls := range e.ClassImplementations {
+		if table, ok := impls[typeName].(*MethodTable); ok && !table.Native {
+			traits = append(traits, trait)
+		}
+	}
+	sort.Strings(traits)
+	return traits
+}
+
+// traitsOf: T -> List<String>
+func builtinTraitsOf(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("traitsOf expects 1 argument, got %d", len(args))
+	}
+	return stringsToList(e.implementedTraits(reflectTarget(args[0])))
+}
+
+// implements: (T, String) -> Bool
+func builtinImplements(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("implements expects 2 arguments, got %d", len(args))
+	}
+	name, ok := args[1].(*List)
+	if !ok {
+		return newError("implements expects a trait name, got %s", args[1].Type())
+	}
+	trait := ListToString(name)
+	for _, t := range e.implementedTraits(reflectTarget(args[0])) {
+		if t == trait {
+			return TRUE
+		}
+	}
+	return FALSE
+}
+
+// functionParamNames returns the parameter names of fn, or nil when they are
+// not known (builtins, constructors)
+func functionParamNames(fn Object) []string {
+	switch f := fn.(type) {
+	case ParamNamer:
+		return f.ParamNames()
+	case *PartialApplication:
+		var names []string
+		if f.Function != nil {
+			names = f.Function.ParamNames()
+		} else if namer, ok := f.VMClosure.(ParamNamer); ok {
+			names = namer.ParamNames()
+		}
+		if len(f.AppliedArgs) <= len(names) {
+			return names[len(f.AppliedArgs):]
+		}
+	}
+	return nil
+}
+
+// functionArity: F -> Int
+// A variadic parameter is not counted: it takes any number of arguments.
+func builtinFunctionArity(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("functionArity expects 1 argument, got %d", len(args))
+	}
+	fn := reflectTarget(args[0])
+	arity := -1
+	switch f := fn.(type) {
+	case ParamNamer:
+		arity = len(f.ParamNames())
+	case *PartialApplication:
+		if names := functionParamNames(f); names != nil {
+			arity = len(names)
+		} else {
+			arity = f.RemainingParams
+		}
+	case *Constructor:
+		return &Integer{Value: int64(f.Arity)}
+	}
+	if arity < 0 {
+		fnType, ok := fn.RuntimeType().(typesystem.TFunc)
+		if !ok {
+			return newError("functionArity expects a function, got %s", fn.Type())
+		}
+		arity = len(fnType.Params)
+	}
+	if functionIsVariadic(fn) && arity > 0 {
+		arity--
+	}
+	return &Integer{Value: int64(arity)}
+}
+
+func functionIsVariadic(fn Object) bool {
+	switch f := fn.(type) {
+	case ParamNamer:
+		return f.IsVariadic()
+	case *PartialApplication:
+		if f.Function != nil {
+			return f.Function.IsVariadic()
+		}
+		if namer, ok := f.VMClosure.(ParamNamer); ok {
+			return namer.IsVariadic()
+		}
+		if f.Builtin != nil {
+			return functionIsVariadic(f.Builtin)
+		}
+		return false
+	}
+	fnType, ok := fn.RuntimeType().(typesystem.TFunc)
+	return ok && fnType.IsVariadic
+}
+
+// functionParams: F -> List<String>
+// Parameter names of a function; empty for builtins and constructors.
+func builtinFunctionParams(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("functionParams expects 1 argument, got %d", len(args))
+	}
+	return stringsToList(functionParamNames(reflectTarget(args[0])))
+}
+
+// SetReflectBuiltinTypes sets type info for reflect builtins
+func SetReflectBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	F := typesystem.TVar{Name: "F"}
+	dynamicType := typesystem.TCon{Name: "Dynamic"}
+	stringType := typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{typesystem.Char}}
+	listOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{t}}
+	}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{t}}
+	}
+
+	types := map[string]typesystem.Type{
+		"toDynamic":       typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: dynamicType},
+		"fromDynamic":     typesystem.TFunc{Params: []typesystem.Type{typesystem.TType{Type: T}, dynamicType}, ReturnType: optionOf(T)},
+		"dynamicTypeName": typesystem.TFunc{Params: []typesystem.Type{dynamicType}, ReturnType: stringType},
+		"fieldNames":      typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(stringType)},
+		"fields":          typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(typesystem.TTuple{Elements: []typesystem.Type{stringType, dynamicType}})},
+		"getField":        typesystem.TFunc{Params: []typesystem.Type{T, stringType}, ReturnType: optionOf(dynamicType)},
+		"fieldNamesOf":    typesystem.TFunc{Params: []typesystem.Type{typesystem.TType{Type: T}}, ReturnType: listOf(stringType)},
+		"constructorName": typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: optionOf(stringType)},
+		"constructorArgs": typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(dynamicType)},
+		"constructorsOf":  typesystem.TFunc{Params: []typesystem.Type{typesystem.TType{Type: T}}, ReturnType: listOf(typesystem.TTuple{Elements: []typesystem.Type{stringType, typesystem.Int}})},
+		"traitsOf":        typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(stringType)},
+		"implements":      typesystem.TFunc{Params: []typesystem.Type{T, stringType}, ReturnType: typesystem.Bool},
+		"functionArity":   typesystem.TFunc{Params: []typesystem.Type{F}, ReturnType: typesystem.Int},
+		"functionParams":  typesystem.TFunc{Params: []typesystem.Type{F}, ReturnType: listOf(stringType)},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package evaluator
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Set - persistent hash set
+// ============================================================================
+
+// Set is an immutable hash set stored in the same HAMT as Map, with every
+// element mapped to itself.
+type Set struct {
+	hamt *PersistentMap
+}
+
+func newSet() *Set {
+	return &Set{hamt: EmptyMap()}
+}
+
+func (s *Set) Type() ObjectType { return "SET" }
+func (s *Set) TypeName() string { return "Set" }
+
+// Inspect shows a set as the call that builds it. The elements are sorted so
+// that equal sets print the same, which map keys rely on.
+func (s *Set) Inspect() string {
+	elements := s.Elements()
+	sort.SliceStable(elements, func(i, j int) bool {
+		return compareObjects(elements[i], elements[j]) < 0
+	})
+	return inspectCall("setFrom", elements)
+}
+
+func (s *Set) RuntimeType() typesystem.Type {
+	var elemType typesystem.Type = typesystem.TVar{Name: "T"}
+	if keys := s.hamt.Keys(); len(keys) > 0 {
+		elemType = keys[0].RuntimeType()
+	}
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Set"},
+		Args:        []typesystem.Type{elemType},
+	}
+}
+
+// Hash is independent of the order of the elements, like Map's
+func (s *Set) Hash() uint32 {
+	h := uint32(0)
+	for _, key := range s.hamt.Keys() {
+		h ^= key.Hash()
+	}
+	return h
+}
+
+// Elements returns the elements in unspecified order
+func (s *Set) Elements() []Object {
+	return s.hamt.Keys()
+}
+
+// Len returns the number of elements
+func (s *Set) Len() int {
+	return s.hamt.Len()
+}
+
+func (s *Set) add(value Object) *Set {
+	if s.hamt.Contains(value) {
+		return s
+	}
+	return &Set{hamt: s.hamt.Put(value, value)}
+}
+
+func (s *Set) remove(value Object) *Set {
+	if !s.hamt.Contains(value) {
+		return s
+	}
+	return &Set{hamt: s.hamt.Remove(value)}
+}
+
+func (s *Set) contains(value Object) bool {
+	return s.hamt.Contains(value)
+}
+
+// isSubset reports whether every element of s is in other
+func (s *Set) isSubset(other *Set) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for _, key := range s.hamt.Keys() {
+		if !other.contains(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// equals checks that both sets have the same elements
+func (s *Set) equals(other *Set) bool {
+	return s.Len() == other.Len() && s.isSubset(other)
+}
+
+// Collection is implemented by the library collections. Their Iter instances
+// walk a snapshot of the elements (see registerCollectionIterInstances).
+type Collection interface {
+	Object
+	TypeName() string
+	Elements() []Object
+}
+
+// collectionTypeNames lists the types implementing Collection
+var collectionTypeNames = []string{"Set", "OrderedSet", "Heap", "OrderedMap"}
+
+// registerCollectionIterInstances registers the Iter instances of the library
+// collections. The iterator yields the elements in the order of Elements.
+func registerCollectionIterInstances(e *Evaluator) {
+	if _, ok := e.ClassImplementations[config.IterTraitName]; !ok {
+		e.ClassImplementations[config.IterTraitName] = make(map[string]Object)
+	}
+	iter := &Builtin{
+		Name: config.IterMethodName,
+		Fn: func(eval *Evaluator, args ...Object) Object {
+			if len(args) != 1 {
+				return newError("iter expects 1 argument, got %d", len(args))
+			}
+			c, ok := args[0].(Collection)
+			if !ok {
+				return newError("iter: expected a collection, got %s", args[0].Type())
+			}
+			elements := c.Elements()
+			next := 0
+			return &Builtin{
+				Name: "next",
+				Fn: func(eval *Evaluator, args ...Object) Object {
+					if next >= len(elements) {
+						return makeZero()
+					}
+					next++
+					return makeSome(elements[next-1])
+				},
+			}
+		},
+	}
+	for _, name := range collectionTypeNames {
+		e.ClassImplementations[config.IterTraitName][name] = &MethodTable{
+			Methods: map[string]Object{config.IterMethodName: iter},
+		}
+	}
+}
+
+// inspectCall renders a collection as name([elem, ...])
+func inspectCall(name string, elements []Object) string {
+	var out bytes.Buffer
+	out.WriteString(name)
+	out.WriteString("([")
+	for i, el := range elements {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(el.Inspect())
+	}
+	out.WriteString("])")
+	return out.String()
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterSetBuiltins registers the Set type and functions
+func RegisterSetBuiltins(env *Environment) {
+	// Types
+	env.Set("Set", &TypeObject{TypeVal: typesystem.TCon{Name: "Set"}})
+
+	// Functions
+	builtins := SetBuiltins()
+	SetSetBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// SetBuiltins returns built-in functions for lib/set virtual package
+func SetBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		"setNew":        {Fn: builtinSetNew, Name: "setNew"},
+		"setFrom":       {Fn: builtinSetFrom, Name: "setFrom"},
+		"setAdd":        {Fn: builtinSetAdd, Name: "setAdd"},
+		"setRemove":     {Fn: builtinSetRemove, Name: "setRemove"},
+		"setContains":   {Fn: builtinSetContains, Name: "setContains"},
+		"setSize":       {Fn: builtinSetSize, Name: "setSize"},
+		"setToList":     {Fn: builtinSetToList, Name: "setToList"},
+		"setUnion":      {Fn: builtinSetUnion, Name: "setUnion"},
+		"setIntersect":  {Fn: builtinSetIntersect, Name: "setIntersect"},
+		"setDifference": {Fn: builtinSetDifference, Name: "setDifference"},
+		"setIsSubset":   {Fn: builtinSetIsSubset, Name: "setIsSubset"},
+	}
+}
+
+// setArgs checks that there are sets+extra arguments, the first sets of them
+// being sets
+func setArgs(name string, args []Object, sets, extra int) ([]*Set, Object) {
+	if len(args) != sets+extra {
+		return nil, newError("%s expects %d arguments, got %d", name, sets+extra, len(args))
+	}
+	result := make([]*Set, sets)
+	for i := 0; i < sets; i++ {
+		s, ok := args[i].(*Set)
+		if !ok {
+			return nil, newError("%s expects a Set, got %s", name, args[i].Type())
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// setNew: () -> Set<T>
+func builtinSetNew(e *Evaluator, args ...Object) Object {
+	if len(args) != 0 {
+		return newError("setNew expects 0 arguments, got %d", len(args))
+	}
+	return newSet()
+}
+
+// setFrom: List<T> -> Set<T>
+func builtinSetFrom(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("setFrom expects 1 argument, got %d", len(args))
+	}
+	list, ok := args[0].(*List)
+	if !ok {
+		return newError("setFrom expects a list, got %s", args[0].Type())
+	}
+	s := newSet()
+	for _, item := range list.ToSlice() {
+		s = s.add(item)
+	}
+	return s
+}
+
+// setAdd: (Set<T>, T) -> Set<T>
+func builtinSetAdd(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setAdd", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	return sets[0].add(args[1])
+}
+
+// setRemove: (Set<T>, T) -> Set<T>
+func builtinSetRemove(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setRemove", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	return sets[0].remove(args[1])
+}
+
+// setContains: (Set<T>, T) -> Bool
+func builtinSetContains(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setContains", args, 1, 1)
+	if err != nil {
+		return err
+	}
+	return e.nativeBoolToBooleanObject(sets[0].contains(args[1]))
+}
+
+// setSize: Set<T> -> Int
+func builtinSetSize(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setSize", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return &Integer{Value: int64(sets[0].Len())}
+}
+
+// setToList: Set<T> -> List<T>
+func builtinSetToList(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setToList", args, 1, 0)
+	if err != nil {
+		return err
+	}
+	return newList(sets[0].Elements())
+}
+
+// setUnion: (Set<T>, Set<T>) -> Set<T>
+// Adds the elements of the smaller set to the larger one.
+func builtinSetUnion(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setUnion", args, 2, 0)
+	if err != nil {
+		return err
+	}
+	big, small := sets[0], sets[1]
+	if small.Len() > big.Len() {
+		big, small = small, big
+	}
+	for _, key := range small.hamt.Keys() {
+		big = big.add(key)
+	}
+	return big
+}
+
+// setIntersect: (Set<T>, Set<T>) -> Set<T>
+func builtinSetIntersect(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setIntersect", args, 2, 0)
+	if err != nil {
+		return err
+	}
+	big, small := sets[0], sets[1]
+	if small.Len() > big.Len() {
+		big, small = small, big
+	}
+	result := newSet()
+	for _, key := range small.hamt.Keys() {
+		if big.contains(key) {
+			result = result.add(key)
+		}
+	}
+	return result
+}
+
+// setDifference: (Set<T>, Set<T>) -> Set<T>
+// Elements of the first set that are not in the second.
+func builtinSetDifference(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setDifference", args, 2, 0)
+	if err != nil {
+		return err
+	}
+	result := sets[0]
+	for _, key := range sets[1].hamt.Keys() {
+		result = result.remove(key)
+	}
+	return result
+}
+
+// setIsSubset: (Set<T>, Set<T>) -> Bool
+// Whether every element of the first set is in the second.
+func builtinSetIsSubset(e *Evaluator, args ...Object) Object {
+	sets, err := setArgs("setIsSubset", args, 2, 0)
+	if err != nil {
+		return err
+	}
+	return e.nativeBoolToBooleanObject(sets[0].isSubset(sets[1]))
+}
+
+// SetSetBuiltinTypes sets type info for set builtins
+func SetSetBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	setT := typesystem.TApp{Constructor: typesystem.TCon{Name: "Set"}, Args: []typesystem.Type{T}}
+	listT := typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{T}}
+
+	types := map[string]typesystem.Type{
+		"setNew":        typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: setT},
+		"setFrom":       typesystem.TFunc{Params: []typesystem.Type{listT}, ReturnType: setT},
+		"setAdd":        typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT},
+		"setRemove":     typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT},
+		"setContains":   typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: typesystem.Bool},
+		"setSize":       typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: typesystem.Int},
+		"setToList":     typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: listT},
+		"setUnion":      typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT},
+		"setIntersect":  typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT},
+		"setDifference": typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT},
+		"setIsSubset":   typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: typesystem.Bool},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
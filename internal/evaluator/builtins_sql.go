@@ -3,7 +3,9 @@ package evaluator
 import (
 	"database/sql"
 	"fmt"
+	"math/big"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -41,14 +43,15 @@ func (s *SqlTx) Hash() uint32 {
 
 // SqlValue ADT constructors
 var (
-	sqlNullCtor   string = "SqlNull"
-	sqlIntCtor    string = "SqlInt"
-	sqlFloatCtor  string = "SqlFloat"
-	sqlStringCtor string = "SqlString"
-	sqlBoolCtor   string = "SqlBool"
-	sqlBytesCtor  string = "SqlBytes"
-	sqlTimeCtor   string = "SqlTime"
-	sqlBigIntCtor string = "SqlBigInt"
+	sqlNullCtor    string = "SqlNull"
+	sqlIntCtor     string = "SqlInt"
+	sqlFloatCtor   string = "SqlFloat"
+	sqlStringCtor  string = "SqlString"
+	sqlBoolCtor    string = "SqlBool"
+	sqlBytesCtor   string = "SqlBytes"
+	sqlTimeCtor    string = "SqlTime"
+	sqlBigIntCtor  string = "SqlBigInt"
+	sqlDecimalCtor string = "SqlDecimal"
 )
 
 // Global registry of open databases for cleanup
@@ -67,6 +70,15 @@ func registerSqlDB(db *SqlDB) int64 {
 	return id
 }
 
+// sqlFailure reports a failed statement, or cancellation if the statement
+// was interrupted because its task was cancelled
+func sqlFailure(e *Evaluator, err error) Object {
+	if e.taskContext().Err() != nil {
+		return cancelledError()
+	}
+	return makeFailStr(err.Error())
+}
+
 // convertPlaceholders converts $1, $2 style to ? for SQLite
 func convertPlaceholders(query string) string {
 	re := regexp.MustCompile(`\$(\d+)`)
@@ -129,12 +141,15 @@ func sqlObjectToGoValue(obj Object) interface{} {
 	case *Rational:
 		f, _ := o.Value.Float64()
 		return f
+	case *Decimal:
+		// Bound as TEXT, never as float64, so the driver cannot round it
+		return formatDecimal(o)
 	case *DataInstance:
 		// Handle SqlValue variants
 		switch o.Name {
 		case sqlNullCtor:
 			return nil
-		case sqlIntCtor, sqlFloatCtor, sqlStringCtor, sqlBoolCtor, sqlBytesCtor, sqlBigIntCtor:
+		case sqlIntCtor, sqlFloatCtor, sqlStringCtor, sqlBoolCtor, sqlBytesCtor, sqlBigIntCtor, sqlDecimalCtor:
 			if len(o.Fields) > 0 {
 				return sqlObjectToGoValue(o.Fields[0])
 			}
@@ -174,6 +189,9 @@ func sqlObjectToGoValue(obj Object) interface{} {
 
 // sqlListToString converts List<Char> to Go string
 func sqlListToString(list *List) string {
+	if s, ok := list.AsString(); ok {
+		return s
+	}
 	var sb strings.Builder
 	for _, elem := range list.ToSlice() {
 		if ch, ok := elem.(*Char); ok {
@@ -209,17 +227,91 @@ func rowToMap(rows *sql.Rows) (Object, error) {
 		return nil, err
 	}
 
+	// Columns declared DECIMAL or NUMERIC are read as Decimal
+	colTypes, _ := rows.ColumnTypes()
+
 	// Build Map
 	m := newMap()
 	for i, col := range columns {
 		key := stringToList(col)
 		val := goValueToSqlValue(values[i])
+		if i < len(colTypes) && isDecimalColumn(colTypes[i].DatabaseTypeName()) {
+			if dec, ok := sqlDecimalValue(values[i], decimalColumnScale(colTypes[i].DatabaseTypeName())); ok {
+				val = &DataInstance{Name: sqlDecimalCtor, TypeName: "SqlValue", Fields: []Object{dec}}
+			}
+		}
 		m = m.put(key, val)
 	}
 
 	return m, nil
 }
 
+// isDecimalColumn reports whether a declared column type is DECIMAL(p,s)
+// or NUMERIC(p,s)
+func isDecimalColumn(typeName string) bool {
+	typeName = strings.ToUpper(typeName)
+	return strings.HasPrefix(typeName, "DECIMAL") || strings.HasPrefix(typeName, "NUMERIC")
+}
+
+// sqlExactDigits is how many significant digits survive a REAL (DBL_DIG).
+// SQLite stores well-formed numbers in DECIMAL columns as INTEGER or REAL,
+// so longer values may already have been rounded.
+const sqlExactDigits = 15
+
+// decimalColumnScale returns s of DECIMAL(p,s), 0 for DECIMAL(p) and -1
+// when the declared type has no precision.
+func decimalColumnScale(typeName string) int {
+	_, args, ok := strings.Cut(typeName, "(")
+	if !ok {
+		return -1
+	}
+	args = strings.TrimSuffix(strings.TrimSpace(args), ")")
+	_, s, ok := strings.Cut(args, ",")
+	if !ok {
+		return 0
+	}
+	scale, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return -1
+	}
+	return scale
+}
+
+// sqlDecimalValue converts a scanned value of a decimal column. Text keeps
+// all digits. An INTEGER or REAL is converted only when it has at most
+// sqlExactDigits significant digits, so a rounded value stays SqlInt or
+// SqlFloat instead of posing as an exact SqlDecimal. The declared scale
+// restores trailing zeros the storage dropped (7.50 stored as 7.5).
+func sqlDecimalValue(val interface{}, scale int) (*Decimal, bool) {
+	var dec *Decimal
+	var ok bool
+	switch v := val.(type) {
+	case string:
+		dec, ok = parseDecimal(v)
+	case []byte:
+		dec, ok = parseDecimal(string(v))
+	case int64:
+		dec = &Decimal{Unscaled: big.NewInt(v)}
+		ok = significantDigits(dec.Unscaled) <= sqlExactDigits
+	case float64:
+		dec, ok = parseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+		ok = ok && significantDigits(dec.Unscaled) <= sqlExactDigits
+	}
+	if !ok {
+		return nil, false
+	}
+	if scale > dec.Scale {
+		dec = &Decimal{Unscaled: unscaledAt(dec, scale), Scale: scale}
+	}
+	return dec, true
+}
+
+// significantDigits counts the digits of n without trailing zeros.
+func significantDigits(n *big.Int) int {
+	digits := strings.TrimRight(new(big.Int).Abs(n).String(), "0")
+	return max(len(digits), 1)
+}
+
 // builtinSqlOpen opens a database connection
 func builtinSqlOpen(e *Evaluator, args ...Object) Object {
 	if len(args) != 2 {
@@ -313,9 +405,9 @@ func builtinSqlQuery(e *Evaluator, args ...Object) Object {
 		params = paramsListToGoValues(paramList)
 	}
 
-	rows, err := sqlDB.db.Query(query, params...)
+	rows, err := sqlDB.db.QueryContext(e.taskContext(), query, params...)
 	if err != nil {
-		return makeFailStr(err.Error())
+		return sqlFailure(e, err)
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -358,9 +450,9 @@ func builtinSqlQueryRow(e *Evaluator, args ...Object) Object {
 		params = paramsListToGoValues(paramList)
 	}
 
-	rows, err := sqlDB.db.Query(query, params...)
+	rows, err := sqlDB.db.QueryContext(e.taskContext(), query, params...)
 	if err != nil {
-		return makeFailStr(err.Error())
+		return sqlFailure(e, err)
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -402,9 +494,9 @@ func builtinSqlExec(e *Evaluator, args ...Object) Object {
 		params = paramsListToGoValues(paramList)
 	}
 
-	result, err := sqlDB.db.Exec(query, params...)
+	result, err := sqlDB.db.ExecContext(e.taskContext(), query, params...)
 	if err != nil {
-		return makeFailStr(err.Error())
+		return sqlFailure(e, err)
 	}
 
 	affected, err := result.RowsAffected()
@@ -438,9 +530,9 @@ func builtinSqlLastInsertId(e *Evaluator, args ...Object) Object {
 		params = paramsListToGoValues(paramList)
 	}
 
-	result, err := sqlDB.db.Exec(query, params...)
+	result, err := sqlDB.db.ExecContext(e.taskContext(), query, params...)
 	if err != nil {
-		return makeFailStr(err.Error())
+		return sqlFailure(e, err)
 	}
 
 	lastID, err := result.LastInsertId()
@@ -531,9 +623,9 @@ func builtinSqlTxQuery(e *Evaluator, args ...Object) Object {
 		params = paramsListToGoValues(paramList)
 	}
 
-	rows, err := sqlTx.tx.Query(query, params...)
+	rows, err := sqlTx.tx.QueryContext(e.taskContext(), query, params...)
 	if err != nil {
-		return makeFailStr(err.Error())
+		return sqlFailure(e, err)
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -576,9 +668,9 @@ func builtinSqlTxExec(e *Evaluator, args ...Object) Object {
 		params = paramsListToGoValues(paramList)
 	}
 
-	result, err := sqlTx.tx.Exec(query, params...)
+	result, err := sqlTx.tx.ExecContext(e.taskContext(), query, params...)
 	if err != nil {
-		return makeFailStr(err.Error())
+		return sqlFailure(e, err)
 	}
 
 	affected, err := result.RowsAffected()
@@ -603,7 +695,7 @@ func builtinSqlUnwrap(e *Evaluator, args ...Object) Object {
 	switch dataInst.Name {
 	case sqlNullCtor:
 		return makeZero() // Return Option Zero for null
-	case sqlIntCtor, sqlFloatCtor, sqlStringCtor, sqlBoolCtor, sqlBytesCtor, sqlTimeCtor, sqlBigIntCtor:
+	case sqlIntCtor, sqlFloatCtor, sqlStringCtor, sqlBoolCtor, sqlBytesCtor, sqlTimeCtor, sqlBigIntCtor, sqlDecimalCtor:
 		if len(dataInst.Fields) > 0 {
 			return makeSome(dataInst.Fields[0])
 		}
@@ -672,6 +764,7 @@ func RegisterSqlBuiltins(env *Environment) {
 	env.Set("SqlBytes", &Constructor{Name: "SqlBytes", TypeName: "SqlValue", Arity: 1})
 	env.Set("SqlTime", &Constructor{Name: "SqlTime", TypeName: "SqlValue", Arity: 1})
 	env.Set("SqlBigInt", &Constructor{Name: "SqlBigInt", TypeName: "SqlValue", Arity: 1})
+	env.Set("SqlDecimal", &Constructor{Name: "SqlDecimal", TypeName: "SqlValue", Arity: 1})
 
 	// Functions
 	builtins := SqlBuiltins()
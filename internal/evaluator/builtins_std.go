@@ -29,20 +29,7 @@ func registerShowTrait(e *Evaluator, env *Environment) {
 		Arity:     1,
 	})
 
-	// Generic Show implementation using objectToString
-	genericShow := &Builtin{
-		Name: methodName,
-		TypeInfo: typesystem.TFunc{
-			Params:     []typesystem.Type{typesystem.TVar{Name: "a"}},
-			ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{typesystem.Char}},
-		},
-		Fn: func(eval *Evaluator, args ...Object) Object {
-			if len(args) != 1 {
-				return newError("show expects 1 argument, got %d", len(args))
-			}
-			return stringToList(objectToString(args[0]))
-		},
-	}
+	genericShow := newGenericShow()
 
 	// Register instances for all standard types
 	types := []string{
@@ -62,6 +49,7 @@ func registerShowTrait(e *Evaluator, env *Environment) {
 			Methods: map[string]Object{
 				methodName: genericShow,
 			},
+			Native: true,
 		}
 	}
 	
@@ -73,3 +61,21 @@ func registerShowTrait(e *Evaluator, env *Environment) {
 	// Let's check getTypeName in VM or evaluator.
 }
 
+
+// newGenericShow returns the structural Show implementation using
+// objectToString. It backs the standard instances and derived ones.
+func newGenericShow() *Builtin {
+	return &Builtin{
+		Name: "show",
+		TypeInfo: typesystem.TFunc{
+			Params:     []typesystem.Type{typesystem.TVar{Name: "a"}},
+			ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{typesystem.Char}},
+		},
+		Fn: func(eval *Evaluator, args ...Object) Object {
+			if len(args) != 1 {
+				return newError("show expects 1 argument, got %d", len(args))
+			}
+			return stringToList(objectToString(args[0]))
+		},
+	}
+}
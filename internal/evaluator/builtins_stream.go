@@ -0,0 +1,793 @@
+package evaluator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// ============================================================================
+// Stream - lazy pull-based sequence
+// ============================================================================
+
+// Stream describes a pipeline: a source and the map/filter stages applied to
+// each element. Nothing runs until a terminal operation opens a cursor, and
+// every terminal opens a fresh one, so a stream over a list, a range or a file
+// can be consumed more than once. Channel and iterator sources are one-shot.
+//
+// Adjacent map and filter stages are fused: they are kept in one slice and
+// applied to each element in a single loop, instead of wrapping the source in
+// one cursor per stage.
+type Stream struct {
+	id     int64
+	open   func(e *Evaluator) (*streamCursor, Object)
+	stages []streamStage
+}
+
+// streamStage is a fused map or filter step
+type streamStage struct {
+	fn     Object
+	filter bool
+}
+
+// streamCursor is one run of a stream. next returns the next element and
+// true, false once the stream is exhausted, or an error object and true.
+// close releases the source (e.g. an open file) and may be nil.
+type streamCursor struct {
+	next  func(e *Evaluator) (Object, bool)
+	close func()
+}
+
+var streamCounter int64
+
+func (s *Stream) Type() ObjectType { return "STREAM" }
+func (s *Stream) TypeName() string { return "Stream" }
+func (s *Stream) Inspect() string  { return fmt.Sprintf("<Stream:%d>", s.id) }
+func (s *Stream) RuntimeType() typesystem.Type {
+	return typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Stream"},
+		Args:        []typesystem.Type{typesystem.TVar{Name: "T"}},
+	}
+}
+func (s *Stream) Hash() uint32 { return uint32(s.id) }
+
+// newStream creates a stream without stages from a source
+func newStream(open func(e *Evaluator) (*streamCursor, Object)) *Stream {
+	return &Stream{id: atomic.AddInt64(&streamCounter, 1), open: open}
+}
+
+// withStage returns a copy of s with one more fused stage. The stage slice is
+// copied so that streams sharing a prefix do not see each other's stages.
+func (s *Stream) withStage(stage streamStage) *Stream {
+	stages := make([]streamStage, len(s.stages), len(s.stages)+1)
+	copy(stages, s.stages)
+	return &Stream{
+		id:     atomic.AddInt64(&streamCounter, 1),
+		open:   s.open,
+		stages: append(stages, stage),
+	}
+}
+
+// cursor opens the source and applies the fused stages to it
+func (s *Stream) cursor(e *Evaluator) (*streamCursor, Object) {
+	c, err := s.open(e)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.stages) == 0 {
+		return c, nil
+	}
+
+	stages := s.stages
+	pull := c.next
+	c.next = func(e *Evaluator) (Object, bool) {
+	element:
+		for {
+			value, ok := pull(e)
+			if !ok || isError(value) {
+				return value, ok
+			}
+			for _, stage := range stages {
+				result := e.ApplyFunction(stage.fn, []Object{value})
+				if isError(result) {
+					return result, true
+				}
+				if !stage.filter {
+					value = result
+					continue
+				}
+				keep, ok := result.(*Boolean)
+				if !ok {
+					return newError("streamFilter predicate must return Bool, got %s", result.Type()), true
+				}
+				if !keep.Value {
+					continue element
+				}
+			}
+			return value, true
+		}
+	}
+	return c, nil
+}
+
+func (c *streamCursor) release() {
+	if c.close != nil {
+		c.close()
+	}
+}
+
+// drain runs s, calling visit for every element until visit returns false.
+// It returns the first error of the source, the stages or visit.
+func (s *Stream) drain(e *Evaluator, visit func(value Object) (bool, Object)) Object {
+	c, err := s.cursor(e)
+	if err != nil {
+		return err
+	}
+	defer c.release()
+
+	ctx := e.taskContext()
+	for {
+		if ctx.Err() != nil {
+			return cancelledError()
+		}
+		value, ok := c.next(e)
+		if !ok {
+			return nil
+		}
+		if isError(value) {
+			return value
+		}
+		more, err := visit(value)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// ============================================================================
+// Builtins
+// ============================================================================
+
+// RegisterStreamBuiltins registers the Stream type and functions
+func RegisterStreamBuiltins(env *Environment) {
+	// Types
+	env.Set("Stream", &TypeObject{TypeVal: typesystem.TCon{Name: "Stream"}})
+
+	// Functions
+	builtins := StreamBuiltins()
+	SetStreamBuiltinTypes(builtins)
+	for name, fn := range builtins {
+		env.Set(name, fn)
+	}
+}
+
+// StreamBuiltins returns built-in functions for lib/stream virtual package
+func StreamBuiltins() map[string]*Builtin {
+	return map[string]*Builtin{
+		// Sources
+		"streamFromList": {Fn: builtinStreamFromList, Name: "streamFromList"},
+		"streamRange":    {Fn: builtinStreamRange, Name: "streamRange"},
+		"streamIterate":  {Fn: builtinStreamIterate, Name: "streamIterate"},
+		"streamLines":    {Fn: builtinStreamLines, Name: "streamLines"},
+		"streamFromChan": {Fn: builtinStreamFromChan, Name: "streamFromChan"},
+		"streamFromIter": {Fn: builtinStreamFromIter, Name: "streamFromIter"},
+
+		// Combinators
+		"streamMap":     {Fn: builtinStreamMap, Name: "streamMap"},
+		"streamFilter":  {Fn: builtinStreamFilter, Name: "streamFilter"},
+		"streamFlatMap": {Fn: builtinStreamFlatMap, Name: "streamFlatMap"},
+		"streamTake":    {Fn: builtinStreamTake, Name: "streamTake"},
+		"streamDrop":    {Fn: builtinStreamDrop, Name: "streamDrop"},
+		"streamZip":     {Fn: builtinStreamZip, Name: "streamZip"},
+		"streamChunk":   {Fn: builtinStreamChunk, Name: "streamChunk"},
+		"streamWindow":  {Fn: builtinStreamWindow, Name: "streamWindow"},
+
+		// Terminals
+		"streamToList":  {Fn: builtinStreamToList, Name: "streamToList"},
+		"streamFold":    {Fn: builtinStreamFold, Name: "streamFold"},
+		"streamForEach": {Fn: builtinStreamForEach, Name: "streamForEach"},
+		"streamCount":   {Fn: builtinStreamCount, Name: "streamCount"},
+		"streamFirst":   {Fn: builtinStreamFirst, Name: "streamFirst"},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Sources
+// ----------------------------------------------------------------------------
+
+// streamFromList: List<T> -> Stream<T>
+func builtinStreamFromList(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("streamFromList expects 1 argument, got %d", len(args))
+	}
+	list, ok := args[0].(*List)
+	if !ok {
+		return newError("streamFromList expects a list, got %s", args[0].Type())
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		i := 0
+		return &streamCursor{next: func(e *Evaluator) (Object, bool) {
+			if i >= list.Len() {
+				return nil, false
+			}
+			i++
+			return list.get(i - 1), true
+		}}, nil
+	})
+}
+
+// streamRange: (Int, Int) -> Stream<Int>
+// Counts from start up to, but not including, end, like range in lib/list.
+func builtinStreamRange(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("streamRange expects 2 arguments, got %d", len(args))
+	}
+	start, ok := args[0].(*Integer)
+	if !ok {
+		return newError("streamRange expects integers, got %s", args[0].Type())
+	}
+	end, ok := args[1].(*Integer)
+	if !ok {
+		return newError("streamRange expects integers, got %s", args[1].Type())
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		i := start.Value
+		return &streamCursor{next: func(e *Evaluator) (Object, bool) {
+			if i >= end.Value {
+				return nil, false
+			}
+			i++
+			return &Integer{Value: i - 1}, true
+		}}, nil
+	})
+}
+
+// streamIterate: ((T) -> T, T) -> Stream<T>
+// The infinite stream seed, fn(seed), fn(fn(seed)), ...
+func builtinStreamIterate(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("streamIterate expects 2 arguments, got %d", len(args))
+	}
+	fn, seed := args[0], args[1]
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		var current Object
+		return &streamCursor{next: func(e *Evaluator) (Object, bool) {
+			if current == nil {
+				current = seed
+			} else {
+				current = e.ApplyFunction(fn, []Object{current})
+			}
+			return current, true
+		}}, nil
+	})
+}
+
+// streamLines: String -> Stream<String>
+// Reads a file line by line without loading it. Line endings ("\n" or
+// "\r\n") are removed. The file is opened when the stream runs and closed
+// when it stops, so a missing file is reported by the terminal operation.
+func builtinStreamLines(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("streamLines expects 1 argument, got %d", len(args))
+	}
+	pathList, ok := args[0].(*List)
+	if !ok {
+		return newError("streamLines expects a string path, got %s", args[0].Type())
+	}
+	path := charListToString(pathList)
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, newError("streamLines: %s", err.Error())
+		}
+		reader := bufio.NewReader(file)
+		done := false
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				if done {
+					return nil, false
+				}
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					done = true
+					if err != io.EOF {
+						return newError("streamLines: %s", err.Error()), true
+					}
+					if line == "" {
+						return nil, false
+					}
+				}
+				line = strings.TrimSuffix(line, "\n")
+				line = strings.TrimSuffix(line, "\r")
+				return newStringList(line), true
+			},
+			close: func() { file.Close() },
+		}, nil
+	})
+}
+
+// streamFromChan: Chan<T> -> Stream<T>
+// Receives until the channel is closed and drained.
+func builtinStreamFromChan(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("streamFromChan expects 1 argument, got %d", len(args))
+	}
+	ch, ok := args[0].(*Channel)
+	if !ok {
+		return newError("streamFromChan expects a Chan, got %s", args[0].Type())
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		return &streamCursor{next: func(e *Evaluator) (Object, bool) {
+			select {
+			case value, ok := <-ch.ch:
+				if !ok {
+					return nil, false
+				}
+				return value, true
+			case <-e.taskContext().Done():
+				return cancelledError(), true
+			}
+		}}, nil
+	})
+}
+
+// streamFromIter: (() -> Option<T>) -> Stream<T>
+// Pulls from an iterator function, such as one returned by an Iter
+// instance's iter, until it returns Zero.
+func builtinStreamFromIter(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("streamFromIter expects 1 argument, got %d", len(args))
+	}
+	fn := args[0]
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		return &streamCursor{next: func(e *Evaluator) (Object, bool) {
+			result := e.ApplyFunction(fn, []Object{})
+			if isError(result) {
+				return result, true
+			}
+			option, ok := result.(*DataInstance)
+			if !ok {
+				return newError("streamFromIter iterator must return Option, got %s", result.Type()), true
+			}
+			if option.Name == config.SomeCtorName && len(option.Fields) == 1 {
+				return option.Fields[0], true
+			}
+			return nil, false
+		}}, nil
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Combinators
+// ----------------------------------------------------------------------------
+
+// streamArg checks that the last argument of a combinator is a stream
+func streamArg(name string, args []Object, count int) (*Stream, Object) {
+	if len(args) != count {
+		return nil, newError("%s expects %d arguments, got %d", name, count, len(args))
+	}
+	s, ok := args[count-1].(*Stream)
+	if !ok {
+		return nil, newError("%s expects a Stream, got %s", name, args[count-1].Type())
+	}
+	return s, nil
+}
+
+// sizeArg checks the size argument of streamTake, streamDrop and friends
+func sizeArg(name string, arg Object, min int64) (int64, Object) {
+	n, ok := arg.(*Integer)
+	if !ok {
+		return 0, newError("%s expects Int, got %s", name, arg.Type())
+	}
+	if n.Value < min {
+		return 0, newError("%s expects a size of at least %d, got %d", name, min, n.Value)
+	}
+	return n.Value, nil
+}
+
+// streamMap: ((T) -> U, Stream<T>) -> Stream<U>
+func builtinStreamMap(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamMap", args, 2)
+	if err != nil {
+		return err
+	}
+	return s.withStage(streamStage{fn: args[0]})
+}
+
+// streamFilter: ((T) -> Bool, Stream<T>) -> Stream<T>
+func builtinStreamFilter(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamFilter", args, 2)
+	if err != nil {
+		return err
+	}
+	return s.withStage(streamStage{fn: args[0], filter: true})
+}
+
+// streamFlatMap: ((T) -> Stream<U>, Stream<T>) -> Stream<U>
+func builtinStreamFlatMap(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamFlatMap", args, 2)
+	if err != nil {
+		return err
+	}
+	fn := args[0]
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		outer, err := s.cursor(e)
+		if err != nil {
+			return nil, err
+		}
+		var inner *streamCursor
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				for {
+					if inner != nil {
+						value, ok := inner.next(e)
+						if ok {
+							return value, true
+						}
+						inner.release()
+						inner = nil
+					}
+					value, ok := outer.next(e)
+					if !ok || isError(value) {
+						return value, ok
+					}
+					result := e.ApplyFunction(fn, []Object{value})
+					if isError(result) {
+						return result, true
+					}
+					sub, isStream := result.(*Stream)
+					if !isStream {
+						return newError("streamFlatMap function must return a Stream, got %s", result.Type()), true
+					}
+					if inner, err = sub.cursor(e); err != nil {
+						return err, true
+					}
+				}
+			},
+			close: func() {
+				if inner != nil {
+					inner.release()
+				}
+				outer.release()
+			},
+		}, nil
+	})
+}
+
+// streamTake: (Int, Stream<T>) -> Stream<T>
+// Stops pulling from the source after n elements, so it can end an infinite
+// stream.
+func builtinStreamTake(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamTake", args, 2)
+	if err != nil {
+		return err
+	}
+	n, err := sizeArg("streamTake", args[0], 0)
+	if err != nil {
+		return err
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		c, err := s.cursor(e)
+		if err != nil {
+			return nil, err
+		}
+		taken := int64(0)
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				if taken >= n {
+					return nil, false
+				}
+				taken++
+				return c.next(e)
+			},
+			close: c.release,
+		}, nil
+	})
+}
+
+// streamDrop: (Int, Stream<T>) -> Stream<T>
+func builtinStreamDrop(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamDrop", args, 2)
+	if err != nil {
+		return err
+	}
+	n, err := sizeArg("streamDrop", args[0], 0)
+	if err != nil {
+		return err
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		c, err := s.cursor(e)
+		if err != nil {
+			return nil, err
+		}
+		dropped := int64(0)
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				for ; dropped < n; dropped++ {
+					value, ok := c.next(e)
+					if !ok || isError(value) {
+						return value, ok
+					}
+				}
+				return c.next(e)
+			},
+			close: c.release,
+		}, nil
+	})
+}
+
+// streamZip: (Stream<A>, Stream<B>) -> Stream<(A, B)>
+// Ends with the shorter stream.
+func builtinStreamZip(e *Evaluator, args ...Object) Object {
+	if len(args) != 2 {
+		return newError("streamZip expects 2 arguments, got %d", len(args))
+	}
+	left, ok := args[0].(*Stream)
+	if !ok {
+		return newError("streamZip expects a Stream, got %s", args[0].Type())
+	}
+	right, ok := args[1].(*Stream)
+	if !ok {
+		return newError("streamZip expects a Stream, got %s", args[1].Type())
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		a, err := left.cursor(e)
+		if err != nil {
+			return nil, err
+		}
+		b, err := right.cursor(e)
+		if err != nil {
+			a.release()
+			return nil, err
+		}
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				x, ok := a.next(e)
+				if !ok || isError(x) {
+					return x, ok
+				}
+				y, ok := b.next(e)
+				if !ok || isError(y) {
+					return y, ok
+				}
+				return &Tuple{Elements: []Object{x, y}}, true
+			},
+			close: func() {
+				a.release()
+				b.release()
+			},
+		}, nil
+	})
+}
+
+// streamChunk: (Int, Stream<T>) -> Stream<List<T>>
+// Groups consecutive elements into lists of n; the last one may be shorter.
+func builtinStreamChunk(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamChunk", args, 2)
+	if err != nil {
+		return err
+	}
+	n, err := sizeArg("streamChunk", args[0], 1)
+	if err != nil {
+		return err
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		c, err := s.cursor(e)
+		if err != nil {
+			return nil, err
+		}
+		done := false
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				if done {
+					return nil, false
+				}
+				chunk := make([]Object, 0, n)
+				for int64(len(chunk)) < n {
+					value, ok := c.next(e)
+					if !ok {
+						done = true
+						break
+					}
+					if isError(value) {
+						return value, true
+					}
+					chunk = append(chunk, value)
+				}
+				if len(chunk) == 0 {
+					return nil, false
+				}
+				return newList(chunk), true
+			},
+			close: c.release,
+		}, nil
+	})
+}
+
+// streamWindow: (Int, Stream<T>) -> Stream<List<T>>
+// Sliding windows of n consecutive elements, advancing by one. A stream with
+// fewer than n elements has no windows.
+func builtinStreamWindow(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamWindow", args, 2)
+	if err != nil {
+		return err
+	}
+	n, err := sizeArg("streamWindow", args[0], 1)
+	if err != nil {
+		return err
+	}
+	return newStream(func(e *Evaluator) (*streamCursor, Object) {
+		c, err := s.cursor(e)
+		if err != nil {
+			return nil, err
+		}
+		var window []Object
+		return &streamCursor{
+			next: func(e *Evaluator) (Object, bool) {
+				if int64(len(window)) == n {
+					window = window[1:]
+				}
+				for int64(len(window)) < n {
+					value, ok := c.next(e)
+					if !ok || isError(value) {
+						return value, ok
+					}
+					window = append(window, value)
+				}
+				out := make([]Object, n)
+				copy(out, window)
+				return newList(out), true
+			},
+			close: c.release,
+		}, nil
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Terminals
+// ----------------------------------------------------------------------------
+
+// streamToList: Stream<T> -> List<T>
+func builtinStreamToList(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamToList", args, 1)
+	if err != nil {
+		return err
+	}
+	var items []Object
+	if err := s.drain(e, func(value Object) (bool, Object) {
+		items = append(items, value)
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	return newList(items)
+}
+
+// streamFold: ((U, T) -> U, U, Stream<T>) -> U
+func builtinStreamFold(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamFold", args, 3)
+	if err != nil {
+		return err
+	}
+	fn, acc := args[0], args[1]
+	if err := s.drain(e, func(value Object) (bool, Object) {
+		acc = e.ApplyFunction(fn, []Object{acc, value})
+		if isError(acc) {
+			return false, acc
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	return acc
+}
+
+// streamForEach: ((T) -> Nil, Stream<T>) -> Nil
+func builtinStreamForEach(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamForEach", args, 2)
+	if err != nil {
+		return err
+	}
+	if err := s.drain(e, func(value Object) (bool, Object) {
+		if result := e.ApplyFunction(args[0], []Object{value}); isError(result) {
+			return false, result
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	return &Nil{}
+}
+
+// streamCount: Stream<T> -> Int
+func builtinStreamCount(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamCount", args, 1)
+	if err != nil {
+		return err
+	}
+	count := int64(0)
+	if err := s.drain(e, func(value Object) (bool, Object) {
+		count++
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	return &Integer{Value: count}
+}
+
+// streamFirst: Stream<T> -> Option<T>
+// Pulls a single element, so it also works on infinite streams.
+func builtinStreamFirst(e *Evaluator, args ...Object) Object {
+	s, err := streamArg("streamFirst", args, 1)
+	if err != nil {
+		return err
+	}
+	result := makeZero()
+	if err := s.drain(e, func(value Object) (bool, Object) {
+		result = makeSome(value)
+		return false, nil
+	}); err != nil {
+		return err
+	}
+	return result
+}
+
+// SetStreamBuiltinTypes sets type info for stream builtins
+func SetStreamBuiltinTypes(builtins map[string]*Builtin) {
+	T := typesystem.TVar{Name: "T"}
+	U := typesystem.TVar{Name: "U"}
+	A := typesystem.TVar{Name: "A"}
+	B := typesystem.TVar{Name: "B"}
+
+	streamOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "Stream"}, Args: []typesystem.Type{t}}
+	}
+	listOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: config.ListTypeName}, Args: []typesystem.Type{t}}
+	}
+	optionT := typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{T}}
+	stringType := listOf(typesystem.Char)
+	streamT := streamOf(T)
+
+	types := map[string]typesystem.Type{
+		// Sources
+		"streamFromList": typesystem.TFunc{Params: []typesystem.Type{listOf(T)}, ReturnType: streamT},
+		"streamRange":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, typesystem.Int}, ReturnType: streamOf(typesystem.Int)},
+		"streamIterate":  typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: T}, T}, ReturnType: streamT},
+		"streamLines":    typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: streamOf(stringType)},
+		"streamFromChan": typesystem.TFunc{Params: []typesystem.Type{typesystem.TApp{Constructor: typesystem.TCon{Name: "Chan"}, Args: []typesystem.Type{T}}}, ReturnType: streamT},
+		"streamFromIter": typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: optionT}}, ReturnType: streamT},
+
+		// Combinators
+		"streamMap":     typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: U}, streamT}, ReturnType: streamOf(U)},
+		"streamFilter":  typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: typesystem.Bool}, streamT}, ReturnType: streamT},
+		"streamFlatMap": typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: streamOf(U)}, streamT}, ReturnType: streamOf(U)},
+		"streamTake":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamT},
+		"streamDrop":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamT},
+		"streamZip":     typesystem.TFunc{Params: []typesystem.Type{streamOf(A), streamOf(B)}, ReturnType: streamOf(typesystem.TTuple{Elements: []typesystem.Type{A, B}})},
+		"streamChunk":   typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamOf(listOf(T))},
+		"streamWindow":  typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamOf(listOf(T))},
+
+		// Terminals
+		"streamToList":  typesystem.TFunc{Params: []typesystem.Type{streamT}, ReturnType: listOf(T)},
+		"streamFold":    typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{U, T}, ReturnType: U}, U, streamT}, ReturnType: U},
+		"streamForEach": typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: typesystem.Nil}, streamT}, ReturnType: typesystem.Nil},
+		"streamCount":   typesystem.TFunc{Params: []typesystem.Type{streamT}, ReturnType: typesystem.Int},
+		"streamFirst":   typesystem.TFunc{Params: []typesystem.Type{streamT}, ReturnType: optionT},
+	}
+
+	for name, typ := range types {
+		if b, ok := builtins[name]; ok {
+			b.TypeInfo = typ
+		}
+	}
+}
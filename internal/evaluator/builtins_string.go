@@ -87,6 +87,9 @@ func listToGoString(obj Object) (string, bool) {
 	if !ok {
 		return "", false
 	}
+	if s, ok := list.AsString(); ok {
+		return s, true
+	}
 
 	var sb strings.Builder
 	for _, elem := range list.ToSlice() {
@@ -109,6 +112,9 @@ func IsStringList(l *List) bool {
 	if l.Len() == 0 {
 		return false // Empty list is not considered a string
 	}
+	if _, ok := l.AsString(); ok {
+		return true
+	}
 	for i := 0; i < l.Len(); i++ {
 		if _, ok := l.Get(i).(*Char); !ok {
 			return false
@@ -118,11 +124,7 @@ func IsStringList(l *List) bool {
 }
 
 func goStringToList(s string) *List {
-	elements := make([]Object, 0, len(s))
-	for _, r := range s {
-		elements = append(elements, &Char{Value: int64(r)})
-	}
-	return newListWithType(elements, "Char")
+	return newStringList(s)
 }
 
 func goStringsToList(strs []string) *List {
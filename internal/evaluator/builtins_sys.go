@@ -17,6 +17,23 @@ func SysBuiltins() map[string]*Builtin {
 	}
 }
 
+// ScriptArgs, when non-nil, replaces os.Args[1:] as the arguments seen by sysArgs
+// and flagParse. Standalone executables set it so the program sees its own path
+// first, just like a script run as "funxy script.lang args...".
+var ScriptArgs []string
+
+// programArgs returns command line arguments (excluding the interpreter name)
+func programArgs() []string {
+	if ScriptArgs != nil {
+		return ScriptArgs
+	}
+	// os.Args[0] is the program name, skip it
+	if len(os.Args) > 1 {
+		return os.Args[1:]
+	}
+	return []string{}
+}
+
 // args: () -> List<String>
 // Returns command line arguments (excluding program name)
 func builtinArgs(e *Evaluator, args ...Object) Object {
@@ -24,13 +41,7 @@ func builtinArgs(e *Evaluator, args ...Object) Object {
 		return newError("args expects 0 arguments, got %d", len(args))
 	}
 
-	// os.Args[0] is the program name, skip it
-	osArgs := os.Args
-	if len(osArgs) > 1 {
-		osArgs = osArgs[1:]
-	} else {
-		osArgs = []string{}
-	}
+	osArgs := programArgs()
 
 	elements := make([]Object, len(osArgs))
 	for i, arg := range osArgs {
@@ -106,13 +117,17 @@ func builtinExec(e *Evaluator, args ...Object) Object {
 		cmdArgs[i] = listToString(argList)
 	}
 
-	// Execute command
-	cmd := exec.Command(cmdName, cmdArgs...)
+	// Execute command; cancelling the task kills the process
+	ctx := e.taskContext()
+	cmd := exec.CommandContext(ctx, cmdName, cmdArgs...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
+	if ctx.Err() != nil {
+		return cancelledError()
+	}
 	exitCode := 0
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -1,6 +1,8 @@
 package evaluator
 
 import (
+	"context"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,6 +22,12 @@ type Task struct {
 	err       string
 	cancelled atomic.Bool
 	mu        sync.Mutex
+
+	// ctx is cancelled by taskCancel, by the enclosing scope, or when the
+	// task completes; scope is the taskScope the task belongs to
+	ctx    context.Context
+	cancel context.CancelFunc
+	scope  *taskScope
 }
 
 func (t *Task) Type() ObjectType { return "TASK" }
@@ -56,8 +64,48 @@ func NewTask() *Task {
 	return &Task{done: make(chan struct{})}
 }
 
+// NewTaskFrom creates a task started by code running in e (exported for VM).
+// The task is cancelled with e's task and joins e's taskScope, if any.
+func NewTaskFrom(e *Evaluator) *Task {
+	parent := context.Background()
+	var scope *taskScope
+	if e != nil {
+		parent = e.taskContext()
+		scope = e.scope
+	}
+	task := &Task{done: make(chan struct{}), scope: scope}
+	task.ctx, task.cancel = context.WithCancel(parent)
+	if scope != nil {
+		scope.add(task)
+	}
+	return task
+}
+
+// Bind makes e run as part of the task (exported for VM): blocking builtins
+// called through e stop when the task is cancelled. The goroutine running a
+// task holds a pool slot.
+func (t *Task) Bind(e *Evaluator) {
+	e.ctx = t.ctx
+	e.scope = t.scope
+	e.poolSlot = true
+}
+
+// Cancelled reports whether the task was cancelled directly or through its
+// scope (exported for VM)
+func (t *Task) Cancelled() bool {
+	return t.cancelled.Load() || (t.ctx != nil && t.ctx.Err() != nil)
+}
+
+// release frees the task's context once it has completed
+func (t *Task) release() {
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
 // Complete completes the task with a result or error (exported for VM)
 func (t *Task) Complete(result Object) {
+	defer t.release()
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	defer close(t.done)
@@ -93,11 +141,73 @@ func AcquirePoolSlot() {
 	poolCond.L.Unlock()
 }
 
+// TryAcquirePoolSlot takes a pool slot if one is free, without waiting
+func TryAcquirePoolSlot() bool {
+	poolCond.L.Lock()
+	defer poolCond.L.Unlock()
+	if atomic.LoadInt64(&poolCurrent) >= atomic.LoadInt64(&poolLimit) {
+		return false
+	}
+	atomic.AddInt64(&poolCurrent, 1)
+	return true
+}
+
 func ReleasePoolSlot() {
 	atomic.AddInt64(&poolCurrent, -1)
 	poolCond.Signal()
 }
 
+// ============================================================================
+// Task scopes
+// ============================================================================
+
+// taskScope owns the tasks started while it is active, including tasks
+// started by those tasks. The first failure cancels all of them.
+type taskScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	err    string
+}
+
+func newTaskScope(parent context.Context) *taskScope {
+	s := &taskScope{}
+	s.ctx, s.cancel = context.WithCancel(parent)
+	return s
+}
+
+// add makes the scope wait for t and fail if t fails
+func (s *taskScope) add(t *Task) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		<-t.done
+		t.mu.Lock()
+		err := t.err
+		t.mu.Unlock()
+		if err != "" {
+			s.fail(err)
+		}
+	}()
+}
+
+// cancelledError is returned by blocking builtins interrupted because
+// their task was cancelled
+func cancelledError() *Error {
+	return newError("cancelled")
+}
+
+// fail records the first error and cancels the remaining tasks
+func (s *taskScope) fail(err string) {
+	s.mu.Lock()
+	if s.err == "" {
+		s.err = err
+	}
+	s.mu.Unlock()
+	s.cancel()
+}
+
 // ============================================================================
 // Builtins
 // ============================================================================
@@ -123,6 +233,7 @@ func TaskBuiltins() map[string]*Builtin {
 		"taskCancel":      {Fn: builtinTaskCancel, Name: "taskCancel"},
 		"taskIsDone":      {Fn: builtinTaskIsDone, Name: "taskIsDone"},
 		"taskIsCancelled": {Fn: builtinTaskIsCancelled, Name: "taskIsCancelled"},
+		"taskScope":       {Fn: builtinTaskScope, Name: "taskScope"},
 
 		// Pool
 		"taskSetGlobalPool": {Fn: builtinTaskSetGlobalPool, Name: "taskSetGlobalPool"},
@@ -152,18 +263,20 @@ func builtinAsync(e *Evaluator, args ...Object) Object {
 		return e.AsyncHandler(fn, []Object{})
 	}
 
-	task := &Task{done: make(chan struct{})}
+	task := NewTaskFrom(e)
 
 	// Clone evaluator for goroutine
 	evalClone := e.Clone()
+	task.Bind(evalClone)
 
 	go func() {
 		AcquirePoolSlot()
 		defer ReleasePoolSlot()
+		defer task.release()
 		defer close(task.done)
 
 		// Check if cancelled before starting
-		if task.cancelled.Load() {
+		if task.Cancelled() {
 			task.mu.Lock()
 			task.err = "cancelled"
 			task.mu.Unlock()
@@ -578,6 +691,10 @@ func builtinTaskCancel(e *Evaluator, args ...Object) Object {
 	}
 
 	task.cancelled.Store(true)
+	// Interrupt blocking builtins the task is waiting in
+	if task.cancel != nil {
+		task.cancel()
+	}
 	return &Nil{}
 }
 
@@ -617,6 +734,34 @@ func builtinTaskIsCancelled(e *Evaluator, args ...Object) Object {
 	return FALSE
 }
 
+// taskScope: (() -> T) -> Result<String, T>
+// Runs fn, then waits for every task started inside it. The first failure,
+// in fn or in any task, cancels the others and becomes the result.
+func builtinTaskScope(e *Evaluator, args ...Object) Object {
+	if len(args) != 1 {
+		return newError("taskScope expects 1 argument, got %d", len(args))
+	}
+
+	scope := newTaskScope(e.taskContext())
+	outerCtx, outerScope := e.ctx, e.scope
+	e.ctx, e.scope = scope.ctx, scope
+	result := e.ApplyFunction(args[0], []Object{})
+	e.ctx, e.scope = outerCtx, outerScope
+
+	if err, ok := result.(*Error); ok {
+		scope.fail(strings.TrimPrefix(err.Message, "ERROR: "))
+	}
+	scope.wg.Wait()
+	scope.cancel()
+
+	scope.mu.Lock()
+	defer scope.mu.Unlock()
+	if scope.err != "" {
+		return makeFailStr(scope.err)
+	}
+	return makeOk(result)
+}
+
 // ============================================================================
 // Pool
 // ============================================================================
@@ -653,6 +798,28 @@ func builtinTaskGetGlobalPool(e *Evaluator, args ...Object) Object {
 // Combinators
 // ============================================================================
 
+// waitFor waits for source to complete. If t is cancelled first, t fails
+// as cancelled and waitFor returns false.
+func (t *Task) waitFor(source *Task) bool {
+	select {
+	case <-source.done:
+		return true
+	case <-t.ctx.Done():
+		t.mu.Lock()
+		t.err = "cancelled"
+		t.mu.Unlock()
+		return false
+	}
+}
+
+// applyInTask calls the callback of a combinator on the tree-walk backend.
+// The goroutine holds a pool slot while the callback runs, as async tasks do.
+func applyInTask(e *Evaluator, fn Object, args []Object) Object {
+	AcquirePoolSlot()
+	defer ReleasePoolSlot()
+	return e.ApplyFunction(fn, args)
+}
+
 // taskMap: (Task<T>, (T) -> U) -> Task<U>
 func builtinTaskMap(e *Evaluator, args ...Object) Object {
 	if len(args) != 2 {
@@ -671,7 +838,7 @@ func builtinTaskMap(e *Evaluator, args ...Object) Object {
 		fn = e.CaptureHandler(fn)
 	}
 
-	newTask := &Task{done: make(chan struct{})}
+	newTask := NewTaskFrom(e)
 
 	// Use Fork if available (creates isolated VM), otherwise Clone (tree-walk)
 	var evalClone *Evaluator
@@ -680,11 +847,15 @@ func builtinTaskMap(e *Evaluator, args ...Object) Object {
 	} else {
 		evalClone = e.Clone()
 	}
+	newTask.Bind(evalClone)
 
 	go func() {
+		defer newTask.release()
 		defer close(newTask.done)
 
-		<-task.done
+		if !newTask.waitFor(task) {
+			return
+		}
 
 		task.mu.Lock()
 		if task.err != "" {
@@ -714,7 +885,7 @@ func builtinTaskMap(e *Evaluator, args ...Object) Object {
 				mapped = taskObj // Should be Error
 			}
 		} else {
-			mapped = evalClone.ApplyFunction(fn, []Object{result})
+			mapped = applyInTask(evalClone, fn, []Object{result})
 		}
 
 		newTask.mu.Lock()
@@ -747,7 +918,7 @@ func builtinTaskFlatMap(e *Evaluator, args ...Object) Object {
 		fn = e.CaptureHandler(fn)
 	}
 
-	newTask := &Task{done: make(chan struct{})}
+	newTask := NewTaskFrom(e)
 
 	// Use Fork if available (creates isolated VM), otherwise Clone (tree-walk)
 	var evalClone *Evaluator
@@ -756,11 +927,15 @@ func builtinTaskFlatMap(e *Evaluator, args ...Object) Object {
 	} else {
 		evalClone = e.Clone()
 	}
+	newTask.Bind(evalClone)
 
 	go func() {
+		defer newTask.release()
 		defer close(newTask.done)
 
-		<-task.done
+		if !newTask.waitFor(task) {
+			return
+		}
 
 		task.mu.Lock()
 		if task.err != "" {
@@ -790,7 +965,7 @@ func builtinTaskFlatMap(e *Evaluator, args ...Object) Object {
 				innerTaskObj = tObj
 			}
 		} else {
-			innerTaskObj = evalClone.ApplyFunction(fn, []Object{result})
+			innerTaskObj = applyInTask(evalClone, fn, []Object{result})
 		}
 
 		if isError(innerTaskObj) {
@@ -842,7 +1017,7 @@ func builtinTaskCatch(e *Evaluator, args ...Object) Object {
 		fn = e.CaptureHandler(fn)
 	}
 
-	newTask := &Task{done: make(chan struct{})}
+	newTask := NewTaskFrom(e)
 
 	// Use Fork if available (creates isolated VM), otherwise Clone (tree-walk)
 	var evalClone *Evaluator
@@ -851,11 +1026,15 @@ func builtinTaskCatch(e *Evaluator, args ...Object) Object {
 	} else {
 		evalClone = e.Clone()
 	}
+	newTask.Bind(evalClone)
 
 	go func() {
+		defer newTask.release()
 		defer close(newTask.done)
 
-		<-task.done
+		if !newTask.waitFor(task) {
+			return
+		}
 
 		task.mu.Lock()
 		if task.err == "" {
@@ -887,7 +1066,7 @@ func builtinTaskCatch(e *Evaluator, args ...Object) Object {
 				recovered = tObj
 			}
 		} else {
-			recovered = evalClone.ApplyFunction(fn, []Object{errList})
+			recovered = applyInTask(evalClone, fn, []Object{errList})
 		}
 
 		newTask.mu.Lock()
@@ -970,6 +1149,7 @@ func SetTaskBuiltinTypes(builtins map[string]*Builtin) {
 		"taskCancel":      typesystem.TFunc{Params: []typesystem.Type{taskT}, ReturnType: typesystem.Nil},
 		"taskIsDone":      typesystem.TFunc{Params: []typesystem.Type{taskT}, ReturnType: typesystem.Bool},
 		"taskIsCancelled": typesystem.TFunc{Params: []typesystem.Type{taskT}, ReturnType: typesystem.Bool},
+		"taskScope":       typesystem.TFunc{Params: []typesystem.Type{fnVoidT}, ReturnType: resultStringT},
 
 		// Pool
 		"taskSetGlobalPool": typesystem.TFunc{Params: []typesystem.Type{typesystem.Int}, ReturnType: typesystem.Nil},
@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"context"
 	"github.com/funvibe/funxy/internal/typesystem"
 	"time"
 )
@@ -56,7 +57,9 @@ func builtinSleep(e *Evaluator, args ...Object) Object {
 	if seconds.Value < 0 {
 		return newError("sleep: duration cannot be negative")
 	}
-	time.Sleep(time.Duration(seconds.Value) * time.Second)
+	if !sleepContext(e.taskContext(), time.Duration(seconds.Value)*time.Second) {
+		return cancelledError()
+	}
 	return &Nil{}
 }
 
@@ -73,10 +76,24 @@ func builtinSleepMs(e *Evaluator, args ...Object) Object {
 	if ms.Value < 0 {
 		return newError("sleepMs: duration cannot be negative")
 	}
-	time.Sleep(time.Duration(ms.Value) * time.Millisecond)
+	if !sleepContext(e.taskContext(), time.Duration(ms.Value)*time.Millisecond) {
+		return cancelledError()
+	}
 	return &Nil{}
 }
 
+// sleepContext pauses for d, returning false if ctx is cancelled first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // SetTimeBuiltinTypes sets type info for time builtins
 func SetTimeBuiltinTypes(builtins map[string]*Builtin) {
 	types := map[string]typesystem.Type{
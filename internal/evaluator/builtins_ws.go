@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"bufio"
+	"context"
 	"crypto/rand"
 	"crypto/sha1"
 	"encoding/base64"
@@ -433,8 +434,14 @@ func builtinWsRecv(e *Evaluator, args ...Object) Object {
 	// Clear deadline for blocking read
 	_ = conn.conn.SetReadDeadline(time.Time{})
 
+	ctx := e.taskContext()
+	stop := interruptReadOnCancel(ctx, conn.conn)
 	message, err := wsReadMessage(conn)
+	stop()
 	if err != nil {
+		if ctx.Err() != nil {
+			return cancelledError()
+		}
 		return makeFailStr(err.Error())
 	}
 
@@ -469,8 +476,14 @@ func builtinWsRecvTimeout(e *Evaluator, args ...Object) Object {
 	// Set read deadline
 	_ = conn.conn.SetReadDeadline(time.Now().Add(time.Duration(timeoutMs.Value) * time.Millisecond))
 
+	ctx := e.taskContext()
+	stop := interruptReadOnCancel(ctx, conn.conn)
 	message, err := wsReadMessage(conn)
+	stop()
 	if err != nil {
+		if ctx.Err() != nil {
+			return cancelledError()
+		}
 		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 			// Timeout -> return Ok(Zero)
 			return makeOk(makeZero())
@@ -482,6 +495,14 @@ func builtinWsRecvTimeout(e *Evaluator, args ...Object) Object {
 	return makeOk(makeSome(stringToList(message)))
 }
 
+// interruptReadOnCancel unblocks a pending read on conn when ctx is
+// cancelled. Call the returned function once the read is over.
+func interruptReadOnCancel(ctx context.Context, conn net.Conn) func() bool {
+	return context.AfterFunc(ctx, func() {
+		_ = conn.SetReadDeadline(time.Now())
+	})
+}
+
 // wsReadMessage reads a complete WebSocket message using funbit
 func wsReadMessage(ws *wsConnection) (string, error) {
 	ws.closeMu.Lock()
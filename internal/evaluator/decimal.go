@@ -0,0 +1,306 @@
+package evaluator
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// Fixed-point decimals. A Decimal is Unscaled / 10^Scale; the scale is kept
+// through + - * so 1.10d + 2.05d prints as 3.15 and money never passes
+// through float64. Both backends share this code.
+
+// Decimal is a value of the Decimal type. Scale is never negative.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+func (d *Decimal) Type() ObjectType             { return DECIMAL_OBJ }
+func (d *Decimal) RuntimeType() typesystem.Type { return typesystem.Decimal }
+func (d *Decimal) Inspect() string              { return formatDecimal(d) }
+
+// Hash ignores trailing zeros so that 1.0d and 1.00d, which are equal,
+// hash alike.
+func (d *Decimal) Hash() uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(formatDecimal(stripDecimal(d, 0))))
+	return h.Sum32()
+}
+
+// NewDecimalLiteral builds the value of a literal such as 12.34d.
+func NewDecimalLiteral(lit *ast.DecimalLiteral) *Decimal {
+	return &Decimal{Unscaled: lit.Unscaled, Scale: lit.Scale}
+}
+
+// Rounding modes accepted by decimalRound and decimalDiv
+const (
+	roundHalfEven = "half-even"
+	roundHalfUp   = "half-up"
+	roundHalfDown = "half-down"
+	roundUp       = "up"
+	roundDown     = "down"
+	roundCeiling  = "ceiling"
+	roundFloor    = "floor"
+)
+
+func isRoundingMode(mode string) bool {
+	switch mode {
+	case roundHalfEven, roundHalfUp, roundHalfDown, roundUp, roundDown, roundCeiling, roundFloor:
+		return true
+	}
+	return false
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// unscaledAt returns the unscaled value of d at a scale >= d.Scale.
+func unscaledAt(d *Decimal, scale int) *big.Int {
+	if scale == d.Scale {
+		return d.Unscaled
+	}
+	return new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale))
+}
+
+// alignDecimals brings a and b to the larger of their scales.
+func alignDecimals(a, b *Decimal) (x, y *big.Int, scale int) {
+	scale = max(a.Scale, b.Scale)
+	return unscaledAt(a, scale), unscaledAt(b, scale), scale
+}
+
+// roundQuotient rounds the truncated quotient q of a division with
+// remainder r by den. negative is the sign of the exact result.
+func roundQuotient(q, r, den *big.Int, negative bool, mode string) *big.Int {
+	if r.Sign() == 0 {
+		return q
+	}
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	half := twice.Cmp(new(big.Int).Abs(den))
+
+	var away bool
+	switch mode {
+	case roundUp:
+		away = true
+	case roundDown:
+		away = false
+	case roundCeiling:
+		away = !negative
+	case roundFloor:
+		away = negative
+	case roundHalfUp:
+		away = half >= 0
+	case roundHalfDown:
+		away = half > 0
+	default: // half-even
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	}
+	if !away {
+		return q
+	}
+	if negative {
+		return new(big.Int).Sub(q, big.NewInt(1))
+	}
+	return new(big.Int).Add(q, big.NewInt(1))
+}
+
+// roundDecimal rescales d. Raising the scale is exact; lowering it rounds.
+func roundDecimal(d *Decimal, scale int, mode string) *Decimal {
+	if scale >= d.Scale {
+		return &Decimal{Unscaled: unscaledAt(d, scale), Scale: scale}
+	}
+	den := pow10(d.Scale - scale)
+	q, r := new(big.Int).QuoRem(d.Unscaled, den, new(big.Int))
+	return &Decimal{Unscaled: roundQuotient(q, r, den, d.Unscaled.Sign() < 0, mode), Scale: scale}
+}
+
+// divideDecimals computes a / b rounded to scale. ok is false when b is zero.
+func divideDecimals(a, b *Decimal, scale int, mode string) (*Decimal, bool) {
+	if b.Unscaled.Sign() == 0 {
+		return nil, false
+	}
+	// a/b = a.U * 10^(b.S + scale) / (b.U * 10^a.S) at the requested scale
+	num := new(big.Int).Mul(a.Unscaled, pow10(b.Scale+scale))
+	den := new(big.Int).Mul(b.Unscaled, pow10(a.Scale))
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	negative := num.Sign()*den.Sign() < 0
+	return &Decimal{Unscaled: roundQuotient(q, r, den, negative, mode), Scale: scale}, true
+}
+
+// stripDecimal drops trailing zeros from the fraction, keeping at least
+// minScale digits.
+func stripDecimal(d *Decimal, minScale int) *Decimal {
+	u, scale := new(big.Int).Set(d.Unscaled), d.Scale
+	ten, rem := big.NewInt(10), new(big.Int)
+	for scale > minScale {
+		q, r := new(big.Int).QuoRem(u, ten, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		u, scale = q, scale-1
+	}
+	return &Decimal{Unscaled: u, Scale: scale}
+}
+
+// CompareDecimals orders two decimals by value, regardless of scale.
+func CompareDecimals(a, b *Decimal) int {
+	x, y, _ := alignDecimals(a, b)
+	return x.Cmp(y)
+}
+
+// NegateDecimal implements prefix '-'.
+func NegateDecimal(d *Decimal) *Decimal {
+	return &Decimal{Unscaled: new(big.Int).Neg(d.Unscaled), Scale: d.Scale}
+}
+
+// DecimalInfix evaluates a binary operator on two decimals. Division
+// rounds half-even to config.DecimalDivisionScale digits, then drops the
+// trailing zeros beyond the scales of the operands.
+func DecimalInfix(operator string, left, right Object) Object {
+	l, ok1 := left.(*Decimal)
+	r, ok2 := right.(*Decimal)
+	if !ok1 || !ok2 {
+		return newError("type mismatch: %s %s %s", left.RuntimeType(), operator, right.RuntimeType())
+	}
+
+	switch operator {
+	case "+", "-", "%":
+		x, y, scale := alignDecimals(l, r)
+		result := new(big.Int)
+		switch operator {
+		case "+":
+			result.Add(x, y)
+		case "-":
+			result.Sub(x, y)
+		case "%":
+			if y.Sign() == 0 {
+				return newError("modulo by zero")
+			}
+			result.Rem(x, y)
+		}
+		return &Decimal{Unscaled: result, Scale: scale}
+	case "*":
+		return &Decimal{Unscaled: new(big.Int).Mul(l.Unscaled, r.Unscaled), Scale: l.Scale + r.Scale}
+	case "/":
+		keep := max(l.Scale, r.Scale)
+		result, ok := divideDecimals(l, r, max(keep, config.DecimalDivisionScale), roundHalfEven)
+		if !ok {
+			return newError("division by zero")
+		}
+		return stripDecimal(result, keep)
+	case "**":
+		exp := stripDecimal(r, 0)
+		if exp.Scale != 0 || exp.Unscaled.Sign() < 0 || !exp.Unscaled.IsInt64() {
+			return newError("Decimal exponent must be a non-negative integer, got %s", r.Inspect())
+		}
+		n := exp.Unscaled.Int64()
+		return &Decimal{Unscaled: new(big.Int).Exp(l.Unscaled, exp.Unscaled, nil), Scale: l.Scale * int(n)}
+	case "==":
+		return boolObject(CompareDecimals(l, r) == 0)
+	case "!=":
+		return boolObject(CompareDecimals(l, r) != 0)
+	case "<":
+		return boolObject(CompareDecimals(l, r) < 0)
+	case "<=":
+		return boolObject(CompareDecimals(l, r) <= 0)
+	case ">":
+		return boolObject(CompareDecimals(l, r) > 0)
+	case ">=":
+		return boolObject(CompareDecimals(l, r) >= 0)
+	}
+	return newError("unknown operator: Decimal %s Decimal", operator)
+}
+
+// formatDecimal writes d in plain notation with exactly Scale fraction digits.
+func formatDecimal(d *Decimal) string {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	if d.Scale > 0 {
+		if len(digits) <= d.Scale {
+			digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+	}
+	if d.Unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// parseDecimal parses plain or exponent notation ("-12.50", "1.5e-3")
+// without going through float64. The scale follows the written digits.
+func parseDecimal(s string) (*Decimal, bool) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, false
+		}
+		mantissa, exp = s[:i], e
+	}
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	intPart, frac, _ := strings.Cut(mantissa, ".")
+	if intPart == "" && frac == "" {
+		return nil, false
+	}
+	for _, c := range intPart + frac {
+		if c < '0' || c > '9' {
+			return nil, false
+		}
+	}
+	u, ok := new(big.Int).SetString(sign+intPart+frac, 10)
+	if !ok {
+		return nil, false
+	}
+	scale := len(frac) - exp
+	if scale < 0 {
+		return &Decimal{Unscaled: u.Mul(u, pow10(-scale)), Scale: 0}, true
+	}
+	return &Decimal{Unscaled: u, Scale: scale}, true
+}
+
+// decimalToRat returns the exact value of d as a fraction.
+func decimalToRat(d *Decimal) *big.Rat {
+	return new(big.Rat).SetFrac(d.Unscaled, pow10(d.Scale))
+}
+
+// decimalFormatter lets sprintf print a Decimal with %f and %.2f without
+// converting it to float64. A precision rounds half-even.
+type decimalFormatter struct{ d *Decimal }
+
+func (f decimalFormatter) Format(s fmt.State, verb rune) {
+	d := f.d
+	if prec, ok := s.Precision(); ok && (verb == 'f' || verb == 'F') {
+		d = roundDecimal(d, prec, roundHalfEven)
+	}
+	text := formatDecimal(d)
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign, text = "-", text[1:]
+	} else if s.Flag('+') {
+		sign = "+"
+	}
+	if w, ok := s.Width(); ok && len(sign)+len(text) < w {
+		pad := w - len(sign) - len(text)
+		switch {
+		case s.Flag('-'):
+			text += strings.Repeat(" ", pad)
+		case s.Flag('0'):
+			text = strings.Repeat("0", pad) + text
+		default:
+			sign = strings.Repeat(" ", pad) + sign
+		}
+	}
+	io.WriteString(s, sign+text)
+}
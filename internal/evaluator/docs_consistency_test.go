@@ -56,7 +56,8 @@ func TestVirtualModulesConsistency(t *testing.T) {
 		"lib/sql", "lib/ws", "lib/date", "lib/rand", "lib/test",
 		"lib/http", "lib/regex", "lib/crypto", "lib/json", "lib/char",
 		"lib/bignum", "lib/tuple", "lib/sys", "lib/io", "lib/bytes",
-		"lib/bits", "lib/map",
+		"lib/bits", "lib/map", "lib/actor", "lib/chan", "lib/ref", "lib/stream",
+		"lib/set", "lib/ordered", "lib/heap", "lib/reflect",
 	}
 
 	for _, pkgPath := range pkgNames {
@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"context"
 	"io"
 	"os"
 	"github.com/funvibe/funxy/internal/ast"
@@ -60,6 +61,9 @@ type Evaluator struct {
 	TypeAliases map[string]typesystem.Type
 	// VMCallHandler is set by VM to allow builtins to call VM closures
 	VMCallHandler VMCallHandler
+	// inVMDispatch is set while a trait method is handed back to the VM, which
+	// falls back to the evaluator when it finds no implementation either
+	inVMDispatch bool
 	// AsyncHandler is a callback for handling async function execution (used by VM)
 	AsyncHandler AsyncHandler
 	// CaptureHandler is a callback for safe capturing of closures for async execution
@@ -67,11 +71,23 @@ type Evaluator struct {
 
 	// Fork creates a thread-safe copy of the evaluator for background execution
 	Fork func() *Evaluator
+
+	// Process this evaluator runs in (lib/actor); nil for the main program
+	Process *Process
+
+	// ctx is cancelled when the task this evaluator runs is cancelled;
+	// blocking builtins observe it. scope is the enclosing taskScope, if any.
+	ctx   context.Context
+	scope *taskScope
+	// poolSlot is set if the goroutine running e holds a slot of the global
+	// task pool (tasks and parallel chunks)
+	poolSlot bool
 }
 
 // ModuleLoader interface (same as in Analyzer, should probably be in a common package)
 type ModuleLoader interface {
 	GetModule(path string) (interface{}, error)
+	ResolveImport(baseDir, importPath string) string
 }
 
 type LoadedModule interface {
@@ -153,6 +169,14 @@ func (e *Evaluator) SetLoader(l ModuleLoader) {
 	e.Loader = l
 }
 
+// taskContext returns the context blocking builtins should give up on
+func (e *Evaluator) taskContext() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
 // Clone creates a copy of the evaluator for use in a goroutine
 // Shares immutable state but creates new mutable state
 func (e *Evaluator) Clone() *Evaluator {
@@ -238,8 +262,12 @@ func (e *Evaluator) evalCore(node ast.Node, env *Environment) Object {
 		return &Float{Value: node.Value}
 	case *ast.BigIntLiteral:
 		return &BigInt{Value: node.Value}
+	case *ast.SizedIntLiteral:
+		return NewSizedIntLiteral(node)
 	case *ast.RationalLiteral:
 		return &Rational{Value: node.Value}
+	case *ast.DecimalLiteral:
+		return NewDecimalLiteral(node)
 	case *ast.BooleanLiteral:
 		return e.nativeBoolToBooleanObject(node.Value)
 	case *ast.NilLiteral:
@@ -250,6 +278,10 @@ func (e *Evaluator) evalCore(node ast.Node, env *Environment) Object {
 		return e.evalListLiteral(node, env)
 	case *ast.MapLiteral:
 		return e.evalMapLiteral(node, env)
+	case *ast.ListComprehension:
+		return e.evalListComprehension(node, env)
+	case *ast.MapComprehension:
+		return e.evalMapComprehension(node, env)
 	case *ast.RecordLiteral:
 		return e.evalRecordLiteral(node, env)
 	case *ast.MemberExpression:
@@ -268,6 +300,8 @@ func (e *Evaluator) evalCore(node ast.Node, env *Environment) Object {
 		return e.evalBytesLiteral(node, env)
 	case *ast.BitsLiteral:
 		return e.evalBitsLiteral(node, env)
+	case *ast.BitsExpression:
+		return e.evalBitsExpression(node, env)
 	case *ast.PrefixExpression:
 		right := e.Eval(node.Right, env)
 		if isError(right) {
@@ -330,7 +364,11 @@ func (e *Evaluator) evalCore(node ast.Node, env *Environment) Object {
 				}
 				if boolVal, ok := isEmpty.(*Boolean); ok && boolVal.Value {
 					// Empty: evaluate and return right (short-circuit)
-					return e.Eval(node.Right, env)
+					right := e.Eval(node.Right, env)
+					if node.PackRight != nil && !isError(right) {
+						right = PackTraitObjects(right, node.PackRight, e.lookupTraitMethods)
+					}
+					return right
 				}
 			}
 
@@ -393,10 +431,16 @@ func (e *Evaluator) evalCore(node ast.Node, env *Environment) Object {
 		if isError(left) {
 			return left
 		}
+		if node.PackLeft != nil {
+			left = PackTraitObjects(left, node.PackLeft, e.lookupTraitMethods)
+		}
 		right := e.Eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
+		if node.PackRight != nil {
+			right = PackTraitObjects(right, node.PackRight, e.lookupTraitMethods)
+		}
 		return e.EvalInfixExpression(node.Operator, left, right)
 	case *ast.PostfixExpression:
 		left := e.Eval(node.Left, env)
@@ -450,7 +494,13 @@ func (e *Evaluator) evalCore(node ast.Node, env *Environment) Object {
 				list.ElementType = elemType
 			}
 		}
+		if node.Pack != nil {
+			val = PackTraitObjects(val, node.Pack, e.lookupTraitMethods)
+		}
 		return val
+	case *ast.DefaultArgument:
+		return DEFAULT_ARGUMENT
+
 	case *ast.SpreadExpression:
 		// SpreadExpression evaluated in isolation just evaluates its inner expression
 		// This allows it to be used, but typically it's handled by evalExpressions contextually.
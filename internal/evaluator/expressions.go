@@ -43,6 +43,81 @@ func (e *Evaluator) evalMapLiteral(node *ast.MapLiteral, env *Environment) Objec
 	return result
 }
 
+func (e *Evaluator) evalListComprehension(node *ast.ListComprehension, env *Environment) Object {
+	var elements []Object
+	if err := e.evalComprehensionClauses(node.Clauses, env, func(scope *Environment) Object {
+		val := e.Eval(node.Output, scope)
+		if isError(val) {
+			return val
+		}
+		elements = append(elements, val)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return newList(elements)
+}
+
+func (e *Evaluator) evalMapComprehension(node *ast.MapComprehension, env *Environment) Object {
+	result := newMap()
+	if err := e.evalComprehensionClauses(node.Clauses, env, func(scope *Environment) Object {
+		key := e.Eval(node.Key, scope)
+		if isError(key) {
+			return key
+		}
+		value := e.Eval(node.Value, scope)
+		if isError(value) {
+			return value
+		}
+		result = result.put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return result
+}
+
+// evalComprehensionClauses runs emit once for every combination of generator
+// items that passes the filters. Items that do not match a generator pattern
+// are skipped. It returns the first error, or nil.
+func (e *Evaluator) evalComprehensionClauses(clauses []*ast.ComprehensionClause, env *Environment, emit func(*Environment) Object) Object {
+	if len(clauses) == 0 {
+		return emit(env)
+	}
+
+	clause := clauses[0]
+	if clause.Pattern == nil {
+		cond := e.Eval(clause.Filter, env)
+		if isError(cond) {
+			return cond
+		}
+		if !e.isTruthy(cond) {
+			return nil
+		}
+		return e.evalComprehensionClauses(clauses[1:], env, emit)
+	}
+
+	iterable := e.Eval(clause.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+	res, _ := e.forEachItem(iterable, env, func(item Object) (Object, bool) {
+		matched, bindings := e.matchPattern(clause.Pattern, item, env)
+		if !matched {
+			return nil, false
+		}
+		scope := NewEnclosedEnvironment(env)
+		for name, val := range bindings {
+			scope.Set(name, val)
+		}
+		if err := e.evalComprehensionClauses(clauses[1:], scope, emit); err != nil {
+			return err, true
+		}
+		return nil, false
+	})
+	return res
+}
+
 func (e *Evaluator) evalRecordLiteral(node *ast.RecordLiteral, env *Environment) Object {
 	fields := make(map[string]Object)
 	var typeName string
@@ -246,12 +321,8 @@ func (e *Evaluator) evalIndexExpression(node *ast.IndexExpression, env *Environm
 }
 
 func (e *Evaluator) evalStringLiteral(node *ast.StringLiteral, env *Environment) Object {
-	var elements []Object
-	for _, r := range node.Value {
-		elements = append(elements, &Char{Value: int64(r)})
-	}
 	// Strings are always List<Char>
-	return newListWithType(elements, "Char")
+	return newStringList(node.Value)
 }
 
 func (e *Evaluator) evalFormatStringLiteral(node *ast.FormatStringLiteral, env *Environment) Object {
@@ -284,7 +355,7 @@ func (e *Evaluator) evalFormatStringLiteral(node *ast.FormatStringLiteral, env *
 }
 
 func (e *Evaluator) evalInterpolatedString(node *ast.InterpolatedString, env *Environment) Object {
-	var result []Object
+	var result strings.Builder
 
 	for _, part := range node.Parts {
 		val := e.Eval(part, env)
@@ -293,22 +364,21 @@ func (e *Evaluator) evalInterpolatedString(node *ast.InterpolatedString, env *En
 		}
 
 		// Convert value to string (List<Char>)
-		chars := e.objectToChars(val)
-		result = append(result, chars...)
+		result.WriteString(e.objectToText(val))
 	}
 
-	return newListWithType(result, "Char")
+	return newStringList(result.String())
 }
 
-// objectToChars converts any object to its string representation as []Object of Char
-func (e *Evaluator) objectToChars(obj Object) []Object {
+// objectToText converts any object to its string representation
+func (e *Evaluator) objectToText(obj Object) string {
 	var str string
 
 	switch o := obj.(type) {
 	case *List:
 		// If it's already a string (List<Char>), extract it
 		if o.ElementType == "Char" {
-			return o.ToSlice()
+			return ListToString(o)
 		}
 		// Otherwise use Inspect
 		str = o.Inspect()
@@ -324,11 +394,7 @@ func (e *Evaluator) objectToChars(obj Object) []Object {
 		str = obj.Inspect()
 	}
 
-	var result []Object
-	for _, r := range str {
-		result = append(result, &Char{Value: int64(r)})
-	}
-	return result
+	return str
 }
 
 func (e *Evaluator) evalCharLiteral(node *ast.CharLiteral, env *Environment) Object {
@@ -399,6 +465,29 @@ func (e *Evaluator) evalBitsLiteral(node *ast.BitsLiteral, env *Environment) Obj
 	}
 }
 
+func (e *Evaluator) evalBitsExpression(node *ast.BitsExpression, env *Environment) Object {
+	values := make([]Object, len(node.Segments))
+	sizes := make([]Object, len(node.Segments))
+	for i, seg := range node.Segments {
+		values[i] = e.Eval(seg.Value, env)
+		if isError(values[i]) {
+			return values[i]
+		}
+		if seg.Size != nil {
+			sizes[i] = e.Eval(seg.Size, env)
+			if isError(sizes[i]) {
+				return sizes[i]
+			}
+		}
+	}
+	bits, err := BuildBits(LowerBitsSegments(node.Segments), values, sizes)
+	if err != nil {
+		tok := node.GetToken()
+		return newErrorWithLocation(tok.Line, tok.Column, "binary construction: %s", err.Error())
+	}
+	return bits
+}
+
 func (e *Evaluator) evalIfExpression(ie *ast.IfExpression, env *Environment) Object {
 	condition := e.Eval(ie.Condition, env)
 	if isError(condition) {
@@ -477,6 +566,12 @@ func (e *Evaluator) matchPattern(pat ast.Pattern, val Object, env *Environment)
 				return intVal.Value == litVal, bindings
 			}
 		}
+		if sizedVal, ok := val.(*SizedInt); ok {
+			if lit, ok := p.Value.(*ast.SizedIntLiteral); ok {
+				litVal := NewSizedIntLiteral(lit)
+				return sizedVal.Kind == litVal.Kind && sizedVal.Value == litVal.Value, bindings
+			}
+		}
 		if boolVal, ok := val.(*Boolean); ok {
 			if litVal, ok := p.Value.(bool); ok {
 				return boolVal.Value == litVal, bindings
@@ -527,7 +622,33 @@ func (e *Evaluator) matchPattern(pat ast.Pattern, val Object, env *Environment)
 		}
 		return true, bindings
 
+	case *ast.BitsPattern:
+		segments := LowerBitsSegments(p.Segments)
+		outer := make(map[string]int64)
+		for _, name := range BitsFreeNames(segments) {
+			if n, ok := env.Get(name); ok {
+				if i, ok := n.(*Integer); ok {
+					outer[name] = i.Value
+				}
+			}
+		}
+		captures, matched := MatchBitsPattern(segments, val, outer)
+		if !matched {
+			return false, bindings
+		}
+		i := 0
+		for _, seg := range segments {
+			if seg.Bind != "" {
+				bindings[seg.Bind] = captures[i]
+				i++
+			}
+		}
+		return true, bindings
+
 	case *ast.ConstructorPattern:
+		if p.Newtype {
+			return e.matchPattern(p.Elements[0], val, env)
+		}
 		dataVal, ok := val.(*DataInstance)
 		if !ok {
 			return false, bindings
@@ -795,6 +916,12 @@ func (e *Evaluator) matchesType(val Object, astType ast.Type) bool {
 		case "Rational":
 			_, ok := val.(*Rational)
 			return ok
+		case "Decimal":
+			_, ok := val.(*Decimal)
+			return ok
+		case "Int8", "Int16", "Int32", "UInt8", "UInt16", "UInt32", "UInt64":
+			s, ok := val.(*SizedInt)
+			return ok && s.Kind == typeName
 		case "List":
 			_, ok := val.(*List)
 			return ok
@@ -913,6 +1040,10 @@ func (e *Evaluator) evalAssignExpression(node *ast.AssignExpression, env *Enviro
 		}
 	}
 
+	if node.Pack != nil {
+		val = PackTraitObjects(val, node.Pack, e.lookupTraitMethods)
+	}
+
 	if ident, ok := node.Left.(*ast.Identifier); ok {
 		if !env.Update(ident.Value, val) {
 			env.Set(ident.Value, val)
@@ -933,6 +1064,15 @@ func (e *Evaluator) evalAssignExpression(node *ast.AssignExpression, env *Enviro
 	return newError("invalid assignment target")
 }
 
+// attachCallSchema appends the expected result type the analyzer resolved
+// for builtins decoding into it (jsonDecode)
+func attachCallSchema(node *ast.CallExpression, args []Object) []Object {
+	if node.Schema == nil || len(args) == 0 {
+		return args
+	}
+	return append(args, &TypeObject{TypeVal: node.Schema.Type, Schema: node.Schema})
+}
+
 func (e *Evaluator) evalCallExpression(node *ast.CallExpression, env *Environment) Object {
 	// Special handling for default() to avoid init cycle
 	if ident, ok := node.Function.(*ast.Identifier); ok && ident.Value == "default" {
@@ -955,10 +1095,12 @@ func (e *Evaluator) evalCallExpression(node *ast.CallExpression, env *Environmen
 		if isError(function) {
 			return function
 		}
-		args := e.evalExpressions(node.Arguments, env)
+		args := e.evalCallArguments(node, env)
 		if len(args) == 1 && isError(args[0]) {
 			return args[0]
 		}
+		e.packCallArgs(node, args)
+		args = attachCallSchema(node, args)
 		tc := &TailCall{Func: function, Args: args}
 		if tok := node.GetToken(); tok.Type != "" {
 			tc.Line = tok.Line
@@ -974,10 +1116,12 @@ func (e *Evaluator) evalCallExpression(node *ast.CallExpression, env *Environmen
 	if isError(function) {
 		return function
 	}
-	args := e.evalExpressions(node.Arguments, env)
+	args := e.evalCallArguments(node, env)
 	if len(args) == 1 && isError(args[0]) {
 		return args[0]
 	}
+	e.packCallArgs(node, args)
+	args = attachCallSchema(node, args)
 
 	// Push call frame with call site info (where the call is made from)
 	funcName := getFunctionName(function)
@@ -1059,9 +1203,16 @@ func (e *Evaluator) evalPrefixExpression(operator string, right Object) Object {
 		} else if right.Type() == RATIONAL_OBJ {
 			value := right.(*Rational).Value
 			return &Rational{Value: new(big.Rat).Neg(value)}
+		} else if right.Type() == SIZED_INT_OBJ {
+			return NegateSizedInt(right.(*SizedInt))
+		} else if right.Type() == DECIMAL_OBJ {
+			return NegateDecimal(right.(*Decimal))
 		}
 		return newError("unknown operator: %s%s", operator, right.Type())
 	case "~":
+		if right.Type() == SIZED_INT_OBJ {
+			return ComplementSizedInt(right.(*SizedInt))
+		}
 		if right.Type() != INTEGER_OBJ {
 			return newError("unknown operator: %s%s", operator, right.Type())
 		}
@@ -1120,6 +1271,12 @@ func (e *Evaluator) EvalInfixExpression(operator string, left, right Object) Obj
 	if left.Type() == RATIONAL_OBJ && right.Type() == RATIONAL_OBJ {
 		return e.evalRationalInfixExpression(operator, left, right)
 	}
+	if left.Type() == SIZED_INT_OBJ {
+		return SizedIntInfix(operator, left, right)
+	}
+	if left.Type() == DECIMAL_OBJ {
+		return DecimalInfix(operator, left, right)
+	}
 	if left.Type() == BOOLEAN_OBJ && right.Type() == BOOLEAN_OBJ {
 		return e.evalBooleanInfixExpression(operator, left, right)
 	}
@@ -1623,6 +1780,20 @@ func (e *Evaluator) compareObjects(left, right Object) int {
 		}
 	}
 
+	// Compare sized integers
+	if leftInt, ok := left.(*SizedInt); ok {
+		if rightInt, ok := right.(*SizedInt); ok {
+			return CompareSizedInts(leftInt, rightInt)
+		}
+	}
+
+	// Compare decimals by value
+	if leftDec, ok := left.(*Decimal); ok {
+		if rightDec, ok := right.(*Decimal); ok {
+			return CompareDecimals(leftDec, rightDec)
+		}
+	}
+
 	// Compare strings (lists of chars)
 	if leftList, ok := left.(*List); ok {
 		if rightList, ok := right.(*List); ok {
@@ -31,6 +31,7 @@ func extractListElementType(t ast.Type) string {
 // Helper for method table
 type MethodTable struct {
 	Methods map[string]Object
+	Native  bool // Standard instance of a builtin type, not declared in the program
 }
 
 func (mt *MethodTable) Type() ObjectType             { return "METHOD_TABLE" }
@@ -44,6 +45,8 @@ func (mt *MethodTable) Hash() uint32 {
 var (
 	TRUE  = &Boolean{Value: true}
 	FALSE = &Boolean{Value: false}
+
+	DEFAULT_ARGUMENT = &DefaultArgument{}
 )
 
 // getTypeName returns a human-readable type name for debugging
@@ -87,8 +90,12 @@ func getTypeName(obj Object) string {
 		return v.TypeName
 	case *BigInt:
 		return "BigInt"
+	case *SizedInt:
+		return v.Kind
 	case *Rational:
 		return "Rational"
+	case *Decimal:
+		return "Decimal"
 	case *Bytes:
 		return "Bytes"
 	case *Bits:
@@ -219,8 +226,12 @@ func getRuntimeTypeName(obj Object) string {
 		return RUNTIME_TYPE_FLOAT
 	case *BigInt:
 		return RUNTIME_TYPE_BIGINT
+	case *SizedInt:
+		return o.Kind
 	case *Rational:
 		return RUNTIME_TYPE_RATIONAL
+	case *Decimal:
+		return "Decimal"
 	case *Boolean:
 		return RUNTIME_TYPE_BOOL
 	case *Char:
@@ -246,6 +257,8 @@ func getRuntimeTypeName(obj Object) string {
 			return o.TypeName[dotIndex+1:]
 		}
 		return o.TypeName
+	case Collection:
+		return o.TypeName()
 	default:
 		return string(obj.Type())
 	}
@@ -285,8 +298,12 @@ func (e *Evaluator) areObjectsEqual(a, b Object) bool {
 		return a.Value == b.(*Float).Value
 	case *BigInt:
 		return a.Value.Cmp(b.(*BigInt).Value) == 0
+	case *SizedInt:
+		return *a == *b.(*SizedInt)
 	case *Rational:
 		return a.Value.Cmp(b.(*Rational).Value) == 0
+	case *Decimal:
+		return CompareDecimals(a, b.(*Decimal)) == 0
 	case *Boolean:
 		return a.Value == b.(*Boolean).Value
 	case *Char:
@@ -355,6 +372,24 @@ func (e *Evaluator) areObjectsEqual(a, b Object) bool {
 		return a.equals(b.(*Map), e)
 	case *Uuid:
 		return a.Value == b.(*Uuid).Value
+	case *Process:
+		return a == b
+	case *Channel:
+		return a == b
+	case *Ref:
+		return a == b
+	case *Stream:
+		return a == b
+	case *Set:
+		return a.equals(b.(*Set))
+	case *OrderedMap:
+		return a.equals(b.(*OrderedMap), e.areObjectsEqual)
+	case *OrderedSet:
+		return a.equals(b.(*OrderedSet), e.areObjectsEqual)
+	case *Heap:
+		return a.equals(b.(*Heap), e.areObjectsEqual)
+	case *Dynamic:
+		return e.areObjectsEqual(a.Value, b.(*Dynamic).Value)
 	}
 	return false
 }
@@ -396,6 +431,27 @@ func (e *Evaluator) evalExpressions(exps []ast.Expression, env *Environment) []O
 	return result
 }
 
+// evalCallArguments evaluates the arguments of a call. Named arguments are
+// stored in parameter order but evaluated in the order they were written.
+func (e *Evaluator) evalCallArguments(node *ast.CallExpression, env *Environment) []Object {
+	if node.ArgOrder == nil {
+		return e.evalExpressions(node.Arguments, env)
+	}
+	values := make([][]Object, len(node.Arguments))
+	for _, i := range node.ArgOrder {
+		vals := e.evalExpressions(node.Arguments[i:i+1], env)
+		if len(vals) == 1 && isError(vals[0]) {
+			return vals
+		}
+		values[i] = vals
+	}
+	var result []Object
+	for _, vals := range values {
+		result = append(result, vals...)
+	}
+	return result
+}
+
 // ApplyFunction applies a function to arguments (exported for VM)
 func (e *Evaluator) ApplyFunction(fn Object, args []Object) Object {
 	switch fn := fn.(type) {
@@ -443,7 +499,7 @@ func (e *Evaluator) ApplyFunction(fn Object, args []Object) Object {
 			if param.IsIgnored {
 				continue
 			}
-			if i < len(args) {
+			if i < len(args) && !isDefaultArgument(args[i]) {
 				extendedEnv.Set(param.Name.Value, args[i])
 			} else if param.Default != nil {
 				defaultVal := e.Eval(param.Default, fn.Env)
@@ -523,7 +579,7 @@ func (e *Evaluator) ApplyFunction(fn Object, args []Object) Object {
 						if param.IsIgnored {
 							continue
 						}
-						if i < len(nextArgs) {
+						if i < len(nextArgs) && !isDefaultArgument(nextArgs[i]) {
 							nextEnv.Set(param.Name.Value, nextArgs[i])
 						} else if param.Default != nil {
 							defaultVal := e.Eval(param.Default, fn.Env)
@@ -616,6 +672,7 @@ func (e *Evaluator) ApplyFunction(fn Object, args []Object) Object {
 						args = append(args, fn.DefaultArgs[defaultStartIdx:]...)
 					}
 				}
+				args = FillDefaultArguments(args, fn.DefaultArgs, totalParams)
 			}
 		}
 		return fn.Fn(e, args...)
@@ -649,21 +706,30 @@ func (e *Evaluator) ApplyFunction(fn Object, args []Object) Object {
 				RemainingParams: fn.Arity - len(args),
 			}
 		}
+		if fn.Newtype {
+			return args[0]
+		}
 		// Note: TypeArgs is not inferred from fields - that would incorrectly treat
 		// constructor arguments (e.g., Circle Int) as type parameters.
 		// TypeArgs should only be set when the type is actually generic.
 		return &DataInstance{Name: fn.Name, Fields: args, TypeName: fn.TypeName}
 	case *TypeObject:
-		var typeArgs []typesystem.Type
+		var typeArgs []*TypeObject
 		for _, arg := range args {
 			if tArg, ok := arg.(*TypeObject); ok {
-				typeArgs = append(typeArgs, tArg.TypeVal)
+				typeArgs = append(typeArgs, tArg)
 			} else {
 				return newError("type application expects types as arguments, got %s", arg.Type())
 			}
 		}
-		return &TypeObject{TypeVal: typesystem.TApp{Constructor: fn.TypeVal, Args: typeArgs}}
+		return ApplyTypeObject(fn, typeArgs)
 	case *ClassMethod:
+		// Trait objects carry the implementation they were packed with
+		args, captured := UnpackTraitObjects(fn.ClassName, fn.Name, args)
+		if captured != nil {
+			return e.ApplyFunction(captured, args)
+		}
+
 		// Try to find implementation by checking each argument
 		// This supports HKT where the type constructor might not be in the first argument
 		// (e.g., Functor.fmap(f, fa) where fa: F<A> is the second argument)
@@ -759,6 +825,16 @@ func (e *Evaluator) ApplyFunction(fn Object, args []Object) Object {
 			dispatchTypeName = "unknown"
 		}
 
+		// On the VM backend, trait defaults are compiled by the VM on first use
+		if e.VMCallHandler != nil && !e.inVMDispatch {
+			e.inVMDispatch = true
+			result := e.VMCallHandler(fn, args)
+			e.inVMDispatch = false
+			if result != nil {
+				return result
+			}
+		}
+
 		// Fallback to trait default implementation
 		if e.TraitDefaults != nil {
 			key := fn.ClassName + "." + fn.Name
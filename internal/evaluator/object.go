@@ -39,6 +39,7 @@ const (
 	MAP_OBJ             = "MAP"             // Immutable hash map
 	BYTES_OBJ           = "BYTES"           // Byte sequence
 	BITS_OBJ            = "BITS"            // Bit sequence
+	SIZED_INT_OBJ       = "SIZED_INT"       // Int8 ... UInt64
 
 	// Runtime Type Names (Canonical)
 	RUNTIME_TYPE_INT    = "Int"
@@ -56,6 +57,7 @@ const (
 	TAIL_CALL_OBJ       = "TAIL_CALL"    // New for TCO
 	BIG_INT_OBJ         = "BIG_INT"
 	RATIONAL_OBJ        = "RATIONAL"
+	DECIMAL_OBJ         = "DECIMAL"
 	COMPOSED_FUNC_OBJ       = "COMPOSED_FUNC"
 	PARTIAL_APPLICATION_OBJ = "PARTIAL_APPLICATION"
 )
@@ -161,6 +163,42 @@ func (n *Nil) Inspect() string         { return "Nil" }
 func (n *Nil) RuntimeType() typesystem.Type { return typesystem.TCon{Name: "Nil"} }
 func (n *Nil) Hash() uint32            { return 0 }
 
+// DefaultArgument is passed for a parameter skipped by named arguments
+// (ast.DefaultArgument); the callee binds the parameter's default instead.
+type DefaultArgument struct{}
+
+func (d *DefaultArgument) Type() ObjectType             { return "DEFAULT_ARGUMENT" }
+func (d *DefaultArgument) Inspect() string              { return "_" }
+func (d *DefaultArgument) RuntimeType() typesystem.Type { return typesystem.TCon{Name: "Nil"} }
+func (d *DefaultArgument) Hash() uint32                 { return 0 }
+
+func isDefaultArgument(obj Object) bool {
+	_, ok := obj.(*DefaultArgument)
+	return ok
+}
+
+// FillDefaultArguments replaces the DefaultArguments among the arguments of
+// a builtin with its defaults, which cover the last len(defaults) of
+// totalParams parameters.
+func FillDefaultArguments(args, defaults []Object, totalParams int) []Object {
+	var filled []Object
+	defaultStart := totalParams - len(defaults)
+	for i, arg := range args {
+		if !isDefaultArgument(arg) || i < defaultStart || i >= totalParams {
+			continue
+		}
+		if filled == nil {
+			filled = make([]Object, len(args))
+			copy(filled, args)
+		}
+		filled[i] = defaults[i-defaultStart]
+	}
+	if filled == nil {
+		return args
+	}
+	return filled
+}
+
 // Error
 type Error struct {
 	Message    string
@@ -282,6 +320,20 @@ func (f *Function) Hash() uint32 {
 	return uint32(uintptr(unsafe.Pointer(f)))
 }
 
+// ParamNames returns the names of the parameters in declaration order
+func (f *Function) ParamNames() []string {
+	names := make([]string, len(f.Parameters))
+	for i, p := range f.Parameters {
+		names[i] = p.Name.Value
+	}
+	return names
+}
+
+// IsVariadic reports whether the last parameter collects the remaining arguments
+func (f *Function) IsVariadic() bool {
+	return len(f.Parameters) > 0 && f.Parameters[len(f.Parameters)-1].IsVariadic
+}
+
 // OperatorFunction represents an operator used as a function, e.g., (+)
 type OperatorFunction struct {
 	Operator  string
@@ -471,10 +523,12 @@ func (d *DataInstance) Hash() uint32 {
 }
 
 // Constructor represents a function that creates a DataInstance.
+// A newtype constructor returns its argument unchanged.
 type Constructor struct {
 	Name     string
 	TypeName string
-	Arity    int // Number of expected arguments
+	Arity    int  // Number of expected arguments
+	Newtype  bool // Constructor of a newtype: the value is not wrapped
 }
 
 func (c *Constructor) Type() ObjectType            { return CONSTRUCTOR_OBJ }
@@ -529,6 +583,35 @@ func (t *Tuple) Hash() uint32 {
 // TypeObject represents a runtime type value.
 type TypeObject struct {
 	TypeVal typesystem.Type
+	Schema  *typesystem.Schema // Definitions behind TypeVal, when the analyzer resolved them
+}
+
+// ApplyTypeObject applies a generic type to type arguments, e.g. List(User).
+// The result's schema combines the definitions of the type and its arguments.
+func ApplyTypeObject(fn *TypeObject, args []*TypeObject) *TypeObject {
+	typeArgs := make([]typesystem.Type, len(args))
+	schemaArgs := make([]typesystem.Type, len(args))
+	var schema *typesystem.Schema
+	for i, arg := range args {
+		typeArgs[i] = arg.TypeVal
+		schemaArgs[i] = arg.TypeVal
+		if arg.Schema != nil {
+			schemaArgs[i] = arg.Schema.Type
+			schema = schema.Merge(arg.Schema)
+		}
+	}
+	result := &TypeObject{TypeVal: typesystem.TApp{Constructor: fn.TypeVal, Args: typeArgs}}
+	if schema == nil && fn.Schema == nil {
+		return result
+	}
+	con := fn.TypeVal
+	if fn.Schema != nil {
+		con = fn.Schema.Type
+		schema = schema.Merge(fn.Schema)
+	}
+	schema.Type = typesystem.TApp{Constructor: con, Args: schemaArgs}
+	result.Schema = schema
+	return result
 }
 
 func (t *TypeObject) Type() ObjectType          { return TYPE_OBJ }
@@ -549,15 +632,22 @@ func (c *Char) Hash() uint32              { return uint32(c.Value) }
 // List represents a homogeneous (in principle, though runtime allows heterogenous) immutable collection.
 // It uses a hybrid representation:
 // - If vector is non-nil, it relies on PersistentVector (O(1) append, O(1) index).
-// - If vector is nil, it acts as a Cons list (head/tail) (O(1) prepend).
+// - If text is non-nil, it is a String backed by UTF-8 text (see text.go).
+// - Otherwise, it acts as a Cons list (head/tail) (O(1) prepend).
 type List struct {
 	vector      *PersistentVector
+	text        *text
 	head        Object
 	tail        *List
 	length      int    // Cached length for Cons lists (vector tracks its own length)
 	ElementType string // Optional: declared element type
 }
 
+// isCons reports whether the list is a Cons cell (head/tail)
+func (l *List) isCons() bool {
+	return l.vector == nil && l.text == nil
+}
+
 // newList creates a new List from a slice of Objects (internal)
 func newList(elements []Object) *List {
 	v := VectorFrom(elements)
@@ -583,6 +673,9 @@ func (l *List) len() int {
 	if l.vector != nil {
 	return l.vector.Len()
 	}
+	if l.text != nil {
+		return l.text.length
+	}
 	return l.length
 }
 
@@ -600,11 +693,14 @@ func (l *List) get(i int) Object {
 	if l.vector != nil {
 	return l.vector.Get(i)
 	}
+	if l.text != nil {
+		return &Char{Value: int64(l.text.runeAt(i))}
+	}
 
 	// Traversal for Cons
 	curr := l
 	idx := i
-	for curr != nil && curr.vector == nil {
+	for curr != nil && curr.isCons() {
 		if idx == 0 {
 			return curr.head
 		}
@@ -615,8 +711,8 @@ func (l *List) get(i int) Object {
 	if curr == nil {
 		return nil
 	}
-	// We reached a vector part of the list
-	return curr.vector.Get(idx)
+	// We reached a vector or text part of the list
+	return curr.get(idx)
 }
 
 // Get returns the element at index i (exported for VM)
@@ -645,15 +741,18 @@ func (l *List) ToSlice() []Object {
 	if l.vector != nil {
 	return l.vector.ToSlice()
 	}
+	if l.text != nil {
+		return l.text.chars()
+	}
 
 	result := make([]Object, 0, l.len())
 	curr := l
-	for curr != nil && curr.vector == nil {
+	for curr != nil && curr.isCons() {
 		result = append(result, curr.head)
 		curr = curr.tail
 	}
-	if curr != nil && curr.vector != nil {
-		result = append(result, curr.vector.ToSlice()...)
+	if curr != nil {
+		result = append(result, curr.ToSlice()...)
 	}
 	return result
 }
@@ -672,6 +771,12 @@ func (l *List) Slice(start, end int) *List {
 	if end > length {
 		end = length
 	}
+	if l.text != nil {
+		if start >= end {
+			return newStringList("")
+		}
+		return &List{text: l.text.slice(start, end), ElementType: l.ElementType}
+	}
 	if start >= end {
 		return NewList([]Object{})
 	}
@@ -717,6 +822,25 @@ func (l *List) concat(other *List) *List {
 	return &List{vector: l.vector.Concat(other.vector), ElementType: l.ElementType}
 	}
 
+	// Strings stay text-backed; an empty list on either side is a no-op
+	if l.text != nil && other.text != nil {
+		return newStringList(l.text.s + other.text.s)
+	}
+	if other.len() == 0 && (l.text != nil || other.text != nil) {
+		return l
+	}
+	if l.len() == 0 && (l.text != nil || other.text != nil) {
+		return other
+	}
+	// A String joined with a list of chars is still a String
+	if l.text != nil || other.text != nil {
+		if left, ok := listToGoString(l); ok {
+			if right, ok := listToGoString(other); ok {
+				return newStringList(left + right)
+			}
+		}
+	}
+
 	// Fallback: convert to slice and create new Vector-based list
 	result := make([]Object, 0, l.len()+other.len())
 	result = append(result, l.ToSlice()...)
@@ -724,12 +848,24 @@ func (l *List) concat(other *List) *List {
 	return NewList(result)
 }
 
+// Append returns a new list with val added at the end (exported for VM)
+func (l *List) Append(val Object) *List {
+	if l.vector != nil {
+		return &List{vector: l.vector.Append(val), ElementType: l.ElementType}
+	}
+	return l.concat(newList([]Object{val}))
+}
+
 // Concat concatenates two lists (exported for VM)
 func (l *List) Concat(other *List) *List {
 	return l.concat(other)
 }
 
 func (l *List) Inspect() string {
+	if l.text != nil && l.text.length > 0 {
+		return "\"" + l.text.s + "\""
+	}
+
 	// Heuristic: If all elements are chars, print as string
 	if l.len() > 0 {
 		allChars := true
@@ -782,6 +918,13 @@ func (l *List) RuntimeType() typesystem.Type {
 
 func (l *List) Hash() uint32 {
 	h := uint32(1)
+	if l.text != nil {
+		// Same as hashing the Char elements
+		for _, r := range l.text.s {
+			h = 31*h + uint32(r)
+		}
+		return h
+	}
 	for _, obj := range l.ToSlice() {
 		h = 31*h + obj.Hash()
 	}
@@ -791,15 +934,22 @@ func (l *List) Hash() uint32 {
 // GobEncode implements gob encoding for List
 func (l *List) GobEncode() ([]byte, error) {
 	// Serialize as a simple slice of elements plus element type
-	// This avoids dealing with the complex internal structure
-	elements := l.ToSlice()
+	// This avoids dealing with the complex internal structure.
+	// Strings are stored as their text.
 	gobList := struct {
 		Elements    []Object
 		ElementType string
+		Text        string
+		IsText      bool
 	}{
-		Elements:    elements,
 		ElementType: l.ElementType,
 	}
+	if l.text != nil {
+		gobList.Text = l.text.s
+		gobList.IsText = true
+	} else {
+		gobList.Elements = l.ToSlice()
+	}
 	buf := new(bytes.Buffer)
 	enc := gob.NewEncoder(buf)
 	if err := enc.Encode(gobList); err != nil {
@@ -815,10 +965,16 @@ func (l *List) GobDecode(data []byte) error {
 	var gobList struct {
 		Elements    []Object
 		ElementType string
+		Text        string
+		IsText      bool
 	}
 	if err := dec.Decode(&gobList); err != nil {
 		return err
 	}
+	if gobList.IsText {
+		*l = *newStringList(gobList.Text)
+		return nil
+	}
 	// Reconstruct the list
 	newList := NewList(gobList.Elements)
 	newList.ElementType = gobList.ElementType
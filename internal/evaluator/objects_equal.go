@@ -118,10 +118,18 @@ func ObjectsEqual(a, b Object) bool {
 		if bVal, ok := b.(*BigInt); ok {
 			return aVal.Value.Cmp(bVal.Value) == 0
 		}
+	case *SizedInt:
+		if bVal, ok := b.(*SizedInt); ok {
+			return *aVal == *bVal
+		}
 	case *Rational:
 		if bVal, ok := b.(*Rational); ok {
 			return aVal.Value.Cmp(bVal.Value) == 0
 		}
+	case *Decimal:
+		if bVal, ok := b.(*Decimal); ok {
+			return CompareDecimals(aVal, bVal) == 0
+		}
 	case *Uuid:
 		if bVal, ok := b.(*Uuid); ok {
 			return aVal.Value == bVal.Value
@@ -130,6 +138,26 @@ func ObjectsEqual(a, b Object) bool {
 		if bVal, ok := b.(*TypeObject); ok {
 			return aVal.TypeVal.String() == bVal.TypeVal.String()
 		}
+	case *Set:
+		if bVal, ok := b.(*Set); ok {
+			return aVal.equals(bVal)
+		}
+	case *OrderedMap:
+		if bVal, ok := b.(*OrderedMap); ok {
+			return aVal.equals(bVal, ObjectsEqual)
+		}
+	case *OrderedSet:
+		if bVal, ok := b.(*OrderedSet); ok {
+			return aVal.equals(bVal, ObjectsEqual)
+		}
+	case *Heap:
+		if bVal, ok := b.(*Heap); ok {
+			return aVal.equals(bVal, ObjectsEqual)
+		}
+	case *Dynamic:
+		if bVal, ok := b.(*Dynamic); ok {
+			return ObjectsEqual(aVal.Value, bVal.Value)
+		}
 	}
 
 	return false
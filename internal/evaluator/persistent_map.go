@@ -21,9 +21,13 @@ type PersistentMap struct {
 	count int
 }
 
-// hamtNode is a node in the HAMT
+// hamtNode is a node in the HAMT. Each of the 32 slots selected by the hash
+// bits at the node's level holds either an entry or a child node. Below the
+// last level the hash is exhausted and the node is a plain list of entries
+// whose hashes are equal.
 type hamtNode struct {
-	bitmap   uint32      // which indices are populated
+	bitmap   uint32      // which indices hold entries
+	nodemap  uint32      // which indices hold child nodes
 	entries  []hamtEntry // actual entries (compressed)
 	children []*hamtNode // child nodes for hash collisions at deeper levels
 }
@@ -160,152 +164,172 @@ func (m *PersistentMap) Merge(other *PersistentMap) *PersistentMap {
 // --- hamtNode methods ---
 
 func (n *hamtNode) get(hash uint32, key Object, shift uint) Object {
-	idx := (hash >> shift) & hamtMask
-	bit := uint32(1) << idx
-
-	if n.bitmap&bit == 0 {
-		return nil // not present
+	if shift >= 32 {
+		if i := n.find(hash, key); i >= 0 {
+			return n.entries[i].value
+		}
+		return nil
 	}
 
-	pos := popcount(n.bitmap & (bit - 1))
+	idx := (hash >> shift) & hamtMask
+	bit := uint32(1) << idx
 
-	// Check entries first
-	if pos < len(n.entries) {
-		entry := n.entries[pos]
+	if n.bitmap&bit != 0 {
+		entry := n.entries[popcount(n.bitmap&(bit-1))]
 		if entry.hash == hash && objectsEqualForMap(entry.key, key) {
 			return entry.value
 		}
+		return nil
 	}
 
-	// Check children for deeper traversal
-	childIdx := pos - len(n.entries)
-	if childIdx >= 0 && childIdx < len(n.children) && n.children[childIdx] != nil {
-		return n.children[childIdx].get(hash, key, shift+hamtBits)
+	if n.nodemap&bit != 0 {
+		return n.children[popcount(n.nodemap&(bit-1))].get(hash, key, shift+hamtBits)
 	}
 
-	return nil
+	return nil // not present
 }
 
 func (n *hamtNode) put(hash uint32, key, value Object, shift uint) (*hamtNode, bool) {
-	idx := (hash >> shift) & hamtMask
-	bit := uint32(1) << idx
-
-	// Clone node
-	newNode := &hamtNode{
-		bitmap:   n.bitmap,
-		entries:  make([]hamtEntry, len(n.entries)),
-		children: make([]*hamtNode, len(n.children)),
-	}
-	copy(newNode.entries, n.entries)
-	copy(newNode.children, n.children)
-
-	if n.bitmap&bit == 0 {
-		// New entry
-		newNode.bitmap |= bit
-		pos := popcount(newNode.bitmap & (bit - 1))
-		newEntry := hamtEntry{hash: hash, key: key, value: value}
-
-		// Insert at position
-		newNode.entries = append(newNode.entries, hamtEntry{})
-		copy(newNode.entries[pos+1:], newNode.entries[pos:])
-		newNode.entries[pos] = newEntry
+	newNode := n.clone()
+	newEntry := hamtEntry{hash: hash, key: key, value: value}
 
+	if shift >= 32 {
+		// Hash exhausted - linear search in entries
+		if i := newNode.find(hash, key); i >= 0 {
+			newNode.entries[i] = newEntry
+			return newNode, false
+		}
+		newNode.entries = append(newNode.entries, newEntry)
 		return newNode, true
 	}
 
-	pos := popcount(n.bitmap & (bit - 1))
+	idx := (hash >> shift) & hamtMask
+	bit := uint32(1) << idx
 
-	// Check if updating existing entry
-	if pos < len(newNode.entries) {
-		entry := newNode.entries[pos]
-		if entry.hash == hash && objectsEqualForMap(entry.key, key) {
+	if n.bitmap&bit != 0 {
+		pos := popcount(n.bitmap & (bit - 1))
+		existing := newNode.entries[pos]
+		if existing.hash == hash && objectsEqualForMap(existing.key, key) {
 			// Update existing
-			newNode.entries[pos] = hamtEntry{hash: hash, key: key, value: value}
+			newNode.entries[pos] = newEntry
 			return newNode, false
 		}
 
-		// Hash collision at this level - need to go deeper or create child
-		if shift+hamtBits >= 32 {
-			// Max depth - linear search in entries
-			for i, e := range newNode.entries {
-				if e.hash == hash && objectsEqualForMap(e.key, key) {
-					newNode.entries[i] = hamtEntry{hash: hash, key: key, value: value}
-					return newNode, false
-				}
-			}
-			// Append new entry
-			newNode.entries = append(newNode.entries, hamtEntry{hash: hash, key: key, value: value})
-			return newNode, true
-		}
-
-		// Create child node with existing entry and new entry
-		existingEntry := newNode.entries[pos]
+		// Two keys share this slot - move both into a child node
 		child := &hamtNode{}
-		child, _ = child.put(existingEntry.hash, existingEntry.key, existingEntry.value, shift+hamtBits)
-		child, added := child.put(hash, key, value, shift+hamtBits)
+		child, _ = child.put(existing.hash, existing.key, existing.value, shift+hamtBits)
+		child, _ = child.put(hash, key, value, shift+hamtBits)
 
-		// Remove entry from this level, add child
 		newNode.entries = append(newNode.entries[:pos], newNode.entries[pos+1:]...)
-		newNode.children = append(newNode.children, child)
-
-		return newNode, added
+		newNode.bitmap &^= bit
+		newNode.insertChild(bit, child)
+		return newNode, true
 	}
 
-	// Delegate to child
-	childIdx := pos - len(newNode.entries)
-	if childIdx >= 0 && childIdx < len(newNode.children) && newNode.children[childIdx] != nil {
+	if n.nodemap&bit != 0 {
+		// Delegate to child
+		childIdx := popcount(n.nodemap & (bit - 1))
 		newChild, added := newNode.children[childIdx].put(hash, key, value, shift+hamtBits)
 		newNode.children[childIdx] = newChild
 		return newNode, added
 	}
 
-	// Should not reach here
-	return newNode, false
+	// New entry
+	pos := popcount(n.bitmap & (bit - 1))
+	newNode.entries = append(newNode.entries, hamtEntry{})
+	copy(newNode.entries[pos+1:], newNode.entries[pos:])
+	newNode.entries[pos] = newEntry
+	newNode.bitmap |= bit
+	return newNode, true
 }
 
 func (n *hamtNode) remove(hash uint32, key Object, shift uint) (*hamtNode, bool) {
+	if shift >= 32 {
+		i := n.find(hash, key)
+		if i < 0 {
+			return n, false
+		}
+		newNode := n.clone()
+		newNode.entries = append(newNode.entries[:i], newNode.entries[i+1:]...)
+		return newNode, true
+	}
+
 	idx := (hash >> shift) & hamtMask
 	bit := uint32(1) << idx
 
-	if n.bitmap&bit == 0 {
-		return n, false // not present
+	if n.bitmap&bit != 0 {
+		pos := popcount(n.bitmap & (bit - 1))
+		entry := n.entries[pos]
+		if entry.hash != hash || !objectsEqualForMap(entry.key, key) {
+			return n, false
+		}
+		newNode := n.clone()
+		newNode.entries = append(newNode.entries[:pos], newNode.entries[pos+1:]...)
+		newNode.bitmap &^= bit
+		return newNode, true
 	}
 
-	pos := popcount(n.bitmap & (bit - 1))
+	if n.nodemap&bit != 0 {
+		childIdx := popcount(n.nodemap & (bit - 1))
+		newChild, removed := n.children[childIdx].remove(hash, key, shift+hamtBits)
+		if !removed {
+			return n, false
+		}
+		newNode := n.clone()
+		switch {
+		case len(newChild.entries) == 0 && len(newChild.children) == 0:
+			// Child is empty - drop it
+			newNode.children = append(newNode.children[:childIdx], newNode.children[childIdx+1:]...)
+			newNode.nodemap &^= bit
+		case len(newChild.entries) == 1 && len(newChild.children) == 0:
+			// A single entry left - pull it back up into this slot
+			newNode.children = append(newNode.children[:childIdx], newNode.children[childIdx+1:]...)
+			newNode.nodemap &^= bit
+			pos := popcount(newNode.bitmap & (bit - 1))
+			newNode.entries = append(newNode.entries, hamtEntry{})
+			copy(newNode.entries[pos+1:], newNode.entries[pos:])
+			newNode.entries[pos] = newChild.entries[0]
+			newNode.bitmap |= bit
+		default:
+			newNode.children[childIdx] = newChild
+		}
+		return newNode, true
+	}
 
-	// Clone node
+	return n, false // not present
+}
+
+// clone copies the node so it can be changed without affecting n
+func (n *hamtNode) clone() *hamtNode {
 	newNode := &hamtNode{
 		bitmap:   n.bitmap,
+		nodemap:  n.nodemap,
 		entries:  make([]hamtEntry, len(n.entries)),
 		children: make([]*hamtNode, len(n.children)),
 	}
 	copy(newNode.entries, n.entries)
 	copy(newNode.children, n.children)
+	return newNode
+}
 
-	// Check entries
-	if pos < len(newNode.entries) {
-		entry := newNode.entries[pos]
-		if entry.hash == hash && objectsEqualForMap(entry.key, key) {
-			// Remove entry
-			newNode.entries = append(newNode.entries[:pos], newNode.entries[pos+1:]...)
-			if len(newNode.entries) == 0 && len(newNode.children) == 0 {
-				newNode.bitmap &^= bit
-			}
-			return newNode, true
-		}
-	}
+// insertChild adds child at the slot given by bit, which must be free
+func (n *hamtNode) insertChild(bit uint32, child *hamtNode) {
+	pos := popcount(n.nodemap & (bit - 1))
+	n.children = append(n.children, nil)
+	copy(n.children[pos+1:], n.children[pos:])
+	n.children[pos] = child
+	n.nodemap |= bit
+}
 
-	// Check children
-	childIdx := pos - len(newNode.entries)
-	if childIdx >= 0 && childIdx < len(newNode.children) && newNode.children[childIdx] != nil {
-		newChild, removed := newNode.children[childIdx].remove(hash, key, shift+hamtBits)
-		if removed {
-			newNode.children[childIdx] = newChild
-			return newNode, true
+// find returns the index of key in the entries of a node below the last
+// level, or -1
+func (n *hamtNode) find(hash uint32, key Object) int {
+	for i, e := range n.entries {
+		if e.hash == hash && objectsEqualForMap(e.key, key) {
+			return i
 		}
 	}
-
-	return n, false
+	return -1
 }
 
 func (n *hamtNode) collectKeys(keys *[]Object) {
@@ -350,6 +374,12 @@ func hashObject(obj Object) uint32 {
 
 // objectsEqualForMap checks equality for map keys
 func objectsEqualForMap(a, b Object) bool {
+	// 1.0d and 1.00d print differently but are the same key
+	if x, ok := a.(*Decimal); ok {
+		if y, ok := b.(*Decimal); ok {
+			return CompareDecimals(x, y) == 0
+		}
+	}
 	return a.Inspect() == b.Inspect()
 }
 
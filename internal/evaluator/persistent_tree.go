@@ -0,0 +1,237 @@
+package evaluator
+
+// Persistent AVL tree implementation
+// Backs the ordered maps and sets of lib/ordered and the heaps of lib/heap.
+// Updates copy the path from the root to the changed node and share the rest.
+
+// treeNode is a node of the tree; sets leave value nil
+type treeNode struct {
+	key    Object
+	value  Object
+	left   *treeNode
+	right  *treeNode
+	height int
+	size   int
+}
+
+// compareFunc orders keys: negative if a < b, zero if equal, positive if a > b
+type compareFunc func(a, b Object) int
+
+func treeHeight(n *treeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func treeSize(n *treeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func newTreeNode(key, value Object, left, right *treeNode) *treeNode {
+	height := treeHeight(left)
+	if h := treeHeight(right); h > height {
+		height = h
+	}
+	return &treeNode{
+		key:    key,
+		value:  value,
+		left:   left,
+		right:  right,
+		height: height + 1,
+		size:   treeSize(left) + treeSize(right) + 1,
+	}
+}
+
+// treeBalance builds a node from subtrees whose heights differ by at most 2,
+// rotating when they differ by 2
+func treeBalance(key, value Object, left, right *treeNode) *treeNode {
+	hl, hr := treeHeight(left), treeHeight(right)
+	if hl > hr+1 {
+		if treeHeight(left.left) >= treeHeight(left.right) {
+			return newTreeNode(left.key, left.value, left.left, newTreeNode(key, value, left.right, right))
+		}
+		return newTreeNode(left.right.key, left.right.value,
+			newTreeNode(left.key, left.value, left.left, left.right.left),
+			newTreeNode(key, value, left.right.right, right))
+	}
+	if hr > hl+1 {
+		if treeHeight(right.right) >= treeHeight(right.left) {
+			return newTreeNode(right.key, right.value, newTreeNode(key, value, left, right.left), right.right)
+		}
+		return newTreeNode(right.left.key, right.left.value,
+			newTreeNode(key, value, left, right.left.left),
+			newTreeNode(right.key, right.value, right.left.right, right.right))
+	}
+	return newTreeNode(key, value, left, right)
+}
+
+// treePut returns a tree with key bound to value
+func treePut(n *treeNode, key, value Object, cmp compareFunc) *treeNode {
+	if n == nil {
+		return newTreeNode(key, value, nil, nil)
+	}
+	c := cmp(key, n.key)
+	switch {
+	case c < 0:
+		return treeBalance(n.key, n.value, treePut(n.left, key, value, cmp), n.right)
+	case c > 0:
+		return treeBalance(n.key, n.value, n.left, treePut(n.right, key, value, cmp))
+	default:
+		return newTreeNode(key, value, n.left, n.right)
+	}
+}
+
+// treeGet returns the node holding key, or nil
+func treeGet(n *treeNode, key Object, cmp compareFunc) *treeNode {
+	for n != nil {
+		c := cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// treeRemove returns a tree without key
+func treeRemove(n *treeNode, key Object, cmp compareFunc) *treeNode {
+	if n == nil {
+		return nil
+	}
+	c := cmp(key, n.key)
+	switch {
+	case c < 0:
+		return treeBalance(n.key, n.value, treeRemove(n.left, key, cmp), n.right)
+	case c > 0:
+		return treeBalance(n.key, n.value, n.left, treeRemove(n.right, key, cmp))
+	}
+	if n.left == nil {
+		return n.right
+	}
+	if n.right == nil {
+		return n.left
+	}
+	min := treeMin(n.right)
+	return treeBalance(min.key, min.value, n.left, treeRemoveMin(n.right))
+}
+
+func treeRemoveMin(n *treeNode) *treeNode {
+	if n.left == nil {
+		return n.right
+	}
+	return treeBalance(n.key, n.value, treeRemoveMin(n.left), n.right)
+}
+
+func treeMin(n *treeNode) *treeNode {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func treeMax(n *treeNode) *treeNode {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// treeFloor returns the node with the greatest key <= key, or nil
+func treeFloor(n *treeNode, key Object, cmp compareFunc) *treeNode {
+	var best *treeNode
+	for n != nil {
+		c := cmp(key, n.key)
+		if c == 0 {
+			return n
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			best = n
+			n = n.right
+		}
+	}
+	return best
+}
+
+// treeCeiling returns the node with the least key >= key, or nil
+func treeCeiling(n *treeNode, key Object, cmp compareFunc) *treeNode {
+	var best *treeNode
+	for n != nil {
+		c := cmp(key, n.key)
+		if c == 0 {
+			return n
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			best = n
+			n = n.left
+		}
+	}
+	return best
+}
+
+// treeNodes returns the nodes in key order
+func treeNodes(n *treeNode) []*treeNode {
+	nodes := make([]*treeNode, 0, treeSize(n))
+	var walk func(n *treeNode)
+	walk = func(n *treeNode) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		nodes = append(nodes, n)
+		walk(n.right)
+	}
+	walk(n)
+	return nodes
+}
+
+// treeRange returns the nodes with lo <= key < hi in key order, skipping the
+// subtrees that lie outside the range
+func treeRange(n *treeNode, lo, hi Object, cmp compareFunc) []*treeNode {
+	var nodes []*treeNode
+	var walk func(n *treeNode)
+	walk = func(n *treeNode) {
+		if n == nil {
+			return
+		}
+		aboveLo := cmp(n.key, lo) >= 0
+		belowHi := cmp(n.key, hi) < 0
+		if aboveLo {
+			walk(n.left)
+		}
+		if aboveLo && belowHi {
+			nodes = append(nodes, n)
+		}
+		if belowHi {
+			walk(n.right)
+		}
+	}
+	walk(n)
+	return nodes
+}
+
+// treeFromSorted builds a balanced tree from nodes already in key order
+func treeFromSorted(nodes []*treeNode) *treeNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	return newTreeNode(nodes[mid].key, nodes[mid].value, treeFromSorted(nodes[:mid]), treeFromSorted(nodes[mid+1:]))
+}
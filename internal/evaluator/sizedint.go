@@ -0,0 +1,439 @@
+package evaluator
+
+import (
+	"hash/fnv"
+	"math/big"
+	"strconv"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// Fixed-width integers (Int8 ... UInt64). Arithmetic operators fail with a
+// runtime error on overflow; the checked*, wrapping* and saturating*
+// builtins pick another behaviour explicitly. Both backends share this code.
+
+// SizedInt is a value of one of the sized integer types. Value holds the
+// bits sign-extended (signed types) or zero-extended (unsigned types) to 64.
+type SizedInt struct {
+	Kind  string // Int8, UInt16, ...
+	Value uint64
+}
+
+func (s *SizedInt) Type() ObjectType             { return SIZED_INT_OBJ }
+func (s *SizedInt) RuntimeType() typesystem.Type { return typesystem.TCon{Name: s.Kind} }
+func (s *SizedInt) Inspect() string {
+	if s.info().Signed {
+		return strconv.FormatInt(int64(s.Value), 10)
+	}
+	return strconv.FormatUint(s.Value, 10)
+}
+func (s *SizedInt) Hash() uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s.Kind))
+	return h.Sum32() ^ uint32(s.Value^(s.Value>>32))
+}
+
+func (s *SizedInt) info() *config.IntTypeInfo {
+	return config.GetIntTypeInfo(s.Kind)
+}
+
+// NewSizedIntLiteral builds the value of a literal such as 200u8 or -5i16.
+// The analyzer has checked that it fits.
+func NewSizedIntLiteral(lit *ast.SizedIntLiteral) *SizedInt {
+	if lit.Negative {
+		return &SizedInt{Kind: lit.Type, Value: -lit.Value}
+	}
+	return &SizedInt{Kind: lit.Type, Value: lit.Value}
+}
+
+// Overflow behaviour of sized integer arithmetic
+type overflowMode int
+
+const (
+	overflowChecked overflowMode = iota
+	overflowWrapping
+	overflowSaturating
+)
+
+// integralValue returns the type and exact value of an Int or sized integer.
+func integralValue(obj Object) (*config.IntTypeInfo, *big.Int, bool) {
+	switch v := obj.(type) {
+	case *Integer:
+		return config.GetIntTypeInfo("Int"), big.NewInt(v.Value), true
+	case *SizedInt:
+		info := v.info()
+		if info.Signed {
+			return info, big.NewInt(int64(v.Value)), true
+		}
+		return info, new(big.Int).SetUint64(v.Value), true
+	}
+	return nil, nil, false
+}
+
+func intTypeBounds(info *config.IntTypeInfo) (lo, hi *big.Int) {
+	if info.Signed {
+		hi = new(big.Int).Lsh(big.NewInt(1), uint(info.Bits-1))
+		lo = new(big.Int).Neg(hi)
+		return lo, hi.Sub(hi, big.NewInt(1))
+	}
+	hi = new(big.Int).Lsh(big.NewInt(1), uint(info.Bits))
+	return big.NewInt(0), hi.Sub(hi, big.NewInt(1))
+}
+
+// fitIntegral converts an exact result to the given type. ok is false when
+// the value does not fit and mode is overflowChecked.
+func fitIntegral(info *config.IntTypeInfo, v *big.Int, mode overflowMode) (Object, bool) {
+	lo, hi := intTypeBounds(info)
+	if v.Cmp(lo) < 0 || v.Cmp(hi) > 0 {
+		switch mode {
+		case overflowChecked:
+			return nil, false
+		case overflowSaturating:
+			if v.Sign() < 0 {
+				v = lo
+			} else {
+				v = hi
+			}
+		case overflowWrapping:
+			mask := new(big.Int).Lsh(big.NewInt(1), uint(info.Bits))
+			v = new(big.Int).Mod(v, mask)
+			if v.Cmp(hi) > 0 {
+				v.Sub(v, mask)
+			}
+		}
+	}
+	if info.Suffix == "" {
+		return &Integer{Value: v.Int64()}, true
+	}
+	if info.Signed {
+		return &SizedInt{Kind: info.Name, Value: uint64(v.Int64())}, true
+	}
+	return &SizedInt{Kind: info.Name, Value: v.Uint64()}, true
+}
+
+// integralArith computes op exactly and fits the result into info.
+// divByZero reports division or modulo by zero.
+func integralArith(info *config.IntTypeInfo, op string, x, y *big.Int, mode overflowMode) (result Object, ok, divByZero bool) {
+	r := new(big.Int)
+	switch op {
+	case "+":
+		r.Add(x, y)
+	case "-":
+		r.Sub(x, y)
+	case "*":
+		r.Mul(x, y)
+	case "/", "%":
+		if y.Sign() == 0 {
+			return nil, false, true
+		}
+		// Truncated division, like Int
+		if op == "/" {
+			r.Quo(x, y)
+		} else {
+			r.Rem(x, y)
+		}
+	case "**":
+		if y.Sign() < 0 {
+			return nil, false, true
+		}
+		r = integralPow(info, x, y, mode)
+	}
+	result, ok = fitIntegral(info, r, mode)
+	return result, ok, false
+}
+
+// integralPow raises x to y. Bases other than 0 and ±1 overflow every type
+// before the 64th power, so larger exponents are only computed modulo
+// 2^Bits (wrapping) or replaced by a value out of range.
+func integralPow(info *config.IntTypeInfo, x, y *big.Int, mode overflowMode) *big.Int {
+	if y.Cmp(big.NewInt(64)) <= 0 || x.CmpAbs(big.NewInt(1)) <= 0 {
+		return new(big.Int).Exp(x, y, nil)
+	}
+	if mode == overflowWrapping {
+		mask := new(big.Int).Lsh(big.NewInt(1), uint(info.Bits))
+		return new(big.Int).Exp(new(big.Int).Mod(x, mask), y, mask)
+	}
+	// Out of range, with the sign of the true result
+	out := new(big.Int).Lsh(big.NewInt(1), 64)
+	if x.Sign() < 0 && y.Bit(0) == 1 {
+		out.Neg(out)
+	}
+	return out
+}
+
+// SizedIntInfix evaluates a binary operator on two sized integers of the
+// same type (or a sized integer shifted by an Int). Overflow in arithmetic
+// is a runtime error.
+func SizedIntInfix(operator string, left, right Object) Object {
+	l, ok := left.(*SizedInt)
+	if !ok {
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+	info, x, _ := integralValue(l)
+
+	if operator == "<<" || operator == ">>" {
+		return sizedIntShift(l, operator, right)
+	}
+
+	r, ok := right.(*SizedInt)
+	if !ok || r.Kind != l.Kind {
+		return newError("type mismatch: %s %s %s", l.Kind, operator, right.RuntimeType())
+	}
+	_, y, _ := integralValue(r)
+
+	switch operator {
+	case "+", "-", "*", "/", "%", "**":
+		result, ok, divByZero := integralArith(info, operator, x, y, overflowChecked)
+		if divByZero {
+			switch operator {
+			case "/":
+				return newError("division by zero")
+			case "%":
+				return newError("modulo by zero")
+			default:
+				return newError("negative exponent for %s", info.Name)
+			}
+		}
+		if !ok {
+			return newError("%s overflow: %s %s %s", info.Name, l.Inspect(), operator, r.Inspect())
+		}
+		return result
+	case "&":
+		return &SizedInt{Kind: l.Kind, Value: l.Value & r.Value}
+	case "|":
+		return &SizedInt{Kind: l.Kind, Value: l.Value | r.Value}
+	case "^":
+		return &SizedInt{Kind: l.Kind, Value: l.Value ^ r.Value}
+	case "==":
+		return boolObject(l.Value == r.Value)
+	case "!=":
+		return boolObject(l.Value != r.Value)
+	case "<":
+		return boolObject(CompareSizedInts(l, r) < 0)
+	case "<=":
+		return boolObject(CompareSizedInts(l, r) <= 0)
+	case ">":
+		return boolObject(CompareSizedInts(l, r) > 0)
+	case ">=":
+		return boolObject(CompareSizedInts(l, r) >= 0)
+	}
+	return newError("unknown operator: %s %s %s", l.Kind, operator, r.Kind)
+}
+
+func boolObject(b bool) *Boolean {
+	if b {
+		return TRUE
+	}
+	return FALSE
+}
+
+// sizedIntShift shifts within the width of the type: bits shifted out are
+// dropped, >> is arithmetic for signed types and logical for unsigned ones.
+func sizedIntShift(l *SizedInt, operator string, right Object) Object {
+	_, n, ok := integralValue(right)
+	if !ok {
+		return newError("shift amount must be an integer, got %s", right.Type())
+	}
+	if n.Sign() < 0 {
+		return newError("negative shift amount %s", n)
+	}
+	info := l.info()
+	shift := uint64(info.Bits)
+	if n.IsUint64() && n.Uint64() < shift {
+		shift = n.Uint64()
+	}
+	if operator == "<<" {
+		if shift >= 64 {
+			return wrapSizedInt(info, 0)
+		}
+		return wrapSizedInt(info, l.Value<<shift)
+	}
+	if info.Signed {
+		if shift >= 64 {
+			shift = 63
+		}
+		return &SizedInt{Kind: l.Kind, Value: uint64(int64(l.Value) >> shift)}
+	}
+	if shift >= 64 {
+		return &SizedInt{Kind: l.Kind, Value: 0}
+	}
+	return &SizedInt{Kind: l.Kind, Value: l.Value >> shift}
+}
+
+// wrapSizedInt keeps the low Bits bits of v, sign-extending for signed types.
+func wrapSizedInt(info *config.IntTypeInfo, v uint64) *SizedInt {
+	if info.Bits < 64 {
+		v &= uint64(1)<<info.Bits - 1
+		if info.Signed && v>>(info.Bits-1) == 1 {
+			v |= ^uint64(0) << info.Bits
+		}
+	}
+	return &SizedInt{Kind: info.Name, Value: v}
+}
+
+// CompareSizedInts orders two sized integers of the same type.
+func CompareSizedInts(a, b *SizedInt) int {
+	if a.info().Signed {
+		x, y := int64(a.Value), int64(b.Value)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a.Value < b.Value:
+		return -1
+	case a.Value > b.Value:
+		return 1
+	}
+	return 0
+}
+
+// NegateSizedInt implements prefix '-', which overflows for unsigned types
+// and for the minimum of a signed type.
+func NegateSizedInt(s *SizedInt) Object {
+	info, x, _ := integralValue(s)
+	result, ok := fitIntegral(info, x.Neg(x), overflowChecked)
+	if !ok {
+		return newError("%s overflow: -%s", info.Name, s.Inspect())
+	}
+	return result
+}
+
+// ComplementSizedInt implements prefix '~'.
+func ComplementSizedInt(s *SizedInt) Object {
+	return wrapSizedInt(s.info(), ^s.Value)
+}
+
+// IntegerBuiltins returns the prelude functions for converting between
+// integer types and for arithmetic with an explicit overflow behaviour.
+func IntegerBuiltins() map[string]*Builtin {
+	builtins := map[string]*Builtin{
+		"intCast":     {Name: "intCast", Fn: convertIntegralBuiltin("intCast", overflowChecked)},
+		"intWrap":     {Name: "intWrap", Fn: convertIntegralBuiltin("intWrap", overflowWrapping)},
+		"intSaturate": {Name: "intSaturate", Fn: convertIntegralBuiltin("intSaturate", overflowSaturating)},
+	}
+	modes := []struct {
+		prefix string
+		mode   overflowMode
+	}{
+		{"checked", overflowChecked},
+		{"wrapping", overflowWrapping},
+		{"saturating", overflowSaturating},
+	}
+	ops := []struct{ name, op string }{{"Add", "+"}, {"Sub", "-"}, {"Mul", "*"}, {"Div", "/"}}
+	for _, m := range modes {
+		for _, op := range ops {
+			if op.op == "/" && m.mode != overflowChecked {
+				continue
+			}
+			name := m.prefix + op.name
+			builtins[name] = &Builtin{Name: name, Fn: integralArithBuiltin(name, op.op, m.mode)}
+		}
+	}
+	SetIntegerBuiltinTypes(builtins)
+	return builtins
+}
+
+// SetIntegerBuiltinTypes sets type info for the integer builtins
+func SetIntegerBuiltinTypes(builtins map[string]*Builtin) {
+	t := typesystem.TVar{Name: "T"}
+	a := typesystem.TVar{Name: "A"}
+	integral := func(names ...string) []typesystem.Constraint {
+		cs := make([]typesystem.Constraint, len(names))
+		for i, n := range names {
+			cs[i] = typesystem.Constraint{TypeVar: n, Trait: config.IntegralTraitName}
+		}
+		return cs
+	}
+	optionT := typesystem.TApp{Constructor: typesystem.TCon{Name: config.OptionTypeName}, Args: []typesystem.Type{t}}
+
+	for name, b := range builtins {
+		switch name {
+		case "intCast":
+			b.TypeInfo = typesystem.TFunc{Params: []typesystem.Type{typesystem.TType{Type: t}, a}, ReturnType: optionT, Constraints: integral("T", "A")}
+		case "intWrap", "intSaturate":
+			b.TypeInfo = typesystem.TFunc{Params: []typesystem.Type{typesystem.TType{Type: t}, a}, ReturnType: t, Constraints: integral("T", "A")}
+		case "checkedAdd", "checkedSub", "checkedMul", "checkedDiv":
+			b.TypeInfo = typesystem.TFunc{Params: []typesystem.Type{t, t}, ReturnType: optionT, Constraints: integral("T")}
+		default:
+			b.TypeInfo = typesystem.TFunc{Params: []typesystem.Type{t, t}, ReturnType: t, Constraints: integral("T")}
+		}
+	}
+}
+
+// convertIntegralBuiltin: (Type<T>, A) -> T (Option<T> for intCast)
+func convertIntegralBuiltin(name string, mode overflowMode) func(e *Evaluator, args ...Object) Object {
+	return func(e *Evaluator, args ...Object) Object {
+		if len(args) != 2 {
+			return newError("%s expects 2 arguments, got %d", name, len(args))
+		}
+		typeObj, ok := args[0].(*TypeObject)
+		if !ok {
+			return newError("%s expects a type as first argument, got %s", name, args[0].Type())
+		}
+		con, _ := typeObj.TypeVal.(typesystem.TCon)
+		target := config.GetIntTypeInfo(con.Name)
+		if target == nil {
+			return newError("%s expects an integer type, got %s", name, typeObj.TypeVal)
+		}
+		_, v, ok := integralValue(args[1])
+		if !ok {
+			return newError("%s expects an integer as second argument, got %s", name, args[1].Type())
+		}
+		result, ok := fitIntegral(target, v, mode)
+		if mode != overflowChecked {
+			return result
+		}
+		if !ok {
+			return makeZero()
+		}
+		return makeSome(result)
+	}
+}
+
+// integralArithBuiltin: (T, T) -> T (Option<T> when checked)
+func integralArithBuiltin(name, op string, mode overflowMode) func(e *Evaluator, args ...Object) Object {
+	return func(e *Evaluator, args ...Object) Object {
+		if len(args) != 2 {
+			return newError("%s expects 2 arguments, got %d", name, len(args))
+		}
+		info, x, ok := integralValue(args[0])
+		if !ok {
+			return newError("%s expects integers, got %s", name, args[0].Type())
+		}
+		yInfo, y, ok := integralValue(args[1])
+		if !ok || yInfo != info {
+			return newError("%s expects two %s, got %s", name, info.Name, args[1].RuntimeType())
+		}
+		result, ok, divByZero := integralArith(info, op, x, y, mode)
+		if mode == overflowChecked {
+			if !ok || divByZero {
+				return makeZero()
+			}
+			return makeSome(result)
+		}
+		if divByZero {
+			return newError("division by zero")
+		}
+		return result
+	}
+}
+
+// parseSizedInt parses a decimal string for read(s, Int8) and friends.
+func parseSizedInt(s string, info *config.IntTypeInfo) Object {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return makeZero()
+	}
+	result, ok := fitIntegral(info, v, overflowChecked)
+	if !ok {
+		return makeZero()
+	}
+	return makeSome(result)
+}
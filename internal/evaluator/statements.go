@@ -29,7 +29,7 @@ func (e *Evaluator) evalImportStatement(node *ast.ImportStatement, env *Environm
 	}
 
 	importPath := node.Path.Value
-	pathToCheck := utils.ResolveImportPath(e.BaseDir, importPath)
+	pathToCheck := e.Loader.ResolveImport(e.BaseDir, importPath)
 
 	modInterface, err := e.Loader.GetModule(pathToCheck)
 	if err != nil {
@@ -267,6 +267,30 @@ func GetVirtualModuleBuiltins(name string) map[string]Object {
 	case "json":
 		RegisterJsonBuiltins(env)
 		return env.GetStore()
+	case "actor":
+		RegisterActorBuiltins(env)
+		return env.GetStore()
+	case "chan":
+		RegisterChanBuiltins(env)
+		return env.GetStore()
+	case "ref":
+		RegisterRefBuiltins(env)
+		return env.GetStore()
+	case "stream":
+		RegisterStreamBuiltins(env)
+		return env.GetStore()
+	case "set":
+		RegisterSetBuiltins(env)
+		return env.GetStore()
+	case "ordered":
+		RegisterOrderedBuiltins(env)
+		return env.GetStore()
+	case "heap":
+		RegisterHeapBuiltins(env)
+		return env.GetStore()
+	case "reflect":
+		RegisterReflectBuiltins(env)
+		return env.GetStore()
 	case "crypto":
 		builtins = CryptoBuiltins()
 		SetCryptoBuiltinTypes(builtins)
@@ -604,7 +628,7 @@ func (e *Evaluator) evalBlockStatement(block *ast.BlockStatement, env *Environme
 
 func (e *Evaluator) evalTypeDeclaration(node *ast.TypeDeclarationStatement, env *Environment) Object {
 	tCon := typesystem.TCon{Name: node.Name.Value}
-	env.Set(node.Name.Value, &TypeObject{TypeVal: tCon})
+	env.Set(node.Name.Value, &TypeObject{TypeVal: tCon, Schema: node.Schema})
 
 	if node.IsAlias {
 		// For type aliases, store TCon with the alias name (not the expanded type)
@@ -615,15 +639,61 @@ func (e *Evaluator) evalTypeDeclaration(node *ast.TypeDeclarationStatement, env
 		}
 		underlyingType := analyzer.BuildType(node.TargetType, nil, nil)
 		e.TypeAliases[node.Name.Value] = underlyingType
-		return &Nil{}
+		return e.registerDerivedInstances(node, env)
+	}
+
+	if node.IsNewtype {
+		// default() of a newtype is the default of the wrapped type
+		if e.TypeAliases == nil {
+			e.TypeAliases = make(map[string]typesystem.Type)
+		}
+		e.TypeAliases[node.Name.Value] = analyzer.BuildType(node.TargetType, nil, nil)
 	}
 
 	for _, c := range node.Constructors {
 		if len(c.Parameters) == 0 {
 			env.Set(c.Name.Value, &DataInstance{Name: c.Name.Value, Fields: []Object{}, TypeName: node.Name.Value})
 		} else {
-			env.Set(c.Name.Value, &Constructor{Name: c.Name.Value, TypeName: node.Name.Value, Arity: len(c.Parameters)})
+			env.Set(c.Name.Value, &Constructor{Name: c.Name.Value, TypeName: node.Name.Value, Arity: len(c.Parameters), Newtype: node.IsNewtype})
+		}
+	}
+	return e.registerDerivedInstances(node, env)
+}
+
+// registerDerivedInstances registers the instances named in a type's deriving
+// clause. Instances synthesized by the analyzer are evaluated like user ones;
+// Show and Json are structural and registered directly.
+func (e *Evaluator) registerDerivedInstances(node *ast.TypeDeclarationStatement, env *Environment) Object {
+	if node.IsNewtype {
+		// Newtype values use the instances of the wrapped type
+		return &Nil{}
+	}
+	for _, inst := range node.Derived {
+		if res := e.evalInstanceDeclaration(inst, env); isError(res) {
+			return res
+		}
+	}
+
+	typeName := node.Name.Value
+	for _, trait := range node.Deriving {
+		methods := make(map[string]Object)
+		switch trait.Value {
+		case "Show":
+			methods["show"] = newGenericShow()
+		case "Json":
+			// jsonDecode rebuilds values of this type from their constructors
+			for _, c := range node.Constructors {
+				if ctor, ok := env.Get(c.Name.Value); ok {
+					methods[c.Name.Value] = ctor
+				}
+			}
+		default:
+			continue
 		}
+		if _, ok := e.ClassImplementations[trait.Value]; !ok {
+			e.ClassImplementations[trait.Value] = make(map[string]Object)
+		}
+		e.ClassImplementations[trait.Value][typeName] = &MethodTable{Methods: methods}
 	}
 	return &Nil{}
 }
@@ -817,6 +887,66 @@ func (e *Evaluator) evalExtensionMethod(node *ast.FunctionStatement, env *Enviro
 	return &Nil{}
 }
 
+// forEachItem iterates over a List or an Iter instance, calling fn for each item.
+// Iteration stops early when fn returns true; the result is then fn's object, or
+// an error object if the iteration protocol itself failed.
+func (e *Evaluator) forEachItem(iterable Object, env *Environment, fn func(item Object) (Object, bool)) (Object, bool) {
+	var iteratorFn Object
+
+	// Look up iter method from Iter trait implementation for this type
+	iterableTypeName := getRuntimeTypeName(iterable)
+	if iterMethod, found := e.lookupTraitMethod(config.IterTraitName, iterableTypeName, config.IterMethodName); found {
+		res := e.ApplyFunction(iterMethod, []Object{iterable})
+		if !isError(res) {
+			iteratorFn = res
+		}
+	}
+
+	// Fallback: try direct environment lookup (for backward compatibility)
+	if iteratorFn == nil {
+		if iterSym, ok := env.Get(config.IterMethodName); ok {
+			res := e.ApplyFunction(iterSym, []Object{iterable})
+			if !isError(res) {
+				iteratorFn = res
+			}
+		}
+	}
+
+	if iteratorFn != nil {
+		for {
+			stepRes := e.ApplyFunction(iteratorFn, []Object{})
+			if isError(stepRes) {
+				return stepRes, true
+			}
+
+			if data, ok := stepRes.(*DataInstance); ok && data.TypeName == config.OptionTypeName {
+				if data.Name == config.SomeCtorName {
+					if res, stop := fn(data.Fields[0]); stop {
+						return res, true
+					}
+				} else if data.Name == config.ZeroCtorName {
+					return nil, false
+				} else {
+					return newError("iterator returned unexpected Option variant: %s", data.Name), true
+				}
+			} else {
+				return newError("iterator must return Option, got %s", stepRes.Type()), true
+			}
+		}
+	}
+
+	list, ok := iterable.(*List)
+	if !ok {
+		return newError("iterable must be List or implement Iter trait, got %s", iterable.Type()), true
+	}
+	for _, item := range list.ToSlice() {
+		if res, stop := fn(item); stop {
+			return res, true
+		}
+	}
+	return nil, false
+}
+
 func (e *Evaluator) evalForExpression(node *ast.ForExpression, env *Environment) Object {
 	loopEnv := NewEnclosedEnvironment(env)
 
@@ -850,87 +980,20 @@ func (e *Evaluator) evalForExpression(node *ast.ForExpression, env *Environment)
 			return iterable
 		}
 
-		var iteratorFn Object
-
-		// Look up iter method from Iter trait implementation for this type
-		iterableTypeName := getRuntimeTypeName(iterable)
-		if iterMethod, found := e.lookupTraitMethod(config.IterTraitName, iterableTypeName, config.IterMethodName); found {
-			res := e.ApplyFunction(iterMethod, []Object{iterable})
-			if !isError(res) {
-				iteratorFn = res
-			}
-		}
-
-		// Fallback: try direct environment lookup (for backward compatibility)
-		if iteratorFn == nil {
-			if iterSym, ok := env.Get(config.IterMethodName); ok {
-				res := e.ApplyFunction(iterSym, []Object{iterable})
-				if !isError(res) {
-					iteratorFn = res
-				}
-			}
-		}
+		itemName := node.ItemName.Value
+		if res, stopped := e.forEachItem(iterable, env, func(item Object) (Object, bool) {
+			loopEnv.Set(itemName, item)
 
-		if iteratorFn != nil {
-			itemName := node.ItemName.Value
-
-			for {
-				stepRes := e.ApplyFunction(iteratorFn, []Object{})
-				if isError(stepRes) {
-					return stepRes
-				}
-
-				if data, ok := stepRes.(*DataInstance); ok && data.TypeName == config.OptionTypeName {
-					if data.Name == config.SomeCtorName {
-						val := data.Fields[0]
-						loopEnv.Set(itemName, val)
-
-						res, shouldBreak := runBody()
-						if shouldBreak {
-							if isError(res) || res.Type() == RETURN_VALUE_OBJ {
-								return res
-							}
-							return res
-						}
-						if res != nil {
-							lastResult = res
-						}
-					} else if data.Name == config.ZeroCtorName {
-						break
-					} else {
-						return newError("iterator returned unexpected Option variant: %s", data.Name)
-					}
-				} else {
-					return newError("iterator must return Option, got %s", stepRes.Type())
-				}
-			}
-
-		} else {
-			var items []Object
-			if list, ok := iterable.(*List); ok {
-				items = list.ToSlice()
-			} else if str, ok := iterable.(*List); ok {
-				items = str.ToSlice()
-			} else {
-				return newError("iterable must be List or implement Iter trait, got %s", iterable.Type())
+			res, shouldBreak := runBody()
+			if shouldBreak {
+				return res, true
 			}
-
-			itemName := node.ItemName.Value
-
-			for _, item := range items {
-				loopEnv.Set(itemName, item)
-
-				res, shouldBreak := runBody()
-				if shouldBreak {
-					if isError(res) || res.Type() == RETURN_VALUE_OBJ {
-						return res
-					}
-					return res
-				}
-				if res != nil {
-					lastResult = res
-				}
+			if res != nil {
+				lastResult = res
 			}
+			return nil, false
+		}); stopped {
+			return res
 		}
 
 	} else {
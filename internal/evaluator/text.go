@@ -0,0 +1,83 @@
+package evaluator
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// text is the representation of String values (List<Char> at the type level).
+// Keeping the UTF-8 bytes avoids boxing every character: conversion from and to
+// Go strings is O(1), length is O(1), and concatenation is a single copy.
+// Characters are only materialized when a String is used as a generic list.
+type text struct {
+	s      string
+	length int  // Number of chars (runes)
+	ascii  bool // Char index == byte index
+
+	once  sync.Once
+	runes []rune // Decoded lazily for random access into non-ASCII text
+}
+
+func newText(s string) *text {
+	n := utf8.RuneCountInString(s)
+	return &text{s: s, length: n, ascii: n == len(s)}
+}
+
+// newStringList creates a String from a Go string
+func newStringList(s string) *List {
+	return &List{text: newText(s), ElementType: "Char"}
+}
+
+func (t *text) decode() []rune {
+	t.once.Do(func() {
+		t.runes = []rune(t.s)
+	})
+	return t.runes
+}
+
+// runeAt returns the i-th char (0 <= i < length)
+func (t *text) runeAt(i int) rune {
+	if t.ascii {
+		return rune(t.s[i])
+	}
+	return t.decode()[i]
+}
+
+// slice returns chars [start, end) (0 <= start < end <= length)
+func (t *text) slice(start, end int) *text {
+	if t.ascii {
+		return &text{s: t.s[start:end], length: end - start, ascii: true}
+	}
+	// Find byte offsets without decoding the whole text
+	byteStart, byteEnd := len(t.s), len(t.s)
+	idx := 0
+	for offset := range t.s {
+		if idx == start {
+			byteStart = offset
+		}
+		if idx == end {
+			byteEnd = offset
+			break
+		}
+		idx++
+	}
+	return newText(t.s[byteStart:byteEnd])
+}
+
+// chars materializes the text as Char objects
+func (t *text) chars() []Object {
+	result := make([]Object, 0, t.length)
+	for _, r := range t.s {
+		result = append(result, &Char{Value: int64(r)})
+	}
+	return result
+}
+
+// AsString returns the Go string for a String-backed list without copying.
+// ok is false for lists that are not text-backed (use ListToString for those).
+func (l *List) AsString() (s string, ok bool) {
+	if l.text != nil {
+		return l.text.s, true
+	}
+	return "", false
+}
@@ -0,0 +1,74 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+// charList builds the element-wise representation of s for comparison
+func charList(s string) *List {
+	var chars []Object
+	for _, r := range s {
+		chars = append(chars, &Char{Value: int64(r)})
+	}
+	return newListWithType(chars, "Char")
+}
+
+// TestTextListMatchesCharList checks that text-backed strings behave like
+// lists of Char for every List operation.
+func TestTextListMatchesCharList(t *testing.T) {
+	for _, s := range []string{"", "a", "hello", "héllo wörld", "日本語テキスト", "a😀b"} {
+		text := newStringList(s)
+		chars := charList(s)
+
+		if text.Len() != chars.Len() {
+			t.Errorf("%q: Len = %d, want %d", s, text.Len(), chars.Len())
+		}
+		if !ObjectsEqual(text, chars) {
+			t.Errorf("%q: text and char lists are not equal", s)
+		}
+		if text.Hash() != chars.Hash() {
+			t.Errorf("%q: Hash = %d, want %d", s, text.Hash(), chars.Hash())
+		}
+		if len(s) > 0 && text.Inspect() != chars.Inspect() {
+			t.Errorf("%q: Inspect = %s, want %s", s, text.Inspect(), chars.Inspect())
+		}
+		for i := 0; i < chars.Len(); i++ {
+			if !ObjectsEqual(text.Get(i), chars.Get(i)) {
+				t.Errorf("%q: Get(%d) = %s, want %s", s, i, text.Get(i).Inspect(), chars.Get(i).Inspect())
+			}
+		}
+		for start := 0; start <= chars.Len(); start++ {
+			for end := start; end <= chars.Len(); end++ {
+				got, want := text.Slice(start, end), chars.Slice(start, end)
+				if !ObjectsEqual(got, want) {
+					t.Errorf("%q: Slice(%d, %d) = %s, want %s", s, start, end, got.Inspect(), want.Inspect())
+				}
+			}
+		}
+
+		if got := ListToString(text.Concat(newStringList("!"))); got != s+"!" {
+			t.Errorf("%q: Concat = %q", s, got)
+		}
+		if got := ListToString(text.Prepend(&Char{Value: '>'})); got != ">"+s {
+			t.Errorf("%q: Prepend = %q", s, got)
+		}
+		if got := ListToString(chars); got != s {
+			t.Errorf("%q: ListToString(char list) = %q", s, got)
+		}
+	}
+}
+
+func TestTextListGob(t *testing.T) {
+	original := newStringList("héllo")
+	data, err := original.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded List
+	if err := decoded.GobDecode(data); err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := decoded.AsString(); !ok || s != "héllo" {
+		t.Errorf("decoded = %q (text-backed: %v), want \"héllo\"", s, ok)
+	}
+}
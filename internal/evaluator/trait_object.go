@@ -0,0 +1,146 @@
+package evaluator
+
+import (
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// TraitObject is a value packed with the implementation of a trait for its
+// type (a value of type dyn Trait). Calls to the trait's methods go through
+// the captured methods instead of looking the value's type up again.
+type TraitObject struct {
+	Value   Object
+	Trait   string
+	Methods map[string]Object // Methods of the instance; nil if only defaults apply
+}
+
+func (t *TraitObject) Type() ObjectType { return "TRAIT_OBJECT" }
+
+// Inspect shows the packed value, so trait objects print like what they hold
+func (t *TraitObject) Inspect() string { return t.Value.Inspect() }
+func (t *TraitObject) RuntimeType() typesystem.Type {
+	return typesystem.TDyn(t.Trait)
+}
+func (t *TraitObject) Hash() uint32 { return t.Value.Hash() }
+
+// MethodLookup finds the methods of trait's instance for the type of v.
+type MethodLookup func(trait string, v Object) map[string]Object
+
+// PackTraitObjects packs the parts of v that t declares as dyn Trait into
+// trait objects. Elements of lists, Option, tuples and record fields are
+// packed where their types say so, and so are Map values; anything else is
+// returned unchanged.
+func PackTraitObjects(v Object, t typesystem.Type, lookup MethodLookup) Object {
+	if trait, ok := typesystem.DynTrait(t); ok {
+		if obj, ok := v.(*TraitObject); ok {
+			if obj.Trait == trait {
+				return obj
+			}
+			v = obj.Value
+		}
+		return &TraitObject{Value: v, Trait: trait, Methods: lookup(trait, v)}
+	}
+
+	switch t := t.(type) {
+	case typesystem.TApp:
+		tCon, ok := t.Constructor.(typesystem.TCon)
+		if !ok || len(t.Args) == 0 {
+			return v
+		}
+		switch tCon.Name {
+		case config.ListTypeName:
+			if list, ok := v.(*List); ok {
+				items := list.ToSlice()
+				packed := make([]Object, len(items))
+				for i, item := range items {
+					packed[i] = PackTraitObjects(item, t.Args[0], lookup)
+				}
+				return newListWithType(packed, list.ElementType)
+			}
+		case config.MapTypeName:
+			if m, ok := v.(*Map); ok && len(t.Args) == 2 {
+				packed := m
+				for _, item := range m.hamt.Items() {
+					packed = packed.put(item.Key, PackTraitObjects(item.Value, t.Args[1], lookup))
+				}
+				return packed
+			}
+		case config.OptionTypeName:
+			if data, ok := v.(*DataInstance); ok && data.Name == config.SomeCtorName && len(data.Fields) == 1 {
+				return &DataInstance{
+					Name:     data.Name,
+					Fields:   []Object{PackTraitObjects(data.Fields[0], t.Args[0], lookup)},
+					TypeName: data.TypeName,
+					TypeArgs: data.TypeArgs,
+				}
+			}
+		}
+	case typesystem.TTuple:
+		if tuple, ok := v.(*Tuple); ok && len(tuple.Elements) == len(t.Elements) {
+			packed := make([]Object, len(tuple.Elements))
+			for i, el := range tuple.Elements {
+				packed[i] = PackTraitObjects(el, t.Elements[i], lookup)
+			}
+			return &Tuple{Elements: packed}
+		}
+	case typesystem.TRecord:
+		if rec, ok := v.(*RecordInstance); ok {
+			fields := make([]RecordField, len(rec.Fields))
+			for i, f := range rec.Fields {
+				fields[i] = f
+				if ft, ok := t.Fields[f.Key]; ok {
+					fields[i].Value = PackTraitObjects(f.Value, ft, lookup)
+				}
+			}
+			return &RecordInstance{Fields: fields, TypeName: rec.TypeName}
+		}
+	}
+	return v
+}
+
+// UnpackTraitObjects prepares a call of a trait method: trait objects among
+// args are replaced by the values they hold, and if one of them was packed
+// for the method's trait, its captured implementation is returned.
+func UnpackTraitObjects(className, method string, args []Object) ([]Object, Object) {
+	var unpacked []Object
+	var captured Object
+	for i, arg := range args {
+		obj, ok := arg.(*TraitObject)
+		if !ok {
+			continue
+		}
+		if unpacked == nil {
+			unpacked = make([]Object, len(args))
+			copy(unpacked, args)
+		}
+		unpacked[i] = obj.Value
+		if captured == nil && obj.Trait == className {
+			captured = obj.Methods[method]
+		}
+	}
+	if unpacked == nil {
+		return args, nil
+	}
+	return unpacked, captured
+}
+
+// packCallArgs packs the arguments the analyzer marked for dyn Trait parameters.
+func (e *Evaluator) packCallArgs(node *ast.CallExpression, args []Object) {
+	if len(node.PackArgs) != len(args) {
+		return
+	}
+	for i, t := range node.PackArgs {
+		if t != nil {
+			args[i] = PackTraitObjects(args[i], t, e.lookupTraitMethods)
+		}
+	}
+}
+
+// lookupTraitMethods is the MethodLookup of the tree-walk evaluator.
+func (e *Evaluator) lookupTraitMethods(trait string, v Object) map[string]Object {
+	if table, ok := e.ClassImplementations[trait][getRuntimeTypeName(v)].(*MethodTable); ok {
+		return table.Methods
+	}
+	return nil
+}
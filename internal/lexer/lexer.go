@@ -3,8 +3,10 @@ package lexer
 import (
 	"fmt"
 	"math/big"
+	"github.com/funvibe/funxy/internal/config"
 	"github.com/funvibe/funxy/internal/token"
 	"strconv"
+	"strings"
 )
 
 type Lexer struct {
@@ -140,10 +142,13 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.DOT, l.ch, l.line, l.column)
 		}
 	case '<':
-		// <, <=, <<, <>, <|>, <*>, <$>, <:>, <~>
+		// <, <=, <<, <-, <>, <|>, <*>, <$>, <:>, <~>
 		if l.peekChar() == '<' {
 			l.readChar()
 			tok = token.Token{Type: token.LSHIFT, Lexeme: "<<", Literal: "<<", Line: l.line, Column: l.column}
+		} else if l.peekChar() == '-' {
+			l.readChar()
+			tok = token.Token{Type: token.LARROW, Lexeme: "<-", Literal: "<-", Line: l.line, Column: l.column}
 		} else if l.peekChar() == '=' {
 			l.readChar()
 			tok = token.Token{Type: token.LTE, Lexeme: "<=", Literal: "<=", Line: l.line, Column: l.column}
@@ -214,6 +219,8 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.LPAREN, l.ch, l.line, l.column)
 	case ')':
 		tok = newToken(token.RPAREN, l.ch, l.line, l.column)
+	case ';':
+		tok = newToken(token.SEMICOLON, l.ch, l.line, l.column)
 	case ',':
 		if l.peekChar() == ',' {
 			ch := l.ch
@@ -269,6 +276,12 @@ func (l *Lexer) NextToken() token.Token {
 		} else if l.peekChar() == '.' {
 			l.readChar()
 			tok = token.Token{Type: token.OPTIONAL_CHAIN, Lexeme: "?.", Literal: "?.", Line: l.line, Column: l.column}
+		} else if p := l.peekChar(); 'a' <= p && p <= 'z' {
+			// ?name - a named typed hole
+			line, col := l.line, l.column
+			l.readChar()
+			name := l.readIdentifier()
+			return token.Token{Type: token.HOLE, Lexeme: "?" + name, Literal: name, Line: line, Column: col}
 		} else {
 			tok = newToken(token.QUESTION, l.ch, l.line, l.column)
 		}
@@ -611,6 +624,8 @@ func (l *Lexer) readNumber() token.Token {
 	// Check suffixes
 	isBigInt := false
 	isRational := false
+	isDecimal := false
+	var sized *config.IntTypeInfo
 
 	if l.ch == 'n' {
 		isBigInt = true
@@ -618,6 +633,15 @@ func (l *Lexer) readNumber() token.Token {
 	} else if l.ch == 'r' {
 		isRational = true
 		l.readChar()
+	} else if l.ch == 'd' && base == 10 && !isLetter(l.peekChar()) && !isDigit(l.peekChar()) {
+		isDecimal = true
+		l.readChar()
+	} else if l.ch == 'i' || l.ch == 'u' {
+		if sized = l.sizedIntSuffix(); sized != nil {
+			for range sized.Suffix {
+				l.readChar()
+			}
+		}
 	}
 
 	lexeme := l.input[position:l.position]
@@ -642,6 +666,19 @@ func (l *Lexer) readNumber() token.Token {
 		return token.Token{Type: token.BIG_INT, Lexeme: lexeme, Literal: val, Line: l.line, Column: l.column}
 	}
 
+	if sized != nil {
+		if isFloat {
+			return token.Token{Type: token.ILLEGAL, Lexeme: lexeme, Literal: sized.Name + " cannot have decimal point", Line: l.line, Column: l.column}
+		}
+		literalText = lexeme[:len(lexeme)-len(sized.Suffix)]
+		// The range depends on the sign, so the analyzer checks it.
+		val, err := strconv.ParseUint(literalText, 0, 64)
+		if err != nil {
+			return token.Token{Type: token.ILLEGAL, Lexeme: lexeme, Literal: "Integer literal out of range for " + sized.Name, Line: l.line, Column: l.column}
+		}
+		return token.Token{Type: token.SIZED_INT, Lexeme: lexeme, Literal: token.SizedInt{Type: sized.Name, Value: val}, Line: l.line, Column: l.column}
+	}
+
 	if isRational {
 		if base != 10 {
 			return token.Token{Type: token.ILLEGAL, Lexeme: lexeme, Literal: "Rational must be base 10", Line: l.line, Column: l.column}
@@ -657,6 +694,21 @@ func (l *Lexer) readNumber() token.Token {
 		return token.Token{Type: token.RATIONAL, Lexeme: lexeme, Literal: val, Line: l.line, Column: l.column}
 	}
 
+	if isDecimal {
+		// Remove 'd' suffix; the scale is the number of digits after the point
+		literalText = lexeme[:len(lexeme)-1]
+		scale := 0
+		if dot := strings.IndexByte(literalText, '.'); dot >= 0 {
+			scale = len(literalText) - dot - 1
+			literalText = literalText[:dot] + literalText[dot+1:]
+		}
+		val, ok := new(big.Int).SetString(literalText, 10)
+		if !ok {
+			return token.Token{Type: token.ILLEGAL, Lexeme: lexeme, Literal: "Invalid Decimal", Line: l.line, Column: l.column}
+		}
+		return token.Token{Type: token.DECIMAL, Lexeme: lexeme, Literal: token.Decimal{Unscaled: val, Scale: scale}, Line: l.line, Column: l.column}
+	}
+
 	// Regular Int or Float
 	if isFloat {
 		val, err := strconv.ParseFloat(literalText, 64)
@@ -675,6 +727,25 @@ func (l *Lexer) readNumber() token.Token {
 	}
 }
 
+// sizedIntSuffix recognizes a sized integer suffix (i8, u16, ...) at the
+// current position. The suffix must not run into an identifier.
+func (l *Lexer) sizedIntSuffix() *config.IntTypeInfo {
+	rest := l.input[l.position:]
+	for i := range config.IntTypes {
+		info := &config.IntTypes[i]
+		if info.Suffix == "" || len(rest) < len(info.Suffix) || rest[:len(info.Suffix)] != info.Suffix {
+			continue
+		}
+		if len(rest) > len(info.Suffix) {
+			if next := rest[len(info.Suffix)]; isLetter(next) || isDigit(next) {
+				continue
+			}
+		}
+		return info
+	}
+	return nil
+}
+
 func isHexDigit(ch byte) bool {
 	return isDigit(ch) || ('a' <= ch && ch <= 'f') || ('A' <= ch && ch <= 'F')
 }
@@ -0,0 +1,329 @@
+package modules
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/symbols"
+)
+
+// CacheEnvVar overrides the cache directory; "off" disables caching
+const CacheEnvVar = "FUNXY_CACHE"
+
+// cacheFormatVersion must be bumped whenever the entry layout changes
+const cacheFormatVersion = 2
+
+// cacheMagic starts every cache entry file
+var cacheMagic = []byte("FXYC")
+
+// Cache is a persistent store of analyzed modules and their compiled code,
+// keyed by module directory and validated by content hashes.
+//
+// A module's hash covers its own sources, the hashes of every user module it
+// imports and the funxy executable itself, so editing any dependency (or
+// upgrading funxy) invalidates all modules that depend on it.
+type Cache struct {
+	Dir string
+
+	salt    string
+	entries map[string]*CacheEntry // Entries read during this run, by module dir
+	hashes  map[string]string      // Current module hashes, by module dir
+	sources map[string]string      // Source hashes, by module dir
+	hashing map[string]bool        // Cycle detection while hashing
+}
+
+// CacheEntry is the cached state of one module
+type CacheEntry struct {
+	Version    int
+	SourceHash string
+	Hash       string
+	Name       string
+	Exports    map[string]bool
+
+	// Deps are directories of imported user modules, in import order,
+	// and Imports the import paths they were resolved from
+	Deps    []string
+	Imports []string
+
+	// HeaderSymbols is the serialized SymbolTable after headers analysis (what
+	// importers see); SymbolTable is the table after full analysis
+	HeaderSymbols []byte
+	SymbolTable   *symbols.SymbolTable
+
+	// Code is the compiled module, opaque to the loader (owned by the VM)
+	Code []byte
+}
+
+// DefaultCacheDir returns the cache directory: $FUNXY_CACHE or <user cache dir>/funxy.
+// Returns "" when caching is disabled or no cache directory is available.
+func DefaultCacheDir() string {
+	if dir := os.Getenv(CacheEnvVar); dir != "" {
+		if dir == "off" {
+			return ""
+		}
+		return dir
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(base, "funxy")
+}
+
+// NewCache creates a cache stored in dir
+func NewCache(dir string) *Cache {
+	return &Cache{
+		Dir:     dir,
+		salt:    executableSalt(),
+		entries: make(map[string]*CacheEntry),
+		hashes:  make(map[string]string),
+		sources: make(map[string]string),
+		hashing: make(map[string]bool),
+	}
+}
+
+// NewCachedLoader creates a loader backed by the default cache (if enabled)
+func NewCachedLoader() *Loader {
+	l := NewLoader()
+	if dir := DefaultCacheDir(); dir != "" {
+		l.Cache = NewCache(dir)
+	}
+	return l
+}
+
+// executableSalt identifies the running funxy build, since cached code is
+// only valid for the compiler that produced it
+func executableSalt() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+}
+
+// entryPath returns the file holding the entry for a module directory
+func (c *Cache) entryPath(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".mod")
+}
+
+// Lookup returns the stored entry for a module directory (nil if absent or unreadable)
+func (c *Cache) Lookup(dir string) *CacheEntry {
+	if entry, ok := c.entries[dir]; ok {
+		return entry
+	}
+
+	var entry *CacheEntry
+	if data, err := os.ReadFile(c.entryPath(dir)); err == nil {
+		entry = decodeCacheEntry(data)
+	}
+	c.entries[dir] = entry
+	return entry
+}
+
+// Store writes an entry for a module directory.
+// Modules whose dependencies are not cached (e.g. import cycles) are skipped.
+func (c *Cache) Store(dir, sourceHash, name string, exports map[string]bool, deps, imports []string, headerSymbols []byte, st *symbols.SymbolTable, code []byte) error {
+	hash := c.combineHash(sourceHash, deps)
+	if hash == "" {
+		return nil
+	}
+
+	entry := &CacheEntry{
+		Version:       cacheFormatVersion,
+		SourceHash:    sourceHash,
+		Hash:          hash,
+		Name:          name,
+		Exports:       exports,
+		Deps:          deps,
+		Imports:       imports,
+		HeaderSymbols: headerSymbols,
+		SymbolTable:   st,
+		Code:          code,
+	}
+
+	buf := new(bytes.Buffer)
+	buf.Write(cacheMagic)
+	if err := gob.NewEncoder(buf).Encode(entry); err != nil {
+		return fmt.Errorf("gob encoding failed: %w", err)
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+
+	// Write atomically so concurrent runs never observe partial entries
+	tmp, err := os.CreateTemp(c.Dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.entryPath(dir)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	c.entries[dir] = entry
+	c.hashes[dir] = hash
+	c.sources[dir] = sourceHash
+	return nil
+}
+
+// Valid reports whether entry is up to date for dir: same sources and
+// unchanged dependencies
+func (c *Cache) Valid(dir string, entry *CacheEntry) bool {
+	return entry != nil && entry.Hash != "" && c.moduleHash(dir) == entry.Hash
+}
+
+// moduleHash computes the current hash of a module directory from its
+// cache entry. Returns "" for modules that are not (validly) cached.
+func (c *Cache) moduleHash(dir string) string {
+	if hash, ok := c.hashes[dir]; ok {
+		return hash
+	}
+	if c.hashing[dir] {
+		return "" // Cyclic dependencies are never cached
+	}
+	c.hashing[dir] = true
+	defer delete(c.hashing, dir)
+
+	var hash string
+	if members := packageGroupMembers(dir); members != nil {
+		hash = c.groupHash(dir, members)
+	} else if entry := c.Lookup(dir); entry != nil && entry.SourceHash == c.sourceHash(dir) {
+		if combined := c.combineHash(entry.SourceHash, entry.Deps); combined == entry.Hash {
+			hash = combined
+		}
+	}
+
+	if hash != "" {
+		c.hashes[dir] = hash
+	}
+	return hash
+}
+
+// groupHash hashes a package group from the hashes of its sub-packages
+func (c *Cache) groupHash(dir string, members []string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "group\x00")
+	for _, name := range members {
+		subHash := c.moduleHash(filepath.Join(dir, name))
+		if subHash == "" {
+			return ""
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", name, subHash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// combineHash derives a module hash from its source hash and its dependencies
+func (c *Cache) combineHash(sourceHash string, deps []string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "funxy-cache-v%d\x00%s\x00%s\x00", cacheFormatVersion, c.salt, sourceHash)
+	for _, dep := range deps {
+		depHash := c.moduleHash(dep)
+		if depHash == "" {
+			return ""
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", dep, depHash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sourceHash hashes the source files of a module directory as read from disk
+func (c *Cache) sourceHash(dir string) string {
+	if hash, ok := c.sources[dir]; ok {
+		return hash
+	}
+	var hash string
+	if files, err := readSourceFiles(dir); err == nil {
+		hash = hashSources(files)
+	}
+	c.sources[dir] = hash
+	return hash
+}
+
+// setSourceHash records the hash of sources already read by the loader
+func (c *Cache) setSourceHash(dir, hash string) {
+	c.sources[dir] = hash
+}
+
+// sourceFile is a module source file read from disk
+type sourceFile struct {
+	Path    string
+	Content []byte
+}
+
+// readSourceFiles reads the source files of a module directory in a deterministic order
+func readSourceFiles(absPath string) ([]sourceFile, error) {
+	// Detect which extension to use for this package
+	pkgExt := detectPackageExtension(absPath)
+
+	files, err := os.ReadDir(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), pkgExt) {
+			paths = append(paths, filepath.Join(absPath, f.Name()))
+		}
+	}
+	// Sort for deterministic processing order
+	sort.Strings(paths)
+
+	sources := make([]sourceFile, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, sourceFile{Path: path, Content: content})
+	}
+	return sources, nil
+}
+
+// hashSources hashes file names and contents
+func hashSources(files []sourceFile) string {
+	h := sha256.New()
+	for _, f := range files {
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.Base(f.Path), len(f.Content))
+		h.Write(f.Content)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// decodeCacheEntry parses an entry file, returning nil for foreign or outdated data
+func decodeCacheEntry(data []byte) *CacheEntry {
+	if !bytes.HasPrefix(data, cacheMagic) {
+		return nil
+	}
+	var entry CacheEntry
+	if err := gob.NewDecoder(bytes.NewReader(data[len(cacheMagic):])).Decode(&entry); err != nil {
+		return nil
+	}
+	if entry.Version != cacheFormatVersion {
+		return nil
+	}
+	return &entry
+}
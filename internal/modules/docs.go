@@ -14,6 +14,8 @@ type DocEntry struct {
 	Description string // e.g., "Apply function to each element"
 	Example     string // e.g., "map(fn, [1,2,3])"
 	Category    string // e.g., "Higher-Order", "Access", "Arithmetic"
+
+	Members []*DocEntry // Constructors of a type or methods of a trait (user packages)
 }
 
 // DocPackage represents documentation for a package
@@ -24,6 +26,7 @@ type DocPackage struct {
 	Types       []*DocEntry // Types defined in package
 	Traits      []*DocEntry // Traits defined in package
 	Operators   []*DocEntry // Operators defined in package
+	Instances   []*DocEntry // Trait instances declared in package (user packages)
 }
 
 // docPackages stores all documentation
@@ -108,11 +111,21 @@ func FormatDocEntry(e *DocEntry) string {
 	}
 	sb.WriteString("\n")
 	if e.Description != "" {
-		sb.WriteString(fmt.Sprintf("    %s\n", e.Description))
+		sb.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(e.Description, "\n", "\n    ")))
 	}
 	if e.Example != "" {
 		sb.WriteString(fmt.Sprintf("    Example: %s\n", e.Example))
 	}
+	for _, m := range e.Members {
+		sb.WriteString(fmt.Sprintf("    | %s", m.Name))
+		if m.Signature != "" {
+			sb.WriteString(fmt.Sprintf(" : %s", m.Signature))
+		}
+		if m.Category != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", m.Category))
+		}
+		sb.WriteString("\n")
+	}
 	return sb.String()
 }
 
@@ -142,6 +155,14 @@ func FormatDocPackage(pkg *DocPackage) string {
 		sb.WriteString("\n")
 	}
 
+	if len(pkg.Instances) > 0 {
+		sb.WriteString("Instances:\n")
+		for _, inst := range pkg.Instances {
+			sb.WriteString(FormatDocEntry(inst))
+		}
+		sb.WriteString("\n")
+	}
+
 	if len(pkg.Operators) > 0 {
 		sb.WriteString("Operators:\n")
 		for _, op := range pkg.Operators {
@@ -189,12 +210,27 @@ func PrintHelp() string {
 	sb.WriteString("  funxy <file>                Run a program\n")
 	sb.WriteString("  funxy -c <file>             Compile to bytecode (.fbc)\n")
 	sb.WriteString("  funxy -r <file>             Run compiled bytecode (.fbc)\n")
+	sb.WriteString("  funxy build -o <out> <file> Build a standalone executable\n")
+	sb.WriteString("  funxy doc <dir> [-md|-html] Generate documentation for a package\n")
+	sb.WriteString("  funxy vendor                Copy funxy.mod dependencies into vendor/\n")
 	sb.WriteString("  funxy -help                 Show this help\n")
 	sb.WriteString("  funxy -help packages        Show lib packages\n")
 	sb.WriteString("  funxy -help <package>       Show package documentation\n")
 	sb.WriteString("  funxy -help search <term>   Search documentation\n")
 	sb.WriteString("  funxy -help precedence      Show operator precedence\n")
 	sb.WriteString("\n")
+	sb.WriteString("Backend selection (before the file argument, or via FUNXY_BACKEND):\n")
+	sb.WriteString("  --backend=vm                Bytecode VM (default)\n")
+	sb.WriteString("  --backend=tree              Tree-walk interpreter\n")
+	sb.WriteString("  --backend=both              Run on both and report any divergence\n")
+	sb.WriteString("\n")
+	sb.WriteString("Module cache (vm backend): analyzed and compiled packages are cached in\n")
+	sb.WriteString("  <user cache dir>/funxy; set FUNXY_CACHE=<dir> to move it or FUNXY_CACHE=off to disable\n")
+	sb.WriteString("\n")
+	sb.WriteString("Imports: paths starting with '.' are relative to the importing file; other paths are\n")
+	sb.WriteString("  resolved via funxy.mod (require <name> => <dir>, vendor/<name> preferred), the\n")
+	sb.WriteString("  project root, then the directories listed in FUNXY_PATH\n")
+	sb.WriteString("\n")
 	sb.WriteString("File extensions: .lang, .funxy, .fx\n")
 	sb.WriteString("\n")
 	sb.WriteString("Note: Bytecode compilation (-c) works for single-file programs.\n")
@@ -302,6 +338,14 @@ func InitDocumentation() {
 	initUuidDocs()
 	initLogDocs()
 	initTaskDocs()
+	initActorDocs()
+	initChanDocs()
+	initRefDocs()
+	initStreamDocs()
+	initSetDocs()
+	initOrderedDocs()
+	initHeapDocs()
+	initReflectDocs()
 	initCsvDocs()
 	initFlagDocs()
 
@@ -551,6 +595,10 @@ func initListDocs() {
 		"foldr":     {Description: "Right fold with initial value", Category: "Higher-Order"},
 		"partition": {Description: "Split by predicate into (matching, non-matching)", Category: "Higher-Order"},
 		"forEach":   {Description: "Apply function to each element for side effects", Category: "Higher-Order"},
+		// Parallel
+		"parMap":    {Description: "map on all cores, order preserved", Category: "Parallel"},
+		"parFilter": {Description: "filter on all cores, order preserved", Category: "Parallel"},
+		"parFold":   {Description: "Map each element and combine with (<>) on all cores (requires Monoid)", Category: "Parallel"},
 		// Transform
 		"reverse": {Description: "Reverse list", Category: "Transform"},
 		"concat":  {Description: "Flatten one level of nesting", Category: "Transform"},
@@ -615,8 +663,8 @@ func initBytesDocs() {
 		// Modification
 		"bytesConcat": {Description: "Concatenate bytes", Category: "Modification"},
 		// Numeric Encoding
-		"bytesEncodeInt":    {Description: "Encode Int to bytes", Category: "Numeric"},
-		"bytesDecodeInt":    {Description: "Decode bytes to Int", Category: "Numeric"},
+		"bytesEncodeInt":    {Description: "Encode an integer (Int or sized) to bytes", Category: "Numeric"},
+		"bytesDecodeInt":    {Description: "Decode bytes to Int, or to the integer type given", Category: "Numeric"},
 		"bytesEncodeBigInt": {Description: "Encode BigInt to bytes", Category: "Numeric"},
 		"bytesDecodeBigInt": {Description: "Decode bytes to BigInt (returns Result)", Category: "Numeric"},
 		"bytesEncodeFloat":  {Description: "Encode Float to bytes (size 4 or 8)", Category: "Numeric"},
@@ -851,8 +899,20 @@ func initBignumDocs() {
 		"ratDenom":       {Description: "Get denominator", Category: "Rational"},
 		"ratToFloat":     {Description: "Convert to Float", Category: "Rational"},
 		"ratToString":    {Description: "Convert to string (\"num/denom\")", Category: "Rational"},
+
+		"decimalNew":          {Description: "Parse Decimal from string (\"12.50\", \"1e-3\")", Category: "Decimal"},
+		"decimalFromInt":      {Description: "Create Decimal from Int", Category: "Decimal"},
+		"decimalFromFloat":    {Description: "Create Decimal from the shortest form of a Float", Category: "Decimal"},
+		"decimalFromUnscaled": {Description: "Create Decimal from unscaled value and scale (1234, 2 -> 12.34)", Category: "Decimal"},
+		"decimalUnscaled":     {Description: "Get unscaled value", Category: "Decimal"},
+		"decimalScale":        {Description: "Get number of fraction digits", Category: "Decimal"},
+		"decimalRound":        {Description: "Round to a scale (mode: half-even, half-up, half-down, up, down, ceiling, floor)", Category: "Decimal"},
+		"decimalDiv":          {Description: "Divide rounding to a scale (Zero on division by zero)", Category: "Decimal"},
+		"decimalToString":     {Description: "Convert to plain string", Category: "Decimal"},
+		"decimalToFloat":      {Description: "Convert to nearest Float", Category: "Decimal"},
+		"decimalToRational":   {Description: "Convert to exact Rational", Category: "Decimal"},
 	}
-	pkg := generatePackageDocs("lib/bignum", "Arbitrary precision numbers (BigInt, Rational)", meta, nil)
+	pkg := generatePackageDocs("lib/bignum", "Arbitrary precision numbers (BigInt, Rational, Decimal)", meta, nil)
 	RegisterDocPackage(pkg)
 }
 
@@ -881,6 +941,7 @@ func initJsonDocs() {
 	meta := map[string]*DocMeta{
 		"jsonEncode":    {Description: "Encode value to JSON string"},
 		"jsonDecode":    {Description: "Decode JSON to typed value"},
+		"jsonDecodeAs":  {Description: "Decode JSON into the given type, with the path of the first mismatch"},
 		"jsonParse":     {Description: "Parse JSON to Json ADT"},
 		"jsonFromValue": {Description: "Convert value to Json ADT"},
 		"jsonGet":       {Description: "Get field from JObj"},
@@ -888,6 +949,7 @@ func initJsonDocs() {
 	}
 	types := []*DocEntry{
 		{Name: "Json", Signature: "JNull | JBool Bool | JNum Float | JStr String | JArr List<Json> | JObj List<(String, Json)>", Description: "JSON value ADT"},
+		{Name: "JsonError", Signature: "{ path: String, message: String }", Description: "Typed decoding error"},
 	}
 	pkg := generatePackageDocs("lib/json", "JSON encoding, decoding, and manipulation", meta, types)
 	RegisterDocPackage(pkg)
@@ -1122,7 +1184,7 @@ func initSqlDocs() {
 	}
 
 	types := []*DocEntry{
-		{Name: "SqlValue", Signature: "SqlNull | SqlInt Int | SqlFloat Float | SqlString String | SqlBool Bool | SqlBytes Bytes | SqlTime Date | SqlBigInt BigInt", Description: "SQL value ADT"},
+		{Name: "SqlValue", Signature: "SqlNull | SqlInt Int | SqlFloat Float | SqlString String | SqlBool Bool | SqlBytes Bytes | SqlTime Date | SqlBigInt BigInt | SqlDecimal Decimal", Description: "SQL value ADT"},
 		{Name: "SqlDB", Signature: "opaque", Description: "Database connection handle"},
 		{Name: "SqlTx", Signature: "opaque", Description: "Transaction handle"},
 		{Name: "Row", Signature: "Map<String, SqlValue>", Description: "Query result row"},
@@ -1335,9 +1397,10 @@ func initTaskDocs() {
 		"awaitFirstTimeout": {Description: "Wait for first completed with timeout", Category: "Awaiting"},
 
 		// Control
-		"taskCancel":      {Description: "Cancel task (if not started)", Category: "Control"},
+		"taskCancel":      {Description: "Cancel task, interrupting blocking calls", Category: "Control"},
 		"taskIsDone":      {Description: "Check if task completed", Category: "Control"},
 		"taskIsCancelled": {Description: "Check if task was cancelled", Category: "Control"},
+		"taskScope":       {Description: "Run a block that waits for its tasks; first failure cancels the rest", Category: "Control"},
 
 		// Pool
 		"taskSetGlobalPool": {Description: "Set maximum concurrent tasks", Category: "Pool"},
@@ -1357,6 +1420,282 @@ func initTaskDocs() {
 	RegisterDocPackage(pkg)
 }
 
+// ============================================================================
+// lib/actor Documentation
+// ============================================================================
+
+func initActorDocs() {
+	meta := map[string]*DocMeta{
+		// Processes
+		"spawn":     {Description: "Start a process running the function", Category: "Processes"},
+		"spawnLink": {Description: "Start a process linked to the caller", Category: "Processes"},
+		"self":      {Description: "Pid of the calling process", Category: "Processes"},
+		"isAlive":   {Description: "Check if a process is still running", Category: "Processes"},
+		"exit":      {Description: "Send an exit signal with a reason (\"kill\" cannot be trapped)", Category: "Processes"},
+
+		// Messages
+		"send":           {Description: "Put a message in a process mailbox", Category: "Messages"},
+		"receive":        {Description: "Wait for the first message the handler accepts with Some", Category: "Messages"},
+		"receiveTimeout": {Description: "Like receive, Zero after timeout (ms)", Category: "Messages"},
+
+		// Links and monitors
+		"link":      {Description: "Link the caller and a process: exits propagate both ways", Category: "Links"},
+		"unlink":    {Description: "Remove a link", Category: "Links"},
+		"monitor":   {Description: "Receive Down(pid, reason) when the process exits", Category: "Links"},
+		"demonitor": {Description: "Stop monitoring a process", Category: "Links"},
+		"trapExit":  {Description: "Receive linked exits as Exit(pid, reason) messages", Category: "Links"},
+
+		// Registry
+		"register": {Description: "Register a process under a name (false if taken)", Category: "Registry"},
+		"whereis":  {Description: "Look up a registered process", Category: "Registry"},
+
+		// Supervision
+		"supervise":          {Description: "Start a supervisor (one_for_one, one_for_all, rest_for_one)", Category: "Supervision"},
+		"supervisorChildren": {Description: "Current child processes of a supervisor", Category: "Supervision"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Pid", Signature: "opaque", Description: "Process identifier"},
+		{Name: "Signal", Signature: "Down Pid String | Exit Pid String", Description: "Exit notification from a monitor or a trapped link"},
+	}
+
+	pkg := generatePackageDocs("lib/actor", "Lightweight processes with mailboxes, links, monitors and supervisors", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/chan Documentation
+// ============================================================================
+
+func initChanDocs() {
+	meta := map[string]*DocMeta{
+		// Channels
+		"chanNew":   {Description: "Create a channel with a buffer size (0 = unbuffered)", Category: "Channels"},
+		"chanSend":  {Description: "Send a value, blocking until there is room", Category: "Channels"},
+		"chanRecv":  {Description: "Receive a value, Zero once closed and drained", Category: "Channels"},
+		"chanClose": {Description: "Close a channel; receivers drain what is left", Category: "Channels"},
+
+		// Select
+		"onRecv":        {Description: "Select case receiving from a channel", Category: "Select"},
+		"onSend":        {Description: "Select case sending to a channel", Category: "Select"},
+		"select":        {Description: "Wait for the first ready case and run its handler", Category: "Select"},
+		"selectTimeout": {Description: "Like select, Zero after timeout (ms)", Category: "Select"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Chan<T>", Signature: "opaque", Description: "Channel carrying values of type T"},
+		{Name: "SelectCase<R>", Signature: "opaque", Description: "Branch of a select producing R"},
+	}
+
+	pkg := generatePackageDocs("lib/chan", "Typed channels and select for communicating tasks", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/ref Documentation
+// ============================================================================
+
+func initRefDocs() {
+	meta := map[string]*DocMeta{
+		"refNew":    {Description: "Create a Ref holding a value", Category: "Refs"},
+		"refGet":    {Description: "Read the current value", Category: "Refs"},
+		"refSet":    {Description: "Replace the value", Category: "Refs"},
+		"refUpdate": {Description: "Apply a function atomically (compare-and-swap, retried on conflict), return the new value", Category: "Refs"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Ref<T>", Signature: "opaque", Description: "Atomic mutable cell that tasks can share"},
+	}
+
+	pkg := generatePackageDocs("lib/ref", "Atomic references for state shared between tasks", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/stream Documentation
+// ============================================================================
+
+func initStreamDocs() {
+	meta := map[string]*DocMeta{
+		// Sources
+		"streamFromList": {Description: "Stream over the elements of a list", Category: "Sources"},
+		"streamRange":    {Description: "Integers from start up to (not including) end", Category: "Sources"},
+		"streamIterate":  {Description: "Infinite stream seed, f(seed), f(f(seed)), ...", Category: "Sources"},
+		"streamLines":    {Description: "Lines of a file, read lazily without line endings", Category: "Sources"},
+		"streamFromChan": {Description: "Values received from a channel until it is closed (one-shot)", Category: "Sources"},
+		"streamFromIter": {Description: "Values of an iterator function until it returns Zero (one-shot)", Category: "Sources"},
+
+		// Combinators
+		"streamMap":     {Description: "Apply a function to each element (fused with neighbouring map/filter)", Category: "Combinators"},
+		"streamFilter":  {Description: "Keep elements satisfying a predicate (fused with neighbouring map/filter)", Category: "Combinators"},
+		"streamFlatMap": {Description: "Replace each element with the elements of a stream", Category: "Combinators"},
+		"streamTake":    {Description: "First n elements; stops pulling from the source", Category: "Combinators"},
+		"streamDrop":    {Description: "Skip the first n elements", Category: "Combinators"},
+		"streamZip":     {Description: "Pair elements of two streams, ending with the shorter", Category: "Combinators"},
+		"streamChunk":   {Description: "Group elements into lists of n (last may be shorter)", Category: "Combinators"},
+		"streamWindow":  {Description: "Sliding windows of n elements, advancing by one", Category: "Combinators"},
+
+		// Terminals
+		"streamToList":  {Description: "Run the stream and collect its elements", Category: "Terminals"},
+		"streamFold":    {Description: "Run the stream, folding from the left", Category: "Terminals"},
+		"streamForEach": {Description: "Run the stream, calling a function for each element", Category: "Terminals"},
+		"streamCount":   {Description: "Run the stream and count its elements", Category: "Terminals"},
+		"streamFirst":   {Description: "First element, pulling nothing more (Zero if empty)", Category: "Terminals"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Stream<T>", Signature: "opaque", Description: "Lazy sequence of T, run by a terminal operation"},
+	}
+
+	pkg := generatePackageDocs("lib/stream", "Lazy streams with fused map/filter stages", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/set Documentation
+// ============================================================================
+
+func initSetDocs() {
+	meta := map[string]*DocMeta{
+		// Construction
+		"setNew":  {Description: "Create an empty set", Category: "Construction"},
+		"setFrom": {Description: "Create a set from a list, dropping duplicates", Category: "Construction"},
+
+		// Access
+		"setAdd":      {Description: "Add an element", Category: "Access"},
+		"setRemove":   {Description: "Remove an element", Category: "Access"},
+		"setContains": {Description: "Check membership", Category: "Access"},
+		"setSize":     {Description: "Number of elements", Category: "Access"},
+		"setToList":   {Description: "Elements as a list, in unspecified order", Category: "Access"},
+
+		// Set algebra
+		"setUnion":      {Description: "Elements in either set", Category: "Set algebra"},
+		"setIntersect":  {Description: "Elements in both sets", Category: "Set algebra"},
+		"setDifference": {Description: "Elements of the first set not in the second", Category: "Set algebra"},
+		"setIsSubset":   {Description: "Whether every element of the first set is in the second", Category: "Set algebra"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Set<T>", Signature: "opaque", Description: "Persistent hash set"},
+	}
+
+	pkg := generatePackageDocs("lib/set", "Persistent hash sets", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/ordered Documentation
+// ============================================================================
+
+func initOrderedDocs() {
+	meta := map[string]*DocMeta{
+		// OrderedMap
+		"orderedMapNew":      {Description: "Create an empty ordered map", Category: "OrderedMap"},
+		"orderedMapFrom":     {Description: "Create an ordered map from (key, value) pairs; later pairs win", Category: "OrderedMap"},
+		"orderedMapPut":      {Description: "Add or replace an entry", Category: "OrderedMap"},
+		"orderedMapGet":      {Description: "Value for a key, Zero if missing", Category: "OrderedMap"},
+		"orderedMapRemove":   {Description: "Remove a key", Category: "OrderedMap"},
+		"orderedMapContains": {Description: "Check if a key exists", Category: "OrderedMap"},
+		"orderedMapSize":     {Description: "Number of entries", Category: "OrderedMap"},
+		"orderedMapKeys":     {Description: "Keys in ascending order", Category: "OrderedMap"},
+		"orderedMapValues":   {Description: "Values in key order", Category: "OrderedMap"},
+		"orderedMapItems":    {Description: "(key, value) pairs in key order", Category: "OrderedMap"},
+		"orderedMapMin":      {Description: "Entry with the least key", Category: "OrderedMap"},
+		"orderedMapMax":      {Description: "Entry with the greatest key", Category: "OrderedMap"},
+		"orderedMapFloor":    {Description: "Entry with the greatest key <= k", Category: "OrderedMap"},
+		"orderedMapCeiling":  {Description: "Entry with the least key >= k", Category: "OrderedMap"},
+		"orderedMapRange":    {Description: "Entries with lo <= key < hi", Category: "OrderedMap"},
+
+		// OrderedSet
+		"orderedSetNew":        {Description: "Create an empty ordered set", Category: "OrderedSet"},
+		"orderedSetFrom":       {Description: "Create an ordered set from a list", Category: "OrderedSet"},
+		"orderedSetAdd":        {Description: "Add an element", Category: "OrderedSet"},
+		"orderedSetRemove":     {Description: "Remove an element", Category: "OrderedSet"},
+		"orderedSetContains":   {Description: "Check membership", Category: "OrderedSet"},
+		"orderedSetSize":       {Description: "Number of elements", Category: "OrderedSet"},
+		"orderedSetToList":     {Description: "Elements in ascending order", Category: "OrderedSet"},
+		"orderedSetMin":        {Description: "Least element", Category: "OrderedSet"},
+		"orderedSetMax":        {Description: "Greatest element", Category: "OrderedSet"},
+		"orderedSetFloor":      {Description: "Greatest element <= x", Category: "OrderedSet"},
+		"orderedSetCeiling":    {Description: "Least element >= x", Category: "OrderedSet"},
+		"orderedSetRange":      {Description: "Elements with lo <= x < hi", Category: "OrderedSet"},
+		"orderedSetUnion":      {Description: "Elements in either set", Category: "OrderedSet"},
+		"orderedSetIntersect":  {Description: "Elements in both sets", Category: "OrderedSet"},
+		"orderedSetDifference": {Description: "Elements of the first set not in the second", Category: "OrderedSet"},
+	}
+
+	types := []*DocEntry{
+		{Name: "OrderedMap<K, V>", Signature: "opaque", Description: "Persistent map sorted by key (K: Order)"},
+		{Name: "OrderedSet<T>", Signature: "opaque", Description: "Persistent sorted set (T: Order)"},
+	}
+
+	pkg := generatePackageDocs("lib/ordered", "Persistent sorted maps and sets with range queries", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/heap Documentation
+// ============================================================================
+
+func initHeapDocs() {
+	meta := map[string]*DocMeta{
+		"heapNew":    {Description: "Create an empty heap", Category: "Heap"},
+		"heapFrom":   {Description: "Create a heap from a list", Category: "Heap"},
+		"heapPush":   {Description: "Add an element", Category: "Heap"},
+		"heapPeek":   {Description: "Least element, Zero if empty", Category: "Heap"},
+		"heapPop":    {Description: "Least element and the rest of the heap, Zero if empty", Category: "Heap"},
+		"heapSize":   {Description: "Number of elements", Category: "Heap"},
+		"heapToList": {Description: "Elements in the order they would be popped", Category: "Heap"},
+		"heapMerge":  {Description: "Combine two heaps", Category: "Heap"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Heap<T>", Signature: "opaque", Description: "Persistent min-priority queue (T: Order); equal elements pop in insertion order"},
+	}
+
+	pkg := generatePackageDocs("lib/heap", "Persistent priority queues", meta, types)
+	RegisterDocPackage(pkg)
+}
+
+// ============================================================================
+// lib/reflect Documentation
+// ============================================================================
+
+func initReflectDocs() {
+	meta := map[string]*DocMeta{
+		// Dynamic values
+		"toDynamic":       {Description: "Box a value of any type", Category: "Dynamic"},
+		"fromDynamic":     {Description: "The boxed value if it has the given type, Zero otherwise", Category: "Dynamic"},
+		"dynamicTypeName": {Description: "Runtime type name of the boxed value", Category: "Dynamic"},
+
+		// Records
+		"fieldNames":   {Description: "Field names of a record in alphabetical order; empty for other values", Category: "Records"},
+		"fields":       {Description: "(name, value) pairs of a record in alphabetical order", Category: "Records"},
+		"getField":     {Description: "Value of a record field by name, Zero if missing", Category: "Records"},
+		"fieldNamesOf": {Description: "Field names of a record type", Category: "Records"},
+
+		// ADTs
+		"constructorName": {Description: "Constructor name of an ADT value, Zero for other values", Category: "ADTs"},
+		"constructorArgs": {Description: "Arguments the constructor was applied to", Category: "ADTs"},
+		"constructorsOf":  {Description: "(name, arity) of each constructor of an ADT, in declaration order", Category: "ADTs"},
+
+		// Traits
+		"traitsOf":   {Description: "Traits with an instance for the value's type, sorted", Category: "Traits"},
+		"implements": {Description: "Whether the value's type has an instance of the named trait", Category: "Traits"},
+
+		// Functions
+		"functionArity":  {Description: "Number of parameters still expected, not counting a variadic one", Category: "Functions"},
+		"functionParams": {Description: "Parameter names; empty for builtins and constructors", Category: "Functions"},
+	}
+
+	types := []*DocEntry{
+		{Name: "Dynamic", Signature: "opaque", Description: "Value of a type known only at runtime"},
+	}
+
+	pkg := generatePackageDocs("lib/reflect", "Runtime reflection over records, ADTs, traits and functions", meta, types)
+	RegisterDocPackage(pkg)
+}
+
 // ============================================================================
 // lib/csv Documentation
 // ============================================================================
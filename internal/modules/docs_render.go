@@ -0,0 +1,201 @@
+package modules
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// docSection is a titled group of entries, in display order
+type docSection struct {
+	Title   string
+	Entries []*DocEntry
+}
+
+// docSections groups a package's entries for Markdown and HTML output.
+// Functions are split by category; uncategorized functions come first.
+func docSections(pkg *DocPackage) []docSection {
+	sections := []docSection{
+		{"Types", pkg.Types},
+		{"Traits", pkg.Traits},
+		{"Instances", pkg.Instances},
+		{"Operators", pkg.Operators},
+	}
+
+	categories := make(map[string][]*DocEntry)
+	for _, f := range pkg.Functions {
+		categories[f.Category] = append(categories[f.Category], f)
+	}
+	var catNames []string
+	for name := range categories {
+		catNames = append(catNames, name)
+	}
+	sort.Strings(catNames)
+	for _, cat := range catNames {
+		title := cat
+		if title == "" {
+			title = "Functions"
+		}
+		sections = append(sections, docSection{title, categories[cat]})
+	}
+
+	var result []docSection
+	for _, s := range sections {
+		if len(s.Entries) > 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// docAnchor builds a stable HTML id / Markdown anchor for an entry
+func docAnchor(pkg *DocPackage, e *DocEntry) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(pkg.Path + "-" + e.Name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+// FormatDocMarkdown renders package documentation as Markdown
+func FormatDocMarkdown(pkgs []*DocPackage) string {
+	var sb strings.Builder
+	for i, pkg := range pkgs {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("# Package `%s`\n\n", pkg.Path))
+		if pkg.Description != "" {
+			sb.WriteString(pkg.Description + "\n\n")
+		}
+
+		sections := docSections(pkg)
+		for _, s := range sections {
+			sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", s.Title, strings.ToLower(strings.ReplaceAll(s.Title, " ", "-"))))
+		}
+		if len(sections) > 0 {
+			sb.WriteString("\n")
+		}
+
+		for _, s := range sections {
+			sb.WriteString(fmt.Sprintf("## %s\n\n", s.Title))
+			for _, e := range s.Entries {
+				sb.WriteString(fmt.Sprintf("<a id=\"%s\"></a>\n", docAnchor(pkg, e)))
+				sb.WriteString(fmt.Sprintf("### `%s`\n\n", e.Name))
+				if e.Signature != "" {
+					sb.WriteString(fmt.Sprintf("```\n%s\n```\n\n", e.Signature))
+				}
+				if e.Description != "" {
+					sb.WriteString(e.Description + "\n\n")
+				}
+				if e.Example != "" {
+					sb.WriteString(fmt.Sprintf("Example: `%s`\n\n", e.Example))
+				}
+				for _, m := range e.Members {
+					sb.WriteString(fmt.Sprintf("- `%s`", m.Name))
+					if m.Signature != "" {
+						sb.WriteString(fmt.Sprintf(" : `%s`", m.Signature))
+					}
+					if m.Category != "" {
+						sb.WriteString(fmt.Sprintf(" _(%s)_", m.Category))
+					}
+					if m.Description != "" {
+						sb.WriteString(" — " + strings.ReplaceAll(m.Description, "\n", " "))
+					}
+					sb.WriteString("\n")
+				}
+				if len(e.Members) > 0 {
+					sb.WriteString("\n")
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+const docHTMLStyle = `body { font-family: -apple-system, "Segoe UI", sans-serif; max-width: 52em; margin: 2em auto; padding: 0 1em; color: #222; }
+nav ul { list-style: none; padding-left: 1em; }
+h2 { border-bottom: 1px solid #ddd; padding-bottom: .2em; margin-top: 2em; }
+h3 { font-family: monospace; font-size: 1.05em; margin-bottom: .3em; }
+pre, code { background: #f5f5f5; border-radius: 3px; }
+pre { padding: .5em .8em; overflow-x: auto; }
+.doc { white-space: pre-wrap; margin: .5em 0 1.2em; }
+.members { margin-top: .3em; }
+.tag { color: #777; font-style: italic; }`
+
+// FormatDocHTML renders package documentation as a standalone HTML page
+func FormatDocHTML(pkgs []*DocPackage) string {
+	esc := html.EscapeString
+
+	title := "Package documentation"
+	if len(pkgs) == 1 {
+		title = "Package " + pkgs[0].Path
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", esc(title)))
+	sb.WriteString("<style>\n" + docHTMLStyle + "\n</style>\n</head>\n<body>\n")
+
+	// Index of all packages and entries
+	sb.WriteString("<nav>\n<ul>\n")
+	for _, pkg := range pkgs {
+		sb.WriteString(fmt.Sprintf("<li><a href=\"#pkg-%s\">%s</a>\n<ul>\n", esc(pkg.Path), esc(pkg.Path)))
+		for _, s := range docSections(pkg) {
+			for _, e := range s.Entries {
+				sb.WriteString(fmt.Sprintf("<li><a href=\"#%s\">%s</a></li>\n", docAnchor(pkg, e), esc(e.Name)))
+			}
+		}
+		sb.WriteString("</ul>\n</li>\n")
+	}
+	sb.WriteString("</ul>\n</nav>\n")
+
+	for _, pkg := range pkgs {
+		sb.WriteString(fmt.Sprintf("<h1 id=\"pkg-%s\">Package %s</h1>\n", esc(pkg.Path), esc(pkg.Path)))
+		if pkg.Description != "" {
+			sb.WriteString(fmt.Sprintf("<p class=\"doc\">%s</p>\n", esc(pkg.Description)))
+		}
+
+		for _, s := range docSections(pkg) {
+			sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", esc(s.Title)))
+			for _, e := range s.Entries {
+				sb.WriteString(fmt.Sprintf("<h3 id=\"%s\">%s</h3>\n", docAnchor(pkg, e), esc(e.Name)))
+				if e.Signature != "" {
+					sb.WriteString(fmt.Sprintf("<pre>%s</pre>\n", esc(e.Signature)))
+				}
+				if e.Description != "" {
+					sb.WriteString(fmt.Sprintf("<p class=\"doc\">%s</p>\n", esc(e.Description)))
+				}
+				if e.Example != "" {
+					sb.WriteString(fmt.Sprintf("<p>Example: <code>%s</code></p>\n", esc(e.Example)))
+				}
+				if len(e.Members) > 0 {
+					sb.WriteString("<ul class=\"members\">\n")
+					for _, m := range e.Members {
+						sb.WriteString(fmt.Sprintf("<li><code>%s</code>", esc(m.Name)))
+						if m.Signature != "" {
+							sb.WriteString(fmt.Sprintf(" : <code>%s</code>", esc(m.Signature)))
+						}
+						if m.Category != "" {
+							sb.WriteString(fmt.Sprintf(" <span class=\"tag\">(%s)</span>", esc(m.Category)))
+						}
+						if m.Description != "" {
+							sb.WriteString(" — " + esc(m.Description))
+						}
+						sb.WriteString("</li>\n")
+					}
+					sb.WriteString("</ul>\n")
+				}
+			}
+		}
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
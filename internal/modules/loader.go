@@ -80,6 +80,8 @@ type Loader struct {
 	LoadedModules map[string]*Module // Cache of loaded modules by path
 	ModulesByName map[string]*Module // Index by package name for quick lookup
 	Processing    map[string]bool    // Cycle detection during loading
+	Cache         *Cache             // Persistent module cache (nil = disabled)
+	SearchPath    *SearchPath        // Resolution of non-relative imports (nil = current directory)
 }
 
 func NewLoader() *Loader {
@@ -151,40 +153,16 @@ func (l *Loader) GetModule(path string) (interface{}, error) {
 // tryLoadPackageGroup checks if a directory contains subdirectories with source files
 // and creates a combined module from all sub-packages
 func (l *Loader) tryLoadPackageGroup(absPath string) (*Module, error) {
-	files, err := os.ReadDir(absPath)
-	if err != nil {
+	if _, err := os.ReadDir(absPath); err != nil {
 		return nil, err
 	}
 
-	// Check if directory has subdirectories with source files (sub-packages)
-	var subPackages []string
-	hasDirectFiles := false
-
-	for _, f := range files {
-		if f.IsDir() {
-			// Check if subdirectory has any source files
-			subPath := filepath.Join(absPath, f.Name())
-			if hasAnySourceFiles(subPath) {
-				subPackages = append(subPackages, f.Name())
-			}
-		} else if hasAnySourceFiles(absPath) {
-			hasDirectFiles = true
-		}
-	}
-
-	// If no sub-packages found, return nil (will be handled as regular module)
-	if len(subPackages) == 0 {
-		return nil, nil
-	}
-
-	// If has both direct files and sub-packages, treat as regular module
-	if hasDirectFiles {
+	subPackages := packageGroupMembers(absPath)
+	if subPackages == nil {
 		return nil, nil
 	}
 
 	// Create combined module from all sub-packages
-	sort.Strings(subPackages)
-
 	combinedMod := &Module{
 		Name:           filepath.Base(absPath),
 		Dir:            absPath,
@@ -218,6 +196,39 @@ func (l *Loader) tryLoadPackageGroup(absPath string) (*Module, error) {
 	return combinedMod, nil
 }
 
+// packageGroupMembers returns the sorted sub-package names if absPath is a
+// package group (only subdirectories with source files), or nil otherwise
+func packageGroupMembers(absPath string) []string {
+	files, err := os.ReadDir(absPath)
+	if err != nil {
+		return nil
+	}
+
+	// Check if directory has subdirectories with source files (sub-packages)
+	var subPackages []string
+	hasDirectFiles := false
+
+	for _, f := range files {
+		if f.IsDir() {
+			// Check if subdirectory has any source files
+			subPath := filepath.Join(absPath, f.Name())
+			if hasAnySourceFiles(subPath) {
+				subPackages = append(subPackages, f.Name())
+			}
+		} else if hasAnySourceFiles(absPath) {
+			hasDirectFiles = true
+		}
+	}
+
+	// If has both direct files and sub-packages, treat as regular module
+	if len(subPackages) == 0 || hasDirectFiles {
+		return nil
+	}
+
+	sort.Strings(subPackages)
+	return subPackages
+}
+
 // Load loads a module (and its dependencies) from a given path.
 // Path can be absolute or relative.
 // If relative, it's relative to the current working directory (initial entry point).
@@ -244,16 +255,9 @@ func (l *Loader) Load(path string) (*Module, error) {
 	// 2. Scan for imports and load dependencies recursively
 	for _, file := range mod.Files {
 		for _, imp := range file.Imports {
-			// Resolve import path relative to the module directory
+			// Resolve import path relative to the module directory or via the search path
 			importPath := imp.Path.Value
-			// Handle relative paths
-			var resolvedPath string
-			if strings.HasPrefix(importPath, ".") {
-				resolvedPath = filepath.Join(mod.Dir, importPath)
-			} else {
-				// TODO: Standard library path or GOPATH-like resolution?
-				resolvedPath, _ = filepath.Abs(importPath)
-			}
+			resolvedPath := l.ResolveImport(mod.Dir, importPath)
 
 			depMod, err := l.Load(resolvedPath) // Recursion
 			if err != nil {
@@ -285,21 +289,11 @@ func (l *Loader) loadDir(absPath string) (*Module, error) {
 	// Detect which extension to use for this package
 	pkgExt := detectPackageExtension(absPath)
 
-	// Use Walk or ReadDir? ReadDir is safer for "one package per dir" as it doesn't recurse.
-	files, err := os.ReadDir(absPath)
+	sourceFiles, err := readSourceFiles(absPath)
 	if err != nil {
 		return nil, err
 	}
 
-	var sourceFiles []string
-	for _, f := range files {
-		if !f.IsDir() && strings.HasSuffix(f.Name(), pkgExt) {
-			sourceFiles = append(sourceFiles, filepath.Join(absPath, f.Name()))
-		}
-	}
-	// Sort for deterministic processing order
-	sort.Strings(sourceFiles)
-
 	if len(sourceFiles) == 0 {
 		return nil, fmt.Errorf("no %s files found in %s (detected extension: %s)", strings.Join(config.SourceFileExtensions, "/"), absPath, pkgExt)
 	}
@@ -310,6 +304,15 @@ func (l *Loader) loadDir(absPath string) (*Module, error) {
 		SymbolTable: symbols.NewSymbolTable(), // Module SymbolTable has builtins
 	}
 
+	// Reuse analysis results and compiled code if nothing changed since the last run
+	if l.Cache != nil {
+		module.SourceHash = hashSources(sourceFiles)
+		l.Cache.setSourceHash(absPath, module.SourceHash)
+		if entry := l.Cache.Lookup(absPath); l.Cache.Valid(absPath, entry) && l.sameResolution(absPath, entry) {
+			return l.restoreModule(absPath, entry)
+		}
+	}
+
 	var packageName string
 	var entryFileExportAll bool
 	var entryFileExports []string
@@ -319,11 +322,8 @@ func (l *Loader) loadDir(absPath string) (*Module, error) {
 	// We can't easily reuse pipeline.NewPipelineContext for multiple files merged into one logic context yet.
 	// But we can parse each file individually.
 
-	for i, file := range sourceFiles {
-		content, err := os.ReadFile(file)
-		if err != nil {
-			return nil, err
-		}
+	for i, source := range sourceFiles {
+		file, content := source.Path, source.Content
 
 		// Parse file manually to avoid dependency cycles with processors if any,
 		// and to have fine-grained control.
@@ -345,6 +345,7 @@ func (l *Loader) loadDir(absPath string) (*Module, error) {
 		}
 
 		module.Files = append(module.Files, root)
+		module.FilePaths = append(module.FilePaths, file)
 
 		// Check Package Declaration
 		var currentFilePackage string
@@ -475,3 +476,84 @@ func (l *Loader) loadDir(absPath string) (*Module, error) {
 	l.ModulesByName[packageName] = module // Index by package name
 	return module, nil
 }
+
+// restoreModule creates an already analyzed module from a cache entry.
+// Its dependencies are loaded as well, as analysis would have done.
+func (l *Loader) restoreModule(absPath string, entry *CacheEntry) (*Module, error) {
+	headerTable := symbols.NewEmptySymbolTable()
+	if err := headerTable.GobDecode(entry.HeaderSymbols); err != nil {
+		return nil, fmt.Errorf("corrupted cache entry for %s: %v", absPath, err)
+	}
+
+	module := &Module{
+		Name:              entry.Name,
+		Dir:               absPath,
+		SymbolTable:       headerTable,
+		Exports:           entry.Exports,
+		SourceHash:        entry.SourceHash,
+		Cached:            entry,
+		HeadersAnalyzed:   true,
+		bodiesSymbolTable: entry.SymbolTable,
+	}
+	if module.Exports == nil {
+		module.Exports = make(map[string]bool)
+	}
+	l.LoadedModules[absPath] = module
+	l.ModulesByName[module.Name] = module // Index by package name
+
+	for _, dep := range entry.Deps {
+		depMod, err := l.GetModule(dep)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependency '%s' in module '%s': %v", dep, module.Name, err)
+		}
+		if m, ok := depMod.(*Module); ok {
+			module.cachedDeps = append(module.cachedDeps, m)
+		}
+	}
+	return module, nil
+}
+
+// moduleDeps returns the directories of user modules imported by a module,
+// along with the import paths they were resolved from
+func (l *Loader) moduleDeps(mod *Module) (deps []string, imports []string) {
+	seen := make(map[string]bool)
+	for _, file := range mod.Files {
+		for _, stmt := range file.Statements {
+			imp, ok := stmt.(*ast.ImportStatement)
+			if !ok || GetVirtualPackage(imp.Path.Value) != nil {
+				continue
+			}
+			dep := l.ResolveImport(mod.Dir, imp.Path.Value)
+			if !seen[dep] {
+				seen[dep] = true
+				deps = append(deps, dep)
+				imports = append(imports, imp.Path.Value)
+			}
+		}
+	}
+	return deps, imports
+}
+
+// sameResolution reports whether a cached module's imports still resolve to the
+// same directories (funxy.mod, vendor/ or FUNXY_PATH may have changed)
+func (l *Loader) sameResolution(absPath string, entry *CacheEntry) bool {
+	if len(entry.Imports) != len(entry.Deps) {
+		return false
+	}
+	for i, importPath := range entry.Imports {
+		if l.ResolveImport(absPath, importPath) != entry.Deps[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// StoreCache saves an analyzed module together with its compiled code.
+// Modules restored from the cache and package groups are not stored.
+func (l *Loader) StoreCache(mod *Module, code []byte) error {
+	if l.Cache == nil || mod.Cached != nil || mod.IsPackageGroup || mod.SourceHash == "" || mod.HeaderSymbols == nil {
+		return nil
+	}
+	deps, imports := l.moduleDeps(mod)
+	return l.Cache.Store(mod.Dir, mod.SourceHash, mod.Name, mod.Exports, deps, imports, mod.HeaderSymbols, mod.SymbolTable, code)
+}
@@ -0,0 +1,296 @@
+package modules
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFile marks a project root and declares its dependencies
+const ManifestFile = "funxy.mod"
+
+// PathEnvVar lists extra directories searched for non-relative imports
+const PathEnvVar = "FUNXY_PATH"
+
+// VendorDir holds vendored copies of dependencies, relative to the project root
+const VendorDir = "vendor"
+
+// Manifest is a parsed funxy.mod file:
+//
+//	# comments start with '#' or '//'
+//	module webapp
+//	require kit => ../shared/kit
+//	require json => "/opt/funxy libs/json" // quoted: contains a space
+//
+// A comment marker only starts a comment at the beginning of a line or after
+// whitespace, outside quotes. Dependency paths are relative to the directory
+// containing the manifest.
+type Manifest struct {
+	Root     string            // Directory containing funxy.mod
+	Module   string            // Project name (optional)
+	Requires map[string]string // Dependency name -> absolute directory
+	Order    []string          // Dependency names in declaration order
+}
+
+// ParseManifest parses the contents of a funxy.mod located in root
+func ParseManifest(root string, content string) (*Manifest, error) {
+	m := &Manifest{
+		Root:     root,
+		Requires: make(map[string]string),
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fields, err := manifestFields(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", ManifestFile, lineNum, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "module":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%s:%d: expected 'module <name>'", ManifestFile, lineNum)
+			}
+			m.Module = fields[1]
+		case "require":
+			if len(fields) != 4 || fields[2] != "=>" {
+				return nil, fmt.Errorf("%s:%d: expected 'require <name> => <path>'", ManifestFile, lineNum)
+			}
+			name, path := fields[1], fields[3]
+			// A name is the first segment of import paths and a directory
+			// under vendor, so it cannot contain a path separator
+			if name == "lib" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+				return nil, fmt.Errorf("%s:%d: invalid dependency name '%s'", ManifestFile, lineNum, name)
+			}
+			if _, exists := m.Requires[name]; exists {
+				return nil, fmt.Errorf("%s:%d: duplicate dependency '%s'", ManifestFile, lineNum, name)
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(root, path)
+			}
+			m.Requires[name] = filepath.Clean(path)
+			m.Order = append(m.Order, name)
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown directive '%s'", ManifestFile, lineNum, fields[0])
+		}
+	}
+	return m, nil
+}
+
+// manifestFields splits a manifest line into whitespace-separated fields.
+// Double quotes group a value containing spaces and are stripped. A comment
+// marker ('#' or '//') only counts at the start of a field, so values such as
+// "https://host/kit" or "a#b" are kept intact.
+func manifestFields(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inField, inQuotes := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case inQuotes:
+			if c == '"' {
+				inQuotes = false
+			} else {
+				field.WriteByte(c)
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case !inField && (c == '#' || strings.HasPrefix(line[i:], "//")):
+			return fields, nil
+		case c == '"':
+			inField, inQuotes = true, true
+		default:
+			inField = true
+			field.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted value")
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// FindManifest looks for funxy.mod in dir and its parents.
+// Returns nil (and no error) when there is none.
+func FindManifest(dir string) (*Manifest, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, ManifestFile)
+		if content, err := os.ReadFile(path); err == nil {
+			return ParseManifest(dir, string(content))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// SearchPath resolves non-relative imports (e.g. "kit/web"). In order, it tries:
+//  1. dependencies required by the manifest (their vendored copy first)
+//  2. the project root (directory of funxy.mod)
+//  3. directories listed in FUNXY_PATH
+//  4. the current working directory
+type SearchPath struct {
+	Manifest *Manifest // nil when the project has no funxy.mod
+	Dirs     []string  // Absolute directories from FUNXY_PATH
+}
+
+// NewSearchPath creates the search path for a program located in dir
+func NewSearchPath(dir string) (*SearchPath, error) {
+	manifest, err := FindManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &SearchPath{Manifest: manifest}
+	for _, entry := range filepath.SplitList(os.Getenv(PathEnvVar)) {
+		if entry == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(entry); err == nil {
+			sp.Dirs = append(sp.Dirs, abs)
+		}
+	}
+	return sp, nil
+}
+
+// Resolve returns the directory for a non-relative import path
+func (sp *SearchPath) Resolve(importPath string) string {
+	if filepath.IsAbs(importPath) {
+		return importPath
+	}
+
+	if m := sp.Manifest; m != nil {
+		name, rest := importPath, ""
+		if i := strings.Index(importPath, "/"); i >= 0 {
+			name, rest = importPath[:i], importPath[i+1:]
+		}
+		if dir, ok := m.Requires[name]; ok {
+			vendored := filepath.Join(m.Root, VendorDir, name)
+			if isDir(vendored) {
+				dir = vendored
+			}
+			return filepath.Join(dir, rest)
+		}
+
+		if candidate := filepath.Join(m.Root, importPath); isDir(candidate) {
+			return candidate
+		}
+	}
+
+	for _, dir := range sp.Dirs {
+		if candidate := filepath.Join(dir, importPath); isDir(candidate) {
+			return candidate
+		}
+	}
+
+	abs, _ := filepath.Abs(importPath)
+	return abs
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// ResolveImport returns the module directory for an import path as written in
+// a module located in baseDir. Virtual packages (lib/*) are returned unchanged.
+func (l *Loader) ResolveImport(baseDir, importPath string) string {
+	if GetVirtualPackage(importPath) != nil {
+		return importPath
+	}
+	if strings.HasPrefix(importPath, ".") {
+		path := filepath.Join(baseDir, importPath)
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+		return path
+	}
+	if l.SearchPath != nil {
+		return l.SearchPath.Resolve(importPath)
+	}
+	abs, _ := filepath.Abs(importPath)
+	return abs
+}
+
+// Vendor copies every dependency required by the manifest into <root>/vendor/<name>,
+// replacing previous copies. Returns the vendored dependency names.
+func (m *Manifest) Vendor() ([]string, error) {
+	vendorRoot := filepath.Join(m.Root, VendorDir)
+	for _, name := range m.Order {
+		src := m.Requires[name]
+		if !isDir(src) {
+			return nil, fmt.Errorf("dependency %s: %s is not a directory", name, src)
+		}
+		dst := filepath.Join(vendorRoot, name)
+		if isWithin(dst, src) || isWithin(src, dst) {
+			return nil, fmt.Errorf("dependency %s: %s overlaps with %s", name, src, dst)
+		}
+		if err := os.RemoveAll(dst); err != nil {
+			return nil, err
+		}
+		if err := copyDir(src, dst); err != nil {
+			return nil, fmt.Errorf("dependency %s: %v", name, err)
+		}
+	}
+	return m.Order, nil
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// copyDir recursively copies a directory, skipping hidden entries
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, info.Mode().Perm())
+	})
+}
@@ -0,0 +1,55 @@
+package modules
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifestComments(t *testing.T) {
+	root := filepath.FromSlash("/project")
+	content := `# project manifest
+// also a comment
+module https://example.com/webapp // trailing comment
+require kit => ../shared/kit#v2 # comment
+require json => "/opt/funxy libs/json" # quoted path
+require web => "//srv/web"
+`
+	m, err := ParseManifest(root, content)
+	if err != nil {
+		t.Fatalf("ParseManifest failed: %v", err)
+	}
+	if m.Module != "https://example.com/webapp" {
+		t.Errorf("module = %q, want %q", m.Module, "https://example.com/webapp")
+	}
+	want := map[string]string{
+		"kit":  filepath.Join(root, "../shared/kit#v2"),
+		"json": filepath.Clean("/opt/funxy libs/json"),
+		"web":  filepath.Clean("//srv/web"),
+	}
+	if !reflect.DeepEqual(m.Requires, want) {
+		t.Errorf("requires = %v, want %v", m.Requires, want)
+	}
+	if !reflect.DeepEqual(m.Order, []string{"kit", "json", "web"}) {
+		t.Errorf("order = %v", m.Order)
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	cases := map[string]string{
+		"require kit\n":               "funxy.mod:1: expected 'require <name> => <path>'",
+		"\nrequire kit => \"../kit\n": "funxy.mod:2: unterminated quoted value",
+		"module a b\n":                "funxy.mod:1: expected 'module <name>'",
+		"require lib => ./lib\n":      "funxy.mod:1: invalid dependency name 'lib'",
+		"require acme/kit => ./kit\n": "funxy.mod:1: invalid dependency name 'acme/kit'",
+		"require lib/x => ./x\n":      "funxy.mod:1: invalid dependency name 'lib/x'",
+		"replace kit => ../kit\n":     "funxy.mod:1: unknown directive 'replace'",
+	}
+	for content, want := range cases {
+		_, err := ParseManifest("/project", content)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseManifest(%q) error = %v, want %q", content, err, want)
+		}
+	}
+}
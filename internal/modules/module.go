@@ -11,6 +11,7 @@ type Module struct {
 	Name        string
 	Dir         string
 	Files       []*ast.Program
+	FilePaths   []string // Source file of each entry in Files
 	SymbolTable *symbols.SymbolTable
 	Exports     map[string]bool              // Set of exported symbol names (local + resolved re-exports)
 	Imports     map[string]*Module           // Map alias/name -> Module
@@ -31,6 +32,16 @@ type Module struct {
 	IsPackageGroup bool     // True if this is a combined package from subdirectories
 	SubPackages    []string // Names of sub-packages (e.g., ["utils", "helpers"])
 
+	// Persistent cache support
+	SourceHash    string      // Hash of the module's source files (set when caching is enabled)
+	Cached        *CacheEntry // Entry the module was restored from (no Files/TypeMap then)
+	HeaderSymbols []byte      // Serialized SymbolTable as importers see it after headers analysis
+
+	// Restored modules expose the headers-stage table until their bodies count as
+	// analyzed, matching what importers observe when analyzing from source
+	bodiesSymbolTable *symbols.SymbolTable
+	cachedDeps        []*Module
+
 	HeadersAnalyzed  bool
 	HeadersAnalyzing bool
 	BodiesAnalyzed   bool
@@ -95,6 +106,21 @@ func (m *Module) IsBodiesAnalyzed() bool {
 
 func (m *Module) SetBodiesAnalyzed(v bool) {
 	m.BodiesAnalyzed = v
+	if v && m.bodiesSymbolTable != nil {
+		m.SymbolTable = m.bodiesSymbolTable
+		m.bodiesSymbolTable = nil
+		// Analyzing a module's bodies analyzes its imports too
+		for _, dep := range m.cachedDeps {
+			dep.SetBodiesAnalyzed(true)
+		}
+	}
+	if v && m.IsPackageGroup {
+		for _, subMod := range m.Imports {
+			if subMod.bodiesSymbolTable != nil {
+				subMod.SetBodiesAnalyzed(true)
+			}
+		}
+	}
 }
 
 func (m *Module) IsBodiesAnalyzing() bool {
@@ -102,6 +128,10 @@ func (m *Module) IsBodiesAnalyzing() bool {
 }
 
 func (m *Module) SetBodiesAnalyzing(v bool) {
+	// Snapshot the headers-stage table for the persistent cache before bodies refine it
+	if v && m.SourceHash != "" && m.Cached == nil && m.HeaderSymbols == nil {
+		m.HeaderSymbols, _ = m.SymbolTable.GobEncode()
+	}
 	m.BodiesAnalyzing = v
 }
 
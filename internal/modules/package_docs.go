@@ -0,0 +1,304 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// Documentation for user packages is extracted from analyzed modules: declarations
+// come from the AST, signatures from the module's symbol table (inferred types),
+// and descriptions from the '//' comment lines directly above each declaration.
+
+// GenerateModuleDocs documents the exported API of an analyzed module.
+// A package group yields one DocPackage per sub-package.
+func GenerateModuleDocs(mod *Module) []*DocPackage {
+	if mod.IsPackageGroup {
+		var names []string
+		for name := range mod.Imports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var pkgs []*DocPackage
+		for _, name := range names {
+			pkgs = append(pkgs, GenerateModuleDocs(mod.Imports[name])...)
+		}
+		return pkgs
+	}
+
+	pkg := &DocPackage{Path: mod.Name}
+	for i, file := range mod.Files {
+		var comments docComments
+		if i < len(mod.FilePaths) {
+			comments = readDocComments(mod.FilePaths[i])
+		}
+
+		for _, stmt := range file.Statements {
+			switch n := stmt.(type) {
+			case *ast.PackageDeclaration:
+				if doc := comments.before(n.Token.Line); doc != "" {
+					if pkg.Description != "" {
+						pkg.Description += "\n\n"
+					}
+					pkg.Description += doc
+				}
+			case *ast.FunctionStatement:
+				if n.Name == nil || !mod.Exports[n.Name.Value] {
+					continue
+				}
+				pkg.Functions = append(pkg.Functions, functionDoc(mod, n, comments))
+			case *ast.ExpressionStatement:
+				assign, ok := n.Expression.(*ast.AssignExpression)
+				if !ok {
+					continue
+				}
+				if ident, ok := assign.Left.(*ast.Identifier); ok && mod.Exports[ident.Value] {
+					pkg.Functions = append(pkg.Functions, valueDoc(mod, ident.Value, n.Token.Line, comments))
+				}
+			case *ast.ConstantDeclaration:
+				if n.Name != nil && mod.Exports[n.Name.Value] {
+					pkg.Functions = append(pkg.Functions, valueDoc(mod, n.Name.Value, n.Token.Line, comments))
+				}
+			case *ast.TypeDeclarationStatement:
+				if n.Name != nil && mod.Exports[n.Name.Value] {
+					pkg.Types = append(pkg.Types, typeDoc(mod, n, comments))
+				}
+			case *ast.TraitDeclaration:
+				if n.Name != nil && mod.Exports[n.Name.Value] {
+					pkg.Traits = append(pkg.Traits, traitDoc(mod, n, comments))
+				}
+			case *ast.InstanceDeclaration:
+				// Instances are global: always part of the package's API
+				pkg.Instances = append(pkg.Instances, instanceDoc(n, comments))
+			}
+		}
+	}
+	return []*DocPackage{pkg}
+}
+
+// docComments holds the lines of a source file for doc comment lookup
+type docComments []string
+
+func readDocComments(path string) docComments {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(content), "\n")
+}
+
+// before returns the '//' comment block ending right above line (1-based)
+func (c docComments) before(line int) string {
+	var block []string
+	for i := line - 2; i >= 0 && i < len(c); i-- {
+		text := strings.TrimSpace(c[i])
+		if !strings.HasPrefix(text, "//") {
+			break
+		}
+		text = strings.TrimPrefix(text, "//")
+		text = strings.TrimPrefix(text, " ")
+		block = append([]string{text}, block...)
+	}
+	return strings.TrimSpace(strings.Join(block, "\n"))
+}
+
+func functionDoc(mod *Module, n *ast.FunctionStatement, comments docComments) *DocEntry {
+	entry := &DocEntry{
+		Name:        n.Name.Value,
+		Description: comments.before(n.Token.Line),
+	}
+
+	if n.Receiver != nil {
+		// Extension method: (s: Shape) size : () -> Int
+		entry.Category = "Extension methods"
+		typeName := astTypeString(n.Receiver.Type)
+		receiver := fmt.Sprintf("(%s: %s)", n.Receiver.Name.Value, typeName)
+		entry.Name = receiver + " " + n.Name.Value
+		if named, ok := n.Receiver.Type.(*ast.NamedType); ok {
+			if t, ok := mod.SymbolTable.GetExtensionMethod(named.Name.Value, n.Name.Value); ok {
+				entry.Signature = typeSignature(t)
+			}
+		}
+		return entry
+	}
+
+	if sym, ok := mod.SymbolTable.Find(n.Name.Value); ok && sym.Type != nil {
+		entry.Signature = typeSignature(sym.Type)
+	}
+	if len(n.Constraints) > 0 {
+		var constraints []string
+		for _, c := range n.Constraints {
+			constraints = append(constraints, c.TypeVar+": "+c.Trait)
+		}
+		entry.Signature = fmt.Sprintf("<%s> %s", strings.Join(constraints, ", "), entry.Signature)
+	}
+	return entry
+}
+
+func valueDoc(mod *Module, name string, line int, comments docComments) *DocEntry {
+	entry := &DocEntry{
+		Name:        name,
+		Description: comments.before(line),
+		Category:    "Values",
+	}
+	if sym, ok := mod.SymbolTable.Find(name); ok && sym.Type != nil {
+		entry.Signature = typeSignature(sym.Type)
+	}
+	return entry
+}
+
+func typeDoc(mod *Module, n *ast.TypeDeclarationStatement, comments docComments) *DocEntry {
+	name := n.Name.Value
+	if len(n.TypeParameters) > 0 {
+		var params []string
+		for _, p := range n.TypeParameters {
+			params = append(params, p.Value)
+		}
+		name += "<" + strings.Join(params, ", ") + ">"
+	}
+
+	entry := &DocEntry{
+		Name:        n.Name.Value,
+		Signature:   "type " + name,
+		Description: comments.before(n.Token.Line),
+	}
+	if n.IsAlias {
+		entry.Signature = "type alias " + name
+	} else if n.IsNewtype {
+		entry.Signature = "newtype " + name
+	}
+	if n.TargetType != nil {
+		target := astTypeString(n.TargetType)
+		if alias, ok := mod.SymbolTable.GetTypeAlias(n.Name.Value); ok {
+			target = typeSignature(alias)
+		}
+		entry.Signature += " = " + target
+	}
+
+	for _, c := range n.Constructors {
+		member := &DocEntry{Name: c.Name.Value}
+		if sym, ok := mod.SymbolTable.Find(c.Name.Value); ok && sym.Type != nil {
+			member.Signature = typeSignature(sym.Type)
+		}
+		entry.Members = append(entry.Members, member)
+	}
+	return entry
+}
+
+func traitDoc(mod *Module, n *ast.TraitDeclaration, comments docComments) *DocEntry {
+	signature := "trait " + n.Name.Value
+	if len(n.TypeParams) > 0 {
+		var params []string
+		for _, p := range n.TypeParams {
+			params = append(params, p.Value)
+		}
+		signature += "<" + strings.Join(params, ", ") + ">"
+	}
+	if len(n.SuperTraits) > 0 {
+		var supers []string
+		for _, st := range n.SuperTraits {
+			supers = append(supers, astTypeString(st))
+		}
+		signature += " : " + strings.Join(supers, ", ")
+	}
+
+	entry := &DocEntry{
+		Name:        n.Name.Value,
+		Signature:   signature,
+		Description: comments.before(n.Token.Line),
+	}
+	for _, method := range n.Signatures {
+		if method.Name == nil {
+			continue
+		}
+		member := &DocEntry{
+			Name:        method.Name.Value,
+			Description: comments.before(method.Token.Line),
+		}
+		if method.Operator != "" {
+			member.Name = "(" + method.Operator + ")"
+		}
+		if t, ok := mod.SymbolTable.GetTraitMethodType(method.Name.Value); ok {
+			member.Signature = typeSignature(t)
+		}
+		if method.Body != nil {
+			member.Category = "default"
+		}
+		entry.Members = append(entry.Members, member)
+	}
+	return entry
+}
+
+func instanceDoc(n *ast.InstanceDeclaration, comments docComments) *DocEntry {
+	trait := n.TraitName.Value
+	if n.ModuleName != nil {
+		trait = n.ModuleName.Value + "." + trait
+	}
+	return &DocEntry{
+		Name:        "instance " + trait + " " + astTypeString(n.Target),
+		Description: comments.before(n.Token.Line),
+	}
+}
+
+// typeSignature renders an inferred type, renaming generated type variables
+// (t12, t40, ...) to a, b, ... in order of appearance
+func typeSignature(t typesystem.Type) string {
+	subst := typesystem.RenameGeneratedVars(t)
+	if len(subst) == 0 {
+		return t.String()
+	}
+	return t.Apply(subst).String()
+}
+
+// astTypeString renders a type annotation in source syntax (e.g. Map<String, List<Int>>)
+func astTypeString(t ast.Type) string {
+	switch n := t.(type) {
+	case nil:
+		return ""
+	case *ast.NamedType:
+		if len(n.Args) == 0 {
+			return n.Name.Value
+		}
+		var args []string
+		for _, a := range n.Args {
+			args = append(args, astTypeString(a))
+		}
+		return n.Name.Value + "<" + strings.Join(args, ", ") + ">"
+	case *ast.TupleType:
+		var elems []string
+		for _, e := range n.Types {
+			elems = append(elems, astTypeString(e))
+		}
+		return "(" + strings.Join(elems, ", ") + ")"
+	case *ast.FunctionType:
+		var params []string
+		for _, p := range n.Parameters {
+			params = append(params, astTypeString(p))
+		}
+		return "(" + strings.Join(params, ", ") + ") -> " + astTypeString(n.ReturnType)
+	case *ast.RecordType:
+		var names []string
+		for name := range n.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var fields []string
+		for _, name := range names {
+			fields = append(fields, name+": "+astTypeString(n.Fields[name]))
+		}
+		return "{ " + strings.Join(fields, ", ") + " }"
+	case *ast.UnionType:
+		var types []string
+		for _, u := range n.Types {
+			types = append(types, astTypeString(u))
+		}
+		return strings.Join(types, " | ")
+	default:
+		return t.TokenLiteral()
+	}
+}
@@ -16,6 +16,9 @@ type VirtualPackage struct {
 	// Types exported by this package (registered on import)
 	Types map[string]typesystem.Type
 
+	// Kinds of the exported type constructors that take parameters, e.g. * -> * for Task
+	Kinds map[string]typesystem.Kind
+
 	// ADT constructors exported by this package
 	Constructors map[string]typesystem.Type
 
@@ -81,6 +84,9 @@ func (vp *VirtualPackage) CreateVirtualModule() *Module {
 		mod.Exports[name] = true
 		mod.SymbolTable.DefineType(name, typ, origin)
 	}
+	for name, kind := range vp.Kinds {
+		mod.SymbolTable.RegisterKind(name, kind)
+	}
 
 	// Register constructors exported by this package
 	for name, typ := range vp.Constructors {
@@ -98,7 +104,11 @@ func (vp *VirtualPackage) CreateVirtualModule() *Module {
 	// Register all simple symbols as exported
 	for name, typ := range vp.Symbols {
 		mod.Exports[name] = true
-		mod.SymbolTable.Define(name, typ, origin)
+		if _, ok := typ.(typesystem.TFunc); ok {
+			mod.SymbolTable.DefineFunction(name, typ, origin)
+		} else {
+			mod.SymbolTable.Define(name, typ, origin)
+		}
 	}
 
 	// Register traits
@@ -157,6 +167,14 @@ func InitVirtualPackages() {
 	initUuidPackage()
 	initLogPackage()
 	initTaskPackage()
+	initActorPackage()
+	initChanPackage()
+	initRefPackage()
+	initStreamPackage()
+	initSetPackage()
+	initOrderedPackage()
+	initHeapPackage()
+	initReflectPackage()
 	initCsvPackage()
 	initFlagPackage()
 
@@ -280,6 +298,15 @@ func initListPackage() {
 			// Generation
 			"range": typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, typesystem.Int}, ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{typesystem.Int}}},
 
+			// Parallel
+			"parMap":    typesystem.TFunc{Params: []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: U}, listT}, ReturnType: listU},
+			"parFilter": typesystem.TFunc{Params: []typesystem.Type{predicateT, listT}, ReturnType: listT},
+			"parFold": typesystem.TFunc{
+				Params:      []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: U}, listT},
+				ReturnType:  U,
+				Constraints: []typesystem.Constraint{{TypeVar: "U", Trait: "Monoid"}},
+			},
+
 			// Sorting
 			"sort": typesystem.TFunc{
 				Params:      []typesystem.Type{listT},
@@ -458,6 +485,10 @@ func initBytesPackage() {
 		Args:        []typesystem.Type{stringType, stringType},
 	}
 
+	// Sized integers: T with Integral<T>
+	sizedType := typesystem.TVar{Name: "T"}
+	integralSized := []typesystem.Constraint{{TypeVar: "T", Trait: config.IntegralTraitName}}
+
 	pkg := &VirtualPackage{
 		Name: "bytes",
 		Symbols: map[string]typesystem.Type{
@@ -483,8 +514,8 @@ func initBytesPackage() {
 			"bytesConcat": typesystem.TFunc{Params: []typesystem.Type{bytesType, bytesType}, ReturnType: bytesType},
 
 			// Numeric encoding/decoding (with default big-endian)
-			"bytesEncodeInt":   typesystem.TFunc{Params: []typesystem.Type{intType, intType, stringType}, ReturnType: bytesType, DefaultCount: 1},
-			"bytesDecodeInt":   typesystem.TFunc{Params: []typesystem.Type{bytesType, stringType}, ReturnType: intType, DefaultCount: 1},
+			"bytesEncodeInt":   typesystem.TFunc{Params: []typesystem.Type{sizedType, intType, stringType}, ReturnType: bytesType, DefaultCount: 1, Constraints: integralSized},
+			"bytesDecodeInt":   typesystem.TFunc{Params: []typesystem.Type{bytesType, stringType, typesystem.TType{Type: sizedType}}, ReturnType: sizedType, DefaultCount: 2, Constraints: integralSized},
 			"bytesEncodeFloat": typesystem.TFunc{Params: []typesystem.Type{typesystem.TCon{Name: "Float"}, intType}, ReturnType: bytesType},
 			"bytesDecodeFloat": typesystem.TFunc{Params: []typesystem.Type{bytesType, intType}, ReturnType: typesystem.TCon{Name: "Float"}},
 
@@ -911,6 +942,11 @@ func initBignumPackage() {
 		Constructor: typesystem.TCon{Name: "Option"},
 		Args:        []typesystem.Type{typesystem.Float},
 	}
+	// Option<Decimal>
+	optionDecimal := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "Option"},
+		Args:        []typesystem.Type{typesystem.Decimal},
+	}
 
 	pkg := &VirtualPackage{
 		Name: "bignum",
@@ -928,6 +964,19 @@ func initBignumPackage() {
 			"ratDenom":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Rational}, ReturnType: typesystem.BigInt},
 			"ratToFloat":  typesystem.TFunc{Params: []typesystem.Type{typesystem.Rational}, ReturnType: optionFloat},
 			"ratToString": typesystem.TFunc{Params: []typesystem.Type{typesystem.Rational}, ReturnType: stringType},
+
+			// Decimal
+			"decimalNew":          typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: typesystem.Decimal},
+			"decimalFromInt":      typesystem.TFunc{Params: []typesystem.Type{typesystem.Int}, ReturnType: typesystem.Decimal},
+			"decimalFromFloat":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Float}, ReturnType: typesystem.Decimal},
+			"decimalFromUnscaled": typesystem.TFunc{Params: []typesystem.Type{typesystem.BigInt, typesystem.Int}, ReturnType: typesystem.Decimal},
+			"decimalUnscaled":     typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.BigInt},
+			"decimalScale":        typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.Int},
+			"decimalRound":        typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal, typesystem.Int, stringType}, ReturnType: typesystem.Decimal, DefaultCount: 1},
+			"decimalDiv":          typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal, typesystem.Decimal, typesystem.Int, stringType}, ReturnType: optionDecimal, DefaultCount: 1},
+			"decimalToString":     typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: stringType},
+			"decimalToFloat":      typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.Float},
+			"decimalToRational":   typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: typesystem.Rational},
 		},
 	}
 
@@ -986,10 +1035,19 @@ func initJsonPackage() {
 	// Generic type variable
 	tVar := typesystem.TVar{Name: "T"}
 
+	// JsonError - where and why typed decoding failed
+	jsonErrorType := typesystem.TRecord{
+		Fields: map[string]typesystem.Type{
+			"path":    stringType,
+			"message": stringType,
+		},
+	}
+
 	pkg := &VirtualPackage{
 		Name: "json",
 		Types: map[string]typesystem.Type{
-			"Json": jsonType,
+			"Json":      jsonType,
+			"JsonError": jsonErrorType,
 		},
 		Constructors: map[string]typesystem.Type{
 			"JNull": jsonType,
@@ -1017,6 +1075,16 @@ func initJsonPackage() {
 				ReturnType: resultType(tVar),
 			},
 
+			// jsonDecodeAs(type: Type<T>, json: String) -> Result<JsonError, T>
+			// Decodes JSON string into a value of the given type
+			"jsonDecodeAs": typesystem.TFunc{
+				Params: []typesystem.Type{typesystem.TType{Type: tVar}, stringType},
+				ReturnType: typesystem.TApp{
+					Constructor: typesystem.TCon{Name: "Result"},
+					Args:        []typesystem.Type{jsonErrorType, tVar},
+				},
+			},
+
 			// jsonParse(str: String) -> Result<Json, String>
 			// Parses JSON string into Json ADT
 			"jsonParse": typesystem.TFunc{
@@ -1256,6 +1324,7 @@ func initHttpPackage() {
 				Params:       []typesystem.Type{stringType, stringType, headersType, stringType, typesystem.Int},
 				ReturnType:   resultStringResponse,
 				DefaultCount: 2,
+				ParamNames:   []string{"method", "url", "headers", "body", "timeout"},
 			},
 
 			// Set default timeout (milliseconds)
@@ -1753,17 +1822,18 @@ func initSqlPackage() {
 			// Date is NOT exported here, user must import lib/date
 		},
 		Constructors: map[string]typesystem.Type{
-			"SqlNull":   sqlValueType,
-			"SqlInt":    typesystem.TFunc{Params: []typesystem.Type{intType}, ReturnType: sqlValueType},
-			"SqlFloat":  typesystem.TFunc{Params: []typesystem.Type{typesystem.Float}, ReturnType: sqlValueType},
-			"SqlString": typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: sqlValueType},
-			"SqlBool":   typesystem.TFunc{Params: []typesystem.Type{boolType}, ReturnType: sqlValueType},
-			"SqlBytes":  typesystem.TFunc{Params: []typesystem.Type{bytesType}, ReturnType: sqlValueType},
-			"SqlTime":   typesystem.TFunc{Params: []typesystem.Type{dateType}, ReturnType: sqlValueType},
-			"SqlBigInt": typesystem.TFunc{Params: []typesystem.Type{bigIntType}, ReturnType: sqlValueType},
+			"SqlNull":    sqlValueType,
+			"SqlInt":     typesystem.TFunc{Params: []typesystem.Type{intType}, ReturnType: sqlValueType},
+			"SqlFloat":   typesystem.TFunc{Params: []typesystem.Type{typesystem.Float}, ReturnType: sqlValueType},
+			"SqlString":  typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: sqlValueType},
+			"SqlBool":    typesystem.TFunc{Params: []typesystem.Type{boolType}, ReturnType: sqlValueType},
+			"SqlBytes":   typesystem.TFunc{Params: []typesystem.Type{bytesType}, ReturnType: sqlValueType},
+			"SqlTime":    typesystem.TFunc{Params: []typesystem.Type{dateType}, ReturnType: sqlValueType},
+			"SqlBigInt":  typesystem.TFunc{Params: []typesystem.Type{bigIntType}, ReturnType: sqlValueType},
+			"SqlDecimal": typesystem.TFunc{Params: []typesystem.Type{typesystem.Decimal}, ReturnType: sqlValueType},
 		},
 		Variants: map[string][]string{
-			"SqlValue": {"SqlNull", "SqlInt", "SqlFloat", "SqlString", "SqlBool", "SqlBytes", "SqlTime", "SqlBigInt"},
+			"SqlValue": {"SqlNull", "SqlInt", "SqlFloat", "SqlString", "SqlBool", "SqlBytes", "SqlTime", "SqlBigInt", "SqlDecimal"},
 		},
 		Symbols: map[string]typesystem.Type{
 			// Connection
@@ -2123,6 +2193,9 @@ func initTaskPackage() {
 		Types: map[string]typesystem.Type{
 			"Task": taskType,
 		},
+		Kinds: map[string]typesystem.Kind{
+			"Task": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
 		Symbols: map[string]typesystem.Type{
 			// Creation
 			"async":       typesystem.TFunc{Params: []typesystem.Type{fnVoidT}, ReturnType: taskT},
@@ -2143,6 +2216,7 @@ func initTaskPackage() {
 			"taskCancel":      typesystem.TFunc{Params: []typesystem.Type{taskT}, ReturnType: typesystem.Nil},
 			"taskIsDone":      typesystem.TFunc{Params: []typesystem.Type{taskT}, ReturnType: typesystem.Bool},
 			"taskIsCancelled": typesystem.TFunc{Params: []typesystem.Type{taskT}, ReturnType: typesystem.Bool},
+			"taskScope":       typesystem.TFunc{Params: []typesystem.Type{fnVoidT}, ReturnType: resultStringT},
 
 			// Pool
 			"taskSetGlobalPool": typesystem.TFunc{Params: []typesystem.Type{typesystem.Int}, ReturnType: typesystem.Nil},
@@ -2287,3 +2361,447 @@ func initFlagPackage() {
 
 	RegisterVirtualPackage("lib/flag", pkg)
 }
+
+// initActorPackage registers the lib/actor virtual package
+func initActorPackage() {
+	T := typesystem.TVar{Name: "T"}
+	R := typesystem.TVar{Name: "R"}
+
+	pid := typesystem.TCon{Name: "Pid"}
+	signal := typesystem.TCon{Name: "Signal"}
+
+	stringType := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "List"},
+		Args:        []typesystem.Type{typesystem.Char},
+	}
+
+	optionType := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{
+			Constructor: typesystem.TCon{Name: "Option"},
+			Args:        []typesystem.Type{t},
+		}
+	}
+
+	// () -> T - process body
+	thunk := typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: T}
+
+	// (T) -> Option<R> - receive handler, Zero leaves the message queued
+	handler := typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: optionType(R)}
+
+	pidToNil := typesystem.TFunc{Params: []typesystem.Type{pid}, ReturnType: typesystem.Nil}
+
+	pkg := &VirtualPackage{
+		Name: "actor",
+		Types: map[string]typesystem.Type{
+			"Pid":    pid,
+			"Signal": signal,
+		},
+		Constructors: map[string]typesystem.Type{
+			"Down": typesystem.TFunc{Params: []typesystem.Type{pid, stringType}, ReturnType: signal},
+			"Exit": typesystem.TFunc{Params: []typesystem.Type{pid, stringType}, ReturnType: signal},
+		},
+		Variants: map[string][]string{
+			"Signal": {"Down", "Exit"},
+		},
+		Symbols: map[string]typesystem.Type{
+			// Processes
+			"spawn":     typesystem.TFunc{Params: []typesystem.Type{thunk}, ReturnType: pid},
+			"spawnLink": typesystem.TFunc{Params: []typesystem.Type{thunk}, ReturnType: pid},
+			"self":      typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: pid},
+			"isAlive":   typesystem.TFunc{Params: []typesystem.Type{pid}, ReturnType: typesystem.Bool},
+			"exit":      typesystem.TFunc{Params: []typesystem.Type{pid, stringType}, ReturnType: typesystem.Nil},
+
+			// Messages
+			"send":           typesystem.TFunc{Params: []typesystem.Type{pid, T}, ReturnType: typesystem.Nil},
+			"receive":        typesystem.TFunc{Params: []typesystem.Type{handler}, ReturnType: R},
+			"receiveTimeout": typesystem.TFunc{Params: []typesystem.Type{handler, typesystem.Int}, ReturnType: optionType(R)},
+
+			// Links and monitors
+			"link":      pidToNil,
+			"unlink":    pidToNil,
+			"monitor":   pidToNil,
+			"demonitor": pidToNil,
+			"trapExit":  typesystem.TFunc{Params: []typesystem.Type{typesystem.Bool}, ReturnType: typesystem.Nil},
+
+			// Registry
+			"register": typesystem.TFunc{Params: []typesystem.Type{stringType, pid}, ReturnType: typesystem.Bool},
+			"whereis":  typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: optionType(pid)},
+
+			// Supervision
+			"supervise": typesystem.TFunc{
+				Params: []typesystem.Type{
+					stringType,
+					typesystem.Int,
+					typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{thunk}},
+				},
+				ReturnType: pid,
+			},
+			"supervisorChildren": typesystem.TFunc{
+				Params:     []typesystem.Type{pid},
+				ReturnType: typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{pid}},
+			},
+		},
+	}
+
+	RegisterVirtualPackage("lib/actor", pkg)
+}
+
+// initChanPackage registers the lib/chan virtual package
+func initChanPackage() {
+	T := typesystem.TVar{Name: "T"}
+	R := typesystem.TVar{Name: "R"}
+
+	chanType := typesystem.TCon{Name: "Chan"}
+	caseType := typesystem.TCon{Name: "SelectCase"}
+
+	chanT := typesystem.TApp{Constructor: chanType, Args: []typesystem.Type{T}}
+	caseR := typesystem.TApp{Constructor: caseType, Args: []typesystem.Type{R}}
+
+	listCaseR := typesystem.TApp{
+		Constructor: typesystem.TCon{Name: "List"},
+		Args:        []typesystem.Type{caseR},
+	}
+
+	optionType := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{
+			Constructor: typesystem.TCon{Name: "Option"},
+			Args:        []typesystem.Type{t},
+		}
+	}
+
+	pkg := &VirtualPackage{
+		Name: "chan",
+		Types: map[string]typesystem.Type{
+			"Chan":       chanType,
+			"SelectCase": caseType,
+		},
+		Kinds: map[string]typesystem.Kind{
+			"Chan":       typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+			"SelectCase": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
+		Symbols: map[string]typesystem.Type{
+			// Channels
+			"chanNew":   typesystem.TFunc{Params: []typesystem.Type{typesystem.Int}, ReturnType: chanT},
+			"chanSend":  typesystem.TFunc{Params: []typesystem.Type{chanT, T}, ReturnType: typesystem.Nil},
+			"chanRecv":  typesystem.TFunc{Params: []typesystem.Type{chanT}, ReturnType: optionType(T)},
+			"chanClose": typesystem.TFunc{Params: []typesystem.Type{chanT}, ReturnType: typesystem.Nil},
+
+			// Select
+			"onRecv": typesystem.TFunc{
+				Params:     []typesystem.Type{chanT, typesystem.TFunc{Params: []typesystem.Type{optionType(T)}, ReturnType: R}},
+				ReturnType: caseR,
+			},
+			"onSend": typesystem.TFunc{
+				Params:     []typesystem.Type{chanT, T, typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: R}},
+				ReturnType: caseR,
+			},
+			"select":        typesystem.TFunc{Params: []typesystem.Type{listCaseR}, ReturnType: R},
+			"selectTimeout": typesystem.TFunc{Params: []typesystem.Type{listCaseR, typesystem.Int}, ReturnType: optionType(R)},
+		},
+	}
+
+	RegisterVirtualPackage("lib/chan", pkg)
+}
+
+// initRefPackage registers the lib/ref virtual package
+func initRefPackage() {
+	T := typesystem.TVar{Name: "T"}
+
+	refType := typesystem.TCon{Name: "Ref"}
+	refT := typesystem.TApp{Constructor: refType, Args: []typesystem.Type{T}}
+
+	pkg := &VirtualPackage{
+		Name: "ref",
+		Types: map[string]typesystem.Type{
+			"Ref": refType,
+		},
+		Kinds: map[string]typesystem.Kind{
+			"Ref": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
+		Symbols: map[string]typesystem.Type{
+			"refNew": typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: refT},
+			"refGet": typesystem.TFunc{Params: []typesystem.Type{refT}, ReturnType: T},
+			"refSet": typesystem.TFunc{Params: []typesystem.Type{refT, T}, ReturnType: typesystem.Nil},
+			"refUpdate": typesystem.TFunc{
+				Params:     []typesystem.Type{refT, typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: T}},
+				ReturnType: T,
+			},
+		},
+	}
+
+	RegisterVirtualPackage("lib/ref", pkg)
+}
+
+// initStreamPackage registers the lib/stream virtual package
+func initStreamPackage() {
+	T := typesystem.TVar{Name: "T"}
+	U := typesystem.TVar{Name: "U"}
+	A := typesystem.TVar{Name: "A"}
+	B := typesystem.TVar{Name: "B"}
+
+	streamType := typesystem.TCon{Name: "Stream"}
+	streamOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: streamType, Args: []typesystem.Type{t}}
+	}
+	listOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{t}}
+	}
+	optionT := typesystem.TApp{Constructor: typesystem.TCon{Name: "Option"}, Args: []typesystem.Type{T}}
+	stringType := listOf(typesystem.Char)
+	streamT := streamOf(T)
+
+	pkg := &VirtualPackage{
+		Name: "stream",
+		Types: map[string]typesystem.Type{
+			"Stream": streamType,
+		},
+		Kinds: map[string]typesystem.Kind{
+			"Stream": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
+		Symbols: map[string]typesystem.Type{
+			// Sources
+			"streamFromList": typesystem.TFunc{Params: []typesystem.Type{listOf(T)}, ReturnType: streamT},
+			"streamRange":    typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, typesystem.Int}, ReturnType: streamOf(typesystem.Int)},
+			"streamIterate": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: T}, T},
+				ReturnType: streamT,
+			},
+			"streamLines": typesystem.TFunc{Params: []typesystem.Type{stringType}, ReturnType: streamOf(stringType)},
+			"streamFromChan": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TApp{Constructor: typesystem.TCon{Name: "Chan"}, Args: []typesystem.Type{T}}},
+				ReturnType: streamT,
+			},
+			"streamFromIter": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: optionT}},
+				ReturnType: streamT,
+			},
+
+			// Combinators
+			"streamMap": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: U}, streamT},
+				ReturnType: streamOf(U),
+			},
+			"streamFilter": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: typesystem.Bool}, streamT},
+				ReturnType: streamT,
+			},
+			"streamFlatMap": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: streamOf(U)}, streamT},
+				ReturnType: streamOf(U),
+			},
+			"streamTake": typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamT},
+			"streamDrop": typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamT},
+			"streamZip": typesystem.TFunc{
+				Params:     []typesystem.Type{streamOf(A), streamOf(B)},
+				ReturnType: streamOf(typesystem.TTuple{Elements: []typesystem.Type{A, B}}),
+			},
+			"streamChunk":  typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamOf(listOf(T))},
+			"streamWindow": typesystem.TFunc{Params: []typesystem.Type{typesystem.Int, streamT}, ReturnType: streamOf(listOf(T))},
+
+			// Terminals
+			"streamToList": typesystem.TFunc{Params: []typesystem.Type{streamT}, ReturnType: listOf(T)},
+			"streamFold": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{U, T}, ReturnType: U}, U, streamT},
+				ReturnType: U,
+			},
+			"streamForEach": typesystem.TFunc{
+				Params:     []typesystem.Type{typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: typesystem.Nil}, streamT},
+				ReturnType: typesystem.Nil,
+			},
+			"streamCount": typesystem.TFunc{Params: []typesystem.Type{streamT}, ReturnType: typesystem.Int},
+			"streamFirst": typesystem.TFunc{Params: []typesystem.Type{streamT}, ReturnType: optionT},
+		},
+	}
+
+	RegisterVirtualPackage("lib/stream", pkg)
+}
+
+// initSetPackage registers the lib/set virtual package
+func initSetPackage() {
+	T := typesystem.TVar{Name: "T"}
+
+	setType := typesystem.TCon{Name: "Set"}
+	setT := typesystem.TApp{Constructor: setType, Args: []typesystem.Type{T}}
+	listT := typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{T}}
+
+	pkg := &VirtualPackage{
+		Name: "set",
+		Types: map[string]typesystem.Type{
+			"Set": setType,
+		},
+		Kinds: map[string]typesystem.Kind{
+			"Set": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
+		Symbols: map[string]typesystem.Type{
+			"setNew":        typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: setT},
+			"setFrom":       typesystem.TFunc{Params: []typesystem.Type{listT}, ReturnType: setT},
+			"setAdd":        typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT},
+			"setRemove":     typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT},
+			"setContains":   typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: typesystem.Bool},
+			"setSize":       typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: typesystem.Int},
+			"setToList":     typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: listT},
+			"setUnion":      typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT},
+			"setIntersect":  typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT},
+			"setDifference": typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT},
+			"setIsSubset":   typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: typesystem.Bool},
+		},
+	}
+
+	RegisterVirtualPackage("lib/set", pkg)
+}
+
+// initOrderedPackage registers the lib/ordered virtual package
+func initOrderedPackage() {
+	T := typesystem.TVar{Name: "T"}
+	K := typesystem.TVar{Name: "K"}
+	V := typesystem.TVar{Name: "V"}
+
+	mapType := typesystem.TCon{Name: "OrderedMap"}
+	setType := typesystem.TCon{Name: "OrderedSet"}
+	mapKV := typesystem.TApp{Constructor: mapType, Args: []typesystem.Type{K, V}}
+	setT := typesystem.TApp{Constructor: setType, Args: []typesystem.Type{T}}
+
+	listOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{t}}
+	}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "Option"}, Args: []typesystem.Type{t}}
+	}
+	pairKV := typesystem.TTuple{Elements: []typesystem.Type{K, V}}
+	orderK := []typesystem.Constraint{{TypeVar: "K", Trait: "Order"}}
+	orderT := []typesystem.Constraint{{TypeVar: "T", Trait: "Order"}}
+
+	pkg := &VirtualPackage{
+		Name: "ordered",
+		Types: map[string]typesystem.Type{
+			"OrderedMap": mapType,
+			"OrderedSet": setType,
+		},
+		Kinds: map[string]typesystem.Kind{
+			"OrderedMap": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star}},
+			"OrderedSet": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
+		Symbols: map[string]typesystem.Type{
+			// OrderedMap
+			"orderedMapNew":      typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: mapKV},
+			"orderedMapFrom":     typesystem.TFunc{Params: []typesystem.Type{listOf(pairKV)}, ReturnType: mapKV, Constraints: orderK},
+			"orderedMapPut":      typesystem.TFunc{Params: []typesystem.Type{mapKV, K, V}, ReturnType: mapKV, Constraints: orderK},
+			"orderedMapGet":      typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: optionOf(V), Constraints: orderK},
+			"orderedMapRemove":   typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: mapKV, Constraints: orderK},
+			"orderedMapContains": typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: typesystem.Bool, Constraints: orderK},
+			"orderedMapSize":     typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: typesystem.Int},
+			"orderedMapKeys":     typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: listOf(K)},
+			"orderedMapValues":   typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: listOf(V)},
+			"orderedMapItems":    typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: listOf(pairKV)},
+			"orderedMapMin":      typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: optionOf(pairKV)},
+			"orderedMapMax":      typesystem.TFunc{Params: []typesystem.Type{mapKV}, ReturnType: optionOf(pairKV)},
+			"orderedMapFloor":    typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: optionOf(pairKV), Constraints: orderK},
+			"orderedMapCeiling":  typesystem.TFunc{Params: []typesystem.Type{mapKV, K}, ReturnType: optionOf(pairKV), Constraints: orderK},
+			"orderedMapRange":    typesystem.TFunc{Params: []typesystem.Type{mapKV, K, K}, ReturnType: mapKV, Constraints: orderK},
+
+			// OrderedSet
+			"orderedSetNew":        typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: setT},
+			"orderedSetFrom":       typesystem.TFunc{Params: []typesystem.Type{listOf(T)}, ReturnType: setT, Constraints: orderT},
+			"orderedSetAdd":        typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT, Constraints: orderT},
+			"orderedSetRemove":     typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: setT, Constraints: orderT},
+			"orderedSetContains":   typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: typesystem.Bool, Constraints: orderT},
+			"orderedSetSize":       typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: typesystem.Int},
+			"orderedSetToList":     typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: listOf(T)},
+			"orderedSetMin":        typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: optionOf(T)},
+			"orderedSetMax":        typesystem.TFunc{Params: []typesystem.Type{setT}, ReturnType: optionOf(T)},
+			"orderedSetFloor":      typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: optionOf(T), Constraints: orderT},
+			"orderedSetCeiling":    typesystem.TFunc{Params: []typesystem.Type{setT, T}, ReturnType: optionOf(T), Constraints: orderT},
+			"orderedSetRange":      typesystem.TFunc{Params: []typesystem.Type{setT, T, T}, ReturnType: setT, Constraints: orderT},
+			"orderedSetUnion":      typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT, Constraints: orderT},
+			"orderedSetIntersect":  typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT, Constraints: orderT},
+			"orderedSetDifference": typesystem.TFunc{Params: []typesystem.Type{setT, setT}, ReturnType: setT, Constraints: orderT},
+		},
+	}
+
+	RegisterVirtualPackage("lib/ordered", pkg)
+}
+
+// initHeapPackage registers the lib/heap virtual package
+func initHeapPackage() {
+	T := typesystem.TVar{Name: "T"}
+
+	heapType := typesystem.TCon{Name: "Heap"}
+	heapT := typesystem.TApp{Constructor: heapType, Args: []typesystem.Type{T}}
+	listT := typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{T}}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "Option"}, Args: []typesystem.Type{t}}
+	}
+	orderT := []typesystem.Constraint{{TypeVar: "T", Trait: "Order"}}
+
+	pkg := &VirtualPackage{
+		Name: "heap",
+		Types: map[string]typesystem.Type{
+			"Heap": heapType,
+		},
+		Kinds: map[string]typesystem.Kind{
+			"Heap": typesystem.KArrow{Left: typesystem.Star, Right: typesystem.Star},
+		},
+		Symbols: map[string]typesystem.Type{
+			"heapNew":    typesystem.TFunc{Params: []typesystem.Type{}, ReturnType: heapT},
+			"heapFrom":   typesystem.TFunc{Params: []typesystem.Type{listT}, ReturnType: heapT, Constraints: orderT},
+			"heapPush":   typesystem.TFunc{Params: []typesystem.Type{heapT, T}, ReturnType: heapT, Constraints: orderT},
+			"heapPeek":   typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: optionOf(T)},
+			"heapPop":    typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: optionOf(typesystem.TTuple{Elements: []typesystem.Type{T, heapT}})},
+			"heapSize":   typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: typesystem.Int},
+			"heapToList": typesystem.TFunc{Params: []typesystem.Type{heapT}, ReturnType: listT},
+			"heapMerge":  typesystem.TFunc{Params: []typesystem.Type{heapT, heapT}, ReturnType: heapT, Constraints: orderT},
+		},
+	}
+
+	RegisterVirtualPackage("lib/heap", pkg)
+}
+
+// initReflectPackage registers the lib/reflect virtual package
+func initReflectPackage() {
+	T := typesystem.TVar{Name: "T"}
+	F := typesystem.TVar{Name: "F"}
+
+	dynamicType := typesystem.TCon{Name: "Dynamic"}
+	stringType := typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{typesystem.Char}}
+	listOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "List"}, Args: []typesystem.Type{t}}
+	}
+	optionOf := func(t typesystem.Type) typesystem.Type {
+		return typesystem.TApp{Constructor: typesystem.TCon{Name: "Option"}, Args: []typesystem.Type{t}}
+	}
+	typeOfT := typesystem.TType{Type: T}
+
+	pkg := &VirtualPackage{
+		Name: "reflect",
+		Types: map[string]typesystem.Type{
+			"Dynamic": dynamicType,
+		},
+		Symbols: map[string]typesystem.Type{
+			// Dynamic values
+			"toDynamic":       typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: dynamicType},
+			"fromDynamic":     typesystem.TFunc{Params: []typesystem.Type{typeOfT, dynamicType}, ReturnType: optionOf(T)},
+			"dynamicTypeName": typesystem.TFunc{Params: []typesystem.Type{dynamicType}, ReturnType: stringType},
+
+			// Records
+			"fieldNames":   typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(stringType)},
+			"fields":       typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(typesystem.TTuple{Elements: []typesystem.Type{stringType, dynamicType}})},
+			"getField":     typesystem.TFunc{Params: []typesystem.Type{T, stringType}, ReturnType: optionOf(dynamicType)},
+			"fieldNamesOf": typesystem.TFunc{Params: []typesystem.Type{typeOfT}, ReturnType: listOf(stringType)},
+
+			// ADTs
+			"constructorName": typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: optionOf(stringType)},
+			"constructorArgs": typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(dynamicType)},
+			"constructorsOf":  typesystem.TFunc{Params: []typesystem.Type{typeOfT}, ReturnType: listOf(typesystem.TTuple{Elements: []typesystem.Type{stringType, typesystem.Int}})},
+
+			// Traits
+			"traitsOf":   typesystem.TFunc{Params: []typesystem.Type{T}, ReturnType: listOf(stringType)},
+			"implements": typesystem.TFunc{Params: []typesystem.Type{T, stringType}, ReturnType: typesystem.Bool},
+
+			// Functions
+			"functionArity":  typesystem.TFunc{Params: []typesystem.Type{F}, ReturnType: typesystem.Int},
+			"functionParams": typesystem.TFunc{Params: []typesystem.Type{F}, ReturnType: listOf(stringType)},
+		},
+	}
+
+	RegisterVirtualPackage("lib/reflect", pkg)
+}
@@ -1,11 +1,14 @@
 package parser
 
 import (
+	"fmt"
 	"math/big"
 	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/config"
 	"github.com/funvibe/funxy/internal/diagnostics"
 	"github.com/funvibe/funxy/internal/lexer"
 	"github.com/funvibe/funxy/internal/token"
+	"strings"
 )
 
 func (p *Parser) parseExpression(precedence int) ast.Expression {
@@ -78,6 +81,11 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 	}
 	p.nextToken()
 	expression.Right = p.parseExpression(PREFIX)
+	// -128i8 is a single literal: 128i8 alone does not fit
+	if lit, ok := expression.Right.(*ast.SizedIntLiteral); ok && expression.Operator == "-" && !lit.Negative {
+		lit.Negative = true
+		return lit
+	}
 	return expression
 }
 
@@ -129,6 +137,7 @@ func (p *Parser) parsePostfixExpression(left ast.Expression) ast.Expression {
 }
 
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	defer p.suspendDelimiters()()
 	startToken := p.curToken
 	p.nextToken() // consume '('
 
@@ -270,6 +279,123 @@ func (p *Parser) parseForExpression() ast.Expression {
 	return expr
 }
 
+// doStep is one statement of a do block: either `name <- value` or a plain statement
+type doStep struct {
+	name  *ast.Identifier // nil for plain statements
+	arrow token.Token
+	value ast.Expression
+	stmt  ast.Statement
+}
+
+// parseDoExpression parses a do block and desugars it into >>= calls:
+//
+//	do { x <- a; y <- b; pure(x + y) }  =>  a >>= fun(x) { b >>= fun(y) { pure(x + y) } }
+//
+// A statement without a binding is sequenced as `e >>= fun(_) { ... }`.
+// Assignments stay local to the rest of the block. Type checking is left to
+// the Monad instance of >>=, so both backends see ordinary code.
+func (p *Parser) parseDoExpression() ast.Expression {
+	doToken := p.curToken
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	lbrace := p.curToken
+	p.nextToken()
+
+	var steps []doStep
+	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
+		if p.curTokenIs(token.NEWLINE) || p.curTokenIs(token.SEMICOLON) || p.curTokenIs(token.COMMA) {
+			p.nextToken()
+			continue
+		}
+
+		if (p.curTokenIs(token.IDENT_LOWER) || p.curTokenIs(token.UNDERSCORE)) && p.peekTokenIs(token.LARROW) {
+			step := doStep{name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Lexeme}}
+			p.nextToken() // consume name
+			step.arrow = p.curToken
+			p.nextToken() // consume <-
+			step.value = p.parseExpression(LOWEST)
+			if step.value == nil {
+				return nil
+			}
+			steps = append(steps, step)
+		} else {
+			stmt := p.parseExpressionStatement()
+			if stmt.Expression == nil {
+				return nil
+			}
+			steps = append(steps, doStep{stmt: stmt})
+		}
+		p.nextToken()
+	}
+
+	if !p.curTokenIs(token.RBRACE) {
+		p.ctx.Errors = append(p.ctx.Errors, diagnostics.NewError(diagnostics.ErrP007, doToken, "missing closing '}'"))
+		return nil
+	}
+	if len(steps) == 0 {
+		p.ctx.Errors = append(p.ctx.Errors, diagnostics.NewError(diagnostics.ErrP007, doToken, "empty do block"))
+		return nil
+	}
+
+	last := steps[len(steps)-1]
+	if last.name != nil {
+		p.ctx.Errors = append(p.ctx.Errors, diagnostics.NewError(diagnostics.ErrP007, last.arrow,
+			"the last statement must be an expression, not a binding"))
+		return nil
+	}
+
+	// Build continuations from the end
+	body := &ast.BlockStatement{Token: lbrace, Statements: []ast.Statement{last.stmt}}
+	for i := len(steps) - 2; i >= 0; i-- {
+		step := steps[i]
+		if step.name == nil {
+			if isDoLocalStatement(step.stmt) {
+				body.Statements = append([]ast.Statement{step.stmt}, body.Statements...)
+				continue
+			}
+			// Sequence: e >>= fun(_) { ... }
+			tok := step.stmt.(*ast.ExpressionStatement).Token
+			step.name = &ast.Identifier{Token: tok, Value: "_"}
+			step.arrow = tok
+			step.value = step.stmt.(*ast.ExpressionStatement).Expression
+		}
+		bindToken := token.Token{Type: token.USER_OP_BIND, Lexeme: ">>=", Literal: ">>=", Line: step.arrow.Line, Column: step.arrow.Column}
+		continuation := &ast.FunctionLiteral{
+			Token: token.Token{Type: token.FUN, Lexeme: "fun", Literal: "fun", Line: step.arrow.Line, Column: step.arrow.Column},
+			Parameters: []*ast.Parameter{{
+				Token:     step.name.Token,
+				Name:      step.name,
+				IsIgnored: step.name.Value == "_",
+			}},
+			Body: body,
+		}
+		bind := &ast.InfixExpression{Token: bindToken, Left: step.value, Operator: ">>=", Right: continuation}
+		body = &ast.BlockStatement{Token: lbrace, Statements: []ast.Statement{
+			&ast.ExpressionStatement{Token: bindToken, Expression: bind},
+		}}
+	}
+
+	if len(body.Statements) == 1 {
+		return body.Statements[0].(*ast.ExpressionStatement).Expression
+	}
+	return body
+}
+
+// isDoLocalStatement reports whether a do block statement is a plain assignment
+// rather than a monadic action to be sequenced.
+func isDoLocalStatement(stmt ast.Statement) bool {
+	es, ok := stmt.(*ast.ExpressionStatement)
+	if !ok {
+		return false
+	}
+	switch es.Expression.(type) {
+	case *ast.AssignExpression, *ast.PatternAssignExpression:
+		return true
+	}
+	return false
+}
+
 func (p *Parser) parseMatchExpression() ast.Expression {
 	ce := &ast.MatchExpression{Token: p.curToken}
 
@@ -326,6 +452,15 @@ func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal.(string)}
 }
 
+// parseTypedHole parses ? and ?name placeholders
+func (p *Parser) parseTypedHole() ast.Expression {
+	hole := &ast.TypedHole{Token: p.curToken}
+	if p.curTokenIs(token.HOLE) {
+		hole.Name = p.curToken.Literal.(string)
+	}
+	return hole
+}
+
 // parseUnderscore parses the _ wildcard as an identifier for use in patterns
 func (p *Parser) parseUnderscore() ast.Expression {
 	return &ast.Identifier{Token: p.curToken, Value: "_"}
@@ -339,6 +474,11 @@ func (p *Parser) parseFloatLiteral() ast.Expression {
 	return &ast.FloatLiteral{Token: p.curToken, Value: p.curToken.Literal.(float64)}
 }
 
+func (p *Parser) parseSizedIntLiteral() ast.Expression {
+	lit := p.curToken.Literal.(token.SizedInt)
+	return &ast.SizedIntLiteral{Token: p.curToken, Type: lit.Type, Value: lit.Value}
+}
+
 func (p *Parser) parseBigIntLiteral() ast.Expression {
 	return &ast.BigIntLiteral{Token: p.curToken, Value: p.curToken.Literal.(*big.Int)}
 }
@@ -347,6 +487,11 @@ func (p *Parser) parseRationalLiteral() ast.Expression {
 	return &ast.RationalLiteral{Token: p.curToken, Value: p.curToken.Literal.(*big.Rat)}
 }
 
+func (p *Parser) parseDecimalLiteral() ast.Expression {
+	lit := p.curToken.Literal.(token.Decimal)
+	return &ast.DecimalLiteral{Token: p.curToken, Unscaled: lit.Unscaled, Scale: lit.Scale}
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	return &ast.BooleanLiteral{Token: p.curToken, Value: p.curTokenIs(token.TRUE)}
 }
@@ -479,7 +624,175 @@ func (p *Parser) parseBitsLiteral() ast.Expression {
 	return lit
 }
 
+// parseBitsExpression parses a binary construction: <<ver:4, len:16/little, data/binary>>
+func (p *Parser) parseBitsExpression() ast.Expression {
+	expr := &ast.BitsExpression{Token: p.curToken}
+	expr.Segments = p.parseBitsSegments(func(seg *ast.BitsSegment) bool {
+		seg.Value = p.parseBitsSegmentExpression()
+		return seg.Value != nil
+	})
+	if expr.Segments == nil {
+		return nil
+	}
+	return expr
+}
+
+// parseBitsSegmentExpression parses a segment value or size, stopping before ':', '/' and '>>'
+func (p *Parser) parseBitsSegmentExpression() ast.Expression {
+	prev := p.bitsSegment
+	p.bitsSegment = true
+	expr := p.parseExpression(LOWEST)
+	p.bitsSegment = prev
+	return expr
+}
+
+// parseBitsSegments parses the segments of a binary construction or pattern up to '>>'.
+// curToken is '<<'; parseValue parses the value part of each segment.
+func (p *Parser) parseBitsSegments(parseValue func(seg *ast.BitsSegment) bool) []*ast.BitsSegment {
+	segments := []*ast.BitsSegment{}
+	for p.peekTokenIs(token.NEWLINE) {
+		p.nextToken()
+	}
+	if p.peekTokenIs(token.RSHIFT) {
+		p.nextToken()
+		return segments
+	}
+
+	for {
+		for p.peekTokenIs(token.NEWLINE) {
+			p.nextToken()
+		}
+		p.nextToken()
+		seg := &ast.BitsSegment{Token: p.curToken}
+		if !parseValue(seg) {
+			return nil
+		}
+		if p.peekTokenIs(token.COLON) {
+			p.nextToken()
+			p.nextToken()
+			if seg.Size = p.parseBitsSegmentExpression(); seg.Size == nil {
+				return nil
+			}
+		}
+		if p.peekTokenIs(token.SLASH) {
+			p.nextToken()
+			if !p.parseBitsSpecifiers(seg) {
+				return nil
+			}
+		}
+		if seg.Type == "" {
+			seg.Type = ast.BitsInteger
+			if seg.IsStringLiteral() {
+				seg.Type = ast.BitsBinary
+			}
+		}
+		segments = append(segments, seg)
+
+		for p.peekTokenIs(token.NEWLINE) {
+			p.nextToken()
+		}
+		if p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+			continue
+		}
+		if !p.expectPeek(token.RSHIFT) {
+			return nil
+		}
+		return segments
+	}
+}
+
+var bitsSegmentTypes = map[string]string{
+	"integer":   ast.BitsInteger,
+	"float":     ast.BitsFloat,
+	"binary":    ast.BitsBinary,
+	"bytes":     ast.BitsBinary,
+	"bits":      ast.BitsBitstring,
+	"bitstring": ast.BitsBitstring,
+	"utf8":      ast.BitsUTF8,
+}
+
+// bitsIntTypes maps sized integer specifiers (int8 ... uint64, byte) to their types
+var bitsIntTypes = func() map[string]string {
+	m := map[string]string{"byte": "UInt8"}
+	for _, info := range config.IntTypes {
+		if info.Suffix != "" {
+			m[strings.ToLower(info.Name)] = info.Name
+		}
+	}
+	return m
+}()
+
+// parseBitsSpecifiers parses the specifier list after '/': big-signed-integer, unit:8.
+// curToken is '/'.
+func (p *Parser) parseBitsSpecifiers(seg *ast.BitsSegment) bool {
+	signedness := false
+	for {
+		if !p.expectPeek(token.IDENT_LOWER) {
+			return false
+		}
+		tok := p.curToken
+		name := tok.Literal.(string)
+		switch name {
+		case "big", "little", "native":
+			if seg.Endianness != "" && seg.Endianness != name {
+				p.bitsSegmentError(tok, "conflicting endianness '%s' and '%s'", seg.Endianness, name)
+			}
+			seg.Endianness = name
+		case "signed", "unsigned":
+			if seg.IntType != "" {
+				p.bitsSegmentError(tok, "signedness of a %s segment follows its type", seg.IntType)
+			}
+			seg.Signed = name == "signed"
+			signedness = true
+		case "unit":
+			if !p.expectPeek(token.COLON) || !p.expectPeek(token.INT) {
+				return false
+			}
+			seg.Unit = p.curToken.Literal.(int64)
+			if seg.Unit < 1 || seg.Unit > 256 {
+				p.bitsSegmentError(p.curToken, "unit must be between 1 and 256, got %d", seg.Unit)
+			}
+		default:
+			if intType, ok := bitsIntTypes[name]; ok {
+				if signedness {
+					p.bitsSegmentError(tok, "signedness of a %s segment follows its type", intType)
+				}
+				if seg.IntType != "" && seg.IntType != intType {
+					p.bitsSegmentError(tok, "conflicting types '%s' and '%s'", strings.ToLower(seg.IntType), name)
+				} else if seg.Type != "" && seg.Type != ast.BitsInteger {
+					p.bitsSegmentError(tok, "conflicting types '%s' and '%s'", seg.Type, name)
+				}
+				seg.Type, seg.IntType = ast.BitsInteger, intType
+				seg.Signed = config.GetIntTypeInfo(intType).Signed
+				break
+			}
+			typ, ok := bitsSegmentTypes[name]
+			if !ok {
+				p.bitsSegmentError(tok, "unknown specifier '%s'", name)
+			} else if seg.Type != "" && seg.Type != typ {
+				p.bitsSegmentError(tok, "conflicting types '%s' and '%s'", seg.Type, typ)
+			} else {
+				seg.Type = typ
+			}
+		}
+		if !p.peekTokenIs(token.MINUS) {
+			return true
+		}
+		p.nextToken()
+	}
+}
+
+// bitsSegmentError reports a bad specifier; parsing carries on with the
+// rest of the segment.
+func (p *Parser) bitsSegmentError(tok token.Token, format string, args ...interface{}) {
+	p.ctx.Errors = append(p.ctx.Errors, diagnostics.NewError(diagnostics.ErrP008, tok, fmt.Sprintf(format, args...)))
+}
+
 func (p *Parser) parseListLiteral() ast.Expression {
+	if p.isComprehension() {
+		return p.parseListComprehension()
+	}
 	list := &ast.ListLiteral{Token: p.curToken}
 	list.Elements = p.parseExpressionList(token.RBRACKET)
 	return list
@@ -487,6 +800,9 @@ func (p *Parser) parseListLiteral() ast.Expression {
 
 // parseMapLiteral parses a map literal: %{ key => value, key2 => value2 }
 func (p *Parser) parseMapLiteral() ast.Expression {
+	if p.isComprehension() {
+		return p.parseMapComprehension()
+	}
 	mapLit := &ast.MapLiteral{Token: p.curToken}
 	mapLit.Pairs = []struct{ Key, Value ast.Expression }{}
 
@@ -585,6 +901,199 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 	return mapLit
 }
 
+// isComprehension looks ahead from an opening '[' or '%{' for a '|' that is
+// followed by a '<-' generator at the same nesting level.
+func (p *Parser) isComprehension() bool {
+	for n := 64; ; n *= 2 {
+		tokens := append([]token.Token{p.peekToken}, p.stream.Peek(n)...)
+		depth := 0
+		sawPipe := false
+		for _, tok := range tokens {
+			switch tok.Type {
+			case token.LPAREN, token.LBRACKET, token.LBRACE, token.PERCENT_LBRACE:
+				depth++
+			case token.RPAREN, token.RBRACKET, token.RBRACE:
+				if depth == 0 {
+					return false
+				}
+				depth--
+			case token.PIPE:
+				if depth == 0 {
+					sawPipe = true
+				}
+			case token.LARROW:
+				if depth == 0 && sawPipe {
+					return true
+				}
+			case token.EOF:
+				return false
+			}
+		}
+		if len(tokens) <= n {
+			return false
+		}
+	}
+}
+
+// isGeneratorClause reports whether the clause starting at curToken is
+// `pattern <- iterable` rather than a filter.
+func (p *Parser) isGeneratorClause() bool {
+	depth := 0
+	for _, tok := range append([]token.Token{p.curToken, p.peekToken}, p.stream.Peek(64)...) {
+		switch tok.Type {
+		case token.LPAREN, token.LBRACKET, token.LBRACE, token.PERCENT_LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			if depth == 0 {
+				return false
+			}
+			depth--
+		case token.COMMA, token.NEWLINE:
+			if depth == 0 {
+				return false
+			}
+		case token.LARROW:
+			if depth == 0 {
+				return true
+			}
+		case token.EOF:
+			return false
+		}
+	}
+	return false
+}
+
+// parseListComprehension parses [output | x <- xs, pred(x), y <- ys]
+func (p *Parser) parseListComprehension() ast.Expression {
+	comp := &ast.ListComprehension{Token: p.curToken}
+
+	for p.peekTokenIs(token.NEWLINE) {
+		p.nextToken()
+	}
+	p.nextToken()
+
+	prev := p.comprehensionHead
+	p.comprehensionHead = true
+	comp.Output = p.parseExpression(LOWEST)
+	p.comprehensionHead = prev
+	if comp.Output == nil {
+		return nil
+	}
+
+	comp.Clauses = p.parseComprehensionClauses(token.RBRACKET)
+	if comp.Clauses == nil {
+		return nil
+	}
+	return comp
+}
+
+// parseMapComprehension parses %{ key => value | (key, value) <- items }.
+// `key: value` is accepted as well.
+func (p *Parser) parseMapComprehension() ast.Expression {
+	comp := &ast.MapComprehension{Token: p.curToken}
+
+	for p.peekTokenIs(token.NEWLINE) {
+		p.nextToken()
+	}
+	p.nextToken()
+
+	prev := p.comprehensionHead
+	p.comprehensionHead = true
+	ok := p.parseMapComprehensionHead(comp)
+	p.comprehensionHead = prev
+	if !ok {
+		return nil
+	}
+
+	comp.Clauses = p.parseComprehensionClauses(token.RBRACE)
+	if comp.Clauses == nil {
+		return nil
+	}
+	return comp
+}
+
+// parseMapComprehensionHead parses `key => value` (or `key: value`)
+func (p *Parser) parseMapComprehensionHead(comp *ast.MapComprehension) bool {
+	// Use PIPE_PREC to stop before =>
+	comp.Key = p.parseExpression(PIPE_PREC)
+	if comp.Key == nil {
+		return false
+	}
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+	} else if !p.expectPeek(token.USER_OP_IMPLY) {
+		return false
+	}
+	p.nextToken()
+	comp.Value = p.parseExpression(PIPE_PREC)
+	return comp.Value != nil
+}
+
+// suspendDelimiters lets '|', ':', '/' and '>>' act as operators again inside
+// brackets nested in a comprehension output or a binary segment:
+// defer p.suspendDelimiters()()
+func (p *Parser) suspendDelimiters() func() {
+	prevHead, prevSegment := p.comprehensionHead, p.bitsSegment
+	p.comprehensionHead, p.bitsSegment = false, false
+	return func() { p.comprehensionHead, p.bitsSegment = prevHead, prevSegment }
+}
+
+// parseComprehensionClauses parses `| clause, clause, ...` up to the closing token.
+// curToken is the last token of the comprehension output.
+func (p *Parser) parseComprehensionClauses(closing token.TokenType) []*ast.ComprehensionClause {
+	for p.peekTokenIs(token.NEWLINE) {
+		p.nextToken()
+	}
+	if !p.expectPeek(token.PIPE) {
+		return nil
+	}
+
+	var clauses []*ast.ComprehensionClause
+	for {
+		p.nextToken()
+		for p.curTokenIs(token.NEWLINE) {
+			p.nextToken()
+		}
+
+		if p.isGeneratorClause() {
+			pattern := p.parsePattern()
+			if pattern == nil {
+				return nil
+			}
+			if !p.expectPeek(token.LARROW) {
+				return nil
+			}
+			clause := &ast.ComprehensionClause{Token: p.curToken, Pattern: pattern}
+			p.nextToken()
+			clause.Iterable = p.parseExpression(LOWEST)
+			if clause.Iterable == nil {
+				return nil
+			}
+			clauses = append(clauses, clause)
+		} else {
+			clause := &ast.ComprehensionClause{Token: p.curToken}
+			clause.Filter = p.parseExpression(LOWEST)
+			if clause.Filter == nil {
+				return nil
+			}
+			clauses = append(clauses, clause)
+		}
+
+		for p.peekTokenIs(token.NEWLINE) {
+			p.nextToken()
+		}
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+	}
+
+	if !p.expectPeek(closing) {
+		return nil
+	}
+	return clauses
+}
+
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	exp := &ast.IndexExpression{Token: p.curToken, Left: left}
 
@@ -745,7 +1254,7 @@ func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
 
 	// Parse arguments (handling Named Args sugar)
-	exp.Arguments = p.parseCallArguments()
+	exp.Arguments, exp.NamedArgs = p.parseCallArguments()
 
 	// Handle Block Syntax (Trailing Lambda/List)
 	// If followed by { ... }, treat as list of expressions and append as last argument
@@ -765,7 +1274,8 @@ func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 // parseCallArguments parses arguments for a function call, handling Named Args sugar
 // func(a: 1, b: 2) -> func({a: 1, b: 2})
 // func(1, b: 2) -> func(1, {b: 2})
-func (p *Parser) parseCallArguments() []ast.Expression {
+func (p *Parser) parseCallArguments() ([]ast.Expression, []string) {
+	defer p.suspendDelimiters()()
 	args := []ast.Expression{}
 	namedArgs := make(map[string]ast.Expression)
 	var namedArgsOrder []string
@@ -781,7 +1291,7 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 
 	// Check for empty call )
 	if p.curTokenIs(token.RPAREN) {
-		return args
+		return args, nil
 	}
 
 	for {
@@ -812,18 +1322,26 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 				p.nextToken()
 			}
 
+			if _, dup := namedArgs[key]; dup {
+				p.ctx.Errors = append(p.ctx.Errors, diagnostics.NewError(
+					diagnostics.ErrP006,
+					p.curToken,
+					"argument '"+key+"' is given more than once",
+				))
+			} else {
+				namedArgsOrder = append(namedArgsOrder, key)
+			}
 			val := p.parseExpression(LOWEST)
 			namedArgs[key] = val
-			namedArgsOrder = append(namedArgsOrder, key)
 		} else {
 			if isNamedMode {
 				// Error: Positional argument after named argument
 				p.ctx.Errors = append(p.ctx.Errors, diagnostics.NewError(
-					diagnostics.ErrP005,
+					diagnostics.ErrP006,
 					p.curToken,
 					"positional argument cannot follow named arguments",
 				))
-				return nil
+				return nil, nil
 			}
 			expr := p.parseExpression(LOWEST)
 
@@ -867,7 +1385,7 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 	}
 
 	if !p.expectPeek(token.RPAREN) {
-		return nil
+		return nil, nil
 	}
 
 Done:
@@ -880,7 +1398,7 @@ Done:
 		args = append(args, rec)
 	}
 
-	return args
+	return args, namedArgsOrder
 }
 
 // parseBlockAsList parses { expr1 \n expr2 } as [expr1, expr2]
@@ -941,6 +1459,7 @@ func (p *Parser) parseBlockAsList() *ast.ListLiteral {
 }
 
 func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
+	defer p.suspendDelimiters()()
 	list := []ast.Expression{}
 
 	// Skip newlines after opening bracket (for multiline lists)
@@ -1262,6 +1781,11 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 						}
 					}
 				}
+			} else if tokenAfterArrow.Type == token.IDENT_LOWER && tokenAfterArrow.Lexeme == "dyn" {
+				// Case: -> dyn Draw { ... }
+				if len(lookahead) >= 3 && lookahead[1].Type == token.IDENT_UPPER && lookahead[2].Type == token.LBRACE {
+					isReturnType = true
+				}
 			} else if tokenAfterArrow.Type == token.LPAREN {
 				// Case: -> (Int, Int) { ... } or -> () -> Int { ... }
 				// Find matching ) and check if { follows
@@ -24,6 +24,14 @@ type Parser struct {
 
 	// disallowTrailingLambda allows disabling trailing lambda syntax in contexts like if/match conditions
 	disallowTrailingLambda bool
+
+	// comprehensionHead stops expressions at '|' and ':' while parsing the
+	// output of a comprehension, so they can separate the clauses
+	comprehensionHead bool
+
+	// bitsSegment stops expressions at ':', '/' and '>>' inside a segment
+	// of a binary construction, so they can delimit size and specifiers
+	bitsSegment bool
 }
 
 type (
@@ -124,10 +132,14 @@ func New(stream pipeline.TokenStream, ctx *pipeline.PipelineContext) *Parser {
 	p.registerPrefix(token.IDENT_LOWER, p.parseIdentifier)
 	p.registerPrefix(token.IDENT_UPPER, p.parseIdentifier)
 	p.registerPrefix(token.UNDERSCORE, p.parseUnderscore)
+	p.registerPrefix(token.QUESTION, p.parseTypedHole)
+	p.registerPrefix(token.HOLE, p.parseTypedHole)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
 	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
 	p.registerPrefix(token.BIG_INT, p.parseBigIntLiteral)
+	p.registerPrefix(token.SIZED_INT, p.parseSizedIntLiteral)
 	p.registerPrefix(token.RATIONAL, p.parseRationalLiteral)
+	p.registerPrefix(token.DECIMAL, p.parseDecimalLiteral)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
 	p.registerPrefix(token.TILDE, p.parsePrefixExpression)
@@ -148,10 +160,12 @@ func New(stream pipeline.TokenStream, ctx *pipeline.PipelineContext) *Parser {
 	p.registerPrefix(token.BITS_BIN, p.parseBitsLiteral)
 	p.registerPrefix(token.BITS_HEX, p.parseBitsLiteral)
 	p.registerPrefix(token.BITS_OCT, p.parseBitsLiteral)
+	p.registerPrefix(token.LSHIFT, p.parseBitsExpression)
 	p.registerPrefix(token.LBRACKET, p.parseListLiteral)
 	p.registerPrefix(token.PERCENT_LBRACE, p.parseMapLiteral)
 	p.registerPrefix(token.FUN, p.parseFunctionLiteral)
 	p.registerPrefix(token.FOR, p.parseForExpression) // for loop
+	p.registerPrefix(token.DO, p.parseDoExpression)   // do-notation
 	p.registerPrefix(token.ELLIPSIS, p.parsePrefixSpreadExpression)
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression) // Re-register explicitly to be safe
 
@@ -401,7 +415,7 @@ func (p *Parser) ParseProgram() *ast.Program {
 		}
 
 		var stmt ast.Statement
-		if p.curToken.Type == token.TYPE {
+		if p.curToken.Type == token.TYPE || p.curToken.Type == token.NEWTYPE {
 			stmt = p.parseTypeDeclarationStatement()
 			if p.peekTokenIs(token.NEWLINE) {
 				p.nextToken()
@@ -620,6 +634,12 @@ func (p *Parser) curPrecedence() int {
 }
 
 func (p *Parser) peekPrecedence() int {
+	if p.comprehensionHead && (p.peekTokenIs(token.PIPE) || p.peekTokenIs(token.COLON)) {
+		return LOWEST
+	}
+	if p.bitsSegment && (p.peekTokenIs(token.COLON) || p.peekTokenIs(token.SLASH) || p.peekTokenIs(token.RSHIFT)) {
+		return LOWEST
+	}
 	if p, ok := precedences[p.peekToken.Type]; ok {
 		return p
 	}
@@ -91,6 +91,22 @@ func (p *Parser) parseAtomicPattern() ast.Pattern {
 		return &ast.PinPattern{Token: caretToken, Name: p.curToken.Literal.(string)}
 	case token.INT:
 		return &ast.LiteralPattern{Token: p.curToken, Value: p.curToken.Literal}
+	case token.SIZED_INT:
+		lit := p.curToken.Literal.(token.SizedInt)
+		return &ast.LiteralPattern{Token: p.curToken, Value: &ast.SizedIntLiteral{Token: p.curToken, Type: lit.Type, Value: lit.Value}}
+	case token.MINUS:
+		// Negative number: -1, -5i8
+		minusToken := p.curToken
+		if !p.peekTokenIs(token.INT) && !p.peekTokenIs(token.SIZED_INT) {
+			return nil
+		}
+		p.nextToken()
+		tok := p.curToken
+		tok.Lexeme = minusToken.Lexeme + tok.Lexeme
+		if lit, ok := tok.Literal.(token.SizedInt); ok {
+			return &ast.LiteralPattern{Token: tok, Value: &ast.SizedIntLiteral{Token: tok, Type: lit.Type, Value: lit.Value, Negative: true}}
+		}
+		return &ast.LiteralPattern{Token: tok, Value: -tok.Literal.(int64)}
 	case token.TRUE, token.FALSE:
 		return &ast.LiteralPattern{Token: p.curToken, Value: p.curTokenIs(token.TRUE)}
 	case token.STRING:
@@ -209,11 +225,38 @@ func (p *Parser) parseAtomicPattern() ast.Pattern {
 			return nil
 		}
 		return &ast.ListPattern{Token: startToken, Elements: elements}
+	case token.LSHIFT:
+		return p.parseBitsPattern()
 	default:
 		return nil
 	}
 }
 
+// parseBitsPattern parses a binary pattern: <<ver:4, ihl:4, len:16/big, rest/binary>>
+func (p *Parser) parseBitsPattern() ast.Pattern {
+	bp := &ast.BitsPattern{Token: p.curToken}
+	bp.Segments = p.parseBitsSegments(func(seg *ast.BitsSegment) bool {
+		// Segment values are plain bindings or literals; a full pattern
+		// would read `name:size` as a type pattern.
+		switch p.curToken.Type {
+		case token.IDENT_LOWER:
+			seg.Pattern = &ast.IdentifierPattern{Token: p.curToken, Value: p.curToken.Literal.(string)}
+		case token.UNDERSCORE:
+			seg.Pattern = &ast.WildcardPattern{Token: p.curToken}
+		case token.INT, token.STRING:
+			seg.Pattern = &ast.LiteralPattern{Token: p.curToken, Value: p.curToken.Literal}
+		default:
+			p.bitsSegmentError(p.curToken, "expected a name, '_' or a literal, got '%s'", p.curToken.Lexeme)
+			return false
+		}
+		return true
+	})
+	if bp.Segments == nil {
+		return nil
+	}
+	return bp
+}
+
 func (p *Parser) parseConstructorPattern() ast.Pattern {
 	cp := &ast.ConstructorPattern{
 		Token: p.curToken,
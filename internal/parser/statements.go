@@ -930,7 +930,9 @@ func (p *Parser) parseTypeDeclarationStatement() *ast.TypeDeclarationStatement {
 	stmt := &ast.TypeDeclarationStatement{Token: p.curToken}
 
 	// 1. Parse Type Name (Constructor) or 'alias'
-	if p.peekTokenIs(token.ALIAS) {
+	if p.curTokenIs(token.NEWTYPE) {
+		stmt.IsNewtype = true
+	} else if p.peekTokenIs(token.ALIAS) {
 		p.nextToken()
 		stmt.IsAlias = true
 	}
@@ -983,7 +985,9 @@ func (p *Parser) parseTypeDeclarationStatement() *ast.TypeDeclarationStatement {
 	p.nextToken() // Move to RHS
 
 	// 4. Parse Right Hand Side
-	if p.curTokenIs(token.LBRACE) {
+	if stmt.IsNewtype {
+		return p.parseNewtypeBody(stmt)
+	} else if p.curTokenIs(token.LBRACE) {
 		// Implicit alias for Record Type
 		stmt.IsAlias = true
 		stmt.TargetType = p.parseType()
@@ -995,7 +999,7 @@ func (p *Parser) parseTypeDeclarationStatement() *ast.TypeDeclarationStatement {
 		if p.curTokenIs(token.IDENT_UPPER) && p.peekTokenIs(token.LT) {
 			stmt.IsAlias = true
 			stmt.TargetType = p.parseType()
-			return stmt
+			return p.parseDerivingClause(stmt)
 		}
 
 		// Heuristic: If RHS starts with '(' it's likely a function type or tuple - treat as alias
@@ -1003,7 +1007,7 @@ func (p *Parser) parseTypeDeclarationStatement() *ast.TypeDeclarationStatement {
 		if p.curTokenIs(token.LPAREN) {
 			stmt.IsAlias = true
 			stmt.TargetType = p.parseType()
-			return stmt
+			return p.parseDerivingClause(stmt)
 		}
 
 		// ADT: Constructor | Constructor ...
@@ -1032,6 +1036,82 @@ func (p *Parser) parseTypeDeclarationStatement() *ast.TypeDeclarationStatement {
 		}
 	}
 
+	return p.parseDerivingClause(stmt)
+}
+
+// parseNewtypeBody parses the right hand side of a newtype: either the wrapped
+// type, 'newtype UserId = Int', whose constructor is then called MkUserId, or
+// an explicitly named constructor, 'newtype Email = EmailOf(String)'.
+func (p *Parser) parseNewtypeBody(stmt *ast.TypeDeclarationStatement) *ast.TypeDeclarationStatement {
+	ctor := &ast.DataConstructor{Token: p.curToken}
+	if p.curTokenIs(token.IDENT_UPPER) && p.peekTokenIs(token.LPAREN) {
+		ctor.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal.(string)}
+		p.nextToken() // (
+		p.nextToken() // move to type
+		stmt.TargetType = p.parseType()
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+	} else {
+		name := "Mk" + stmt.Name.Value
+		ctor.Token = stmt.Name.Token
+		ctor.Name = &ast.Identifier{Token: stmt.Name.Token, Value: name}
+		stmt.TargetType = p.parseType()
+	}
+	if stmt.TargetType == nil {
+		return nil
+	}
+	ctor.Parameters = []ast.Type{stmt.TargetType}
+	stmt.Constructors = []*ast.DataConstructor{ctor}
+	return p.parseDerivingClause(stmt)
+}
+
+// parseDerivingClause parses an optional 'deriving (Equal, Show)' clause that
+// follows a type definition, possibly on the next line. A single trait may be
+// written without parentheses.
+func (p *Parser) parseDerivingClause(stmt *ast.TypeDeclarationStatement) *ast.TypeDeclarationStatement {
+	if !p.peekTokenIs(token.DERIVING) {
+		if !p.peekTokenIs(token.NEWLINE) {
+			return stmt
+		}
+		found := false
+		for _, tok := range p.stream.Peek(10) {
+			if tok.Type != token.NEWLINE {
+				found = tok.Type == token.DERIVING
+				break
+			}
+		}
+		if !found {
+			return stmt
+		}
+		for p.peekTokenIs(token.NEWLINE) {
+			p.nextToken()
+		}
+	}
+	p.nextToken() // deriving
+
+	if !p.peekTokenIs(token.LPAREN) {
+		if !p.expectPeek(token.IDENT_UPPER) {
+			return nil
+		}
+		stmt.Deriving = append(stmt.Deriving, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal.(string)})
+		return stmt
+	}
+	p.nextToken() // (
+
+	for {
+		if !p.expectPeek(token.IDENT_UPPER) {
+			return nil
+		}
+		stmt.Deriving = append(stmt.Deriving, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal.(string)})
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken() // ,
+	}
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
 	return stmt
 }
 
@@ -1084,7 +1164,7 @@ func (p *Parser) parseDataConstructor() *ast.DataConstructor {
 
 	// So `parseType` is safe to call.
 
-	for !p.peekTokenIs(token.PIPE) && !p.peekTokenIs(token.NEWLINE) && !p.peekTokenIs(token.EOF) {
+	for !p.peekTokenIs(token.PIPE) && !p.peekTokenIs(token.NEWLINE) && !p.peekTokenIs(token.EOF) && !p.peekTokenIs(token.DERIVING) {
 		p.nextToken()
 		// Use parseNonUnionType to avoid consuming | as part of union type
 		// ADT syntax: Constructor Type Type | Constructor Type
@@ -1139,6 +1219,7 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 }
 
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
+	defer p.suspendDelimiters()()
 	block := &ast.BlockStatement{Token: p.curToken}
 	block.Statements = []ast.Statement{}
 
@@ -1152,7 +1233,7 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 		}
 
 		var stmt ast.Statement
-		if p.curToken.Type == token.TYPE {
+		if p.curToken.Type == token.TYPE || p.curToken.Type == token.NEWTYPE {
 			stmt = p.parseTypeDeclarationStatement()
 			if p.peekTokenIs(token.NEWLINE) {
 				p.nextToken()
@@ -223,6 +223,17 @@ func (p *Parser) parseAtomicType() ast.Type {
 		return t // Grouped type or partial application
 	}
 
+	// Trait object: dyn Draw. `dyn` is contextual, so it stays usable as a name.
+	if p.curTokenIs(token.IDENT_LOWER) && p.curToken.Literal.(string) == "dyn" && p.peekTokenIs(token.IDENT_UPPER) {
+		dynToken := p.curToken
+		p.nextToken() // consume 'dyn'
+		trait, ok := p.parseAtomicType().(*ast.NamedType)
+		if !ok {
+			return nil
+		}
+		return &ast.DynType{Token: dynToken, Trait: trait.Name}
+	}
+
 	if p.curTokenIs(token.IDENT_UPPER) || p.curTokenIs(token.IDENT_LOWER) {
 		nameVal := p.curToken.Literal.(string)
 		startToken := p.curToken
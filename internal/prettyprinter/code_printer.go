@@ -3,6 +3,7 @@ package prettyprinter
 import (
 	"bytes"
 	"github.com/funvibe/funxy/internal/ast"
+	"strconv"
 	"strings"
 )
 
@@ -399,6 +400,10 @@ func (p *CodePrinter) VisitIdentifier(n *ast.Identifier) {
 	p.write(n.Value)
 }
 
+func (p *CodePrinter) VisitTypedHole(n *ast.TypedHole) {
+	p.write("?" + n.Name)
+}
+
 func (p *CodePrinter) VisitIntegerLiteral(n *ast.IntegerLiteral) {
 	p.write(n.Token.Lexeme)
 }
@@ -411,10 +416,21 @@ func (p *CodePrinter) VisitBigIntLiteral(n *ast.BigIntLiteral) {
 	p.write(n.Token.Lexeme)
 }
 
+func (p *CodePrinter) VisitSizedIntLiteral(n *ast.SizedIntLiteral) {
+	if n.Negative {
+		p.write("-")
+	}
+	p.write(n.Token.Lexeme)
+}
+
 func (p *CodePrinter) VisitRationalLiteral(n *ast.RationalLiteral) {
 	p.write(n.Token.Lexeme)
 }
 
+func (p *CodePrinter) VisitDecimalLiteral(n *ast.DecimalLiteral) {
+	p.write(n.Token.Lexeme)
+}
+
 func (p *CodePrinter) VisitBooleanLiteral(n *ast.BooleanLiteral) {
 	p.write(n.Token.Lexeme)
 }
@@ -679,6 +695,10 @@ func (p *CodePrinter) VisitContinueStatement(n *ast.ContinueStatement) {
 }
 
 func (p *CodePrinter) VisitTypeDeclarationStatement(n *ast.TypeDeclarationStatement) {
+	if n.IsNewtype {
+		p.printNewtype(n)
+		return
+	}
 	p.write("type ")
 	if n.IsAlias {
 		p.write("alias ")
@@ -707,6 +727,35 @@ func (p *CodePrinter) VisitTypeDeclarationStatement(n *ast.TypeDeclarationStatem
 		}
 		c.Accept(p)
 	}
+
+	p.printDeriving(n)
+}
+
+// printNewtype prints 'newtype UserId = Int', spelling out the constructor
+// only when it is not the implicit MkUserId.
+func (p *CodePrinter) printNewtype(n *ast.TypeDeclarationStatement) {
+	p.write("newtype ")
+	n.Name.Accept(p)
+	p.write(" = ")
+	if len(n.Constructors) == 1 && n.Constructors[0].Name.Value != "Mk"+n.Name.Value {
+		n.Constructors[0].Accept(p)
+	} else if n.TargetType != nil {
+		n.TargetType.Accept(p)
+	}
+	p.printDeriving(n)
+}
+
+func (p *CodePrinter) printDeriving(n *ast.TypeDeclarationStatement) {
+	if len(n.Deriving) > 0 {
+		p.write(" deriving (")
+		for i, trait := range n.Deriving {
+			if i > 0 {
+				p.write(", ")
+			}
+			trait.Accept(p)
+		}
+		p.write(")")
+	}
 }
 
 func (p *CodePrinter) VisitNamedType(n *ast.NamedType) {
@@ -822,6 +871,56 @@ func (p *CodePrinter) VisitRecordPattern(n *ast.RecordPattern) {
 	p.write("}")
 }
 
+func (p *CodePrinter) VisitBitsExpression(n *ast.BitsExpression) {
+	p.printBitsSegments(n.Segments)
+}
+
+func (p *CodePrinter) VisitBitsPattern(n *ast.BitsPattern) {
+	p.printBitsSegments(n.Segments)
+}
+
+func (p *CodePrinter) printBitsSegments(segments []*ast.BitsSegment) {
+	p.write("<<")
+	for i, seg := range segments {
+		if i > 0 {
+			p.write(", ")
+		}
+		if seg.Value != nil {
+			seg.Value.Accept(p)
+		} else {
+			seg.Pattern.Accept(p)
+		}
+		if seg.Size != nil {
+			p.write(":")
+			seg.Size.Accept(p)
+		}
+		if specs := bitsSpecifiers(seg); specs != "" {
+			p.write("/" + specs)
+		}
+	}
+	p.write(">>")
+}
+
+// bitsSpecifiers renders the non-default specifiers of a segment: little-signed-unit:8
+func bitsSpecifiers(seg *ast.BitsSegment) string {
+	var specs []string
+	if seg.IntType != "" {
+		specs = append(specs, strings.ToLower(seg.IntType))
+	} else if seg.Type != ast.BitsInteger && seg.Type != "" {
+		specs = append(specs, seg.Type)
+	}
+	if seg.Endianness != "" {
+		specs = append(specs, seg.Endianness)
+	}
+	if seg.Signed && seg.IntType == "" {
+		specs = append(specs, "signed")
+	}
+	if seg.Unit != 0 {
+		specs = append(specs, "unit:"+strconv.FormatInt(seg.Unit, 10))
+	}
+	return strings.Join(specs, "-")
+}
+
 func (p *CodePrinter) VisitTypePattern(n *ast.TypePattern) {
 	p.write(n.Name)
 	p.write(": ")
@@ -855,6 +954,10 @@ func (p *CodePrinter) VisitSpreadExpression(n *ast.SpreadExpression) {
 	p.write("...")
 }
 
+func (p *CodePrinter) VisitDefaultArgument(n *ast.DefaultArgument) {
+	p.write("_")
+}
+
 func (p *CodePrinter) VisitSpreadPattern(n *ast.SpreadPattern) {
 	n.Pattern.Accept(p)
 	p.write("...")
@@ -947,6 +1050,38 @@ func (p *CodePrinter) VisitRecordLiteral(n *ast.RecordLiteral) {
 	}
 }
 
+func (p *CodePrinter) VisitListComprehension(n *ast.ListComprehension) {
+	p.write("[")
+	n.Output.Accept(p)
+	p.printClauses(n.Clauses)
+	p.write("]")
+}
+
+func (p *CodePrinter) VisitMapComprehension(n *ast.MapComprehension) {
+	p.write("%{ ")
+	n.Key.Accept(p)
+	p.write(" => ")
+	n.Value.Accept(p)
+	p.printClauses(n.Clauses)
+	p.write(" }")
+}
+
+func (p *CodePrinter) printClauses(clauses []*ast.ComprehensionClause) {
+	p.write(" | ")
+	for i, clause := range clauses {
+		if i > 0 {
+			p.write(", ")
+		}
+		if clause.Pattern != nil {
+			clause.Pattern.Accept(p)
+			p.write(" <- ")
+			clause.Iterable.Accept(p)
+		} else {
+			clause.Filter.Accept(p)
+		}
+	}
+}
+
 func (p *CodePrinter) VisitMapLiteral(n *ast.MapLiteral) {
 	if len(n.Pairs) > 3 {
 		// Multiline with key alignment
@@ -1018,6 +1153,11 @@ func (p *CodePrinter) VisitUnionType(n *ast.UnionType) {
 	}
 }
 
+func (p *CodePrinter) VisitDynType(n *ast.DynType) {
+	p.write("dyn ")
+	p.write(n.Trait.Value)
+}
+
 func (p *CodePrinter) VisitMemberExpression(n *ast.MemberExpression) {
 	n.Left.Accept(p)
 	p.write(".")
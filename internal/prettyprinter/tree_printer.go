@@ -235,6 +235,12 @@ func (p *TreePrinter) VisitIdentifier(n *ast.Identifier) {
 	p.write(")")
 }
 
+func (p *TreePrinter) VisitTypedHole(n *ast.TypedHole) {
+	p.write("TypedHole(?")
+	p.write(n.Name)
+	p.write(")")
+}
+
 func (p *TreePrinter) VisitIntegerLiteral(n *ast.IntegerLiteral) {
 	p.write("IntegerLiteral(")
 	p.write(n.Token.Lexeme)
@@ -253,12 +259,27 @@ func (p *TreePrinter) VisitBigIntLiteral(n *ast.BigIntLiteral) {
 	p.write(")")
 }
 
+func (p *TreePrinter) VisitSizedIntLiteral(n *ast.SizedIntLiteral) {
+	p.write("SizedIntLiteral(")
+	if n.Negative {
+		p.write("-")
+	}
+	p.write(n.Token.Lexeme)
+	p.write(")")
+}
+
 func (p *TreePrinter) VisitRationalLiteral(n *ast.RationalLiteral) {
 	p.write("RationalLiteral(")
 	p.write(n.Token.Lexeme)
 	p.write(")")
 }
 
+func (p *TreePrinter) VisitDecimalLiteral(n *ast.DecimalLiteral) {
+	p.write("DecimalLiteral(")
+	p.write(n.Token.Lexeme)
+	p.write(")")
+}
+
 func (p *TreePrinter) VisitTupleLiteral(n *ast.TupleLiteral) {
 	p.write("Tuple\n")
 	p.indent++
@@ -516,6 +537,48 @@ func (p *TreePrinter) VisitIfExpression(n *ast.IfExpression) {
 	p.indent--
 }
 
+func (p *TreePrinter) VisitListComprehension(n *ast.ListComprehension) {
+	p.write("ListComprehension\n")
+	p.indent++
+	p.writeIndent()
+	p.write("Output: ")
+	n.Output.Accept(p)
+	p.write("\n")
+	p.printClauses(n.Clauses)
+	p.indent--
+}
+
+func (p *TreePrinter) VisitMapComprehension(n *ast.MapComprehension) {
+	p.write("MapComprehension\n")
+	p.indent++
+	p.writeIndent()
+	p.write("Key: ")
+	n.Key.Accept(p)
+	p.write("\n")
+	p.writeIndent()
+	p.write("Value: ")
+	n.Value.Accept(p)
+	p.write("\n")
+	p.printClauses(n.Clauses)
+	p.indent--
+}
+
+func (p *TreePrinter) printClauses(clauses []*ast.ComprehensionClause) {
+	for _, clause := range clauses {
+		p.writeIndent()
+		if clause.Pattern != nil {
+			p.write("Generator: ")
+			clause.Pattern.Accept(p)
+			p.write(" <- ")
+			clause.Iterable.Accept(p)
+		} else {
+			p.write("Filter: ")
+			clause.Filter.Accept(p)
+		}
+		p.write("\n")
+	}
+}
+
 func (p *TreePrinter) VisitForExpression(n *ast.ForExpression) {
 	p.write("For\n")
 	p.indent++
@@ -559,6 +622,9 @@ func (p *TreePrinter) VisitTypeDeclarationStatement(n *ast.TypeDeclarationStatem
 	if n.IsAlias {
 		p.write(" (Alias)")
 	}
+	if n.IsNewtype {
+		p.write(" (Newtype)")
+	}
 	p.write("\n")
 	p.indent++
 	p.writeIndent()
@@ -596,6 +662,18 @@ func (p *TreePrinter) VisitTypeDeclarationStatement(n *ast.TypeDeclarationStatem
 		p.indent--
 	}
 
+	if len(n.Deriving) > 0 {
+		p.writeIndent()
+		p.write("Deriving: ")
+		for i, trait := range n.Deriving {
+			if i > 0 {
+				p.write(", ")
+			}
+			trait.Accept(p)
+		}
+		p.write("\n")
+	}
+
 	p.indent--
 	p.write("\n")
 }
@@ -703,6 +781,48 @@ func (p *TreePrinter) VisitRecordPattern(n *ast.RecordPattern) {
 	p.indent--
 }
 
+func (p *TreePrinter) VisitBitsExpression(n *ast.BitsExpression) {
+	p.write("BitsExpression(")
+	p.printBitsSegments(n.Segments)
+	p.write(")")
+}
+
+func (p *TreePrinter) VisitBitsPattern(n *ast.BitsPattern) {
+	p.write("BitsPattern(")
+	p.printBitsSegments(n.Segments)
+	p.write(")")
+}
+
+func (p *TreePrinter) printBitsSegments(segments []*ast.BitsSegment) {
+	for i, seg := range segments {
+		if i > 0 {
+			p.write(", ")
+		}
+		if seg.Value != nil {
+			seg.Value.Accept(p)
+		} else {
+			seg.Pattern.Accept(p)
+		}
+		if seg.Size != nil {
+			p.write(":")
+			seg.Size.Accept(p)
+		}
+		p.write("/" + seg.Type)
+		if seg.IntType != "" {
+			p.write("-" + strings.ToLower(seg.IntType))
+		}
+		if seg.Endianness != "" {
+			p.write("-" + seg.Endianness)
+		}
+		if seg.Signed && seg.IntType == "" {
+			p.write("-signed")
+		}
+		if seg.Unit != 0 {
+			p.write(fmt.Sprintf("-unit:%d", seg.Unit))
+		}
+	}
+}
+
 func (p *TreePrinter) VisitTypePattern(n *ast.TypePattern) {
 	p.write("TypePattern(" + n.Name + ": ")
 	n.Type.Accept(p)
@@ -738,6 +858,10 @@ func (p *TreePrinter) VisitSpreadExpression(n *ast.SpreadExpression) {
 	p.write("...)")
 }
 
+func (p *TreePrinter) VisitDefaultArgument(n *ast.DefaultArgument) {
+	p.write("DefaultArgument")
+}
+
 func (p *TreePrinter) VisitSpreadPattern(n *ast.SpreadPattern) {
 	p.write("SpreadPattern(")
 	n.Pattern.Accept(p)
@@ -802,6 +926,10 @@ func (p *TreePrinter) VisitUnionType(n *ast.UnionType) {
 	p.indent--
 }
 
+func (p *TreePrinter) VisitDynType(n *ast.DynType) {
+	p.write("DynType: " + n.Trait.Value)
+}
+
 func (p *TreePrinter) VisitMemberExpression(n *ast.MemberExpression) {
 	p.write("MemberAccess\n")
 	p.indent++
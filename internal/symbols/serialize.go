@@ -0,0 +1,111 @@
+package symbols
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+func init() {
+	// Types and kinds are stored in the table as interface values
+	gob.Register(typesystem.TCon{})
+	gob.Register(typesystem.TVar{})
+	gob.Register(typesystem.TApp{})
+	gob.Register(typesystem.TFunc{})
+	gob.Register(typesystem.TRecord{})
+	gob.Register(typesystem.TTuple{})
+	gob.Register(typesystem.TUnion{})
+	gob.Register(typesystem.TType{})
+	gob.Register(typesystem.KStar{})
+	gob.Register(typesystem.KArrow{})
+}
+
+// symbolTableData is the serialized form of a SymbolTable.
+// The outer scope is not serialized: only module-level tables are persisted.
+type symbolTableData struct {
+	Store               map[string]Symbol
+	Types               map[string]typesystem.Type
+	TraitMethods        map[string]string
+	TraitTypeParams     map[string][]string
+	TraitSuperTraits    map[string][]string
+	TraitDefaultMethods map[string]map[string]bool
+	TraitAllMethods     map[string][]string
+	OperatorTraits      map[string]string
+	Implementations     map[string][]typesystem.Type
+	InstanceMethods     map[string]map[string]map[string]typesystem.Type
+	ExtensionMethods    map[string]map[string]typesystem.Type
+	GenericTypeParams   map[string][]string
+	FuncConstraints     map[string][]Constraint
+	Variants            map[string][]string
+	Kinds               map[string]typesystem.Kind
+	ModuleAliases       map[string]string
+	TypeAliases         map[string]typesystem.Type
+	Newtypes            map[string]typesystem.Type
+}
+
+// GobEncode implements gob.GobEncoder
+func (s *SymbolTable) GobEncode() ([]byte, error) {
+	data := symbolTableData{
+		Store:               s.store,
+		Types:               s.types,
+		TraitMethods:        s.traitMethods,
+		TraitTypeParams:     s.traitTypeParams,
+		TraitSuperTraits:    s.traitSuperTraits,
+		TraitDefaultMethods: s.traitDefaultMethods,
+		TraitAllMethods:     s.traitAllMethods,
+		OperatorTraits:      s.operatorTraits,
+		Implementations:     s.implementations,
+		InstanceMethods:     s.instanceMethods,
+		ExtensionMethods:    s.extensionMethods,
+		GenericTypeParams:   s.genericTypeParams,
+		FuncConstraints:     s.funcConstraints,
+		Variants:            s.variants,
+		Kinds:               s.kinds,
+		ModuleAliases:       s.moduleAliases,
+		TypeAliases:         s.typeAliases,
+		Newtypes:            s.newtypes,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(&data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder
+func (s *SymbolTable) GobDecode(b []byte) error {
+	var data symbolTableData
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&data); err != nil {
+		return err
+	}
+
+	// Start from empty maps so that tables missing in the data stay usable
+	*s = *NewEmptySymbolTable()
+	copyMap(s.store, data.Store)
+	copyMap(s.types, data.Types)
+	copyMap(s.traitMethods, data.TraitMethods)
+	copyMap(s.traitTypeParams, data.TraitTypeParams)
+	copyMap(s.traitSuperTraits, data.TraitSuperTraits)
+	copyMap(s.traitDefaultMethods, data.TraitDefaultMethods)
+	copyMap(s.traitAllMethods, data.TraitAllMethods)
+	copyMap(s.operatorTraits, data.OperatorTraits)
+	copyMap(s.implementations, data.Implementations)
+	copyMap(s.instanceMethods, data.InstanceMethods)
+	copyMap(s.extensionMethods, data.ExtensionMethods)
+	copyMap(s.genericTypeParams, data.GenericTypeParams)
+	copyMap(s.funcConstraints, data.FuncConstraints)
+	copyMap(s.variants, data.Variants)
+	copyMap(s.kinds, data.Kinds)
+	copyMap(s.moduleAliases, data.ModuleAliases)
+	copyMap(s.typeAliases, data.TypeAliases)
+	copyMap(s.newtypes, data.Newtypes)
+	return nil
+}
+
+func copyMap[V any](dst, src map[string]V) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
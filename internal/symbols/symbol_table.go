@@ -25,6 +25,7 @@ type Symbol struct {
 	IsConstant     bool            // True if defined with :- (immutable)
 	UnderlyingType typesystem.Type // For type aliases: the underlying type (e.g., TRecord for type Vector = {...})
 	OriginModule   string          // Module where symbol was originally defined (for re-export conflict detection)
+	IsFunction     bool            // True if bound by a function declaration; reassignment clears it
 }
 
 // GetTypeForUnification returns the underlying type for unification/field access.
@@ -101,6 +102,15 @@ type SymbolTable struct {
 	// For type alias `type Vector = { x: Int, y: Int }`, stores Vector -> TRecord
 	// The main types map stores TCon{Name: "Vector"} for proper module tagging
 	typeAliases map[string]typesystem.Type
+
+	// Newtypes: TypeName -> wrapped type
+	// For `newtype UserId = Int`, stores UserId -> Int. Values of a newtype
+	// are not wrapped at runtime.
+	newtypes map[string]typesystem.Type
+
+	// Derived instances: TraitName -> TypeName. A derived Equal or Order
+	// compares the fields, so it holds for Box<T> only if T implements the trait.
+	derivedInstances map[string]map[string]bool
 }
 
 type Constraint struct {
@@ -127,6 +137,8 @@ func NewEmptySymbolTable() *SymbolTable {
 		kinds:               make(map[string]typesystem.Kind),
 		moduleAliases:       make(map[string]string),
 		typeAliases:         make(map[string]typesystem.Type),
+		newtypes:            make(map[string]typesystem.Type),
+		derivedInstances:    make(map[string]map[string]bool),
 	}
 }
 
@@ -159,6 +171,14 @@ func (st *SymbolTable) InitBuiltins() {
 	}
 	st.DefineType("String", stringType, prelude)
 	st.RegisterKind("String", typesystem.Star)
+	for _, info := range config.IntTypes {
+		if info.Suffix != "" {
+			st.DefineType(info.Name, typesystem.TCon{Name: info.Name}, prelude)
+			st.RegisterKind(info.Name, typesystem.Star)
+		}
+	}
+	st.DefineType("Byte", typesystem.UInt8, prelude)
+	st.RegisterKind("Byte", typesystem.Star)
 
 	// Built-in ADTs for error handling
 	// type Result e t = Ok t | Fail e  (like Haskell's Either e a)
@@ -238,6 +258,12 @@ func (s *SymbolTable) Define(name string, t typesystem.Type, origin string) {
 	s.store[name] = Symbol{Name: name, Type: t, Kind: VariableSymbol, IsConstant: false, OriginModule: origin}
 }
 
+// DefineFunction defines a declared function. Its type's parameter names
+// and defaults describe the value bound, so calls may pass arguments by name.
+func (s *SymbolTable) DefineFunction(name string, t typesystem.Type, origin string) {
+	s.store[name] = Symbol{Name: name, Type: t, Kind: VariableSymbol, OriginModule: origin, IsFunction: true}
+}
+
 func (s *SymbolTable) DefineConstant(name string, t typesystem.Type, origin string) {
 	s.store[name] = Symbol{Name: name, Type: t, Kind: VariableSymbol, IsConstant: true, OriginModule: origin}
 }
@@ -346,6 +372,20 @@ func (s *SymbolTable) GetTraitSuperTraits(name string) ([]string, bool) {
 	return t, ok
 }
 
+// TraitExtends reports whether trait is super or has it among its super traits.
+func (s *SymbolTable) TraitExtends(trait, super string) bool {
+	if trait == super {
+		return true
+	}
+	supers, _ := s.GetTraitSuperTraits(trait)
+	for _, st := range supers {
+		if s.TraitExtends(st, super) {
+			return true
+		}
+	}
+	return false
+}
+
 // TraitExists checks if a trait is defined in this scope or any outer scope
 func (s *SymbolTable) TraitExists(name string) bool {
 	if _, ok := s.implementations[name]; ok {
@@ -565,6 +605,11 @@ func (s *SymbolTable) RegisterImplementation(traitName string, t typesystem.Type
 }
 
 func (s *SymbolTable) IsImplementationExists(traitName string, t typesystem.Type) bool {
+	// A trait object implements its trait and the trait's super traits
+	if trait, ok := typesystem.DynTrait(t); ok {
+		return s.TraitExtends(trait, traitName)
+	}
+
 	// Collect types to check: original type + resolved alias if applicable
 	typesToCheck := []typesystem.Type{t}
 
@@ -717,6 +762,11 @@ func (s *SymbolTable) All() map[string]Symbol {
 	return s.store
 }
 
+// Outer returns the enclosing scope, or nil for the global scope.
+func (s *SymbolTable) Outer() *SymbolTable {
+	return s.outer
+}
+
 func (s *SymbolTable) ResolveType(name string) (typesystem.Type, bool) {
 	// Handle Qualified Types (e.g. math.Vector)
 	if strings.Contains(name, ".") {
@@ -788,6 +838,21 @@ func (s *SymbolTable) Update(name string, t typesystem.Type) error {
 	return typesystem.NewSymbolNotFoundError(name)
 }
 
+// Reassign updates the type of a variable that is given a new value.
+// Afterwards it no longer names the function it may have been declared as.
+func (s *SymbolTable) Reassign(name string, t typesystem.Type) error {
+	if sym, ok := s.store[name]; ok {
+		sym.Type = t
+		sym.IsFunction = false
+		s.store[name] = sym
+		return nil
+	}
+	if s.outer != nil {
+		return s.outer.Reassign(name, t)
+	}
+	return typesystem.NewSymbolNotFoundError(name)
+}
+
 func (s *SymbolTable) GetTraitForMethod(methodName string) (string, bool) {
 	t, ok := s.traitMethods[methodName]
 	if !ok && s.outer != nil {
@@ -970,9 +1035,45 @@ func (s *SymbolTable) GetKind(typeName string) (typesystem.Kind, bool) {
 }
 
 func (s *SymbolTable) RegisterVariant(typeName, constructorName string) {
+	for _, existing := range s.variants[typeName] {
+		if existing == constructorName {
+			return
+		}
+	}
 	s.variants[typeName] = append(s.variants[typeName], constructorName)
 }
 
+// RegisterNewtype records the wrapped type of a newtype.
+func (s *SymbolTable) RegisterNewtype(typeName string, wrapped typesystem.Type) {
+	s.newtypes[typeName] = wrapped
+}
+
+// GetNewtype returns the wrapped type if typeName is a newtype.
+func (s *SymbolTable) GetNewtype(typeName string) (typesystem.Type, bool) {
+	t, ok := s.newtypes[typeName]
+	if !ok && s.outer != nil {
+		return s.outer.GetNewtype(typeName)
+	}
+	return t, ok
+}
+
+// RegisterDerivedInstance records that the instance of traitName for
+// typeName was derived from the type's fields.
+func (s *SymbolTable) RegisterDerivedInstance(traitName, typeName string) {
+	if s.derivedInstances[traitName] == nil {
+		s.derivedInstances[traitName] = make(map[string]bool)
+	}
+	s.derivedInstances[traitName][typeName] = true
+}
+
+// IsDerivedInstance reports whether the instance of traitName for typeName was derived.
+func (s *SymbolTable) IsDerivedInstance(traitName, typeName string) bool {
+	if s.derivedInstances[traitName][typeName] {
+		return true
+	}
+	return s.outer != nil && s.outer.IsDerivedInstance(traitName, typeName)
+}
+
 func (s *SymbolTable) GetVariants(typeName string) ([]string, bool) {
 	v, ok := s.variants[typeName]
 	if !ok && s.outer != nil {
@@ -1,6 +1,9 @@
 package token
 
-import "fmt"
+import (
+	"fmt"
+	"math/big"
+)
 
 type TokenType string
 
@@ -13,6 +16,20 @@ type Token struct {
 	Literal interface{}
 }
 
+// SizedInt is the literal of a SIZED_INT token: the magnitude and the
+// integer type named by its suffix.
+type SizedInt struct {
+	Type  string
+	Value uint64
+}
+
+// Decimal is the literal of a DECIMAL token: the digits without the point
+// and the number of digits after it.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
 func (t Token) String() string {
 	return fmt.Sprintf("Line %d:%d, Type: %s, Lexeme: '%s'", t.Line, t.Column, t.Type, t.Lexeme)
 }
@@ -52,6 +69,7 @@ const (
 
 	ELLIPSIS  TokenType = "..."
 	QUESTION        TokenType = "?"
+	HOLE            TokenType = "HOLE" // ?name - a named typed hole
 	NULL_COALESCE   TokenType = "??"  // Optional null coalescing
 	OPTIONAL_CHAIN  TokenType = "?."  // Optional chaining
 	CONCAT         TokenType = "++"  // List/String concatenation
@@ -92,11 +110,14 @@ const (
 	COMMA         TokenType = ","
 	COLON_MINUS TokenType = ":-"
 	COLON       TokenType = ":"
+	SEMICOLON   TokenType = ";"
+	LARROW      TokenType = "<-" // Bind in do blocks
 	DOT         TokenType = "."
 
 	// Keywords
 	TYPE     TokenType = "TYPE"
 	ALIAS    TokenType = "ALIAS"
+	NEWTYPE  TokenType = "NEWTYPE" // Opaque wrapper type
 	IF       TokenType = "IF"
 	ELSE     TokenType = "ELSE"
 	TRUE     TokenType = "TRUE"
@@ -113,7 +134,9 @@ const (
 	CONTINUE TokenType = "CONTINUE"
 	PACKAGE  TokenType = "PACKAGE"
 	IMPORT   TokenType = "IMPORT"
-	WHERE    TokenType = "WHERE"  // Constraint clause
+	WHERE    TokenType = "WHERE"    // Constraint clause
+	DO       TokenType = "DO"       // Do-notation block
+	DERIVING TokenType = "DERIVING" // Derived trait instances on a type
 
 	// Special symbols
 	ARROW      TokenType = "->"
@@ -125,7 +148,9 @@ const (
 	INT         TokenType = "INT"
 	FLOAT       TokenType = "FLOAT"
 	BIG_INT     TokenType = "BIG_INT"  // 100n
+	SIZED_INT   TokenType = "SIZED_INT" // 200u8, 1000i16
 	RATIONAL    TokenType = "RATIONAL" // 12.34r
+	DECIMAL     TokenType = "DECIMAL"  // 12.34d
 	STRING        TokenType = "STRING"
 	INTERP_STRING TokenType = "INTERP_STRING" // String with ${...} interpolations
 	FORMAT_STRING TokenType = "FORMAT_STRING" // %".2f" - Format string literal
@@ -145,6 +170,7 @@ const (
 var keywords = map[string]TokenType{
 	"type":     TYPE,
 	"alias":    ALIAS,
+	"newtype":  NEWTYPE,
 	"if":       IF,
 	"else":     ELSE,
 	"true":     TRUE,
@@ -162,6 +188,8 @@ var keywords = map[string]TokenType{
 	"package":  PACKAGE,
 	"import":   IMPORT,
 	"where":    WHERE,
+	"do":       DO,
+	"deriving": DERIVING,
 	"_":        UNDERSCORE,
 }
 
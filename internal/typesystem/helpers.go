@@ -1,5 +1,12 @@
 package typesystem
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
 // FuncType creates a function type (from -> to).
 // This helper creates a TFunc for single argument.
 // For multi-argument functions, construct TFunc directly.
@@ -10,18 +17,131 @@ func FuncType(from, to Type) Type {
 	}
 }
 
+// RenameGeneratedVars maps the type variables generated by inference
+// (t12, t13, ...) to a, b, ... in order of appearance. Passing several types
+// renames them consistently, so related signatures read together. Names
+// already used in the types, such as a user's own type variables (free, or
+// rigid inside the function that declares them), are skipped.
+func RenameGeneratedVars(ts ...Type) Subst {
+	taken := make(map[string]bool)
+	notNamePart := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' }
+	for _, t := range ts {
+		for _, name := range strings.FieldsFunc(t.String(), notNamePart) {
+			taken[name] = true
+		}
+	}
+
+	subst := Subst{}
+	next := 0
+	for _, t := range ts {
+		for _, tv := range t.FreeTypeVariables() {
+			if _, done := subst[tv.Name]; done {
+				continue
+			}
+			if len(tv.Name) < 2 || tv.Name[0] != 't' || strings.Trim(tv.Name[1:], "0123456789") != "" {
+				continue
+			}
+			name := generatedVarName(next)
+			for taken[name] {
+				next++
+				name = generatedVarName(next)
+			}
+			subst[tv.Name] = TVar{Name: name}
+			next++
+		}
+	}
+	return subst
+}
+
+// generatedVarName returns a, b, ..., z, a1, b1, ...
+func generatedVarName(n int) string {
+	name := string(rune('a' + n%26))
+	if n >= 26 {
+		name += fmt.Sprint(n / 26)
+	}
+	return name
+}
+
 // Primitive Types helpers
 var (
 	Int      = TCon{Name: "Int"}
 	Float    = TCon{Name: "Float"}
 	BigInt   = TCon{Name: "BigInt"}
 	Rational = TCon{Name: "Rational"}
+	Decimal  = TCon{Name: "Decimal"}
 	Char     = TCon{Name: "Char"}
 	Bool     = TCon{Name: "Bool"}
 	Nil      = TCon{Name: "Nil"}
+	// Sized integers; Byte is an alias of UInt8
+	Int8   = TCon{Name: "Int8"}
+	Int16  = TCon{Name: "Int16"}
+	Int32  = TCon{Name: "Int32"}
+	UInt8  = TCon{Name: "UInt8"}
+	UInt16 = TCon{Name: "UInt16"}
+	UInt32 = TCon{Name: "UInt32"}
+	UInt64 = TCon{Name: "UInt64"}
 	// String is List<Char>, defined as TApp
 	String = TApp{
 		Constructor: TCon{Name: "List"},
 		Args:        []Type{Char},
 	}
 )
+
+// JoinCallInfo returns t with the parameter names and defaults of its
+// function types kept only where other has the same ones. Use it where values
+// of both (unified) types meet, e.g. list elements or branches: the joined
+// value may be either function, so names and defaults can't be relied on.
+func JoinCallInfo(t, other Type) Type {
+	switch typ := t.(type) {
+	case TFunc:
+		o, ok := other.(TFunc)
+		if !ok || len(o.Params) != len(typ.Params) {
+			return t
+		}
+		params := make([]Type, len(typ.Params))
+		for i, p := range typ.Params {
+			params[i] = JoinCallInfo(p, o.Params[i])
+		}
+		typ.Params = params
+		typ.ReturnType = JoinCallInfo(typ.ReturnType, o.ReturnType)
+		if typ.DefaultCount != o.DefaultCount || !slices.Equal(typ.ParamNames, o.ParamNames) {
+			typ.DefaultCount = 0
+			typ.ParamNames = nil
+		}
+		return typ
+	case TApp:
+		o, ok := other.(TApp)
+		if !ok || len(o.Args) != len(typ.Args) {
+			return t
+		}
+		args := make([]Type, len(typ.Args))
+		for i, a := range typ.Args {
+			args[i] = JoinCallInfo(a, o.Args[i])
+		}
+		return TApp{Constructor: typ.Constructor, Args: args}
+	case TTuple:
+		o, ok := other.(TTuple)
+		if !ok || len(o.Elements) != len(typ.Elements) {
+			return t
+		}
+		elems := make([]Type, len(typ.Elements))
+		for i, e := range typ.Elements {
+			elems[i] = JoinCallInfo(e, o.Elements[i])
+		}
+		return TTuple{Elements: elems}
+	case TRecord:
+		o, ok := other.(TRecord)
+		if !ok {
+			return t
+		}
+		fields := make(map[string]Type, len(typ.Fields))
+		for k, v := range typ.Fields {
+			if ov, ok := o.Fields[k]; ok {
+				v = JoinCallInfo(v, ov)
+			}
+			fields[k] = v
+		}
+		return TRecord{Fields: fields, IsOpen: typ.IsOpen}
+	}
+	return t
+}
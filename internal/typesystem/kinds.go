@@ -19,6 +19,12 @@ func (k KStar) Equal(other Kind) bool {
 	return ok
 }
 
+// GobEncode lets KStar be serialized (gob rejects structs without fields)
+func (k KStar) GobEncode() ([]byte, error) { return []byte{}, nil }
+
+// GobDecode implements gob.GobDecoder
+func (k *KStar) GobDecode([]byte) error { return nil }
+
 // KArrow represents a higher-kinded type (k1 -> k2).
 type KArrow struct {
 	Left  Kind
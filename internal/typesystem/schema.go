@@ -0,0 +1,50 @@
+package typesystem
+
+// Schema is a self-contained description of a type for code that has to
+// inspect values at runtime without a symbol table (e.g. typed JSON decoding).
+// Named records and ADTs stay TCon/TApp in Type and in field types; their
+// definitions are looked up by name in Records and ADTs.
+type Schema struct {
+	Type    Type
+	Records map[string]SchemaRecord
+	ADTs    map[string]SchemaADT
+}
+
+// SchemaRecord is the definition of a named record type.
+type SchemaRecord struct {
+	Params []string // Type parameters, substituted from TApp arguments
+	Fields map[string]Type
+}
+
+// SchemaADT is the definition of an algebraic data type.
+type SchemaADT struct {
+	Params       []string
+	Constructors []SchemaConstructor // In declaration order
+}
+
+// SchemaConstructor is a single constructor of an ADT.
+type SchemaConstructor struct {
+	Name   string
+	Fields []Type
+}
+
+// Merge returns a schema with the definitions of both s and other and no
+// Type. Either may be nil; neither is modified.
+func (s *Schema) Merge(other *Schema) *Schema {
+	merged := &Schema{
+		Records: make(map[string]SchemaRecord),
+		ADTs:    make(map[string]SchemaADT),
+	}
+	for _, part := range []*Schema{s, other} {
+		if part == nil {
+			continue
+		}
+		for name, rec := range part.Records {
+			merged.Records[name] = rec
+		}
+		for name, adt := range part.ADTs {
+			merged.ADTs[name] = adt
+		}
+	}
+	return merged
+}
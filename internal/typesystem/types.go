@@ -102,6 +102,7 @@ func ApplyWithCycleCheck(t Type, s Subst, visited map[string]bool) Type {
 			IsVariadic:   typ.IsVariadic,
 			DefaultCount: typ.DefaultCount,
 			Constraints:  newConstraints,
+			ParamNames:   typ.ParamNames,
 		}
 
 	case TTuple:
@@ -165,6 +166,53 @@ func (t TCon) FreeTypeVariables() []TVar {
 	return []TVar{}
 }
 
+// DynPrefix starts the name of a trait object type: `dyn Draw` is TCon{Name: "dyn Draw"}.
+const DynPrefix = "dyn "
+
+// TDyn returns the trait object type for a trait.
+func TDyn(trait string) TCon {
+	return TCon{Name: DynPrefix + trait}
+}
+
+// DynTrait returns the trait of a trait object type.
+func DynTrait(t Type) (string, bool) {
+	if tCon, ok := t.(TCon); ok && strings.HasPrefix(tCon.Name, DynPrefix) {
+		return tCon.Name[len(DynPrefix):], true
+	}
+	return "", false
+}
+
+// MentionsDyn reports whether a trait object type occurs anywhere in t.
+func MentionsDyn(t Type) bool {
+	switch t := t.(type) {
+	case TCon:
+		_, ok := DynTrait(t)
+		return ok
+	case TApp:
+		if MentionsDyn(t.Constructor) {
+			return true
+		}
+		for _, arg := range t.Args {
+			if MentionsDyn(arg) {
+				return true
+			}
+		}
+	case TTuple:
+		for _, el := range t.Elements {
+			if MentionsDyn(el) {
+				return true
+			}
+		}
+	case TRecord:
+		for _, f := range t.Fields {
+			if MentionsDyn(f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // UnwrapUnderlying recursively unwraps TCon.UnderlyingType until reaching a non-TCon type.
 // Returns the innermost underlying type, or the original type if no UnderlyingType.
 func UnwrapUnderlying(t Type) Type {
@@ -357,6 +405,7 @@ type TFunc struct {
 	IsVariadic   bool
 	DefaultCount int          // Number of parameters with default values (from the end)
 	Constraints  []Constraint // Generic constraints (e.g. T: Show)
+	ParamNames   []string     // Parameter names for named arguments; nil if unknown
 }
 
 func (t TFunc) String() string {
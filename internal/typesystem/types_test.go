@@ -148,3 +148,36 @@ func TestUnifyAllowExtra(t *testing.T) {
 	}
 }
 
+
+func TestRenameGeneratedVars(t *testing.T) {
+	tests := []struct {
+		name string
+		t    Type
+		want string
+	}{
+		{
+			name: "Generated only",
+			t:    TFunc{Params: []Type{TVar{Name: "t12"}}, ReturnType: TVar{Name: "t40"}},
+			want: "(a) -> b",
+		},
+		{
+			name: "Skips user-named vars",
+			t:    TFunc{Params: []Type{TVar{Name: "a"}, TVar{Name: "t3"}}, ReturnType: TVar{Name: "b"}},
+			want: "(a, c) -> b",
+		},
+		{
+			name: "Skips rigid type params",
+			t:    TFunc{Params: []Type{TCon{Name: "a"}}, ReturnType: TVar{Name: "t7"}},
+			want: "(a) -> b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.t.Apply(RenameGeneratedVars(tt.t)).String()
+			if got != tt.want {
+				t.Errorf("RenameGeneratedVars() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
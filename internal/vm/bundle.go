@@ -0,0 +1,265 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/evaluator"
+	"github.com/funvibe/funxy/internal/modules"
+	"github.com/funvibe/funxy/internal/symbols"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// bundleCachePrefix distinguishes bundled modules from on-disk paths in the module cache
+const bundleCachePrefix = "bundle:"
+
+// Bundle is a self-contained compiled program: the main chunk together with
+// every user module it imports, so it can run without sources or a loader.
+type Bundle struct {
+	// Main is the compiled entry file
+	Main *Chunk
+
+	// Modules holds compiled user modules keyed by PendingImport.Resolved
+	Modules map[string]*CompiledModule
+
+	// TypeAliases from the main compiler (for default() support)
+	TypeAliases map[string]typesystem.Type
+
+	// TraitDefaults are precompiled trait default methods ("Trait.method")
+	TraitDefaults map[string]*CompiledFunction
+}
+
+// CompiledModule is a user module compiled into a Bundle
+type CompiledModule struct {
+	Name string
+
+	// Chunk is the module's top-level code (nil for package groups)
+	Chunk *Chunk
+
+	// Exports is the set of exported symbol names
+	Exports map[string]bool
+
+	// SubPackages maps sub-package names to module keys (package groups only)
+	SubPackages map[string]string
+
+	// TraitMethods maps exported trait names to all of their method names
+	TraitMethods map[string][]string
+}
+
+// SetBundle makes the VM resolve user-module imports and trait defaults from a bundle
+func (vm *VM) SetBundle(b *Bundle) {
+	vm.bundle = b
+	vm.compiledDefaults = b.TraitDefaults
+	vm.SetTypeAliases(b.TypeAliases)
+}
+
+// executeBundledModule runs a precompiled module (or package group) from the bundle
+func (vm *VM) executeBundledModule(cm *CompiledModule) (*evaluator.RecordInstance, error) {
+	if cm.Chunk == nil {
+		exports := make(map[string]evaluator.Object)
+
+		names := make([]string, 0, len(cm.SubPackages))
+		for subName := range cm.SubPackages {
+			names = append(names, subName)
+		}
+		sort.Strings(names)
+
+		for _, subName := range names {
+			subMod, ok := vm.bundle.Modules[cm.SubPackages[subName]]
+			if !ok {
+				return nil, fmt.Errorf("sub-package %s is not included in the bundle", subName)
+			}
+			subObj, err := vm.executeBundledModule(subMod)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute sub-package %s: %v", subName, err)
+			}
+			for _, field := range subObj.Fields {
+				exports[field.Key] = field.Value
+			}
+		}
+
+		return evaluator.NewRecord(exports), nil
+	}
+
+	return vm.executeModuleChunk(cm.Name, "", cm.Chunk, cm.Exports, nil)
+}
+
+// bundleBuilder collects compiled modules reachable from the main chunk
+type bundleBuilder struct {
+	bundle      *Bundle
+	loader      *modules.Loader
+	root        string
+	typeAliases map[string]typesystem.Type
+}
+
+// BuildBundle compiles every user module imported (transitively) by the main chunk.
+// baseDir is the directory of the main file; imports are resolved exactly as the
+// VM resolves them at run time and recorded in PendingImport.Resolved.
+func BuildBundle(main *Chunk, baseDir string, loader *modules.Loader, typeAliases map[string]typesystem.Type, traitDefaults map[string]*ast.FunctionStatement) (*Bundle, error) {
+	b := &bundleBuilder{
+		bundle: &Bundle{
+			Main:          main,
+			Modules:       make(map[string]*CompiledModule),
+			TypeAliases:   typeAliases,
+			TraitDefaults: make(map[string]*CompiledFunction),
+		},
+		loader:      loader,
+		root:        baseDir,
+		typeAliases: typeAliases,
+	}
+
+	if err := b.addDefaults(traitDefaults); err != nil {
+		return nil, err
+	}
+	if err := b.resolveImports(main.PendingImports, baseDir); err != nil {
+		return nil, err
+	}
+	return b.bundle, nil
+}
+
+// resolveImports bundles the user modules referenced by imports and records their keys
+func (b *bundleBuilder) resolveImports(imports []PendingImport, dir string) error {
+	for i := range imports {
+		if isVirtualModule(imports[i].Path) {
+			continue
+		}
+
+		absPath := b.loader.ResolveImport(dir, imports[i].Path)
+		modInterface, err := b.loader.GetModule(absPath)
+		if err != nil {
+			return fmt.Errorf("failed to load module %s: %v", imports[i].Path, err)
+		}
+		mod, ok := modInterface.(*modules.Module)
+		if !ok {
+			return fmt.Errorf("invalid module type for %s", imports[i].Path)
+		}
+
+		key, err := b.addModule(mod)
+		if err != nil {
+			return err
+		}
+		imports[i].Resolved = key
+	}
+	return nil
+}
+
+// addModule compiles a module (once) and returns its bundle key
+func (b *bundleBuilder) addModule(mod *modules.Module) (string, error) {
+	key, err := filepath.Rel(b.root, mod.Dir)
+	if err != nil {
+		key = mod.Dir
+	}
+	key = filepath.ToSlash(key)
+
+	if _, ok := b.bundle.Modules[key]; ok {
+		return key, nil
+	}
+
+	cm := &CompiledModule{
+		Name:         mod.Name,
+		Exports:      mod.Exports,
+		TraitMethods: make(map[string][]string),
+	}
+	// Register before compiling dependencies so cyclic imports terminate
+	b.bundle.Modules[key] = cm
+
+	if mod.IsPackageGroup {
+		cm.SubPackages = make(map[string]string)
+		for subName, subMod := range mod.Imports {
+			subKey, err := b.addModule(subMod)
+			if err != nil {
+				return "", err
+			}
+			cm.SubPackages[subName] = subKey
+			for traitName, methods := range b.bundle.Modules[subKey].TraitMethods {
+				cm.TraitMethods[traitName] = methods
+			}
+		}
+		return key, nil
+	}
+
+	chunk, err := compileModuleChunk(mod)
+	if err != nil {
+		return "", err
+	}
+	cm.Chunk = chunk
+
+	if mod.SymbolTable != nil {
+		for name := range mod.Exports {
+			if sym, ok := mod.SymbolTable.Find(name); ok && sym.Kind == symbols.TraitSymbol {
+				cm.TraitMethods[name] = mod.SymbolTable.GetTraitAllMethods(name)
+			}
+		}
+	}
+
+	if err := b.addDefaults(mod.TraitDefaults); err != nil {
+		return "", err
+	}
+	if err := b.resolveImports(chunk.PendingImports, mod.Dir); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// addDefaults precompiles trait default methods into the bundle
+func (b *bundleBuilder) addDefaults(defaults map[string]*ast.FunctionStatement) error {
+	for key, fn := range defaults {
+		compiledFn, err := compileDefaultMethod(fn, b.typeAliases)
+		if err != nil {
+			return fmt.Errorf("compilation error in default method %s: %v", key, err)
+		}
+		b.bundle.TraitDefaults[key] = compiledFn
+	}
+	return nil
+}
+
+// Serialize converts a Bundle to a binary format using gob encoding
+// Format:
+// - Magic number (4 bytes): 0x46585950 ("FXYP")
+// - Version (1 byte): 0x01
+// - Gob-encoded Bundle data
+func (b *Bundle) Serialize() ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Magic number
+	buf.Write([]byte{0x46, 0x58, 0x59, 0x50}) // "FXYP"
+
+	// Version
+	buf.WriteByte(0x01)
+
+	enc := gob.NewEncoder(buf)
+	if err := enc.Encode(b); err != nil {
+		return nil, fmt.Errorf("gob encoding failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DeserializeBundle reconstructs a Bundle from binary format
+func DeserializeBundle(data []byte) (*Bundle, error) {
+	if len(data) < 5 {
+		return nil, fmt.Errorf("data too short")
+	}
+
+	// Check magic number
+	if data[0] != 0x46 || data[1] != 0x58 || data[2] != 0x59 || data[3] != 0x50 {
+		return nil, fmt.Errorf("invalid magic number, expected FXYP")
+	}
+
+	// Check version
+	if data[4] != 0x01 {
+		return nil, fmt.Errorf("unsupported bundle version: %d", data[4])
+	}
+
+	dec := gob.NewDecoder(bytes.NewReader(data[5:]))
+	var bundle Bundle
+	if err := dec.Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("gob decoding failed: %w", err)
+	}
+
+	return &bundle, nil
+}
@@ -27,7 +27,9 @@ func init() {
 	gob.Register(&evaluator.RecordInstance{})
 	gob.Register(&evaluator.Map{})
 	gob.Register(&evaluator.BigInt{})
+	gob.Register(&evaluator.SizedInt{})
 	gob.Register(&evaluator.Rational{})
+	gob.Register(&evaluator.Decimal{})
 	gob.Register(&evaluator.DataInstance{})
 	gob.Register(&evaluator.Constructor{})
 	gob.Register(&evaluator.ClassMethod{})
@@ -57,6 +59,7 @@ func init() {
 	// Register stringConstant - it's defined in compiler_expressions.go
 	// but needs to be registered here for gob serialization
 	gob.Register(&stringConstant{})
+	gob.Register(&BitsSegments{})
 }
 
 // Chunk represents a sequence of bytecode instructions
@@ -93,6 +93,7 @@ type PendingImport struct {
 	Symbols        []string // Specific symbols to import
 	ExcludeSymbols []string // Symbols to exclude when ImportAll is true
 	Alias          string   // Module alias for "import as" syntax
+	Resolved       string   // Bundle module key (set only for standalone executables)
 }
 
 // NewCompiler creates a new compiler for top-level code
@@ -245,6 +246,8 @@ func (c *Compiler) literalToObject(val interface{}) evaluator.Object {
 		return evaluator.StringToList(v)
 	case rune:
 		return &evaluator.Char{Value: int64(v)}
+	case *ast.SizedIntLiteral:
+		return evaluator.NewSizedIntLiteral(v)
 	default:
 		return &evaluator.Nil{}
 	}
@@ -835,8 +838,10 @@ func (c *Compiler) compileFunctionLiteral(lit *ast.FunctionLiteral) error {
 	funcCompiler.function.IsVariadic = isVariadic
 
 	// Parameters become the first locals
+	funcCompiler.function.ParamNames = make([]string, len(lit.Parameters))
 	for i, param := range lit.Parameters {
 		funcCompiler.addLocal(param.Name.Value, i)
+		funcCompiler.function.ParamNames[i] = param.Name.Value
 	}
 	funcCompiler.slotCount = len(lit.Parameters) // Include variadic param as local
 
@@ -967,6 +972,32 @@ func (c *Compiler) compileBitsLiteral(lit *ast.BitsLiteral) error {
 	return nil
 }
 
+// Compile binary construction: <<ver:4, len:16/little, payload/binary>>
+// Each segment pushes its value and then its size, if it has one.
+func (c *Compiler) compileBitsExpression(expr *ast.BitsExpression) error {
+	line := expr.Token.Line
+	pushed := 0
+	for _, seg := range expr.Segments {
+		if err := c.compileExpression(seg.Value); err != nil {
+			return err
+		}
+		pushed++
+		if seg.Size != nil {
+			if err := c.compileExpression(seg.Size); err != nil {
+				return err
+			}
+			pushed++
+		}
+	}
+
+	segIdx := c.currentChunk().AddConstant(&BitsSegments{Segments: evaluator.LowerBitsSegments(expr.Segments)})
+	c.emit(OP_MAKE_BITS, line)
+	c.currentChunk().Write(byte(segIdx>>8), line)
+	c.currentChunk().Write(byte(segIdx), line)
+	c.slotCount -= pushed - 1
+	return nil
+}
+
 // Compile interpolated string: "Hello {name}"
 func (c *Compiler) compileInterpolatedString(expr *ast.InterpolatedString) error {
 	line := expr.Token.Line
@@ -27,11 +27,21 @@ func (c *Compiler) compileExpression(expr ast.Expression) error {
 		c.slotCount++
 		return nil
 
+	case *ast.SizedIntLiteral:
+		c.emitConstant(evaluator.NewSizedIntLiteral(e), e.Token.Line)
+		c.slotCount++
+		return nil
+
 	case *ast.RationalLiteral:
 		c.emitConstant(&evaluator.Rational{Value: e.Value}, e.Token.Line)
 		c.slotCount++
 		return nil
 
+	case *ast.DecimalLiteral:
+		c.emitConstant(evaluator.NewDecimalLiteral(e), e.Token.Line)
+		c.slotCount++
+		return nil
+
 	case *ast.BooleanLiteral:
 		if e.Value {
 			c.emit(OP_TRUE, e.Token.Line)
@@ -83,6 +93,10 @@ func (c *Compiler) compileExpression(expr ast.Expression) error {
 
 	case *ast.ForExpression:
 		return c.compileForExpression(e)
+	case *ast.ListComprehension:
+		return c.compileListComprehension(e)
+	case *ast.MapComprehension:
+		return c.compileMapComprehension(e)
 
 	case *ast.ListLiteral:
 		return c.compileListLiteral(e)
@@ -117,6 +131,9 @@ func (c *Compiler) compileExpression(expr ast.Expression) error {
 	case *ast.BitsLiteral:
 		return c.compileBitsLiteral(e)
 
+	case *ast.BitsExpression:
+		return c.compileBitsExpression(e)
+
 	case *ast.SpreadExpression:
 		// SpreadExpression in isolation just evaluates its inner expression
 		return c.compileExpression(e.Expression)
@@ -161,6 +178,10 @@ func (c *Compiler) compileExpression(expr ast.Expression) error {
 				}
 			}
 		}
+
+		if e.Pack != nil {
+			c.emitPack(e.Pack, line)
+		}
 		return nil
 
 	case *ast.OperatorAsFunction:
@@ -171,6 +192,12 @@ func (c *Compiler) compileExpression(expr ast.Expression) error {
 		c.slotCount++
 		return nil
 
+	case *ast.DefaultArgument:
+		// Parameter skipped by named arguments: the callee binds its default
+		c.emit(OP_DEFAULT_ARG, e.Token.Line)
+		c.slotCount++
+		return nil
+
 	case *ast.TypeApplicationExpression:
 		// Generic type application - just compile inner expression
 		return c.compileExpression(e.Expression)
@@ -240,7 +267,8 @@ func (c *Compiler) compileCallExpression(call *ast.CallExpression) error {
 
 		// Compile remaining arguments - clear context
 		argCount := 0
-		for _, arg := range call.Arguments {
+		for _, i := range callArgOrder(call) {
+			arg := call.Arguments[i]
 			if spread, ok := arg.(*ast.SpreadExpression); ok {
 				if err := c.withTypeContext("", func() error {
 					return c.compileExpression(spread.Expression)
@@ -254,9 +282,15 @@ func (c *Compiler) compileCallExpression(call *ast.CallExpression) error {
 				}); err != nil {
 					return err
 				}
+				c.emitCallArgPack(call, i, line)
 			}
 			argCount++
 		}
+		c.emitReorderArgs(call, line)
+		if call.Schema != nil {
+			c.emitSchemaArgument(call.Schema, line)
+			argCount++
+		}
 
 		// Set context if found (JUST BEFORE CALL)
 		if typeContextName != "" {
@@ -303,7 +337,8 @@ func (c *Compiler) compileCallExpression(call *ast.CallExpression) error {
 
 	// Compile arguments (also not in tail position)
 	argCount := 0
-	for _, arg := range call.Arguments {
+	for _, i := range callArgOrder(call) {
+		arg := call.Arguments[i]
 		if spread, ok := arg.(*ast.SpreadExpression); ok {
 			// Spread expression - compile the inner value (tuple/list)
 			// Arguments shouldn't inherit the call's return type context
@@ -320,9 +355,15 @@ func (c *Compiler) compileCallExpression(call *ast.CallExpression) error {
 			}); err != nil {
 				return err
 			}
+			c.emitCallArgPack(call, i, line)
 		}
 		argCount++
 	}
+	c.emitReorderArgs(call, line)
+	if call.Schema != nil {
+		c.emitSchemaArgument(call.Schema, line)
+		argCount++
+	}
 
 	// Restore tail position for decision
 	c.inTailPosition = wasTail
@@ -360,6 +401,59 @@ func (c *Compiler) compileCallExpression(call *ast.CallExpression) error {
 	return nil
 }
 
+// callArgOrder lists the indexes of call.Arguments in evaluation order
+func callArgOrder(call *ast.CallExpression) []int {
+	if call.ArgOrder != nil {
+		return call.ArgOrder
+	}
+	order := make([]int, len(call.Arguments))
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// emitReorderArgs moves arguments compiled in call.ArgOrder into parameter
+// order. Each argument, spread or not, occupies one stack slot here.
+func (c *Compiler) emitReorderArgs(call *ast.CallExpression, line int) {
+	if call.ArgOrder == nil {
+		return
+	}
+	source := make([]byte, len(call.ArgOrder))
+	for pos, i := range call.ArgOrder {
+		source[i] = byte(pos)
+	}
+	c.emit(OP_REORDER_ARGS, line)
+	c.currentChunk().Write(byte(len(source)), line)
+	for _, pos := range source {
+		c.currentChunk().Write(pos, line)
+	}
+}
+
+// emitSchemaArgument pushes the expected result type appended to a
+// jsonDecode call
+func (c *Compiler) emitSchemaArgument(schema *typesystem.Schema, line int) {
+	c.emitConstant(&evaluator.TypeObject{TypeVal: schema.Type, Schema: schema}, line)
+	c.slotCount++
+}
+
+// emitPack packs the value on top of the stack into trait objects where t
+// says dyn Trait (see evaluator.PackTraitObjects)
+func (c *Compiler) emitPack(t typesystem.Type, line int) {
+	typeIdx := c.currentChunk().AddConstant(&evaluator.TypeObject{TypeVal: t})
+	c.emit(OP_PACK_DYN, line)
+	c.currentChunk().Write(byte(typeIdx>>8), line)
+	c.currentChunk().Write(byte(typeIdx), line)
+}
+
+// emitCallArgPack packs argument i of call if the analyzer marked it for a
+// dyn Trait parameter
+func (c *Compiler) emitCallArgPack(call *ast.CallExpression, i int, line int) {
+	if len(call.PackArgs) == len(call.Arguments) && call.PackArgs[i] != nil {
+		c.emitPack(call.PackArgs[i], line)
+	}
+}
+
 // extractTypeNameFromASTType extracts type constructor name from AST type
 func extractTypeNameFromASTType(typeExpr ast.Type) string {
 	switch t := typeExpr.(type) {
@@ -403,6 +497,16 @@ func (s *StringPatternParts) Inspect() string              { return "<string-pat
 func (s *StringPatternParts) RuntimeType() typesystem.Type { return nil }
 func (s *StringPatternParts) Hash() uint32                 { return 0 }
 
+// BitsSegments holds the segments of a binary construction or pattern
+type BitsSegments struct {
+	Segments []evaluator.BitsSegment
+}
+
+func (s *BitsSegments) Type() evaluator.ObjectType   { return "BITS_SEGMENTS" }
+func (s *BitsSegments) Inspect() string              { return "<bits-segments>" }
+func (s *BitsSegments) RuntimeType() typesystem.Type { return nil }
+func (s *BitsSegments) Hash() uint32                 { return 0 }
+
 func (s *stringConstant) Type() evaluator.ObjectType   { return "STRING_CONST" }
 func (s *stringConstant) Inspect() string              { return s.Value }
 func (s *stringConstant) RuntimeType() typesystem.Type { return nil }
@@ -526,6 +630,11 @@ func (c *Compiler) compileAssignExpression(expr *ast.AssignExpression) error {
 		c.currentChunk().Write(byte(elemTypeIdx), line)
 	}
 
+	// Values stored in dyn Trait variables are packed into trait objects
+	if expr.Pack != nil {
+		c.emitPack(expr.Pack, line)
+	}
+
 	// Get name from Left (must be identifier)
 	ident, ok := expr.Left.(*ast.Identifier)
 	if !ok {
@@ -1044,17 +1153,23 @@ func (c *Compiler) compileInfixExpression(expr *ast.InfixExpression) error {
 	wasTail := c.inTailPosition
 	c.inTailPosition = false
 
+	line := expr.Token.Line
 	if err := c.compileExpression(expr.Left); err != nil {
 		return err
 	}
+	if expr.PackLeft != nil {
+		c.emitPack(expr.PackLeft, line)
+	}
 
 	if err := c.compileExpression(expr.Right); err != nil {
 		return err
 	}
+	if expr.PackRight != nil {
+		c.emitPack(expr.PackRight, line)
+	}
 
 	c.inTailPosition = wasTail
 
-	line := expr.Token.Line
 	switch expr.Operator {
 	case "+":
 		c.emit(OP_ADD, line)
@@ -1149,11 +1264,12 @@ func (c *Compiler) compilePipeOp(expr *ast.InfixExpression) error {
 		}
 
 		// Compile existing arguments
-		for _, arg := range call.Arguments {
-			if err := c.compileExpression(arg); err != nil {
+		for _, i := range callArgOrder(call) {
+			if err := c.compileExpression(call.Arguments[i]); err != nil {
 				return err
 			}
 		}
+		c.emitReorderArgs(call, expr.Token.Line)
 
 		// Compile pipe input (left side) as the last argument
 		if err := c.compileExpression(expr.Left); err != nil {
@@ -1261,6 +1377,7 @@ func (c *Compiler) compileCoalesceOp(expr *ast.InfixExpression) error {
 	// Emit OP_COALESCE which checks if value isEmpty
 	// If empty, jump to default; otherwise unwrap
 	c.emit(OP_COALESCE, line)
+	c.slotCount++ // the bool
 	jumpIfEmpty := c.emitJump(OP_JUMP_IF_FALSE, line)
 	c.emit(OP_POP, line)
 	c.slotCount--
@@ -1268,14 +1385,23 @@ func (c *Compiler) compileCoalesceOp(expr *ast.InfixExpression) error {
 	// Not empty - value is already unwrapped on stack, skip default
 	skipDefault := c.emitJump(OP_JUMP, line)
 
-	// Empty - pop bool and compile default
+	// Empty - pop bool and compile default in place of the value
 	c.patchJump(jumpIfEmpty)
 	c.emit(OP_POP, line) // pop bool
 	c.emit(OP_POP, line) // pop the empty Option
-	c.slotCount -= 2
+	c.slotCount--
+	if expr.PackRight != nil {
+		// The default is packed after it returns, so it is not a tail call
+		wasTail := c.inTailPosition
+		c.inTailPosition = false
+		defer func() { c.inTailPosition = wasTail }()
+	}
 	if err := c.compileExpression(expr.Right); err != nil {
 		return err
 	}
+	if expr.PackRight != nil {
+		c.emitPack(expr.PackRight, line)
+	}
 
 	c.patchJump(skipDefault)
 	return nil
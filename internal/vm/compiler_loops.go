@@ -282,3 +282,165 @@ func (c *Compiler) compileContinueStatement(stmt *ast.ContinueStatement) error {
 	return nil
 }
 
+// compileListComprehension compiles [output | clauses] into nested iterator
+// loops that append to an accumulator local.
+func (c *Compiler) compileListComprehension(expr *ast.ListComprehension) error {
+	line := expr.Token.Line
+
+	// Nothing inside a comprehension is in tail position
+	wasTail := c.inTailPosition
+	c.inTailPosition = false
+	defer func() { c.inTailPosition = wasTail }()
+
+	c.beginScope()
+	c.emit(OP_MAKE_LIST, line)
+	c.currentChunk().Write(byte(0), line)
+	c.currentChunk().Write(byte(0), line)
+	c.slotCount++
+	accSlot := c.slotCount - 1
+	c.addLocal("$acc", accSlot)
+
+	if err := c.compileComprehensionClauses(expr.Clauses, line, func() error {
+		if err := c.compileExpression(expr.Output); err != nil {
+			return err
+		}
+		c.emit(OP_LIST_APPEND, line)
+		c.currentChunk().Write(byte(accSlot), line)
+		c.slotCount--
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// The accumulator stays on the stack as the result
+	c.endScopeNoEmit()
+	return nil
+}
+
+// compileMapComprehension compiles %{key => value | clauses}
+func (c *Compiler) compileMapComprehension(expr *ast.MapComprehension) error {
+	line := expr.Token.Line
+
+	// Nothing inside a comprehension is in tail position
+	wasTail := c.inTailPosition
+	c.inTailPosition = false
+	defer func() { c.inTailPosition = wasTail }()
+
+	c.beginScope()
+	c.emit(OP_MAKE_MAP, line)
+	c.currentChunk().Write(byte(0), line)
+	c.slotCount++
+	accSlot := c.slotCount - 1
+	c.addLocal("$acc", accSlot)
+
+	if err := c.compileComprehensionClauses(expr.Clauses, line, func() error {
+		if err := c.compileExpression(expr.Key); err != nil {
+			return err
+		}
+		if err := c.compileExpression(expr.Value); err != nil {
+			return err
+		}
+		c.emit(OP_MAP_INSERT, line)
+		c.currentChunk().Write(byte(accSlot), line)
+		c.slotCount -= 2
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	c.endScopeNoEmit()
+	return nil
+}
+
+// compileComprehensionClauses compiles generators as iterator loops and
+// filters as conditional skips, calling emitBody in the innermost position.
+// The stack is left as it was found.
+func (c *Compiler) compileComprehensionClauses(clauses []*ast.ComprehensionClause, line int, emitBody func() error) error {
+	if len(clauses) == 0 {
+		return emitBody()
+	}
+	clause := clauses[0]
+
+	if clause.Pattern == nil {
+		if err := c.compileExpression(clause.Filter); err != nil {
+			return err
+		}
+		skipJump := c.emitJump(OP_JUMP_IF_FALSE, line)
+		c.emit(OP_POP, line) // Pop condition (true)
+		c.slotCount--
+
+		if err := c.compileComprehensionClauses(clauses[1:], line, emitBody); err != nil {
+			return err
+		}
+		endJump := c.emitJump(OP_JUMP, line)
+
+		c.patchJump(skipJump)
+		c.emit(OP_POP, line) // Pop condition (false)
+		c.patchJump(endJump)
+		return nil
+	}
+
+	c.beginScope()
+
+	// Same iteration protocol as for-in loops: [iterable, len, index]
+	if err := c.compileExpression(clause.Iterable); err != nil {
+		return err
+	}
+	c.emit(OP_MAKE_ITER, line)
+	iterableSlot := c.slotCount - 1
+	c.addLocal("$iterable", iterableSlot)
+	c.slotCount++
+	lenSlot := c.slotCount - 1
+	c.addLocal("$len", lenSlot)
+	c.emitConstant(&evaluator.Integer{Value: 0}, line)
+	c.slotCount++
+	indexSlot := c.slotCount - 1
+	c.addLocal("$index", indexSlot)
+
+	loopStart := c.currentChunk().Len()
+	c.emit(OP_ITER_NEXT, line)
+	c.currentChunk().Write(byte(iterableSlot), line)
+	c.currentChunk().Write(byte(lenSlot), line)
+	c.currentChunk().Write(byte(indexSlot), line)
+	c.slotCount += 2
+
+	exitJump := c.emitJump(OP_JUMP_IF_FALSE, line)
+	c.emit(OP_POP, line) // Pop continue_flag (true)
+	c.slotCount--
+
+	// Stack: [..., item]. Bind the pattern; items that don't match are skipped.
+	itemSlot := c.slotCount - 1
+	c.beginScope()
+	failJump, err := c.compilePatternCheck(clause.Pattern, line)
+	if err != nil {
+		return err
+	}
+
+	if err := c.compileComprehensionClauses(clauses[1:], line, emitBody); err != nil {
+		return err
+	}
+
+	// Drop bindings and the item, then fetch the next one
+	c.endScope(line)
+	for c.slotCount > itemSlot {
+		c.emit(OP_POP, line)
+		c.slotCount--
+	}
+	c.emitLoop(loopStart, line)
+
+	if failJump >= 0 {
+		// Pattern failure leaves just the item on the stack
+		c.patchJump(failJump)
+		c.emit(OP_POP, line)
+		c.emitLoop(loopStart, line)
+	}
+
+	// Exhausted: pop continue_flag and item, then the iterator state
+	c.patchJump(exitJump)
+	c.slotCount = itemSlot + 2
+	c.emit(OP_POP, line)
+	c.emit(OP_POP, line)
+	c.slotCount -= 2
+	c.endScope(line)
+	return nil
+}
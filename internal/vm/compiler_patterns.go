@@ -32,6 +32,8 @@ func (c *Compiler) compilePatternCheck(pattern ast.Pattern, line int) (int, erro
 
 	case *ast.StringPattern:
 		return c.compileStringPattern(p, line)
+	case *ast.BitsPattern:
+		return c.compileBitsPattern(p, line)
 
 	case *ast.TuplePattern:
 		return c.compileTuplePattern(p, line)
@@ -81,6 +83,11 @@ func (c *Compiler) compileLiteralPattern(p *ast.LiteralPattern, line int) (int,
 }
 
 func (c *Compiler) compileConstructorPattern(p *ast.ConstructorPattern, line int) (int, error) {
+	if p.Newtype {
+		// Newtype values are not wrapped: match the field pattern directly
+		return c.compilePatternCheck(p.Elements[0], line)
+	}
+
 	// Stack at entry: [..., value_to_match]
 	// slotCount includes value_to_match
 	slotAtEntry := c.slotCount - 1 // slot of value_to_match
@@ -283,6 +290,62 @@ func (c *Compiler) compileStringPattern(p *ast.StringPattern, line int) (int, er
 	return failJump, nil
 }
 
+// compileBitsPattern works like compileStringPattern. Sizes that refer to
+// variables outside the pattern are loaded before the match.
+func (c *Compiler) compileBitsPattern(p *ast.BitsPattern, line int) (int, error) {
+	c.emit(OP_DUP, line)
+	c.slotCount++
+
+	segments := evaluator.LowerBitsSegments(p.Segments)
+	free := evaluator.BitsFreeNames(segments)
+	for _, name := range free {
+		if err := c.compileIdentifier(&ast.Identifier{Token: p.Token, Value: name}); err != nil {
+			return 0, err
+		}
+	}
+	segIdx := c.currentChunk().AddConstant(&BitsSegments{Segments: segments})
+
+	var captureNames []string
+	for _, seg := range segments {
+		if seg.Bind != "" {
+			captureNames = append(captureNames, seg.Bind)
+		}
+	}
+	captureCount := len(captureNames)
+
+	c.emit(OP_MATCH_BITS, line)
+	c.currentChunk().Write(byte(segIdx>>8), line)
+	c.currentChunk().Write(byte(segIdx), line)
+	c.currentChunk().Write(byte(captureCount), line)
+	c.currentChunk().Write(byte(len(free)), line)
+
+	// OP_MATCH_BITS pops input and free values, pushes captures (N) + bool (1)
+	c.slotCount += captureCount - len(free)
+
+	checkJump := c.emitJump(OP_JUMP_IF_FALSE, line)
+	c.emit(OP_POP, line) // pop true
+	c.slotCount--
+
+	for i, name := range captureNames {
+		slot := c.slotCount - captureCount + i
+		c.addLocal(name, slot)
+	}
+
+	successJump := c.emitJump(OP_JUMP, line)
+
+	// Failure path
+	c.patchJump(checkJump)
+	c.emit(OP_POP, line) // pop false
+	for i := 0; i < captureCount; i++ {
+		c.emit(OP_POP, line)
+	}
+
+	failJump := c.emitJump(OP_JUMP, line)
+
+	c.patchJump(successJump)
+	return failJump, nil
+}
+
 func (c *Compiler) compileTuplePattern(p *ast.TuplePattern, line int) (int, error) {
 	tupleSlot := c.slotCount - 1
 
@@ -79,8 +79,10 @@ func (c *Compiler) compileFunctionStatement(stmt *ast.FunctionStatement) error {
 	funcCompiler.function.IsVariadic = isVariadic
 	funcCompiler.function.RequiredArity = requiredArity
 
+	funcCompiler.function.ParamNames = make([]string, len(allParams))
 	for i, param := range allParams {
 		funcCompiler.addLocal(param.Name.Value, i)
+		funcCompiler.function.ParamNames[i] = param.Name.Value
 	}
 	funcCompiler.slotCount = len(allParams)
 
@@ -212,7 +214,7 @@ func (c *Compiler) compileTypeDeclaration(stmt *ast.TypeDeclarationStatement) er
 
 	typeName := stmt.Name.Value
 
-	typeObj := &evaluator.TypeObject{TypeVal: typesystem.TCon{Name: typeName}}
+	typeObj := &evaluator.TypeObject{TypeVal: typesystem.TCon{Name: typeName}, Schema: stmt.Schema}
 	c.emitConstant(typeObj, line)
 	c.slotCount++
 	nameIdx := c.currentChunk().AddConstant(&stringConstant{Value: typeName})
@@ -230,26 +232,36 @@ func (c *Compiler) compileTypeDeclaration(stmt *ast.TypeDeclarationStatement) er
 				c.typeAliases[typeName] = underlyingType
 			}
 		}
-		return nil
+		return c.compileDerivedInstances(stmt, nil)
+	}
+
+	if stmt.IsNewtype {
+		// default() of a newtype is the default of the wrapped type
+		if underlyingType := c.astTypeToTypesystemType(stmt.TargetType); underlyingType != nil {
+			c.typeAliases[typeName] = underlyingType
+		}
 	}
 
+	ctorObjs := make([]evaluator.Object, 0, len(stmt.Constructors))
 	for _, ctor := range stmt.Constructors {
 		ctorName := ctor.Name.Value
+		var ctorObj evaluator.Object
 		if len(ctor.Parameters) == 0 {
-			dataInst := &evaluator.DataInstance{
+			ctorObj = &evaluator.DataInstance{
 				Name:     ctorName,
 				Fields:   []evaluator.Object{},
 				TypeName: typeName,
 			}
-			c.emitConstant(dataInst, line)
 		} else {
-			ctorObj := &evaluator.Constructor{
+			ctorObj = &evaluator.Constructor{
 				Name:     ctorName,
 				TypeName: typeName,
 				Arity:    len(ctor.Parameters),
+				Newtype:  stmt.IsNewtype,
 			}
-			c.emitConstant(ctorObj, line)
 		}
+		ctorObjs = append(ctorObjs, ctorObj)
+		c.emitConstant(ctorObj, line)
 		c.slotCount++
 		ctorNameIdx := c.currentChunk().AddConstant(&stringConstant{Value: ctorName})
 		c.emit(OP_SET_GLOBAL, line)
@@ -260,9 +272,68 @@ func (c *Compiler) compileTypeDeclaration(stmt *ast.TypeDeclarationStatement) er
 		c.slotCount--
 	}
 
+	if stmt.IsNewtype {
+		// Newtype values use the instances of the wrapped type
+		return nil
+	}
+	return c.compileDerivedInstances(stmt, ctorObjs)
+}
+
+// compileDerivedInstances compiles the instances named in a type's deriving
+// clause: the ones synthesized by the analyzer like user instances, Show and
+// Json as structural instances registered by OP_DERIVE.
+func (c *Compiler) compileDerivedInstances(stmt *ast.TypeDeclarationStatement, ctorObjs []evaluator.Object) error {
+	line := stmt.Token.Line
+
+	for _, inst := range stmt.Derived {
+		if err := c.compileInstanceDeclaration(inst); err != nil {
+			return err
+		}
+		c.emit(OP_POP, line)
+		c.slotCount--
+	}
+
+	for _, trait := range stmt.Deriving {
+		if trait.Value != "Show" && trait.Value != "Json" {
+			continue
+		}
+		var ctors []evaluator.Object
+		if trait.Value == "Json" {
+			ctors = ctorObjs
+		}
+		// The constructor count is a single byte: register in batches
+		for {
+			batch := ctors
+			if len(batch) > 255 {
+				batch = batch[:255]
+			}
+			ctors = ctors[len(batch):]
+			c.emitDerive(trait.Value, stmt.Name.Value, batch, line)
+			if len(ctors) == 0 {
+				break
+			}
+		}
+	}
 	return nil
 }
 
+func (c *Compiler) emitDerive(traitName, typeName string, ctors []evaluator.Object, line int) {
+	for _, ctor := range ctors {
+		c.emitConstant(ctor, line)
+		c.slotCount++
+	}
+
+	traitIdx := c.currentChunk().AddConstant(&stringConstant{Value: traitName})
+	typeIdx := c.currentChunk().AddConstant(&stringConstant{Value: typeName})
+	c.emit(OP_DERIVE, line)
+	c.currentChunk().Write(byte(traitIdx>>8), line)
+	c.currentChunk().Write(byte(traitIdx), line)
+	c.currentChunk().Write(byte(typeIdx>>8), line)
+	c.currentChunk().Write(byte(typeIdx), line)
+	c.currentChunk().Write(byte(len(ctors)), line)
+	c.slotCount -= len(ctors)
+}
+
 // compileTraitDeclaration compiles trait declarations
 func (c *Compiler) compileTraitDeclaration(stmt *ast.TraitDeclaration) error {
 	line := stmt.Token.Line
@@ -322,8 +393,10 @@ func (c *Compiler) compileInstanceDeclaration(stmt *ast.InstanceDeclaration) err
 		arity := len(method.Parameters)
 		funcCompiler := newFunctionCompiler(c, methodName, arity)
 
+		funcCompiler.function.ParamNames = make([]string, arity)
 		for i, param := range method.Parameters {
 			funcCompiler.addLocal(param.Name.Value, i)
+			funcCompiler.function.ParamNames[i] = param.Name.Value
 		}
 		funcCompiler.slotCount = arity
 
@@ -114,6 +114,10 @@ func disassembleInstruction(sb *strings.Builder, chunk *Chunk, offset int) int {
 
 	case OP_CLOSE_SCOPE:
 		return byteInstruction(sb, "CLOSE_SCOPE", chunk, offset)
+	case OP_LIST_APPEND:
+		return byteInstruction(sb, "LIST_APPEND", chunk, offset)
+	case OP_MAP_INSERT:
+		return byteInstruction(sb, "MAP_INSERT", chunk, offset)
 
 	case OP_MAKE_LIST:
 		return constantInstruction(sb, "MAKE_LIST", chunk, offset)
@@ -144,6 +148,24 @@ func disassembleInstruction(sb *strings.Builder, chunk *Chunk, offset int) int {
 
 	case OP_MATCH_STRING_PATTERN:
 		return constantInstruction(sb, "MATCH_STRING_PATTERN", chunk, offset)
+	case OP_MAKE_BITS:
+		return constantInstruction(sb, "MAKE_BITS", chunk, offset)
+	case OP_PACK_DYN:
+		return constantInstruction(sb, "PACK_DYN", chunk, offset)
+	case OP_DEFAULT_ARG:
+		return simpleInstruction(sb, "DEFAULT_ARG", offset)
+	case OP_REORDER_ARGS:
+		// 1 byte count + one source position per argument
+		count := int(chunk.Code[offset+1])
+		sb.WriteString(fmt.Sprintf("%-16s %4d %v\n", "REORDER_ARGS", count, chunk.Code[offset+2:offset+2+count]))
+		return offset + 2 + count
+	case OP_MATCH_BITS:
+		// 2 bytes constant index + 1 byte capture count + 1 byte free count
+		idx := int(chunk.Code[offset+1])<<8 | int(chunk.Code[offset+2])
+		count := int(chunk.Code[offset+3])
+		free := int(chunk.Code[offset+4])
+		sb.WriteString(fmt.Sprintf("%-20s %4d (captures: %d, free: %d)\n", "MATCH_BITS", idx, count, free))
+		return offset + 5
 	case OP_MATCH_STRING_EXTRACT:
 		// 2 bytes constant index + 1 byte capture count
 		idx := int(chunk.Code[offset+1])<<8 | int(chunk.Code[offset+2])
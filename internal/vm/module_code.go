@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/funvibe/funxy/internal/ast"
+	"github.com/funvibe/funxy/internal/typesystem"
+)
+
+// moduleCode is the compiled form of a module stored in the persistent module cache
+type moduleCode struct {
+	Chunk *Chunk
+
+	// TraitDefaults are the module's trait default methods ("Trait.method"),
+	// precompiled since cached modules have no AST
+	TraitDefaults map[string]*CompiledFunction
+}
+
+// encodeModuleCode serializes a compiled module chunk and its trait defaults
+func encodeModuleCode(chunk *Chunk, traitDefaults map[string]*ast.FunctionStatement, typeAliases map[string]typesystem.Type) ([]byte, error) {
+	code := moduleCode{
+		Chunk:         chunk,
+		TraitDefaults: make(map[string]*CompiledFunction),
+	}
+	for key, fn := range traitDefaults {
+		compiledFn, err := compileDefaultMethod(fn, typeAliases)
+		if err != nil {
+			return nil, err
+		}
+		code.TraitDefaults[key] = compiledFn
+	}
+
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(&code); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeModuleCode restores a module compiled by encodeModuleCode
+func decodeModuleCode(data []byte) (*moduleCode, error) {
+	var code moduleCode
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// mergeCompiledDefaults adds precompiled trait defaults from src to dst.
+// dst may be shared between VMs, so a copy is made when something is added.
+func mergeCompiledDefaults(dst, src map[string]*CompiledFunction) map[string]*CompiledFunction {
+	var merged map[string]*CompiledFunction
+	for key, fn := range src {
+		if _, ok := dst[key]; ok {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[string]*CompiledFunction, len(dst)+len(src))
+			for k, v := range dst {
+				merged[k] = v
+			}
+		}
+		merged[key] = fn
+	}
+	if merged == nil {
+		return dst
+	}
+	return merged
+}
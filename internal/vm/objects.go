@@ -25,6 +25,8 @@ type CompiledFunction struct {
 	DefaultChunks []*Chunk // Bytecode chunks for defaults that need evaluation
 	// TypeInfo stores the function's type signature for getType()
 	TypeInfo typesystem.Type
+	// ParamNames stores the parameter names in declaration order (including variadic)
+	ParamNames []string
 }
 
 func (f *CompiledFunction) Type() evaluator.ObjectType { return "COMPILED_FUNCTION" }
@@ -58,6 +60,16 @@ func (c *ObjClosure) Hash() uint32 {
 	return uint32(uintptr(unsafe.Pointer(c)))
 }
 
+// ParamNames returns the parameter names of the compiled function
+func (c *ObjClosure) ParamNames() []string {
+	return c.Function.ParamNames
+}
+
+// IsVariadic reports whether the compiled function takes variadic arguments
+func (c *ObjClosure) IsVariadic() bool {
+	return c.Function.IsVariadic
+}
+
 // ObjUpvalue represents a captured variable from an enclosing scope
 // It can be "open" (pointing to stack) or "closed" (holding value directly)
 type ObjUpvalue struct {
@@ -132,6 +132,23 @@ const (
 
 	OP_AUTO_CALL // Auto-call nullary method if type context is set
 	OP_FORMATTER // Create format string function: [constant_index] -> [closure]
+
+	// Comprehensions
+	OP_LIST_APPEND // Append to the list in a local slot: [value] -> []
+	OP_MAP_INSERT  // Insert into the map in a local slot: [key, value] -> []
+
+	OP_DERIVE // Register a structural instance: [ctor...] traitIdx typeIdx ctorCount
+
+	// Binary syntax
+	OP_MAKE_BITS  // Build Bits from segments: [value, size?, ...] segmentsIdx -> [bits]
+	OP_MATCH_BITS // Match a binary pattern: [input, free...] segmentsIdx captures free -> [captures..., bool]
+
+	// Trait objects
+	OP_PACK_DYN // Pack the top of stack into trait objects where its type says dyn Trait: typeIdx
+
+	// Named arguments
+	OP_DEFAULT_ARG  // Push the placeholder for a parameter skipped by named arguments
+	OP_REORDER_ARGS // Move arguments evaluated in source order into parameter order: count [source...]
 )
 
 // OpcodeNames maps opcodes to their string names (for debugging)
@@ -242,6 +259,19 @@ var OpcodeNames = map[Opcode]string{
 	OP_HALT: "HALT",
 	OP_AUTO_CALL: "AUTO_CALL",
 	OP_FORMATTER: "FORMATTER",
+
+	OP_LIST_APPEND: "LIST_APPEND",
+	OP_MAP_INSERT:  "MAP_INSERT",
+
+	OP_DERIVE: "DERIVE",
+
+	OP_MAKE_BITS:  "MAKE_BITS",
+	OP_MATCH_BITS: "MATCH_BITS",
+
+	OP_PACK_DYN: "PACK_DYN",
+
+	OP_DEFAULT_ARG:  "DEFAULT_ARG",
+	OP_REORDER_ARGS: "REORDER_ARGS",
 }
 
 
@@ -74,6 +74,9 @@ type VM struct {
 	// Trait default implementations for fallback
 	traitDefaults map[string]*ast.FunctionStatement
 
+	// Precompiled trait defaults ("Trait.method"), used when sources are unavailable
+	compiledDefaults map[string]*CompiledFunction
+
 	// Evaluator for builtin Go functions only (not for Funxy code!)
 	eval *evaluator.Evaluator
 
@@ -82,6 +85,7 @@ type VM struct {
 	baseDir        string                               // Base directory for resolving relative imports
 	currentFile    string                               // Current file name for error messages
 	moduleCache    *PersistentMap                       // Cache of compiled/executed modules
+	bundle         *Bundle                              // Precompiled modules for standalone executables
 	loadingModules map[string]bool                      // Modules currently being loaded (for cyclic detection)
 
 	// Type context stack for ClassMethod dispatch
@@ -127,10 +131,14 @@ func (vm *VM) SetTypeMap(typeMap map[ast.Node]typesystem.Type) {
 	vm.typeMap = typeMap
 }
 
-// SetTypeAliases sets the type aliases from compiler
+// SetTypeAliases sets the type aliases from compiler (and its internal
+// evaluator)
 func (vm *VM) SetTypeAliases(aliases map[string]typesystem.Type) {
 	for k, v := range aliases {
 		vm.typeAliases = vm.typeAliases.Put(k, &evaluator.TypeObject{TypeVal: v})
+		if vm.eval != nil {
+			vm.eval.TypeAliases[k] = v
+		}
 	}
 }
 
@@ -146,6 +154,13 @@ func (vm *VM) SetLoader(loader *modules.Loader) {
 
 // RegisterTraitMethod registers a compiled method for a trait/type combination
 func (vm *VM) RegisterTraitMethod(traitName, typeName, methodName string, closure *ObjClosure) {
+	vm.registerTraitEntry(traitName, typeName, methodName, closure)
+}
+
+// registerTraitEntry stores any object under traitMethods[trait][type][method].
+// Besides compiled closures this holds the native methods of derived Show and
+// the constructor tables of derived Json.
+func (vm *VM) registerTraitEntry(traitName, typeName, methodName string, entry evaluator.Object) {
 	// traitMethods[traitName]
 	var typeMap *PersistentMap
 	if val := vm.traitMethods.Get(traitName); val != nil {
@@ -162,8 +177,8 @@ func (vm *VM) RegisterTraitMethod(traitName, typeName, methodName string, closur
 		methodMap = EmptyMap()
 	}
 
-	// traitMethods[traitName][typeName][methodName] = closure
-	methodMap = methodMap.Put(methodName, closure)
+	// traitMethods[traitName][typeName][methodName] = entry
+	methodMap = methodMap.Put(methodName, entry)
 	typeMap = typeMap.Put(typeName, methodMap)
 	vm.traitMethods = vm.traitMethods.Put(traitName, typeMap)
 }
@@ -174,8 +189,8 @@ func (vm *VM) LookupTraitMethod(traitName, typeName, methodName string) *ObjClos
 		typeMap := typeMapObj.(*PersistentMap)
 		if methodMapObj := typeMap.Get(typeName); methodMapObj != nil {
 			methodMap := methodMapObj.(*PersistentMap)
-			if closureObj := methodMap.Get(methodName); closureObj != nil {
-				return closureObj.(*ObjClosure)
+			if closure, ok := methodMap.Get(methodName).(*ObjClosure); ok {
+				return closure
 			}
 		}
 	}
@@ -184,9 +199,14 @@ func (vm *VM) LookupTraitMethod(traitName, typeName, methodName string) *ObjClos
 
 // LookupTraitMethodAny finds a trait method, returning either ObjClosure or BuiltinClosure
 func (vm *VM) LookupTraitMethodAny(traitName, typeName, methodName string) evaluator.Object {
-	// First check compiled closures
-	if closure := vm.LookupTraitMethod(traitName, typeName, methodName); closure != nil {
-		return closure
+	// First check compiled closures and derived methods
+	if typeMapObj := vm.traitMethods.Get(traitName); typeMapObj != nil {
+		if methodMapObj := typeMapObj.(*PersistentMap).Get(typeName); methodMapObj != nil {
+			switch method := methodMapObj.(*PersistentMap).Get(methodName).(type) {
+			case *ObjClosure, *BuiltinClosure:
+				return method
+			}
+		}
 	}
 	// Then check builtin closures
 	key := traitName + "." + typeName + "." + methodName
@@ -205,8 +225,8 @@ func (vm *VM) LookupOperator(typeName, operator string) *ObjClosure {
 		typeMap := typeMapObj.(*PersistentMap)
 		if methodMapObj := typeMap.Get(typeName); methodMapObj != nil {
 			methodMap := methodMapObj.(*PersistentMap)
-			if closureObj := methodMap.Get(methodName); closureObj != nil {
-				found = closureObj.(*ObjClosure)
+			if closure, ok := methodMap.Get(methodName).(*ObjClosure); ok {
+				found = closure
 				return false // Stop iteration
 		}
 	}
@@ -249,6 +269,54 @@ func (vm *VM) getTypeContext() string {
 
 // compileTraitDefault JIT-compiles a trait default method for a specific type
 func (vm *VM) compileTraitDefault(fn *ast.FunctionStatement, traitName, typeName string) (*ObjClosure, error) {
+	compiledFn, err := compileDefaultMethod(fn, vm.typeAliasMap())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create closure
+	closure := &ObjClosure{
+		Function: compiledFn,
+		Upvalues: make([]*ObjUpvalue, 0),
+	}
+
+	return closure, nil
+}
+
+// typeAliasMap returns the VM's type aliases as a plain map (for compilers)
+func (vm *VM) typeAliasMap() map[string]typesystem.Type {
+	typeAliases := make(map[string]typesystem.Type)
+	vm.typeAliases.Range(func(key string, val evaluator.Object) bool {
+		if typeObj, ok := val.(*evaluator.TypeObject); ok {
+			typeAliases[key] = typeObj.TypeVal
+		}
+		return true
+	})
+	return typeAliases
+}
+
+// lookupTraitDefault returns a closure for the default implementation of a trait method,
+// compiling the analyzer's AST on demand or using a precompiled default from a bundle
+func (vm *VM) lookupTraitDefault(traitName, methodName, typeName string) *ObjClosure {
+	key := traitName + "." + methodName
+	if defaultFn, ok := vm.traitDefaults[key]; ok {
+		closure, err := vm.compileTraitDefault(defaultFn, traitName, typeName)
+		if err != nil {
+			return nil
+		}
+		return closure
+	}
+	if compiledFn, ok := vm.compiledDefaults[key]; ok {
+		return &ObjClosure{
+			Function: compiledFn,
+			Upvalues: make([]*ObjUpvalue, 0),
+		}
+	}
+	return nil
+}
+
+// compileDefaultMethod compiles a trait default method body into a standalone function
+func compileDefaultMethod(fn *ast.FunctionStatement, typeAliases map[string]typesystem.Type) (*CompiledFunction, error) {
 	// Create a mini-compiler for this function
 	compiler := &Compiler{
 		function: &CompiledFunction{
@@ -259,18 +327,10 @@ func (vm *VM) compileTraitDefault(fn *ast.FunctionStatement, traitName, typeName
 		funcType:    TYPE_FUNCTION,
 		locals:      make([]Local, 256),
 		upvalues:    make([]Upvalue, 256),
-		typeAliases: make(map[string]typesystem.Type),
+		typeAliases: typeAliases,
 		scopeDepth:  1, // Function body starts at depth 1
 	}
 
-	// Copy type aliases from VM to compiler
-	vm.typeAliases.Range(func(key string, val evaluator.Object) bool {
-		if typeObj, ok := val.(*evaluator.TypeObject); ok {
-			compiler.typeAliases[key] = typeObj.TypeVal
-		}
-		return true
-	})
-
 	// Add parameters as locals at depth 1
 	for i, param := range fn.Parameters {
 		compiler.locals[i] = Local{Name: param.Name.Value, Depth: 1, Slot: i}
@@ -287,14 +347,12 @@ func (vm *VM) compileTraitDefault(fn *ast.FunctionStatement, traitName, typeName
 	compiledFn.LocalCount = compiler.localCount
 	compiledFn.UpvalueCount = compiler.upvalueCount
 	compiledFn.RequiredArity = len(fn.Parameters)
-
-	// Create closure
-	closure := &ObjClosure{
-		Function: compiledFn,
-		Upvalues: make([]*ObjUpvalue, 0),
+	compiledFn.ParamNames = make([]string, len(fn.Parameters))
+	for i, param := range fn.Parameters {
+		compiledFn.ParamNames[i] = param.Name.Value
 	}
 
-	return closure, nil
+	return compiledFn, nil
 }
 
 // SetBaseDir sets the base directory for resolving relative imports
@@ -383,6 +441,11 @@ func (vm *VM) getEvaluator() *evaluator.Evaluator {
 		typeMap := typeMapObj.(*PersistentMap)
 		typeMap.Range(func(typeName string, methodMapObj evaluator.Object) bool {
 			methodMap := methodMapObj.(*PersistentMap)
+			// Native instances of builtin types only leave an empty map here;
+			// their methods live in builtinTraitMethods
+			if methodMap.Len() == 0 {
+				return true
+			}
 			// Create MethodTable for this type
 			methodTable := &evaluator.MethodTable{
 				Methods: make(map[string]evaluator.Object),
@@ -466,6 +529,11 @@ func (vm *VM) vmCallHandler(closure evaluator.Object, args []evaluator.Object) e
 		return fResult.AsObject()
 	}
 
+	// Trait methods dispatch on the VM's instances, which include the builtin ones
+	if cm, ok := closure.(*evaluator.ClassMethod); ok {
+		return vm.callClassMethodFromBuiltin(cm, args)
+	}
+
 	// Check if it's a VM closure
 	vmClosure, ok := closure.(*ObjClosure)
 	if !ok {
@@ -477,8 +545,9 @@ func (vm *VM) vmCallHandler(closure evaluator.Object, args []evaluator.Object) e
 	// Check arity - partial application if not enough args
 	if len(args) < fn.Arity {
 		return &evaluator.PartialApplication{
-			VMClosure:   closure,
-			AppliedArgs: args,
+			VMClosure:       closure,
+			AppliedArgs:     args,
+			RemainingParams: max(fn.RequiredArity-len(args), 0),
 		}
 	}
 	if len(args) > fn.Arity && !fn.IsVariadic {
@@ -553,6 +622,46 @@ func (vm *VM) vmCallHandler(closure evaluator.Object, args []evaluator.Object) e
 	return &evaluator.Nil{}
 }
 
+// callClassMethodFromBuiltin calls a trait method for a builtin and runs it
+// to completion. Nullary methods such as mempty resolve against the type
+// context of the builtin's call.
+func (vm *VM) callClassMethodFromBuiltin(cm *evaluator.ClassMethod, args []evaluator.Object) evaluator.Object {
+	savedFrameCount := vm.frameCount
+	savedSp := vm.sp
+	savedFrame := vm.frame
+	restore := func() {
+		vm.frameCount = savedFrameCount
+		vm.sp = savedSp
+		vm.frame = savedFrame
+	}
+
+	vm.push(ObjectToValue(cm))
+	for _, arg := range args {
+		vm.push(ObjectToValue(arg))
+	}
+	if err := vm.callValue(ObjectToValue(cm), len(args)); err != nil {
+		restore()
+		return &evaluator.Error{Message: err.Error()}
+	}
+	if vm.frameCount == savedFrameCount {
+		// A builtin method has already pushed its result
+		result := vm.pop()
+		restore()
+		return result.AsObject()
+	}
+	for {
+		result, done, err := vm.step()
+		if err != nil {
+			restore()
+			return &evaluator.Error{Message: err.Error()}
+		}
+		if done && vm.frameCount <= savedFrameCount {
+			restore()
+			return result.AsObject()
+		}
+	}
+}
+
 // step executes one instruction and returns (result, done, error)
 // done is true if OP_RETURN or OP_HALT was executed
 func (vm *VM) step() (Value, bool, error) {
@@ -832,8 +941,12 @@ func (vm *VM) getTypeName(obj Value) string {
 		return "Bits"
 	case *evaluator.BigInt:
 		return "BigInt"
+	case *evaluator.SizedInt:
+		return o.Kind
 	case *evaluator.Rational:
 		return "Rational"
+	case *evaluator.Decimal:
+		return "Decimal"
 	case *evaluator.Char:
 		return "Char"
 	case *evaluator.TypeObject:
@@ -848,6 +961,24 @@ func (vm *VM) getTypeName(obj Value) string {
 		return "Function"
 	case *evaluator.Task:
 		return "Task"
+	case *evaluator.Process:
+		return "Pid"
+	case *evaluator.Channel:
+		return "Chan"
+	case *evaluator.Ref:
+		return "Ref"
+	case *evaluator.Stream:
+		return "Stream"
+	case *evaluator.Set:
+		return "Set"
+	case *evaluator.OrderedMap:
+		return "OrderedMap"
+	case *evaluator.OrderedSet:
+		return "OrderedSet"
+	case *evaluator.Heap:
+		return "Heap"
+	case *evaluator.Dynamic:
+		return "Dynamic"
 	default:
 		return string(obj.Obj.Type())
 	}
@@ -874,6 +1005,16 @@ func (vm *VM) objectsEqual(a, b Value) bool {
 
 func (vm *VM) objectPtrsEqual(a, b evaluator.Object) bool {
 	switch av := a.(type) {
+	case *evaluator.Process:
+		return av == b
+	case *evaluator.Channel:
+		return av == b
+	case *evaluator.Ref:
+		return av == b
+	case *evaluator.Stream:
+		return av == b
+	case *evaluator.Set, *evaluator.OrderedMap, *evaluator.OrderedSet, *evaluator.Heap, *evaluator.Dynamic:
+		return evaluator.ObjectsEqual(a, b)
 	case *evaluator.Integer:
 		if bv, ok := b.(*evaluator.Integer); ok {
 			return av.Value == bv.Value
@@ -894,10 +1035,18 @@ func (vm *VM) objectPtrsEqual(a, b evaluator.Object) bool {
 		if bv, ok := b.(*evaluator.BigInt); ok {
 			return av.Value.Cmp(bv.Value) == 0
 		}
+	case *evaluator.SizedInt:
+		if bv, ok := b.(*evaluator.SizedInt); ok {
+			return *av == *bv
+		}
 	case *evaluator.Rational:
 		if bv, ok := b.(*evaluator.Rational); ok {
 			return av.Value.Cmp(bv.Value) == 0
 		}
+	case *evaluator.Decimal:
+		if bv, ok := b.(*evaluator.Decimal); ok {
+			return evaluator.CompareDecimals(av, bv) == 0
+		}
 	case *evaluator.Bytes:
 		if bv, ok := b.(*evaluator.Bytes); ok {
 			return bytes.Equal(av.ToSlice(), bv.ToSlice())
@@ -1310,6 +1459,8 @@ func (vm *VM) ForkVM() *VM {
 	newVM.builtinTraitMethods = vm.builtinTraitMethods // PersistentMap is safe to share
 	newVM.extensionMethods = vm.extensionMethods // PersistentMap is safe to share
 	newVM.traitDefaults = vm.traitDefaults
+	newVM.compiledDefaults = vm.compiledDefaults
+	newVM.bundle = vm.bundle
 	newVM.moduleCache = vm.moduleCache
 	newVM.currentFile = vm.currentFile
 
@@ -1338,7 +1489,8 @@ func (vm *VM) asyncHandler(fn evaluator.Object, args []evaluator.Object) evaluat
 		args[i] = vm.captureHandler(arg)
 	}
 
-	task := evaluator.NewTask()
+	// The task is cancelled with the caller's task and joins its scope
+	task := evaluator.NewTaskFrom(vm.eval)
 
 	// Create new VM for isolation
 	newVM := New()
@@ -1356,6 +1508,8 @@ func (vm *VM) asyncHandler(fn evaluator.Object, args []evaluator.Object) evaluat
 	newVM.builtinTraitMethods = vm.builtinTraitMethods // PersistentMap is safe to share
 	newVM.extensionMethods = vm.extensionMethods // PersistentMap is safe to share
 	newVM.traitDefaults = vm.traitDefaults       // Read-only at runtime
+	newVM.compiledDefaults = vm.compiledDefaults // Read-only at runtime
+	newVM.bundle = vm.bundle
 	newVM.moduleCache = vm.moduleCache           // Shared persistent map cache
 	newVM.currentFile = vm.currentFile
 
@@ -1392,6 +1546,11 @@ func (vm *VM) asyncHandler(fn evaluator.Object, args []evaluator.Object) evaluat
 
 		// Ensure fresh state
 		newVM.sp = 0
+		if task.Cancelled() {
+			task.Complete(&evaluator.Error{Message: "cancelled"})
+			return
+		}
+		task.Bind(newVM.getEvaluator())
 
 		// Push function first (callValue expects [fn, args...] on stack)
 		if newVM.sp >= len(newVM.stack) {
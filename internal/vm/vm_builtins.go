@@ -1,6 +1,9 @@
 package vm
 
-import "github.com/funvibe/funxy/internal/evaluator"
+import (
+	"github.com/funvibe/funxy/internal/config"
+	"github.com/funvibe/funxy/internal/evaluator"
+)
 
 // SetEvaluator sets the evaluator to use for builtin calls
 func (vm *VM) SetEvaluator(eval *evaluator.Evaluator) {
@@ -106,6 +109,16 @@ func (vm *VM) registerBuiltinTraitImpls() {
 	vm.registerBuiltinTraitMethod("Show", "Rational", "show", func(args []evaluator.Object) evaluator.Object {
 		return evaluator.StringToList(args[0].Inspect())
 	})
+	vm.registerBuiltinTraitMethod("Show", "Decimal", "show", func(args []evaluator.Object) evaluator.Object {
+		return evaluator.StringToList(args[0].Inspect())
+	})
+	for _, info := range config.IntTypes {
+		if info.Suffix != "" {
+			vm.registerBuiltinTraitMethod("Show", info.Name, "show", func(args []evaluator.Object) evaluator.Object {
+				return evaluator.StringToList(args[0].Inspect())
+			})
+		}
+	}
 	vm.registerBuiltinTraitMethod("Show", "Bytes", "show", func(args []evaluator.Object) evaluator.Object {
 		return evaluator.StringToList(args[0].Inspect())
 	})
@@ -123,6 +136,13 @@ func (vm *VM) registerBuiltinTraitImpls() {
 		return evaluator.StringToList(args[0].Inspect())
 	})
 
+	// Library values show as the expression that builds them
+	for _, typeName := range []string{"Set", "OrderedMap", "OrderedSet", "Heap", "Dynamic"} {
+		vm.registerBuiltinTraitMethod("Show", typeName, "show", func(args []evaluator.Object) evaluator.Object {
+			return evaluator.StringToList(args[0].Inspect())
+		})
+	}
+
 	// Semigroup for List
 	vm.registerBuiltinTraitMethod("Semigroup", "List", "(<>)", func(args []evaluator.Object) evaluator.Object {
 		a, ok1 := args[0].(*evaluator.List)
@@ -229,6 +249,32 @@ func (vm *VM) registerBuiltinTraitMethod(traitName, typeName, methodName string,
 	vm.builtinTraitMethods = vm.builtinTraitMethods.Put(traitName+"."+typeName+"."+methodName, bc)
 }
 
+// registerDerivedTrait registers the structural Show or Json instance of a
+// type with a deriving clause. Json keeps the type's constructors so that
+// jsonDecode can rebuild its values.
+func (vm *VM) registerDerivedTrait(traitName, typeName string, ctors []evaluator.Object) {
+	switch traitName {
+	case "Show":
+		vm.registerTraitEntry(traitName, typeName, "show", &BuiltinClosure{
+			Name: traitName + "." + typeName + ".show",
+			Fn: func(args []evaluator.Object) evaluator.Object {
+				return evaluator.StringToList(args[0].Inspect())
+			},
+		})
+	case "Json":
+		for _, ctor := range ctors {
+			name := ""
+			switch c := ctor.(type) {
+			case *evaluator.Constructor:
+				name = c.Name
+			case *evaluator.DataInstance:
+				name = c.Name
+			}
+			vm.registerTraitEntry(traitName, typeName, name, ctor)
+		}
+	}
+}
+
 // importBuiltinsFromEnv imports builtins from an Environment
 func (vm *VM) importBuiltinsFromEnv(env *evaluator.Environment) {
 	builtins := evaluator.GetBuiltinsList()
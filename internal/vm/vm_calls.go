@@ -114,6 +114,35 @@ func (vm *VM) executeDefaultChunk(chunk *Chunk, parentClosure *ObjClosure) (Valu
 	}
 }
 
+// defaultValue evaluates the default of parameter i of closure's function
+func (vm *VM) defaultValue(closure *ObjClosure, i int) (Value, bool, error) {
+	fn := closure.Function
+	defaultIdx := i - fn.RequiredArity
+	if defaultIdx < 0 || defaultIdx >= len(fn.Defaults) {
+		return NilVal(), false, nil
+	}
+	if constIdx := fn.Defaults[defaultIdx]; constIdx >= 0 {
+		return ObjectToValue(fn.Chunk.Constants[constIdx]), true, nil
+	}
+	if fn.DefaultChunks != nil && defaultIdx < len(fn.DefaultChunks) && fn.DefaultChunks[defaultIdx] != nil {
+		defaultVal, err := vm.executeDefaultChunk(fn.DefaultChunks[defaultIdx], closure)
+		if err != nil {
+			return NilVal(), false, err
+		}
+		return defaultVal, true, nil
+	}
+	return NilVal(), false, nil
+}
+
+// isDefaultArgument reports whether v stands for a parameter skipped by named arguments
+func (vm *VM) isDefaultArgument(v Value) bool {
+	if !v.IsObj() {
+		return false
+	}
+	_, ok := v.Obj.(*evaluator.DefaultArgument)
+	return ok
+}
+
 // callClosure sets up a new call frame for a closure
 func (vm *VM) callClosure(closure *ObjClosure, argCount int) error {
 	fn := closure.Function
@@ -147,31 +176,37 @@ func (vm *VM) callClosure(closure *ObjClosure, argCount int) error {
 			}
 			vm.pop()
 			partial := &evaluator.PartialApplication{
-				VMClosure:   closure,
-				AppliedArgs: args,
+				VMClosure:       closure,
+				AppliedArgs:     args,
+				RemainingParams: fn.RequiredArity - argCount,
 			}
 			vm.push(ObjVal(partial))
 			return nil
 		}
-		if argCount < fn.Arity && len(fn.Defaults) > 0 {
-			for i := argCount; i < fn.Arity; i++ {
-				defaultIdx := i - fn.RequiredArity
-				if defaultIdx >= 0 && defaultIdx < len(fn.Defaults) {
-					constIdx := fn.Defaults[defaultIdx]
-					if constIdx >= 0 {
-						vm.push(ObjectToValue(closure.Function.Chunk.Constants[constIdx]))
-						argCount++
-					} else if fn.DefaultChunks != nil && defaultIdx < len(fn.DefaultChunks) && fn.DefaultChunks[defaultIdx] != nil {
-						defaultChunk := fn.DefaultChunks[defaultIdx]
-						defaultVal, err := vm.executeDefaultChunk(defaultChunk, closure)
-						if err != nil {
-							return err
-						}
-						vm.push(defaultVal)
-						argCount++
+		if len(fn.Defaults) > 0 {
+			// Parameters skipped by named arguments
+			for i := 0; i < argCount && i < fn.Arity; i++ {
+				slot := vm.sp - argCount + i
+				if vm.isDefaultArgument(vm.stack[slot]) {
+					defaultVal, ok, err := vm.defaultValue(closure, i)
+					if err != nil {
+						return err
+					}
+					if ok {
+						vm.stack[slot] = defaultVal
 					}
 				}
 			}
+			for i := argCount; i < fn.Arity; i++ {
+				defaultVal, ok, err := vm.defaultValue(closure, i)
+				if err != nil {
+					return err
+				}
+				if ok {
+					vm.push(defaultVal)
+					argCount++
+				}
+			}
 		}
 		if argCount > fn.Arity {
 			return vm.runtimeError("expected %d arguments but got %d", fn.Arity, argCount)
@@ -244,6 +279,17 @@ func (vm *VM) tailCallClosure(closure *ObjClosure, argCount int) error {
 		return vm.callClosure(closure, argCount)
 	}
 
+	// Defaults are bound by a regular call as well
+	if len(fn.Defaults) > 0 && !fn.IsVariadic {
+		needsDefaults := argCount < fn.Arity
+		for i := 0; i < argCount && !needsDefaults; i++ {
+			needsDefaults = vm.isDefaultArgument(vm.stack[vm.sp-argCount+i])
+		}
+		if needsDefaults {
+			return vm.callClosure(closure, argCount)
+		}
+	}
+
 	if argCount > fn.Arity {
 		return vm.runtimeError("expected %d arguments but got %d", fn.Arity, argCount)
 	}
@@ -311,6 +357,7 @@ func (vm *VM) callBuiltin(builtin *evaluator.Builtin, argCount int) error {
 				}
 				args = fullArgs
 			}
+			args = evaluator.FillDefaultArguments(args, builtin.DefaultArgs, totalParams)
 		}
 	}
 
@@ -375,6 +422,14 @@ func (vm *VM) callConstructor(ctor *evaluator.Constructor, argCount int) error {
 		return nil
 	}
 
+	if ctor.Newtype {
+		// The argument is the newtype value, unboxed Values stay unboxed
+		arg := vm.stack[vm.sp-1]
+		vm.sp -= 2
+		vm.push(arg)
+		return nil
+	}
+
 	fields := make([]evaluator.Object, argCount)
 	for i := 0; i < argCount; i++ {
 		fields[i] = vm.stack[vm.sp-argCount+i].AsObject()
@@ -394,19 +449,17 @@ func (vm *VM) callConstructor(ctor *evaluator.Constructor, argCount int) error {
 
 // callTypeObject handles type application like List(Int)
 func (vm *VM) callTypeObject(typeObj *evaluator.TypeObject, argCount int) error {
-	typeArgs := make([]typesystem.Type, argCount)
+	typeArgs := make([]*evaluator.TypeObject, argCount)
 	for i := 0; i < argCount; i++ {
 		arg := vm.stack[vm.sp-argCount+i].AsObject()
 		tArg, ok := arg.(*evaluator.TypeObject)
 		if !ok {
 			return vm.runtimeError("type application expects types as arguments, got %s", arg.Type())
 		}
-		typeArgs[i] = tArg.TypeVal
+		typeArgs[i] = tArg
 	}
 
-	result := &evaluator.TypeObject{
-		TypeVal: typesystem.TApp{Constructor: typeObj.TypeVal, Args: typeArgs},
-	}
+	result := evaluator.ApplyTypeObject(typeObj, typeArgs)
 
 	vm.sp -= argCount + 1
 	vm.push(ObjVal(result))
@@ -601,19 +654,13 @@ func (vm *VM) callClassMethod(cm *evaluator.ClassMethod, argCount int) error {
 		}
 
 		// Check defaults
-		if method == nil {
-			defaultKey := cm.ClassName + "." + cm.Name
-			if defaultFn, ok := vm.traitDefaults[defaultKey]; ok {
-				// For defaults, we need a type name to register against.
-				// Use typeContext if available.
-				if ctx != "" {
-					closure, err := vm.compileTraitDefault(defaultFn, cm.ClassName, ctx)
-					if err == nil && closure != nil {
-						vm.RegisterTraitMethod(cm.ClassName, ctx, cm.Name, closure)
-						method = closure
-						resolvedType = ctx
-					}
-				}
+		// For defaults, we need a type name to register against.
+		// Use typeContext if available.
+		if method == nil && ctx != "" {
+			if closure := vm.lookupTraitDefault(cm.ClassName, cm.Name, ctx); closure != nil {
+				vm.RegisterTraitMethod(cm.ClassName, ctx, cm.Name, closure)
+				method = closure
+				resolvedType = ctx
 			}
 		}
 
@@ -646,8 +693,11 @@ func (vm *VM) callClassMethod(cm *evaluator.ClassMethod, argCount int) error {
 		return nil
 	}
 
+	// Trait objects carry the implementation they were packed with
+	method = vm.unpackTraitObjectArgs(cm, argCount)
+
 	// First try to find method by argument types
-	for i := 0; i < argCount; i++ {
+	for i := 0; i < argCount && method == nil; i++ {
 		arg := vm.peek(argCount - 1 - i)
 		typeName := vm.getTypeName(arg)
 		method = vm.LookupTraitMethodAny(cm.ClassName, typeName, cm.Name)
@@ -678,11 +728,9 @@ func (vm *VM) callClassMethod(cm *evaluator.ClassMethod, argCount int) error {
 		}
 
 		// Try to find and compile trait default
-		defaultKey := cm.ClassName + "." + cm.Name
-		if defaultFn, ok := vm.traitDefaults[defaultKey]; ok && argTypeName != "" {
+		if argTypeName != "" {
 			// JIT compile the default method
-			closure, err := vm.compileTraitDefault(defaultFn, cm.ClassName, argTypeName)
-			if err == nil && closure != nil {
+			if closure := vm.lookupTraitDefault(cm.ClassName, cm.Name, argTypeName); closure != nil {
 				// Register for future use
 				vm.RegisterTraitMethod(cm.ClassName, argTypeName, cm.Name, closure)
 				method = closure
@@ -715,6 +763,51 @@ func (vm *VM) callClassMethod(cm *evaluator.ClassMethod, argCount int) error {
 	return vm.callValue(ObjectToValue(method), argCount)
 }
 
+// unpackTraitObjectArgs replaces trait objects among the arguments of a trait
+// method call with the values they hold, and returns the implementation
+// captured by one packed for the method's trait.
+func (vm *VM) unpackTraitObjectArgs(cm *evaluator.ClassMethod, argCount int) evaluator.Object {
+	base := vm.sp - argCount
+	args := make([]evaluator.Object, argCount)
+	hasTraitObject := false
+	for i := 0; i < argCount; i++ {
+		arg := vm.stack[base+i]
+		if arg.IsObj() {
+			if _, ok := arg.Obj.(*evaluator.TraitObject); ok {
+				hasTraitObject = true
+			}
+		}
+		args[i] = arg.AsObject()
+	}
+	if !hasTraitObject {
+		return nil
+	}
+	unpacked, captured := evaluator.UnpackTraitObjects(cm.ClassName, cm.Name, args)
+	for i, arg := range unpacked {
+		vm.stack[base+i] = ObjectToValue(arg)
+	}
+	return captured
+}
+
+// lookupTraitMethods is the evaluator.MethodLookup of the VM: the compiled
+// methods of trait's instance for the type of v.
+func (vm *VM) lookupTraitMethods(trait string, v evaluator.Object) map[string]evaluator.Object {
+	typeMapObj := vm.traitMethods.Get(trait)
+	if typeMapObj == nil {
+		return nil
+	}
+	methodMapObj := typeMapObj.(*PersistentMap).Get(vm.getTypeName(ObjectToValue(v)))
+	if methodMapObj == nil {
+		return nil
+	}
+	methods := make(map[string]evaluator.Object)
+	methodMapObj.(*PersistentMap).Range(func(name string, method evaluator.Object) bool {
+		methods[name] = method
+		return true
+	})
+	return methods
+}
+
 // callVMComposedFunction calls a composed function natively
 func (vm *VM) callVMComposedFunction(fn *VMComposedFunction, argCount int) error {
 	if argCount != 1 {
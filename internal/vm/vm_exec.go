@@ -19,6 +19,18 @@ func (vm *VM) executeOneOp(op Opcode) error {
 	case OP_NIL:
 		vm.push(NilVal())
 
+	case OP_DEFAULT_ARG:
+		vm.push(ObjVal(evaluator.DEFAULT_ARGUMENT))
+
+	case OP_REORDER_ARGS:
+		count := int(vm.readByte())
+		base := vm.sp - count
+		evaluated := make([]Value, count)
+		copy(evaluated, vm.stack[base:vm.sp])
+		for i := 0; i < count; i++ {
+			vm.stack[base+i] = evaluated[vm.readByte()]
+		}
+
 	case OP_TRUE:
 		vm.push(BoolVal(true))
 
@@ -79,6 +91,14 @@ func (vm *VM) executeOneOp(op Opcode) error {
 			case *evaluator.Rational:
 				res := new(big.Rat).Neg(v.Value)
 				vm.push(ObjVal(&evaluator.Rational{Value: res}))
+			case *evaluator.SizedInt:
+				res := evaluator.NegateSizedInt(v)
+				if err, ok := res.(*evaluator.Error); ok {
+					return fmt.Errorf("%s", err.Message)
+				}
+				vm.push(ObjVal(res))
+			case *evaluator.Decimal:
+				vm.push(ObjVal(evaluator.NegateDecimal(v)))
 			default:
 				return fmt.Errorf("operand must be a number")
 			}
@@ -107,6 +127,8 @@ func (vm *VM) executeOneOp(op Opcode) error {
 		val := vm.pop()
 		if val.IsInt() {
 			vm.push(IntVal(^val.AsInt()))
+		} else if s, ok := val.Obj.(*evaluator.SizedInt); ok {
+			vm.push(ObjVal(evaluator.ComplementSizedInt(s)))
 		} else {
 			return fmt.Errorf("~ operator expects integer, got %s", val.RuntimeType().String())
 		}
@@ -472,6 +494,20 @@ func (vm *VM) executeOneOp(op Opcode) error {
 		closure := vm.pop().AsObject().(*ObjClosure)
 		vm.RegisterTraitMethod(traitName, typeName, methodName, closure)
 
+	case OP_DERIVE:
+		traitIdx := vm.readConstantIndex()
+		typeIdx := vm.readConstantIndex()
+		count := int(vm.readByte())
+
+		traitName := vm.frame.chunk.Constants[traitIdx].(*stringConstant).Value
+		typeName := vm.frame.chunk.Constants[typeIdx].(*stringConstant).Value
+
+		ctors := make([]evaluator.Object, count)
+		for i := count - 1; i >= 0; i-- {
+			ctors[i] = vm.pop().AsObject()
+		}
+		vm.registerDerivedTrait(traitName, typeName, ctors)
+
 	case OP_DEFAULT:
 		// Get default value for type using Default trait
 		typeObj := vm.pop().AsObject()
@@ -625,6 +661,25 @@ func (vm *VM) executeOneOp(op Opcode) error {
 		}
 		vm.push(ObjVal(m))
 
+	case OP_LIST_APPEND:
+		slot := int(vm.readByte())
+		value := vm.pop()
+		list, ok := vm.stack[vm.frame.base+slot].AsObject().(*evaluator.List)
+		if !ok {
+			return vm.runtimeError("comprehension accumulator is not a list")
+		}
+		vm.stack[vm.frame.base+slot] = ObjVal(list.Append(value.AsObject()))
+
+	case OP_MAP_INSERT:
+		slot := int(vm.readByte())
+		value := vm.pop().AsObject()
+		key := vm.pop().AsObject()
+		m, ok := vm.stack[vm.frame.base+slot].AsObject().(*evaluator.Map)
+		if !ok {
+			return vm.runtimeError("comprehension accumulator is not a map")
+		}
+		vm.stack[vm.frame.base+slot] = ObjVal(m.Put(key, value))
+
 	case OP_GET_INDEX:
 		index := vm.pop()
 		obj := vm.pop()
@@ -1146,6 +1201,59 @@ func (vm *VM) executeOneOp(op Opcode) error {
 		}
 		vm.push(BoolVal(true))
 
+	case OP_PACK_DYN:
+		typeIdx := vm.readConstantIndex()
+		packType := vm.frame.chunk.Constants[typeIdx].(*evaluator.TypeObject).TypeVal
+		val := vm.pop().AsObject()
+		vm.push(ObjectToValue(evaluator.PackTraitObjects(val, packType, vm.lookupTraitMethods)))
+
+	case OP_MAKE_BITS:
+		segIdx := vm.readConstantIndex()
+		segments := vm.frame.chunk.Constants[segIdx].(*BitsSegments).Segments
+
+		values := make([]evaluator.Object, len(segments))
+		sizes := make([]evaluator.Object, len(segments))
+		for i := len(segments) - 1; i >= 0; i-- {
+			if segments[i].HasSize {
+				sizes[i] = vm.pop().AsObject()
+			}
+			values[i] = vm.pop().AsObject()
+		}
+		bits, err := evaluator.BuildBits(segments, values, sizes)
+		if err != nil {
+			return vm.runtimeError("binary construction: %s", err.Error())
+		}
+		vm.push(ObjVal(bits))
+
+	case OP_MATCH_BITS:
+		// Format: OP_MATCH_BITS <segments_idx:2> <capture_count:1> <free_count:1>
+		segIdx := vm.readConstantIndex()
+		captureCount := int(vm.readByte())
+		freeCount := int(vm.readByte())
+		segments := vm.frame.chunk.Constants[segIdx].(*BitsSegments).Segments
+
+		outer := make(map[string]int64, freeCount)
+		free := evaluator.BitsFreeNames(segments)
+		for i := freeCount - 1; i >= 0; i-- {
+			if n, ok := vm.pop().AsObject().(*evaluator.Integer); ok {
+				outer[free[i]] = n.Value
+			}
+		}
+		val := vm.pop()
+
+		captures, matched := evaluator.MatchBitsPattern(segments, val.AsObject(), outer)
+		if !matched {
+			for i := 0; i < captureCount; i++ {
+				vm.push(NilVal())
+			}
+			vm.push(BoolVal(false))
+			return nil
+		}
+		for _, capture := range captures {
+			vm.push(ObjVal(capture))
+		}
+		vm.push(BoolVal(true))
+
 	case OP_TRAIT_OP:
 		// Trait-based operator dispatch (e.g., <>, <*>, >>=, ??, ?.)
 		opIdx := vm.readConstantIndex()
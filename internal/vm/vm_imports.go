@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"github.com/funvibe/funxy/internal/ast"
 	"github.com/funvibe/funxy/internal/evaluator"
 	"github.com/funvibe/funxy/internal/modules"
 	"github.com/funvibe/funxy/internal/symbols"
@@ -26,8 +27,8 @@ func (vm *VM) processOneImport(imp PendingImport) error {
 		return vm.importVirtualModule(imp)
 	}
 
-	// User module - need loader
-	if vm.loader == nil {
+	// User module - need loader (or a bundle of precompiled modules)
+	if vm.loader == nil && vm.bundle == nil {
 		return fmt.Errorf("cannot load user module %s: no module loader configured", imp.Path)
 	}
 
@@ -36,20 +37,50 @@ func (vm *VM) processOneImport(imp PendingImport) error {
 
 // importUserModule loads, compiles, and executes a user-defined module
 func (vm *VM) importUserModule(imp PendingImport) error {
-	// Resolve import path
-	importPath := imp.Path
-	if len(importPath) > 0 && importPath[0] == '.' {
-		importPath = filepath.Join(vm.baseDir, importPath)
+	// Standalone executables carry precompiled modules instead of sources
+	if vm.bundle != nil {
+		cm, ok := vm.bundle.Modules[imp.Resolved]
+		if !ok {
+			return fmt.Errorf("module %s is not included in the bundle", imp.Path)
+		}
+		return vm.importModuleOnce(imp, bundleCachePrefix+imp.Resolved, func() (*evaluator.RecordInstance, error) {
+			modObj, err := vm.executeBundledModule(cm)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute module %s: %v", imp.Path, err)
+			}
+			return modObj, nil
+		})
 	}
 
-	// Normalize to absolute path for caching
-	absPath, err := filepath.Abs(importPath)
-	if err != nil {
-		return fmt.Errorf("failed to resolve module path %s: %v", imp.Path, err)
-	}
+	// Resolve import path (absolute, for caching)
+	absPath := vm.loader.ResolveImport(vm.baseDir, imp.Path)
+
+	return vm.importModuleOnce(imp, absPath, func() (*evaluator.RecordInstance, error) {
+		// Load module through loader
+		modInterface, err := vm.loader.GetModule(absPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load module %s: %v", imp.Path, err)
+		}
+
+		mod, ok := modInterface.(*modules.Module)
+		if !ok {
+			return nil, fmt.Errorf("invalid module type for %s", imp.Path)
+		}
+
+		// Compile and execute the module
+		modObj, err := vm.compileAndExecuteModule(mod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute module %s: %v", imp.Path, err)
+		}
+		return modObj, nil
+	})
+}
 
+// importModuleOnce executes a module at most once per cache key, handling
+// cyclic imports with placeholders, and applies the import to globals
+func (vm *VM) importModuleOnce(imp PendingImport, cacheKey string, load func() (*evaluator.RecordInstance, error)) error {
 	// Check cache first
-	if cachedObj := vm.moduleCache.Get(absPath); cachedObj != nil {
+	if cachedObj := vm.moduleCache.Get(cacheKey); cachedObj != nil {
 		if cached, ok := cachedObj.(*evaluator.RecordInstance); ok {
 			return vm.applyModuleImport(imp, cached)
 		}
@@ -59,46 +90,32 @@ func (vm *VM) importUserModule(imp PendingImport) error {
 	if vm.loadingModules == nil {
 		vm.loadingModules = make(map[string]bool)
 	}
-	if vm.loadingModules[absPath] {
+	if vm.loadingModules[cacheKey] {
 		// Cyclic import - create placeholder and continue
 		// The actual module will be populated when it finishes loading
 		placeholder := evaluator.NewRecord(nil)
 		placeholder.TypeName = imp.Path
-		vm.moduleCache = vm.moduleCache.Put(absPath, placeholder)
+		vm.moduleCache = vm.moduleCache.Put(cacheKey, placeholder)
 		return vm.applyModuleImport(imp, placeholder)
 	}
-	vm.loadingModules[absPath] = true
+	vm.loadingModules[cacheKey] = true
 
-	// Load module through loader
-	modInterface, err := vm.loader.GetModule(absPath)
+	modObj, err := load()
 	if err != nil {
-		delete(vm.loadingModules, absPath)
-		return fmt.Errorf("failed to load module %s: %v", imp.Path, err)
-	}
-
-	mod, ok := modInterface.(*modules.Module)
-	if !ok {
-		delete(vm.loadingModules, absPath)
-		return fmt.Errorf("invalid module type for %s", imp.Path)
-	}
-
-	// Compile and execute the module
-	modObj, err := vm.compileAndExecuteModule(mod)
-	if err != nil {
-		delete(vm.loadingModules, absPath)
-		return fmt.Errorf("failed to execute module %s: %v", imp.Path, err)
+		delete(vm.loadingModules, cacheKey)
+		return err
 	}
 
 	// Update cache with actual module (in case placeholder was used)
-	if placeholderObj := vm.moduleCache.Get(absPath); placeholderObj != nil {
+	if placeholderObj := vm.moduleCache.Get(cacheKey); placeholderObj != nil {
 		if placeholder, ok := placeholderObj.(*evaluator.RecordInstance); ok {
 			// Copy fields from actual module to placeholder for cyclic refs
 			placeholder.Fields = make([]evaluator.RecordField, len(modObj.Fields))
 			copy(placeholder.Fields, modObj.Fields)
 		}
 	}
-	vm.moduleCache = vm.moduleCache.Put(absPath, modObj)
-	delete(vm.loadingModules, absPath)
+	vm.moduleCache = vm.moduleCache.Put(cacheKey, modObj)
+	delete(vm.loadingModules, cacheKey)
 
 	return vm.applyModuleImport(imp, modObj)
 }
@@ -126,7 +143,42 @@ func (vm *VM) compileAndExecuteModule(mod *modules.Module) (*evaluator.RecordIns
 		return evaluator.NewRecord(exports), nil
 	}
 
+	// Modules restored from the persistent cache carry their compiled code
+	if mod.Cached != nil {
+		code, err := decodeModuleCode(mod.Cached.Code)
+		if err != nil {
+			return nil, fmt.Errorf("corrupted cache entry for %s: %v", mod.Name, err)
+		}
+		vm.compiledDefaults = mergeCompiledDefaults(vm.compiledDefaults, code.TraitDefaults)
+		return vm.executeModuleChunk(mod.Name, mod.Dir, code.Chunk, mod.Exports, nil)
+	}
+
 	// Regular module compilation
+	chunk, err := compileModuleChunk(mod)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize before execution: running the chunk must not affect the cached code
+	var cacheCode []byte
+	if vm.loader != nil && vm.loader.Cache != nil {
+		cacheCode, _ = encodeModuleCode(chunk, mod.TraitDefaults, vm.typeAliasMap())
+	}
+
+	modObj, err := vm.executeModuleChunk(mod.Name, mod.Dir, chunk, mod.Exports, mod.TraitDefaults)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dependencies were executed (and stored) by now, so their hashes are known
+	if cacheCode != nil {
+		_ = vm.loader.StoreCache(mod, cacheCode)
+	}
+	return modObj, nil
+}
+
+// compileModuleChunk compiles all files of a module into a single chunk
+func compileModuleChunk(mod *modules.Module) (*Chunk, error) {
 	compiler := NewCompiler()
 	compiler.SetBaseDir(mod.Dir)
 
@@ -138,36 +190,42 @@ func (vm *VM) compileAndExecuteModule(mod *modules.Module) (*evaluator.RecordIns
 
 	compiler.emit(OP_HALT, 0)
 	chunk := compiler.currentChunk()
+	chunk.PendingImports = compiler.GetPendingImports()
+	return chunk, nil
+}
 
+// executeModuleChunk runs a compiled module in its own VM and merges its
+// trait implementations, extension methods and defaults into this VM
+func (vm *VM) executeModuleChunk(name, dir string, chunk *Chunk, exportNames map[string]bool, traitDefaults map[string]*ast.FunctionStatement) (*evaluator.RecordInstance, error) {
 	modVM := New()
 	modVM.loader = vm.loader
-	modVM.baseDir = mod.Dir
+	modVM.bundle = vm.bundle
+	modVM.compiledDefaults = vm.compiledDefaults
+	modVM.baseDir = dir
 	// Share module cache and loading state for cyclic import detection
 	modVM.moduleCache = vm.moduleCache
 	modVM.loadingModules = vm.loadingModules
+	modVM.out = vm.out
 	modVM.RegisterBuiltins()
 
 	// Initialize trait defaults from analysis results
-	if mod.TraitDefaults != nil {
-		modVM.traitDefaults = mod.TraitDefaults
+	if traitDefaults != nil {
+		modVM.traitDefaults = traitDefaults
 	}
 
-
-
-	pendingImports := compiler.GetPendingImports()
-	if err := modVM.ProcessImports(pendingImports); err != nil {
-		return nil, fmt.Errorf("import error in module %s: %v", mod.Name, err)
+	if err := modVM.ProcessImports(chunk.PendingImports); err != nil {
+		return nil, fmt.Errorf("import error in module %s: %v", name, err)
 	}
 
 	_, err := modVM.Run(chunk)
 	if err != nil {
-		return nil, fmt.Errorf("runtime error in %s: %v", mod.Name, err)
+		return nil, fmt.Errorf("runtime error in %s: %v", name, err)
 	}
 
 	exports := make(map[string]evaluator.Object)
-	for name := range mod.Exports {
-		if val := modVM.globals.Get(name); val != nil {
-			exports[name] = val
+	for exportName := range exportNames {
+		if val := modVM.globals.Get(exportName); val != nil {
+			exports[exportName] = val
 		}
 	}
 
@@ -201,13 +259,14 @@ func (vm *VM) compileAndExecuteModule(mod *modules.Module) (*evaluator.RecordIns
 				parentMethodMap = EmptyMap()
 			}
 
-			modMethodMap.Range(func(methodName string, closureObj evaluator.Object) bool {
-				closure := closureObj.(*ObjClosure)
-				// Attach module globals to trait methods
-				// ObjClosure.Globals is *PersistentMap, modVM.globals is *PersistentMap
-				closure.Globals = modVM.globals
+			modMethodMap.Range(func(methodName string, entry evaluator.Object) bool {
+				if closure, ok := entry.(*ObjClosure); ok {
+					// Attach module globals to trait methods
+					// ObjClosure.Globals is *PersistentMap, modVM.globals is *PersistentMap
+					closure.Globals = modVM.globals
+				}
 
-				parentMethodMap = parentMethodMap.Put(methodName, closure)
+				parentMethodMap = parentMethodMap.Put(methodName, entry)
 				return true
 			})
 			parentTypeMap = parentTypeMap.Put(typeName, parentMethodMap)
@@ -240,9 +299,13 @@ func (vm *VM) compileAndExecuteModule(mod *modules.Module) (*evaluator.RecordIns
 	})
 
 	// Copy trait defaults from module VM to parent VM
+	if len(modVM.traitDefaults) > 0 && vm.traitDefaults == nil {
+		vm.traitDefaults = make(map[string]*ast.FunctionStatement)
+	}
 	for key, fn := range modVM.traitDefaults {
 		vm.traitDefaults[key] = fn
 	}
+	vm.compiledDefaults = mergeCompiledDefaults(vm.compiledDefaults, modVM.compiledDefaults)
 
 	return evaluator.NewRecord(exports), nil
 }
@@ -260,23 +323,6 @@ func isConstructorForType(val evaluator.Object, typeName string) bool {
 
 // applyModuleImport applies the import specification to globals
 func (vm *VM) applyModuleImport(imp PendingImport, modObj *evaluator.RecordInstance) error {
-	// Try to get the module for trait checking
-	var mod *modules.Module
-	if vm.loader != nil {
-		importPath := imp.Path
-		if len(importPath) > 0 && importPath[0] == '.' {
-			importPath = filepath.Join(vm.baseDir, importPath)
-		}
-		absPath, err := filepath.Abs(importPath)
-		if err == nil {
-			if modInterface, err := vm.loader.GetModule(absPath); err == nil {
-				if m, ok := modInterface.(*modules.Module); ok {
-					mod = m
-				}
-			}
-		}
-	}
-
 	if imp.Alias != "" {
 		vm.globals = vm.globals.Put(imp.Alias, modObj)
 	} else if imp.ImportAll {
@@ -331,18 +377,15 @@ func (vm *VM) applyModuleImport(imp PendingImport, modObj *evaluator.RecordInsta
 				}
 			} else {
 				// Check if it's a trait - traits don't have runtime values but should be importable
-				if mod != nil && mod.SymbolTable != nil {
-					if symbolVal, ok := mod.SymbolTable.Find(sym); ok && symbolVal.Kind == symbols.TraitSymbol {
-						// Trait found - now import all its methods
-						traitMethodNames := mod.SymbolTable.GetTraitAllMethods(sym)
-						for _, methodName := range traitMethodNames {
-							// Try to get the method from modObj
-							if methodVal := modObj.Get(methodName); methodVal != nil {
-								vm.globals = vm.globals.Put(methodName, methodVal)
-							}
+				if traitMethodNames, ok := vm.lookupModuleTrait(imp, sym); ok {
+					// Trait found - now import all its methods
+					for _, methodName := range traitMethodNames {
+						// Try to get the method from modObj
+						if methodVal := modObj.Get(methodName); methodVal != nil {
+							vm.globals = vm.globals.Put(methodName, methodVal)
 						}
-						continue
 					}
+					continue
 				}
 				return fmt.Errorf("symbol '%s' not found in module", sym)
 			}
@@ -357,6 +400,34 @@ func (vm *VM) applyModuleImport(imp PendingImport, modObj *evaluator.RecordInsta
 	return nil
 }
 
+// lookupModuleTrait reports whether sym is a trait declared in the imported module
+// and returns the names of all its methods (including inherited ones)
+func (vm *VM) lookupModuleTrait(imp PendingImport, sym string) ([]string, bool) {
+	if vm.bundle != nil {
+		if cm, ok := vm.bundle.Modules[imp.Resolved]; ok {
+			methods, ok := cm.TraitMethods[sym]
+			return methods, ok
+		}
+		return nil, false
+	}
+
+	if vm.loader == nil {
+		return nil, false
+	}
+	modInterface, err := vm.loader.GetModule(vm.loader.ResolveImport(vm.baseDir, imp.Path))
+	if err != nil {
+		return nil, false
+	}
+	mod, ok := modInterface.(*modules.Module)
+	if !ok || mod.SymbolTable == nil {
+		return nil, false
+	}
+	if symbolVal, ok := mod.SymbolTable.Find(sym); ok && symbolVal.Kind == symbols.TraitSymbol {
+		return mod.SymbolTable.GetTraitAllMethods(sym), true
+	}
+	return nil, false
+}
+
 // isVirtualModule checks if path refers to a built-in virtual module
 func isVirtualModule(path string) bool {
 	return path == "lib" ||
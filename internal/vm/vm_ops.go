@@ -104,6 +104,16 @@ func (vm *VM) binaryOp(op Opcode) error {
 		return nil
 	}
 
+	// Handle sized integers (checked arithmetic)
+	if _, ok := aObj.(*evaluator.SizedInt); ok {
+		return vm.sharedInfixOp(evaluator.SizedIntInfix, opcodeOperator(op), aObj, bObj)
+	}
+
+	// Handle Decimal
+	if _, ok := aObj.(*evaluator.Decimal); ok {
+		return vm.sharedInfixOp(evaluator.DecimalInfix, opcodeOperator(op), aObj, bObj)
+	}
+
 	// Handle Rational
 	aRat, aIsRat := aObj.(*evaluator.Rational)
 	bRat, bIsRat := bObj.(*evaluator.Rational)
@@ -160,6 +170,55 @@ func (vm *VM) binaryOp(op Opcode) error {
 	return fmt.Errorf("no operator %s for types %s and %s", opName, aObj.Type(), bObj.Type())
 }
 
+// sharedInfixOp applies an operator implemented by the evaluator (sized
+// integers, decimals), turning its errors into runtime errors.
+func (vm *VM) sharedInfixOp(infix func(string, evaluator.Object, evaluator.Object) evaluator.Object, operator string, a, b evaluator.Object) error {
+	result := infix(operator, a, b)
+	if err, ok := result.(*evaluator.Error); ok {
+		return fmt.Errorf("%s", err.Message)
+	}
+	vm.push(ObjVal(result))
+	return nil
+}
+
+// opcodeOperator returns the source operator of an arithmetic, bitwise or
+// comparison opcode.
+func opcodeOperator(op Opcode) string {
+	switch op {
+	case OP_ADD:
+		return "+"
+	case OP_SUB:
+		return "-"
+	case OP_MUL:
+		return "*"
+	case OP_DIV:
+		return "/"
+	case OP_MOD:
+		return "%"
+	case OP_POW:
+		return "**"
+	case OP_BAND:
+		return "&"
+	case OP_BOR:
+		return "|"
+	case OP_BXOR:
+		return "^"
+	case OP_LSHIFT:
+		return "<<"
+	case OP_RSHIFT:
+		return ">>"
+	case OP_LT:
+		return "<"
+	case OP_LE:
+		return "<="
+	case OP_GT:
+		return ">"
+	case OP_GE:
+		return ">="
+	}
+	return ""
+}
+
 // intPow computes integer power
 func intPow(base, exp int64) int64 {
 	if exp < 0 {
@@ -202,6 +261,12 @@ func (vm *VM) bitwiseOp(op Opcode) error {
 		return nil
 	}
 
+	if a.IsObj() {
+		if _, ok := a.Obj.(*evaluator.SizedInt); ok {
+			return vm.sharedInfixOp(evaluator.SizedIntInfix, opcodeOperator(op), a.Obj, b.AsObject())
+		}
+	}
+
 	var opName string
 	switch op {
 	case OP_BAND:
@@ -491,6 +556,16 @@ func (vm *VM) comparisonOp(op Opcode) error {
 		return nil
 	}
 
+	// Handle sized integer comparison
+	if _, ok := aObj.(*evaluator.SizedInt); ok {
+		return vm.sharedInfixOp(evaluator.SizedIntInfix, opName, aObj, bObj)
+	}
+
+	// Handle Decimal comparison
+	if _, ok := aObj.(*evaluator.Decimal); ok {
+		return vm.sharedInfixOp(evaluator.DecimalInfix, opName, aObj, bObj)
+	}
+
 	// Handle BigInt comparison
 	aBigInt, aIsBigInt := aObj.(*evaluator.BigInt)
 	bBigInt, bIsBigInt := bObj.(*evaluator.BigInt)
@@ -830,6 +905,18 @@ func (vm *VM) compareObjects(a, b Value) int {
 			return 0
 		}
 	}
+	// Sized integer comparison
+	if aInt, ok := aObj.(*evaluator.SizedInt); ok {
+		if bInt, ok := bObj.(*evaluator.SizedInt); ok {
+			return evaluator.CompareSizedInts(aInt, bInt)
+		}
+	}
+	// Decimal comparison
+	if aDec, ok := aObj.(*evaluator.Decimal); ok {
+		if bDec, ok := bObj.(*evaluator.Decimal); ok {
+			return evaluator.CompareDecimals(aDec, bDec)
+		}
+	}
 	// Char comparison
 	if aChar, ok := aObj.(*evaluator.Char); ok {
 		if bChar, ok := bObj.(*evaluator.Char); ok {
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestModuleCache runs a program twice with a persistent module cache, then
+// edits a transitive dependency and checks that the change is picked up.
+func TestModuleCache(t *testing.T) {
+	projectRoot, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to get project root: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "funxy")
+	cmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/funxy")
+	cmd.Dir = projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, output)
+	}
+
+	srcDir := filepath.Join(tmpDir, "src")
+	for _, dir := range []string{"shapes", "report"} {
+		if err := os.MkdirAll(filepath.Join(srcDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		"shapes/shapes.lang": `package shapes (*)
+
+type Shape = Circle(Int) | Square(Int)
+
+trait Named<T> {
+    fun name(x: T) -> String
+    fun label(x: T) -> String { "<shape>" }
+}
+
+instance Named Shape {
+    fun name(x: Shape) -> String {
+        match x {
+            Circle(_) -> "circle"
+            Square(_) -> "square"
+        }
+    }
+}
+
+fun (s: Shape) size() -> Int {
+    match s {
+        Circle(r) -> r * 2
+        Square(a) -> a
+    }
+}
+`,
+		"report/report.lang": `package report (describe)
+
+import "../shapes" (*)
+
+fun describe(s: Shape) -> String { name(s) ++ " " ++ label(s) ++ " " ++ show(s.size()) }
+`,
+		"main.lang": `import "./report" (describe)
+import "./shapes" (Circle, Square, label)
+
+print(describe(Circle(3)))
+print(label(Square(1)))
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cacheDir := filepath.Join(tmpDir, "cache")
+	run := func() string {
+		cmd := exec.Command(binaryPath, filepath.Join(srcDir, "main.lang"))
+		cmd.Env = append(os.Environ(), "FUNXY_BACKEND=vm", "FUNXY_CACHE="+cacheDir)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("Run failed: %v\n%s", err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	want := "circle <shape> 6\n<shape>"
+	for i := 0; i < 2; i++ {
+		if got := run(); got != want {
+			t.Fatalf("Run %d output mismatch:\n--- want ---\n%s\n--- got ---\n%s", i+1, want, got)
+		}
+	}
+
+	entries, _ := filepath.Glob(filepath.Join(cacheDir, "*.mod"))
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 cache entries, got %d", len(entries))
+	}
+
+	// Changing shapes must invalidate report as well
+	shapesPath := filepath.Join(srcDir, "shapes", "shapes.lang")
+	edited := strings.Replace(files["shapes/shapes.lang"], `"<shape>"`, `"[shape]"`, 1)
+	if err := os.WriteFile(shapesPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want = "circle [shape] 6\n[shape]"
+	for i := 0; i < 2; i++ {
+		if got := run(); got != want {
+			t.Fatalf("Run %d after edit output mismatch:\n--- want ---\n%s\n--- got ---\n%s", i+1, want, got)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestPackageDoc generates documentation for a user package and checks that
+// doc comments, inferred signatures, constructors, traits and instances appear.
+func TestPackageDoc(t *testing.T) {
+	projectRoot, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to get project root: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "funxy")
+	cmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/funxy")
+	cmd.Dir = projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, output)
+	}
+
+	pkgDir := filepath.Join(tmpDir, "shapes")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	source := `// Geometric shapes
+// and their measurements
+package shapes (Shape, Named, area, double, first)
+
+// A 2D shape
+type Shape = Circle(Int) | Square(Int)
+
+trait Named<T> {
+    // Display name
+    fun name(x: T) -> String
+}
+
+instance Named Shape {
+    fun name(x: Shape) -> String { "shape" }
+}
+
+// Area, rounded down
+fun area(s: Shape) -> Int {
+    match s {
+        Circle(r) -> 3 * r * r
+        Square(a) -> a * a
+    }
+}
+
+fun double(x) { x }
+
+fun first(x: a, y) -> a { x }
+
+// Not exported
+fun helper() -> Int { 1 }
+`
+	if err := os.WriteFile(filepath.Join(pkgDir, "shapes.lang"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		cmd := exec.Command(binaryPath, append([]string{"doc", pkgDir}, args...)...)
+		cmd.Env = append(os.Environ(), "FUNXY_CACHE=off")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("doc %v failed: %v\n%s", args, err, output)
+		}
+		return string(output)
+	}
+
+	md := run("-md")
+	for _, want := range []string{
+		"# Package `shapes`",
+		"Geometric shapes\nand their measurements",
+		"type Shape",
+		"- `Circle` : `(Int) -> Shape`",
+		"A 2D shape",
+		"trait Named<T>",
+		"- `name` : `(T) -> String` — Display name",
+		"### `instance Named Shape`",
+		"(Shape) -> Int",
+		"Area, rounded down",
+		"### `double`",
+		// y's generated variable does not take the user's a
+		"(a, b) -> a",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown output is missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "helper") {
+		t.Errorf("Markdown output documents an unexported function:\n%s", md)
+	}
+	// Generated type variables are shown as a, b, ...
+	if generated := regexp.MustCompile(`\bt[0-9]+\b`).FindString(md); generated != "" {
+		t.Errorf("Markdown output contains generated type variable %q:\n%s", generated, md)
+	}
+
+	htmlPath := filepath.Join(tmpDir, "shapes.html")
+	run("-html", "-o", htmlPath)
+	page, err := os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<title>Package shapes</title>", "(Shape) -&gt; Int", `href="#shapes-area"`} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("HTML output is missing %q", want)
+		}
+	}
+
+	if text := run(); !strings.Contains(text, "area : (Shape) -> Int") {
+		t.Errorf("Text output is missing the signature of area:\n%s", text)
+	}
+}
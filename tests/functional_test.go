@@ -13,6 +13,7 @@ import (
 )
 
 var useTreeWalk = flag.Bool("tree", false, "run tests with tree-walk backend")
+var backendName = flag.String("backend", "", "run tests with the given backend (vm, tree or both)")
 
 // TestFunctional runs .lang files through the compiled binary
 // and compares output with .want files.
@@ -29,11 +30,7 @@ func TestFunctional(t *testing.T) {
 
 	// Always build fresh binary
 	t.Log("Building fresh binary...")
-	args := []string{"build"}
-	if *useTreeWalk {
-		args = append(args, "-ldflags", "-X main.BackendType=tree")
-	}
-	args = append(args, "-o", binaryPath, "./cmd/funxy")
+	args := []string{"build", "-o", binaryPath, "./cmd/funxy"}
 
 	cmd := exec.Command("go", args...)
 	cmd.Dir = projectRoot
@@ -41,6 +38,17 @@ func TestFunctional(t *testing.T) {
 		t.Fatalf("Failed to build binary: %v\n%s", err, output)
 	}
 
+	// Backend is selected at run time via FUNXY_BACKEND
+	backendEnv := "FUNXY_BACKEND=vm"
+	if *backendName != "" {
+		backendEnv = "FUNXY_BACKEND=" + *backendName
+	} else if *useTreeWalk {
+		backendEnv = "FUNXY_BACKEND=tree"
+	}
+
+	// Keep the module cache out of the user's cache directory
+	cacheEnv := "FUNXY_CACHE=" + t.TempDir()
+
 	// Find all source files with .want files
 	var testFiles []string
 	err = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
@@ -103,6 +111,7 @@ func TestFunctional(t *testing.T) {
 			// Run binary from project root so that imports like "kit/..." work
 			cmd := exec.Command(binaryPath, absPath)
 			cmd.Dir = projectRoot
+			cmd.Env = append(os.Environ(), backendEnv, cacheEnv)
 			var stdout, stderr bytes.Buffer
 			cmd.Stdout = &stdout
 			cmd.Stderr = &stderr
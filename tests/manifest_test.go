@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestProjectManifest resolves non-relative imports through funxy.mod and
+// FUNXY_PATH, then vendors the dependencies and runs without the originals.
+func TestProjectManifest(t *testing.T) {
+	projectRoot, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to get project root: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "funxy")
+	cmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/funxy")
+	cmd.Dir = projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, output)
+	}
+
+	files := map[string]string{
+		"shared/kit/greet/greet.lang": `package greet (hello)
+
+import "../util" (shout)
+
+fun hello(name: String) -> String { shout("hello, " ++ name) }
+`,
+		"shared/kit/util/util.lang": `package util (shout)
+
+fun shout(s: String) -> String { s ++ "!" }
+`,
+		"extra/brackets/brackets.lang": `package brackets (bracket)
+
+fun bracket(s: String) -> String { "[" ++ s ++ "]" }
+`,
+		"app/funxy.mod": `# test project
+module app
+require kit => ../shared/kit
+`,
+		"app/models/models.lang": `package models (team)
+
+team = "team"
+`,
+		"app/src/main.lang": `import "kit/greet" (hello)
+import "brackets" (bracket)
+import "models" (team)
+
+print(bracket(hello(team)))
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	appDir := filepath.Join(tmpDir, "app")
+	mainPath := filepath.Join(appDir, "src", "main.lang")
+	env := append(os.Environ(), "FUNXY_CACHE=off", "FUNXY_PATH="+filepath.Join(tmpDir, "extra"))
+	run := func(dir string, args ...string) (string, error) {
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = dir
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(output)), err
+	}
+
+	want := "[hello, team!]"
+	for _, backend := range []string{"vm", "tree"} {
+		// Run from an unrelated directory: resolution must not depend on the CWD
+		got, err := run(tmpDir, "--backend="+backend, mainPath)
+		if err != nil {
+			t.Fatalf("%s: run failed: %v\n%s", backend, err, got)
+		}
+		if got != want {
+			t.Errorf("%s: output mismatch:\n--- want ---\n%s\n--- got ---\n%s", backend, want, got)
+		}
+	}
+
+	if got, err := run(filepath.Join(appDir, "src"), "vendor"); err != nil {
+		t.Fatalf("vendor failed: %v\n%s", err, got)
+	}
+	if _, err := os.Stat(filepath.Join(appDir, "vendor", "kit", "util", "util.lang")); err != nil {
+		t.Fatalf("vendored file missing: %v", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(tmpDir, "shared")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := run(tmpDir, mainPath)
+	if err != nil {
+		t.Fatalf("run after vendor failed: %v\n%s", err, got)
+	}
+	if got != want {
+		t.Errorf("output after vendor mismatch:\n--- want ---\n%s\n--- got ---\n%s", want, got)
+	}
+
+	// A malformed manifest is reported, not ignored
+	if err := os.WriteFile(filepath.Join(appDir, "funxy.mod"), []byte("require kit\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = run(tmpDir, mainPath)
+	if err == nil || !strings.Contains(got, "funxy.mod:1") {
+		t.Errorf("expected manifest error, got: %v\n%s", err, got)
+	}
+}
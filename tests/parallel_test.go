@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestParallelChunks runs list_par.lang with several CPUs on both backends,
+// so the list is really split into chunks even on a single-core machine.
+func TestParallelChunks(t *testing.T) {
+	projectRoot, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to get project root: %v", err)
+	}
+
+	binaryPath := filepath.Join(t.TempDir(), "funxy")
+	cmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/funxy")
+	cmd.Dir = projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, output)
+	}
+
+	want, err := os.ReadFile("list_par.want")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, backend := range []string{"vm", "tree"} {
+		cmd := exec.Command(binaryPath, "list_par.lang")
+		cmd.Env = append(os.Environ(), "GOMAXPROCS=4", "FUNXY_BACKEND="+backend, "FUNXY_CACHE="+t.TempDir())
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%s: run failed: %v\n%s", backend, err, output)
+		}
+		if string(output) != string(want) {
+			t.Errorf("%s: output mismatch:\n--- want ---\n%s\n--- got ---\n%s", backend, want, output)
+		}
+	}
+}
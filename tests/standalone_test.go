@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStandaloneBuild builds a standalone executable from a program that imports
+// a user package, then runs it from another directory without any sources.
+func TestStandaloneBuild(t *testing.T) {
+	projectRoot, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to get project root: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "funxy")
+	cmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/funxy")
+	cmd.Dir = projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, output)
+	}
+
+	// Program with a user package next to it
+	srcDir := filepath.Join(tmpDir, "src")
+	if err := os.MkdirAll(filepath.Join(srcDir, "greeter"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"greeter/greeter.lang": `package greeter (greet)
+
+fun greet(name: String) -> String { "Hello, " ++ name }
+`,
+		"app.lang": `import "lib/sys" (sysArgs)
+import "./greeter" (greet)
+
+args = sysArgs()
+print(greet(args[1]))
+print(len(args))
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	appPath := filepath.Join(tmpDir, "app")
+	cmd = exec.Command(binaryPath, "build", "-o", appPath, filepath.Join(srcDir, "app.lang"))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("funxy build failed: %v\n%s", err, output)
+	}
+
+	// Sources must not be needed at run time
+	if err := os.RemoveAll(srcDir); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = exec.Command(appPath, "Funxy", "--backend=tree")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Standalone executable failed: %v\n%s", err, output)
+	}
+
+	want := "Hello, Funxy\n3"
+	if got := strings.TrimSpace(string(output)); got != want {
+		t.Errorf("Output mismatch:\n--- want ---\n%s\n--- got ---\n%s", want, got)
+	}
+}
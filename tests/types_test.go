@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestTypesCommand lists the inferred types of the top-level bindings of a
+// script, with readable type variables and constraints.
+func TestTypesCommand(t *testing.T) {
+	projectRoot, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to get project root: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "funxy")
+	cmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/funxy")
+	cmd.Dir = projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, output)
+	}
+
+	source := `import "lib/list" (map)
+
+type Point = { x: Int, y: Int }
+
+fun describe<T: Show>(xs: List<T>) -> List<String> { map(show, xs) }
+fun (p: Point) norm() -> Int { p.x * p.x + p.y * p.y }
+fun first<A, B>(pair: (A, B)) -> A { pair[0] }
+
+limit :- 10
+names = ["a", "b"]
+swap = fun(a: Int, b: String) -> (b, a)
+print(limit)
+`
+	path := filepath.Join(tmpDir, "script.lang")
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = exec.Command(binaryPath, "types", path)
+	cmd.Env = append(os.Environ(), "FUNXY_CACHE=off")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("types failed: %v\n%s", err, output)
+	}
+	want := `describe : ((List T)) -> (List String) where T: Show
+Point.norm : (Point) -> Int
+first : ((A, B)) -> A
+limit : Int
+names : (List String)
+swap : (Int, String) -> (String, Int)
+`
+	if string(output) != want {
+		t.Errorf("types output:\n%s\nwant:\n%s", output, want)
+	}
+
+	// Analysis errors are reported instead of a partial listing
+	if err := os.WriteFile(path, []byte("x = ?\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd = exec.Command(binaryPath, "types", path)
+	if output, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("types succeeded on a program with a hole:\n%s", output)
+	}
+}